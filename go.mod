@@ -11,6 +11,7 @@ require (
 	github.com/go-playground/validator/v10 v10.22.1
 	github.com/golang-jwt/jwt/v5 v5.2.1
 	github.com/google/uuid v1.6.0
+	github.com/icegreg/chat-smpl/pkg/events v0.0.0
 	github.com/icegreg/chat-smpl/pkg/metrics v0.0.0
 	github.com/icegreg/chat-smpl/pkg/migrate v0.0.0-00010101000000-000000000000
 	github.com/icegreg/chat-smpl/proto/chat v0.0.0
@@ -25,6 +26,7 @@ require (
 	github.com/swaggo/swag v1.16.4
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.45.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250818200422-3122310a409c
 	google.golang.org/grpc v1.74.2
 	google.golang.org/protobuf v1.36.8
 )
@@ -74,12 +76,12 @@ require (
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	golang.org/x/tools v0.38.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250818200422-3122310a409c // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace (
+	github.com/icegreg/chat-smpl/pkg/events => ./pkg/events
 	github.com/icegreg/chat-smpl/pkg/metrics => ./pkg/metrics
 	github.com/icegreg/chat-smpl/pkg/migrate => ./pkg/migrate
 	github.com/icegreg/chat-smpl/proto/chat => ./proto/chat
@@ -0,0 +1,39 @@
+-- Thread subscriptions (follow/unfollow)
+-- Users follow threads to receive thread.reply notifications and see them in their inbox.
+-- A user is subscribed automatically when replying in a thread or being mentioned there.
+
+CREATE TABLE IF NOT EXISTS con_test.thread_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    thread_id UUID NOT NULL REFERENCES con_test.threads(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    -- Messages sent after this moment are counted as unread in the inbox
+    last_read_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT unique_thread_subscription UNIQUE (thread_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_thread_subscriptions_thread_id ON con_test.thread_subscriptions(thread_id);
+CREATE INDEX IF NOT EXISTS idx_thread_subscriptions_user_id ON con_test.thread_subscriptions(user_id);
+
+-- Fix thread counters trigger: messages have sent_at, not created_at.
+-- last_message_at drives the "last activity" ordering of the followed threads inbox.
+CREATE OR REPLACE FUNCTION con_test.update_thread_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.thread_id IS NOT NULL THEN
+        UPDATE con_test.threads
+        SET
+            message_count = message_count + 1,
+            last_message_at = COALESCE(NEW.sent_at, NOW()),
+            updated_at = NOW()
+        WHERE id = NEW.thread_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Index for unread counting in thread inbox
+CREATE INDEX IF NOT EXISTS idx_messages_thread_sent_at ON con_test.messages(thread_id, sent_at) WHERE thread_id IS NOT NULL;
+
+COMMENT ON TABLE con_test.thread_subscriptions IS 'Users following threads (explicit follow, auto-follow on reply or mention)';
+COMMENT ON COLUMN con_test.thread_subscriptions.last_read_at IS 'Last time the follower read the thread; newer messages are unread';
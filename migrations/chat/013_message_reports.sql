@@ -0,0 +1,39 @@
+-- Message reporting and moderation queue
+-- Members flag abusive messages; chat admins and global moderators (users.role owner/moderator)
+-- review the queue and dismiss, delete the message or restrict the sender.
+
+CREATE TABLE IF NOT EXISTS con_test.message_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL, -- No FK: reports outlive permanently deleted messages
+    sender_id UUID NOT NULL,
+    message_content TEXT NOT NULL DEFAULT '', -- Snapshot at report time
+    reporter_id UUID NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    comment TEXT,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    resolution VARCHAR(16), -- dismiss, delete, restrict
+    resolved_by UUID,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(message_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_reports_chat_status ON con_test.message_reports(chat_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_message_reports_status ON con_test.message_reports(status, created_at);
+
+-- Audit trail of moderation actions
+CREATE TABLE IF NOT EXISTS con_test.moderation_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL, -- No FK: the audit trail is kept after chat deletion
+    actor_id UUID NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    report_id UUID,
+    message_id UUID,
+    target_user_id UUID,
+    details TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_log_chat ON con_test.moderation_audit_log(chat_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_log_created_at ON con_test.moderation_audit_log(created_at DESC);
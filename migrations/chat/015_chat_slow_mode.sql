@@ -0,0 +1,2 @@
+-- Per-chat slow mode: participants (except admins) can send one message per interval
+ALTER TABLE con_test.chats ADD COLUMN IF NOT EXISTS slow_mode_seconds INTEGER NOT NULL DEFAULT 0;
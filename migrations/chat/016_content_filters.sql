@@ -0,0 +1,30 @@
+-- Content filter policies and hit log
+-- A policy with chat_id NULL is the global default; a chat policy replaces it for that chat.
+
+CREATE TABLE IF NOT EXISTS con_test.content_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    rules JSONB NOT NULL DEFAULT '{}',
+    updated_by UUID NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One policy per chat plus one global policy
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_policies_chat
+    ON con_test.content_policies((COALESCE(chat_id, '00000000-0000-0000-0000-000000000000'::uuid)));
+
+-- Filter matches for the moderation team (matched text is never stored)
+CREATE TABLE IF NOT EXISTS con_test.content_filter_hits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL,
+    sender_id UUID NOT NULL,
+    message_id UUID, -- NULL when the message was blocked
+    filter VARCHAR(32) NOT NULL,
+    rule VARCHAR(100) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    matches INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_filter_hits_chat ON con_test.content_filter_hits(chat_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_content_filter_hits_created_at ON con_test.content_filter_hits(created_at DESC);
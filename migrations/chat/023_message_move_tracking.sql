@@ -0,0 +1,29 @@
+-- Remember that a message was moved, so tombstone sync can tell a message moved out of the
+-- main chat (previous_thread_id NULL) from a thread message that never moved. change_type alone
+-- is not enough: it is overwritten by every later edit.
+
+ALTER TABLE con_test.messages ADD COLUMN IF NOT EXISTS was_moved BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE con_test.messages SET was_moved = true WHERE change_type = 'moved';
+
+CREATE OR REPLACE FUNCTION con_test.track_message_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.is_deleted AND NOT OLD.is_deleted THEN
+        NEW.change_type := 'deleted';
+    ELSIF OLD.is_deleted AND NOT NEW.is_deleted THEN
+        NEW.change_type := 'restored';
+    ELSIF NEW.thread_id IS DISTINCT FROM OLD.thread_id THEN
+        NEW.change_type := 'moved';
+        NEW.previous_thread_id := OLD.thread_id;
+        NEW.was_moved := true;
+    ELSIF NEW.content IS DISTINCT FROM OLD.content THEN
+        NEW.change_type := 'edited';
+    ELSE
+        RETURN NEW;
+    END IF;
+
+    NEW.change_seq_num := con_test.get_next_seq_num(NEW.chat_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
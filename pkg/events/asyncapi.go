@@ -0,0 +1,193 @@
+package events
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// asyncAPIHeader marks docs/asyncapi.yaml as generated
+const asyncAPIHeader = "# Code generated by go run ./tools/eventdocs. DO NOT EDIT.\n"
+
+const asyncAPIDescription = `События, которые сервисы публикуют в RabbitMQ.
+
+## Архитектура
+` + "```" + `
+Service → RabbitMQ → WebSocket Service → Centrifugo → Client
+` + "```" + `
+
+Каждое событие обёрнуто в конверт Envelope: id, type, version, occurred_at, producer,
+partition_key и payload. Схемы payload генерируются из типов пакета pkg/events. Событие с
+audience: chat адресовано всем участникам чата и не содержит recipients.
+
+События с x-transient: true (индикаторы набора текста и речи) публикуются в недолговечный
+exchange как непостоянные сообщения с TTL и не попадают в историю каналов Centrifugo.
+
+websocket-service пересылает клиентам payload в поле data в канал, указанный в x-centrifugo-channel
+(структура для клиентов описана в /api/docs/events).
+
+## Версионирование
+Добавление необязательного поля не меняет версию. Удаление, переименование, смена типа поля или
+новое обязательное поле повышают version; потребители отклоняют версии новее известных им.
+`
+
+type asyncAPIDoc struct {
+	AsyncAPI           string                     `yaml:"asyncapi"`
+	Info               asyncAPIInfo               `yaml:"info"`
+	Servers            map[string]asyncAPIServer  `yaml:"servers"`
+	DefaultContentType string                     `yaml:"defaultContentType"`
+	Channels           map[string]asyncAPIChannel `yaml:"channels"`
+	Components         asyncAPIComponents         `yaml:"components"`
+}
+
+type asyncAPIInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+type asyncAPIServer struct {
+	URL         string `yaml:"url"`
+	Protocol    string `yaml:"protocol"`
+	Description string `yaml:"description"`
+}
+
+type asyncAPIChannel struct {
+	Description string            `yaml:"description"`
+	Subscribe   asyncAPIOperation `yaml:"subscribe"`
+	Bindings    asyncAPIBindings  `yaml:"bindings"`
+}
+
+type asyncAPIOperation struct {
+	OperationID string         `yaml:"operationId"`
+	Summary     string         `yaml:"summary"`
+	Message     asyncAPIRefObj `yaml:"message"`
+}
+
+type asyncAPIRefObj struct {
+	Ref string `yaml:"$ref"`
+}
+
+type asyncAPIBindings struct {
+	AMQP asyncAPIAMQPBinding `yaml:"amqp"`
+}
+
+type asyncAPIAMQPBinding struct {
+	Is       string               `yaml:"is"`
+	Exchange asyncAPIAMQPExchange `yaml:"exchange"`
+}
+
+type asyncAPIAMQPExchange struct {
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Durable bool   `yaml:"durable"`
+}
+
+type asyncAPIMessage struct {
+	Name              string  `yaml:"name"`
+	Title             string  `yaml:"title"`
+	ContentType       string  `yaml:"contentType"`
+	Producer          string  `yaml:"x-producer"`
+	Version           int     `yaml:"x-version"`
+	CentrifugoChannel string  `yaml:"x-centrifugo-channel,omitempty"`
+	Transient         bool    `yaml:"x-transient,omitempty"`
+	Payload           *Schema `yaml:"payload"`
+}
+
+type asyncAPIComponents struct {
+	Messages map[string]asyncAPIMessage `yaml:"messages"`
+	Schemas  map[string]*Schema         `yaml:"schemas"`
+}
+
+// AsyncAPI renders the AsyncAPI document of all registered events as YAML
+func AsyncAPI() ([]byte, error) {
+	doc := asyncAPIDoc{
+		AsyncAPI: "2.6.0",
+		Info: asyncAPIInfo{
+			Title:       "ChatApp Events API",
+			Version:     "2.0.0",
+			Description: asyncAPIDescription,
+		},
+		Servers: map[string]asyncAPIServer{
+			"rabbitmq": {
+				URL:         "amqp://localhost:5672",
+				Protocol:    "amqp",
+				Description: "RabbitMQ, в который публикуют сервисы",
+			},
+		},
+		DefaultContentType: "application/json",
+		Channels:           make(map[string]asyncAPIChannel),
+		Components: asyncAPIComponents{
+			Messages: make(map[string]asyncAPIMessage),
+			Schemas:  map[string]*Schema{"Envelope": envelopeSchema()},
+		},
+	}
+
+	for _, def := range Definitions() {
+		name := messageName(def.Type)
+		payloadName := reflect.TypeOf(def.Payload).Name()
+
+		doc.Channels[def.Type] = asyncAPIChannel{
+			Description: def.Description,
+			Subscribe: asyncAPIOperation{
+				OperationID: "on" + name,
+				Summary:     def.Description,
+				Message:     asyncAPIRefObj{Ref: "#/components/messages/" + name},
+			},
+			Bindings: asyncAPIBindings{AMQP: asyncAPIAMQPBinding{
+				Is:       "routingKey",
+				Exchange: asyncAPIAMQPExchange{Name: def.Exchange, Type: "topic", Durable: !def.Transient},
+			}},
+		}
+		doc.Components.Messages[name] = asyncAPIMessage{
+			Name:              name,
+			Title:             def.Description,
+			ContentType:       "application/json",
+			Producer:          def.Producer,
+			Version:           def.Version,
+			CentrifugoChannel: def.Channel,
+			Transient:         def.Transient,
+			Payload: &Schema{AllOf: []*Schema{
+				{Ref: "#/components/schemas/Envelope"},
+				{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"type":    {Const: def.Type},
+						"version": {Const: def.Version},
+						"payload": {Ref: "#/components/schemas/" + payloadName},
+					},
+				},
+			}},
+		}
+		doc.Components.Schemas[payloadName] = SchemaOf(def.Payload)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(asyncAPIHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func envelopeSchema() *Schema {
+	s := SchemaOf(Envelope{})
+	s.Description = "Конверт события"
+	return s
+}
+
+// messageName turns an event type into a component name: message.bulk_deleted -> MessageBulkDeleted
+func messageName(eventType string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(eventType, func(r rune) bool { return r == '.' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
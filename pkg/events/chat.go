@@ -0,0 +1,189 @@
+package events
+
+// Chat events, published by chat-service to chat.events. websocket-service forwards them to the
+// personal channel of every recipient, or with CHAT_EVENTS_DELIVERY=chat once to chat:{chatId}.
+
+const chatChannel = "user:{userId}"
+
+type ChatData struct {
+	ID              string `json:"id" format:"uuid" desc:"ID чата"`
+	Name            string `json:"name" desc:"Название чата"`
+	ChatType        string `json:"chat_type" enum:"direct,group,channel" desc:"Тип чата"`
+	CreatedBy       string `json:"created_by" format:"uuid" desc:"ID создателя"`
+	SlowModeSeconds int    `json:"slow_mode_seconds" desc:"Интервал медленного режима в секундах (0 - выключен)"`
+}
+
+type ChatDeletedData struct {
+	ChatID string `json:"chat_id" format:"uuid" desc:"ID удалённого чата"`
+}
+
+// ChatMemberAddedData is sent when a user joins the chat. The new participant is among the
+// recipients.
+type ChatMemberAddedData struct {
+	ChatID string `json:"chat_id" format:"uuid" desc:"ID чата"`
+	UserID string `json:"user_id" format:"uuid" desc:"ID нового участника"`
+	Role   string `json:"role" enum:"admin,member,readonly" desc:"Роль участника"`
+}
+
+// ChatMemberRemovedData is sent when a user stops being a participant of the chat. The removed
+// user is among the recipients.
+type ChatMemberRemovedData struct {
+	ChatID string `json:"chat_id" format:"uuid" desc:"ID чата"`
+	UserID string `json:"user_id" format:"uuid" desc:"ID удалённого участника"`
+	Reason string `json:"reason" enum:"left,removed,banned" desc:"Причина: вышел сам, удалён или заблокирован модератором"`
+}
+
+// MessageButton is an interactive button attached to a message
+type MessageButton struct {
+	ActionID string `json:"action_id,omitempty" desc:"ID действия, передаётся боту при нажатии"`
+	Label    string `json:"label" desc:"Текст кнопки"`
+	Value    string `json:"value,omitempty" desc:"Значение, передаётся боту при нажатии"`
+	URL      string `json:"url,omitempty" desc:"Ссылка (кнопка-ссылка вместо действия)"`
+	Style    string `json:"style,omitempty" enum:"primary,danger" desc:"Стиль кнопки"`
+}
+
+type MessageData struct {
+	ID                string          `json:"id" format:"uuid" desc:"ID сообщения"`
+	ChatID            string          `json:"chat_id" format:"uuid" desc:"ID чата"`
+	SenderID          string          `json:"sender_id" format:"uuid" desc:"ID отправителя"`
+	Content           string          `json:"content" desc:"Текст сообщения"`
+	SentAt            string          `json:"sent_at" format:"date-time" desc:"Время отправки"`
+	UpdatedAt         *string         `json:"updated_at,omitempty" format:"date-time" desc:"Время редактирования"`
+	ParentID          *string         `json:"parent_id,omitempty" format:"uuid" desc:"ID родительского сообщения (для тредов)"`
+	ThreadID          *string         `json:"thread_id,omitempty" format:"uuid" desc:"ID треда, если сообщение отправлено в тред"`
+	SenderUsername    *string         `json:"sender_username,omitempty" desc:"Username отправителя"`
+	SenderDisplayName *string         `json:"sender_display_name,omitempty" desc:"Отображаемое имя отправителя"`
+	SenderAvatarURL   *string         `json:"sender_avatar_url,omitempty" desc:"URL аватара отправителя"`
+	SenderIsBot       bool            `json:"sender_is_bot,omitempty" desc:"Отправлено ботом"`
+	IsSystem          bool            `json:"is_system,omitempty" desc:"Системное сообщение или уведомление (например, напоминание)"`
+	IsEphemeral       bool            `json:"is_ephemeral,omitempty" desc:"Видно только одному пользователю и не сохраняется"`
+	FileLinkIDs       []string        `json:"file_link_ids,omitempty" format:"uuid" desc:"ID прикреплённых файлов"`
+	ReplyToIDs        []string        `json:"reply_to_ids,omitempty" format:"uuid" desc:"ID цитируемых сообщений"`
+	Buttons           []MessageButton `json:"buttons,omitempty" desc:"Интерактивные кнопки"`
+}
+
+type MessageDeletedData struct {
+	MessageID           string `json:"message_id" format:"uuid" desc:"ID удалённого сообщения"`
+	ChatID              string `json:"chat_id" format:"uuid" desc:"ID чата"`
+	IsModeratedDeletion bool   `json:"is_moderated_deletion" desc:"true если удалено модератором"`
+}
+
+type MessageRestoredData struct {
+	MessageID string `json:"message_id" format:"uuid" desc:"ID восстановленного сообщения"`
+	ChatID    string `json:"chat_id" format:"uuid" desc:"ID чата"`
+	SenderID  string `json:"sender_id" format:"uuid" desc:"ID отправителя"`
+	Content   string `json:"content" desc:"Текст сообщения"`
+}
+
+type ReportCreatedData struct {
+	ReportID       string  `json:"report_id" format:"uuid" desc:"ID жалобы"`
+	ChatID         string  `json:"chat_id" format:"uuid" desc:"ID чата"`
+	MessageID      string  `json:"message_id" format:"uuid" desc:"ID сообщения"`
+	SenderID       string  `json:"sender_id" format:"uuid" desc:"ID автора сообщения"`
+	ReporterID     string  `json:"reporter_id" format:"uuid" desc:"ID пожаловавшегося"`
+	Reason         string  `json:"reason" enum:"spam,abuse,harassment,illegal,other" desc:"Причина жалобы"`
+	Comment        *string `json:"comment,omitempty" desc:"Комментарий к жалобе"`
+	MessageContent string  `json:"message_content" desc:"Текст сообщения на момент жалобы"`
+}
+
+type MessagesBulkDeletedData struct {
+	ChatID     string   `json:"chat_id" format:"uuid" desc:"ID чата"`
+	MessageIDs []string `json:"message_ids" format:"uuid" desc:"ID удалённых сообщений"`
+	DeletedBy  string   `json:"deleted_by" format:"uuid" desc:"ID модератора"`
+	SenderID   *string  `json:"sender_id,omitempty" format:"uuid" desc:"ID автора, если удаление выполнялось по отправителю"`
+}
+
+type MessagesMovedData struct {
+	ChatID   string         `json:"chat_id" format:"uuid" desc:"ID чата"`
+	ThreadID string         `json:"thread_id" format:"uuid" desc:"ID треда, в который перенесены сообщения"`
+	Messages []MovedMessage `json:"messages" desc:"Перенесённые сообщения"`
+}
+
+type MovedMessage struct {
+	MessageID    string  `json:"message_id" format:"uuid" desc:"ID сообщения"`
+	FromThreadID *string `json:"from_thread_id,omitempty" format:"uuid" desc:"Исходный тред (нет, если из основного чата)"`
+}
+
+type TypingData struct {
+	IsTyping bool   `json:"is_typing" desc:"true - начал печатать, false - закончил"`
+	UserID   string `json:"user_id" format:"uuid" desc:"ID пользователя, который печатает"`
+}
+
+type ReactionData struct {
+	MessageID string `json:"message_id" format:"uuid" desc:"ID сообщения"`
+	Emoji     string `json:"emoji" desc:"Эмодзи реакции или кастомный эмодзи чата вида :name:"`
+	UserID    string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+}
+
+type ThreadData struct {
+	ID                     string  `json:"id" format:"uuid" desc:"ID треда"`
+	ChatID                 string  `json:"chat_id" format:"uuid" desc:"ID чата"`
+	ParentMessageID        *string `json:"parent_message_id,omitempty" format:"uuid" desc:"ID родительского сообщения"`
+	ThreadType             string  `json:"thread_type" enum:"reply,topic" desc:"Тип треда"`
+	Title                  *string `json:"title,omitempty" desc:"Заголовок треда"`
+	MessageCount           int     `json:"message_count" desc:"Количество сообщений"`
+	CreatedBy              *string `json:"created_by,omitempty" format:"uuid" desc:"ID создателя"`
+	IsArchived             bool    `json:"is_archived" desc:"Архивирован ли тред"`
+	RestrictedParticipants bool    `json:"restricted_participants" desc:"Ограничен ли список участников"`
+}
+
+// ThreadReplyData is sent to thread followers when a new message is posted in a thread
+type ThreadReplyData struct {
+	ThreadID    string      `json:"thread_id" format:"uuid" desc:"ID треда"`
+	ChatID      string      `json:"chat_id" format:"uuid" desc:"ID чата"`
+	ThreadTitle *string     `json:"thread_title,omitempty" desc:"Заголовок треда"`
+	Message     MessageData `json:"message" desc:"Сообщение (структура как в message.created)"`
+}
+
+// DraftData is sent to the draft owner's devices only. Deleted is set when the draft was cleared
+// (sent or discarded); content is then empty.
+type DraftData struct {
+	ChatID     string   `json:"chat_id" format:"uuid" desc:"ID чата"`
+	ThreadID   *string  `json:"thread_id,omitempty" format:"uuid" desc:"ID треда, если черновик в треде"`
+	Content    string   `json:"content" desc:"Текст черновика (пусто, если удалён)"`
+	ReplyToIDs []string `json:"reply_to_ids,omitempty" format:"uuid" desc:"ID цитируемых сообщений"`
+	UpdatedAt  string   `json:"updated_at" format:"date-time" desc:"Время изменения"`
+	Deleted    bool     `json:"deleted" desc:"true, если черновик отправлен или удалён"`
+}
+
+func init() {
+	chat := func(eventType, description string, payload any) Definition {
+		return Definition{
+			Type:        eventType,
+			Version:     1,
+			Producer:    ProducerChat,
+			Exchange:    ExchangeChat,
+			Channel:     chatChannel,
+			Description: description,
+			Payload:     payload,
+		}
+	}
+
+	// Typing indicators are signals, published to a non-durable exchange
+	typing := chat("typing", "Индикатор набора текста", TypingData{})
+	typing.Exchange = ExchangeChatSignals
+	typing.Transient = true
+
+	register(
+		chat("chat.created", "Создан новый чат", ChatData{}),
+		chat("chat.updated", "Чат обновлён (изменено название или настройки)", ChatData{}),
+		chat("chat.deleted", "Чат удалён", ChatDeletedData{}),
+		chat("chat.member_added", "Участник добавлен в чат", ChatMemberAddedData{}),
+		chat("chat.member_removed", "Участник вышел из чата, удалён или заблокирован", ChatMemberRemovedData{}),
+		chat("message.created", "Новое сообщение в чате", MessageData{}),
+		chat("message.updated", "Сообщение отредактировано", MessageData{}),
+		chat("message.deleted", "Сообщение удалено (soft delete)", MessageDeletedData{}),
+		chat("message.restored", "Сообщение восстановлено после удаления", MessageRestoredData{}),
+		chat("message.moved", "Сообщения перенесены в тред", MessagesMovedData{}),
+		chat("message.bulk_deleted", "Массовое удаление сообщений модератором (одно событие на всю операцию)", MessagesBulkDeletedData{}),
+		chat("message.ephemeral", "Ответ на slash-команду или напоминание, видимый только одному пользователю (не сохраняется)", MessageData{}),
+		chat("draft.updated", "Черновик пользователя изменён или очищен (только самому пользователю, для синхронизации устройств)", DraftData{}),
+		chat("report.created", "Новая жалоба на сообщение (только модераторам чата и глобальным модераторам)", ReportCreatedData{}),
+		typing,
+		chat("reaction.added", "Добавлена реакция на сообщение", ReactionData{}),
+		chat("reaction.removed", "Удалена реакция с сообщения", ReactionData{}),
+		chat("thread.created", "Создан новый тред", ThreadData{}),
+		chat("thread.archived", "Тред архивирован", ThreadData{}),
+		chat("thread.reply", "Новое сообщение в треде, на который подписан пользователь (отправляется только подписчикам, кроме автора)", ThreadReplyData{}),
+	)
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Contract is the published schema of an event type at a version
+type Contract struct {
+	Version int     `json:"version"`
+	Schema  *Schema `json:"schema"`
+}
+
+// Contracts returns the current contract of every registered event type
+func Contracts() map[string]Contract {
+	contracts := make(map[string]Contract, len(definitions))
+	for _, def := range definitions {
+		contracts[def.Type] = Contract{Version: def.Version, Schema: SchemaOf(def.Payload)}
+	}
+	return contracts
+}
+
+// CheckCompatibility compares current contracts with previously released ones and returns the
+// changes that would break consumers built against the released schemas: removed event types,
+// version downgrades and, within the same version, removed properties, changed types and new
+// required properties. A version bump allows any change to that event.
+func CheckCompatibility(released, current map[string]Contract) []string {
+	var problems []string
+	for _, eventType := range sortedKeys(released) {
+		old := released[eventType]
+		cur, ok := current[eventType]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: event type removed", eventType))
+		case cur.Version < old.Version:
+			problems = append(problems, fmt.Sprintf("%s: version went down from %d to %d", eventType, old.Version, cur.Version))
+		case cur.Version == old.Version:
+			problems = append(problems, compareSchemas(eventType+" v"+fmt.Sprint(cur.Version), old.Schema, cur.Schema)...)
+		}
+	}
+	return problems
+}
+
+func compareSchemas(path string, old, cur *Schema) []string {
+	if old == nil || cur == nil {
+		return nil
+	}
+	if old.Type != cur.Type {
+		return []string{fmt.Sprintf("%s: type changed from %q to %q", path, old.Type, cur.Type)}
+	}
+	if old.Format != cur.Format {
+		return []string{fmt.Sprintf("%s: format changed from %q to %q", path, old.Format, cur.Format)}
+	}
+
+	var problems []string
+	for _, name := range sortedKeys(old.Properties) {
+		curProp, ok := cur.Properties[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s.%s: property removed", path, name))
+			continue
+		}
+		problems = append(problems, compareSchemas(path+"."+name, old.Properties[name], curProp)...)
+	}
+
+	wasRequired := make(map[string]bool, len(old.Required))
+	for _, name := range old.Required {
+		wasRequired[name] = true
+	}
+	for _, name := range cur.Required {
+		if !wasRequired[name] {
+			problems = append(problems, fmt.Sprintf("%s.%s: property became required", path, name))
+		}
+	}
+
+	if old.Items != nil || cur.Items != nil {
+		problems = append(problems, compareSchemas(path+"[]", old.Items, cur.Items)...)
+	}
+	return problems
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
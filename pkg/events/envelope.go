@@ -0,0 +1,121 @@
+// Package events defines the envelope every service publishes to RabbitMQ and the payload types
+// of all events. Each event type is registered with its current schema version, producer and
+// description; the registry feeds docs/asyncapi.yaml (see tools/eventdocs) and the gateway's
+// /api/docs/events.
+//
+// Versioning: adding an optional payload field keeps the version. Removing or renaming a field,
+// changing its type or making it required is a breaking change and bumps the version;
+// consumers reject versions newer than the one they were built with (see Decode). The tests
+// check the registered schemas against testdata/schemas.json, the last released contract.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Producers
+const (
+	ProducerChat     = "chat-service"
+	ProducerVoice    = "voice-service"
+	ProducerPresence = "presence-service"
+)
+
+// AudienceChat marks an event addressed to every participant of its chat. Such events carry no
+// recipients; consumers resolve the chat's participants themselves, which keeps events of large
+// chats small.
+const AudienceChat = "chat"
+
+var (
+	ErrUnknownType        = errors.New("unknown event type")
+	ErrPayloadMismatch    = errors.New("payload does not match the registered type")
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	ErrNotEnvelope        = errors.New("message is not an event envelope")
+)
+
+// Envelope wraps every event published to RabbitMQ
+type Envelope struct {
+	ID           string          `json:"id" format:"uuid" desc:"Уникальный ID события (для дедупликации)"`
+	Type         string          `json:"type" desc:"Тип события, совпадает с routing key"`
+	Version      int             `json:"version" desc:"Версия схемы payload"`
+	OccurredAt   time.Time       `json:"occurred_at" desc:"Время события"`
+	Producer     string          `json:"producer" desc:"Сервис, опубликовавший событие"`
+	PartitionKey string          `json:"partition_key,omitempty" desc:"Ключ упорядочивания: события с одним ключом обрабатываются по порядку"`
+	ActorID      string          `json:"actor_id,omitempty" format:"uuid" desc:"ID пользователя, инициировавшего событие"`
+	ChatID       string          `json:"chat_id,omitempty" format:"uuid" desc:"ID чата, к которому относится событие"`
+	Recipients   []string        `json:"recipients,omitempty" format:"uuid" desc:"ID пользователей, которым доставляется событие"`
+	Audience     string          `json:"audience,omitempty" enum:"chat" desc:"chat - событие для всех участников чата chat_id, recipients не передаются"`
+	Payload      json.RawMessage `json:"payload" desc:"Данные события (зависят от типа)"`
+}
+
+// Keyed is implemented by payloads that know their partition key
+type Keyed interface {
+	PartitionKey() string
+}
+
+// New wraps payload in an envelope of eventType. The payload must be of the type registered for
+// eventType; the partition key is taken from it when it implements Keyed.
+func New(producer, eventType string, payload any) (*Envelope, error) {
+	def, ok := Lookup(eventType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, eventType)
+	}
+	if got, want := indirectType(reflect.TypeOf(payload)), reflect.TypeOf(def.Payload); got != want {
+		return nil, fmt.Errorf("%w: %s expects %s, got %v", ErrPayloadMismatch, eventType, want, got)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	env := &Envelope{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Version:    def.Version,
+		OccurredAt: time.Now().UTC(),
+		Producer:   producer,
+		Payload:    body,
+	}
+	if keyed, ok := payload.(Keyed); ok {
+		env.PartitionKey = keyed.PartitionKey()
+	}
+	return env, nil
+}
+
+// Decode parses an envelope. Events of a registered type with a newer version than this build
+// knows fail with ErrUnsupportedVersion, so consumers can retry them after being upgraded
+// instead of misreading them. Unknown types are returned as is.
+func Decode(body []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotEnvelope, err)
+	}
+	if env.Type == "" || env.Version == 0 {
+		return nil, ErrNotEnvelope
+	}
+	if def, ok := Lookup(env.Type); ok && env.Version > def.Version {
+		return nil, fmt.Errorf("%w: %s v%d, supported up to v%d", ErrUnsupportedVersion, env.Type, env.Version, def.Version)
+	}
+	return &env, nil
+}
+
+// DecodePayload unmarshals the payload into v
+func (e *Envelope) DecodePayload(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", e.Type, err)
+	}
+	return nil
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
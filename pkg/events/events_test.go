@@ -0,0 +1,154 @@
+package events
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/schemas.json with the current contracts")
+
+const contractsFile = "testdata/schemas.json"
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	for _, def := range Definitions() {
+		t.Run(def.Type, func(t *testing.T) {
+			env, err := New(def.Producer, def.Type, def.Payload)
+			require.NoError(t, err)
+			assert.Equal(t, def.Version, env.Version)
+			assert.NotEmpty(t, env.ID)
+
+			body, err := json.Marshal(env)
+			require.NoError(t, err)
+
+			decoded, err := Decode(body)
+			require.NoError(t, err)
+			assert.Equal(t, def.Type, decoded.Type)
+			assert.Equal(t, def.Producer, decoded.Producer)
+
+			payload := reflect.New(reflect.TypeOf(def.Payload))
+			require.NoError(t, decoded.DecodePayload(payload.Interface()))
+			assert.Equal(t, def.Payload, payload.Elem().Interface())
+		})
+	}
+}
+
+func TestNewChecksPayloadType(t *testing.T) {
+	_, err := New(ProducerChat, "message.created", ReactionData{})
+	assert.ErrorIs(t, err, ErrPayloadMismatch)
+
+	_, err = New(ProducerChat, "message.teleported", MessageData{})
+	assert.ErrorIs(t, err, ErrUnknownType)
+
+	// Pointers to the registered type are accepted
+	_, err = New(ProducerChat, "message.created", &MessageData{})
+	assert.NoError(t, err)
+}
+
+func TestNewTakesPartitionKeyFromPayload(t *testing.T) {
+	env, err := New(ProducerVoice, "participant.joined", ParticipantData{ID: "p-1", ConferenceID: "conf-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "conf-1", env.PartitionKey)
+
+	env, err = New(ProducerChat, "typing", TypingData{UserID: "u-1"})
+	require.NoError(t, err)
+	assert.Empty(t, env.PartitionKey)
+}
+
+func TestDecode(t *testing.T) {
+	_, err := Decode([]byte(`{"type":"message.created","version":2,"payload":{}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	// Pre-envelope messages have no version
+	_, err = Decode([]byte(`{"type":"message.created","timestamp":"2024-01-15T10:30:00Z","data":{}}`))
+	assert.ErrorIs(t, err, ErrNotEnvelope)
+
+	_, err = Decode([]byte(`not json`))
+	assert.ErrorIs(t, err, ErrNotEnvelope)
+
+	// Unknown types pass through for consumers that forward them
+	env, err := Decode([]byte(`{"type":"poll.closed","version":3,"payload":{}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "poll.closed", env.Type)
+}
+
+func TestSchemaOf(t *testing.T) {
+	s := SchemaOf(MessagesBulkDeletedData{})
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"chat_id", "message_ids", "deleted_by"}, s.Required)
+	assert.Equal(t, "uuid", s.Properties["chat_id"].Format)
+	assert.Equal(t, "array", s.Properties["message_ids"].Type)
+	assert.Equal(t, "uuid", s.Properties["message_ids"].Items.Format)
+	assert.Equal(t, "string", s.Properties["sender_id"].Type)
+
+	reason := SchemaOf(ReportCreatedData{}).Properties["reason"]
+	assert.Equal(t, []string{"spam", "abuse", "harassment", "illegal", "other"}, reason.Enum)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	released := map[string]Contract{
+		"reaction.added": {Version: 1, Schema: SchemaOf(ReactionData{})},
+	}
+
+	extended := SchemaOf(ReactionData{})
+	extended.Properties["skin"] = &Schema{Type: "string"}
+	assert.Empty(t, CheckCompatibility(released, map[string]Contract{"reaction.added": {Version: 1, Schema: extended}}))
+
+	type renamed struct {
+		MessageID string `json:"message_id" format:"uuid"`
+		Reaction  string `json:"reaction"`
+		UserID    string `json:"user_id" format:"uuid"`
+	}
+	assert.Equal(t, []string{
+		"reaction.added v1.emoji: property removed",
+		"reaction.added v1.reaction: property became required",
+	}, CheckCompatibility(released, map[string]Contract{"reaction.added": {Version: 1, Schema: SchemaOf(renamed{})}}))
+
+	// The same change is fine with a version bump
+	assert.Empty(t, CheckCompatibility(released, map[string]Contract{"reaction.added": {Version: 2, Schema: SchemaOf(renamed{})}}))
+
+	assert.Equal(t, []string{"reaction.added: event type removed"}, CheckCompatibility(released, map[string]Contract{}))
+}
+
+// TestContractsCompatible fails when a registered schema breaks the released contract without a
+// version bump. After a compatible change, refresh the snapshot with go test -update.
+func TestContractsCompatible(t *testing.T) {
+	current := Contracts()
+
+	if *update {
+		body, err := json.MarshalIndent(current, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(contractsFile, append(body, '\n'), 0o644))
+	}
+
+	body, err := os.ReadFile(contractsFile)
+	require.NoError(t, err)
+	var released map[string]Contract
+	require.NoError(t, json.Unmarshal(body, &released))
+
+	for _, problem := range CheckCompatibility(released, current) {
+		t.Errorf("breaking change: %s (bump the event version)", problem)
+	}
+
+	currentJSON, err := json.Marshal(current)
+	require.NoError(t, err)
+	releasedJSON, err := json.Marshal(released)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(releasedJSON), string(currentJSON), "contracts changed compatibly; run go test -update to refresh %s", contractsFile)
+}
+
+// TestAsyncAPIUpToDate keeps docs/asyncapi.yaml in sync with the registry
+func TestAsyncAPIUpToDate(t *testing.T) {
+	want, err := AsyncAPI()
+	require.NoError(t, err)
+
+	got, err := os.ReadFile("../../docs/asyncapi.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "docs/asyncapi.yaml is stale; run go run ./tools/eventdocs")
+}
@@ -0,0 +1,24 @@
+package events
+
+// Presence events, published by presence-service to presence.events
+
+type PresenceData struct {
+	UserID          string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+	Status          string `json:"status" enum:"available,busy,away,dnd" desc:"Статус присутствия"`
+	IsOnline        bool   `json:"is_online" desc:"Есть ли активные подключения"`
+	ConnectionCount int    `json:"connection_count" desc:"Число активных подключений"`
+	LastSeenAt      string `json:"last_seen_at,omitempty" format:"date-time" desc:"Время последней активности"`
+}
+
+func (d PresenceData) PartitionKey() string { return d.UserID }
+
+func init() {
+	register(Definition{
+		Type:        "presence.changed",
+		Version:     1,
+		Producer:    ProducerPresence,
+		Exchange:    ExchangePresence,
+		Description: "Изменился статус присутствия пользователя",
+		Payload:     PresenceData{},
+	})
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Exchanges events are published to
+const (
+	ExchangeChat     = "chat.events"
+	ExchangeVoice    = "voice.events"
+	ExchangePresence = "presence.events"
+
+	// Non-durable exchanges for ephemeral signals, see Definition.Transient
+	ExchangeChatSignals  = "chat.signals"
+	ExchangeVoiceSignals = "voice.signals"
+)
+
+// Definition describes a registered event type
+type Definition struct {
+	Type        string // Routing key and envelope type
+	Version     int    // Current payload schema version
+	Producer    string
+	Exchange    string
+	Channel     string // Centrifugo channels clients receive it on; empty if not forwarded to clients
+	Description string
+	Payload     any // Zero value of the payload type
+	// Transient events are ephemeral signals: published as expiring non-persistent messages to
+	// a non-durable exchange and kept out of channel history, so a missed one is never recovered
+	Transient bool
+}
+
+var (
+	definitions []Definition
+	byType      = make(map[string]int)
+)
+
+// register adds event definitions. Only this package registers events, so every producer
+// shares one registry.
+func register(defs ...Definition) {
+	for _, def := range defs {
+		if _, ok := byType[def.Type]; ok {
+			panic(fmt.Sprintf("events: %s registered twice", def.Type))
+		}
+		if def.Version < 1 {
+			panic(fmt.Sprintf("events: %s has no version", def.Type))
+		}
+		if t := reflect.TypeOf(def.Payload); t == nil || t.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("events: %s payload must be a struct value", def.Type))
+		}
+		byType[def.Type] = len(definitions)
+		definitions = append(definitions, def)
+	}
+}
+
+// Lookup returns the definition of eventType
+func Lookup(eventType string) (Definition, bool) {
+	i, ok := byType[eventType]
+	if !ok {
+		return Definition{}, false
+	}
+	return definitions[i], true
+}
+
+// Definitions returns all registered events in registration order
+func Definitions() []Definition {
+	return append([]Definition(nil), definitions...)
+}
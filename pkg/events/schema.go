@@ -0,0 +1,122 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is the JSON Schema subset used to describe event payloads. Field order matches the
+// AsyncAPI document.
+type Schema struct {
+	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty" yaml:"enum,omitempty,flow"`
+	Const       any                `json:"const,omitempty" yaml:"const,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// SchemaOf generates the schema of a payload value from its type. Struct fields use their json
+// names; fields without omitempty that are not pointers are required. Field tags add details:
+//
+//	desc:"..."           description
+//	format:"uuid"        string format (for slices, of the items)
+//	enum:"a,b,c"         allowed string values
+func SchemaOf(v any) *Schema {
+	return schemaOfType(reflect.TypeOf(v))
+}
+
+func schemaOfType(t reflect.Type) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{}
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{Type: "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOfType(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		prop := schemaOfType(field.Type)
+		prop.Description = field.Tag.Get("desc")
+		if format := field.Tag.Get("format"); format != "" {
+			if prop.Type == "array" {
+				prop.Items.Format = format
+			} else {
+				prop.Format = format
+			}
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+
+		s.Properties[name] = prop
+		if !omitEmpty && field.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty
+}
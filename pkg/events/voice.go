@@ -0,0 +1,167 @@
+package events
+
+// Voice events, published by voice-service to voice.events. Events of one conference (or call)
+// share a partition key so websocket-service forwards them in order.
+
+type ConferenceData struct {
+	ID               string  `json:"id" format:"uuid" desc:"ID конференции"`
+	Name             string  `json:"name" desc:"Название конференции"`
+	ChatID           *string `json:"chat_id,omitempty" format:"uuid" desc:"ID чата конференции"`
+	CreatedBy        string  `json:"created_by" format:"uuid" desc:"ID создателя"`
+	Status           string  `json:"status" desc:"Статус конференции"`
+	MaxMembers       int     `json:"max_members" desc:"Максимальное число участников"`
+	ParticipantCount int     `json:"participant_count" desc:"Текущее число участников"`
+	StartedAt        *string `json:"started_at,omitempty" format:"date-time" desc:"Время начала"`
+	EndedAt          *string `json:"ended_at,omitempty" format:"date-time" desc:"Время завершения"`
+	CreatedAt        string  `json:"created_at" format:"date-time" desc:"Время создания"`
+}
+
+func (d ConferenceData) PartitionKey() string { return d.ID }
+
+type ParticipantData struct {
+	ID           string  `json:"id" format:"uuid" desc:"ID участника"`
+	ConferenceID string  `json:"conference_id" format:"uuid" desc:"ID конференции"`
+	ChatID       string  `json:"chat_id,omitempty" format:"uuid" desc:"ID чата конференции"`
+	UserID       string  `json:"user_id" format:"uuid" desc:"ID пользователя"`
+	Status       string  `json:"status" desc:"Статус участника"`
+	IsMuted      bool    `json:"is_muted" desc:"Микрофон выключен"`
+	IsDeaf       bool    `json:"is_deaf" desc:"Звук выключен"`
+	IsSpeaking   bool    `json:"is_speaking" desc:"Говорит сейчас"`
+	Username     *string `json:"username,omitempty" desc:"Username пользователя"`
+	DisplayName  *string `json:"display_name,omitempty" desc:"Отображаемое имя"`
+	AvatarURL    *string `json:"avatar_url,omitempty" desc:"URL аватара"`
+	JoinedAt     *string `json:"joined_at,omitempty" format:"date-time" desc:"Время входа"`
+	LeftAt       *string `json:"left_at,omitempty" format:"date-time" desc:"Время выхода"`
+}
+
+func (d ParticipantData) PartitionKey() string { return d.ConferenceID }
+
+type SpeakingData struct {
+	ParticipantID string `json:"participant_id" desc:"ID участника конференции (до появления conference_id - ID участника во FreeSWITCH)"`
+	FSMemberID    string `json:"fs_member_id,omitempty" desc:"ID участника во FreeSWITCH (Member-ID)"`
+	ConferenceID  string `json:"conference_id,omitempty" format:"uuid" desc:"ID конференции"`
+	UserID        string `json:"user_id,omitempty" format:"uuid" desc:"ID пользователя"`
+	IsSpeaking    bool   `json:"is_speaking" desc:"true - начал говорить, false - замолчал"`
+}
+
+func (d SpeakingData) PartitionKey() string {
+	if d.ConferenceID != "" {
+		return d.ConferenceID
+	}
+	return d.ParticipantID
+}
+
+type CallData struct {
+	ID                string  `json:"id" format:"uuid" desc:"ID звонка"`
+	CallerID          string  `json:"caller_id" format:"uuid" desc:"ID звонящего"`
+	CalleeID          string  `json:"callee_id" format:"uuid" desc:"ID вызываемого"`
+	ChatID            *string `json:"chat_id,omitempty" format:"uuid" desc:"ID чата звонка"`
+	ConferenceID      *string `json:"conference_id,omitempty" format:"uuid" desc:"ID конференции звонка"`
+	Status            string  `json:"status" desc:"Статус звонка"`
+	Duration          int     `json:"duration" desc:"Длительность в секундах"`
+	EndReason         *string `json:"end_reason,omitempty" desc:"Причина завершения"`
+	CallerUsername    *string `json:"caller_username,omitempty" desc:"Username звонящего"`
+	CallerDisplayName *string `json:"caller_display_name,omitempty" desc:"Имя звонящего"`
+	CalleeUsername    *string `json:"callee_username,omitempty" desc:"Username вызываемого"`
+	CalleeDisplayName *string `json:"callee_display_name,omitempty" desc:"Имя вызываемого"`
+	StartedAt         *string `json:"started_at,omitempty" format:"date-time" desc:"Время начала"`
+	AnsweredAt        *string `json:"answered_at,omitempty" format:"date-time" desc:"Время ответа"`
+	EndedAt           *string `json:"ended_at,omitempty" format:"date-time" desc:"Время завершения"`
+}
+
+func (d CallData) PartitionKey() string { return d.ID }
+
+type ScheduledConferenceData struct {
+	ID               string  `json:"id" format:"uuid" desc:"ID конференции"`
+	Name             string  `json:"name" desc:"Название конференции"`
+	ChatID           *string `json:"chat_id,omitempty" format:"uuid" desc:"ID чата конференции"`
+	CreatedBy        string  `json:"created_by" format:"uuid" desc:"ID создателя"`
+	Status           string  `json:"status" desc:"Статус конференции"`
+	EventType        string  `json:"event_type" desc:"Тип события конференции"`
+	ScheduledAt      *string `json:"scheduled_at,omitempty" format:"date-time" desc:"Запланированное время"`
+	SeriesID         *string `json:"series_id,omitempty" format:"uuid" desc:"ID серии повторяющихся конференций"`
+	AcceptedCount    int     `json:"accepted_count" desc:"Число принявших приглашение"`
+	DeclinedCount    int     `json:"declined_count" desc:"Число отклонивших приглашение"`
+	ParticipantCount int     `json:"participant_count" desc:"Число участников"`
+	CreatedAt        string  `json:"created_at" format:"date-time" desc:"Время создания"`
+}
+
+func (d ScheduledConferenceData) PartitionKey() string { return d.ID }
+
+type RSVPUpdatedData struct {
+	ConferenceID string `json:"conference_id" format:"uuid" desc:"ID конференции"`
+	UserID       string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+	RSVPStatus   string `json:"rsvp_status" desc:"Новый RSVP статус"`
+}
+
+func (d RSVPUpdatedData) PartitionKey() string { return d.ConferenceID }
+
+type ParticipantRoleChangedData struct {
+	ConferenceID string `json:"conference_id" format:"uuid" desc:"ID конференции"`
+	UserID       string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+	OldRole      string `json:"old_role" desc:"Прежняя роль"`
+	NewRole      string `json:"new_role" desc:"Новая роль"`
+}
+
+func (d ParticipantRoleChangedData) PartitionKey() string { return d.ConferenceID }
+
+type ParticipantRemovedData struct {
+	ConferenceID string `json:"conference_id" format:"uuid" desc:"ID конференции"`
+	UserID       string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+}
+
+func (d ParticipantRemovedData) PartitionKey() string { return d.ConferenceID }
+
+type ConferenceReminderData struct {
+	ConferenceID   string `json:"conference_id" format:"uuid" desc:"ID конференции"`
+	UserID         string `json:"user_id" format:"uuid" desc:"ID пользователя"`
+	ConferenceName string `json:"conference_name" desc:"Название конференции"`
+	ScheduledAt    string `json:"scheduled_at" format:"date-time" desc:"Запланированное время"`
+	MinutesBefore  int    `json:"minutes_before" desc:"За сколько минут до начала"`
+}
+
+func (d ConferenceReminderData) PartitionKey() string { return d.ConferenceID }
+
+func init() {
+	voice := func(eventType, channel, description string, payload any) Definition {
+		return Definition{
+			Type:        eventType,
+			Version:     1,
+			Producer:    ProducerVoice,
+			Exchange:    ExchangeVoice,
+			Channel:     channel,
+			Description: description,
+			Payload:     payload,
+		}
+	}
+
+	const (
+		conferenceChannels  = "chat:{chatId}, conference:{conferenceId}"
+		participantChannels = "conference:{conferenceId}, user:{userId}"
+		callChannels        = "user:{userId}"
+	)
+
+	// Speaking indicators are signals, published to a non-durable exchange
+	speaking := voice("participant.speaking", "conference:{conferenceId}", "Участник начал/прекратил говорить", SpeakingData{})
+	speaking.Exchange = ExchangeVoiceSignals
+	speaking.Transient = true
+
+	register(
+		voice("conference.created", conferenceChannels, "Создана новая конференция", ConferenceData{}),
+		voice("conference.ended", conferenceChannels, "Конференция завершена", ConferenceData{}),
+		voice("conference.scheduled", "", "Запланирована новая конференция", ScheduledConferenceData{}),
+		voice("conference.cancelled", "", "Запланированная конференция отменена", ScheduledConferenceData{}),
+		voice("conference.rsvp_updated", "", "Обновлён RSVP статус участника", RSVPUpdatedData{}),
+		voice("conference.reminder", "", "Напоминание о предстоящей конференции", ConferenceReminderData{}),
+		voice("participant.joined", participantChannels, "Участник присоединился к конференции", ParticipantData{}),
+		voice("participant.left", participantChannels, "Участник покинул конференцию", ParticipantData{}),
+		voice("participant.muted", participantChannels, "Изменён статус mute участника", ParticipantData{}),
+		speaking,
+		voice("participant.role_changed", "", "Изменена роль участника в конференции", ParticipantRoleChangedData{}),
+		voice("participant.added", "", "Участник добавлен в конференцию", ParticipantData{}),
+		voice("participant.removed", "", "Участник удалён из конференции", ParticipantRemovedData{}),
+		voice("call.initiated", callChannels, "Инициирован звонок", CallData{}),
+		voice("call.answered", callChannels, "Звонок принят", CallData{}),
+		voice("call.ended", callChannels, "Звонок завершён", CallData{}),
+	)
+}
@@ -31,6 +31,18 @@ type RabbitMQMetrics struct {
 	MessagesConsumed  *prometheus.CounterVec
 	PublishDuration   *prometheus.HistogramVec
 	PublishErrors     *prometheus.CounterVec
+	MessagesConfirmed *prometheus.CounterVec
+	MessagesNacked    *prometheus.CounterVec
+	MessagesReturned  *prometheus.CounterVec
+	MessagesBuffered  *prometheus.GaugeVec
+}
+
+// FanoutMetrics contains metrics of events fanned out to realtime clients
+type FanoutMetrics struct {
+	Recipients        *prometheus.HistogramVec
+	Duration          *prometheus.HistogramVec
+	Errors            *prometheus.CounterVec
+	MembershipLookups *prometheus.CounterVec
 }
 
 // NewHTTPMetrics creates HTTP metrics for a service
@@ -142,6 +154,70 @@ func NewRabbitMQMetrics(serviceName string) *RabbitMQMetrics {
 			},
 			[]string{"exchange", "error_type"},
 		),
+		MessagesConfirmed: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: serviceName + "_rabbitmq_messages_confirmed_total",
+				Help: "Total number of publishes acked by the broker",
+			},
+			[]string{"exchange"},
+		),
+		MessagesNacked: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: serviceName + "_rabbitmq_messages_nacked_total",
+				Help: "Total number of publishes nacked by the broker",
+			},
+			[]string{"exchange"},
+		),
+		MessagesReturned: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: serviceName + "_rabbitmq_messages_returned_total",
+				Help: "Total number of mandatory publishes returned as unroutable",
+			},
+			[]string{"exchange", "routing_key"},
+		),
+		MessagesBuffered: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: serviceName + "_rabbitmq_messages_buffered",
+				Help: "Number of messages buffered while the RabbitMQ connection is down",
+			},
+			[]string{"exchange"},
+		),
+	}
+}
+
+// NewFanoutMetrics creates fan-out metrics for a service
+func NewFanoutMetrics(serviceName string) *FanoutMetrics {
+	return &FanoutMetrics{
+		Recipients: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    serviceName + "_fanout_recipients",
+				Help:    "Number of recipients an event is fanned out to",
+				Buckets: prometheus.ExponentialBuckets(1, 4, 9), // 1 to 65536
+			},
+			[]string{"delivery"},
+		),
+		Duration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    serviceName + "_fanout_duration_seconds",
+				Help:    "Time to fan an event out to its recipients, including recipient lookup",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"delivery"},
+		),
+		Errors: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: serviceName + "_fanout_errors_total",
+				Help: "Total number of failed event fan-outs",
+			},
+			[]string{"delivery"},
+		),
+		MembershipLookups: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: serviceName + "_membership_lookups_total",
+				Help: "Total number of chat membership lookups by result (hit, miss, error)",
+			},
+			[]string{"result"},
+		),
 	}
 }
 
@@ -49,6 +49,11 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // getRoutePath returns the route pattern or path
 func getRoutePath(r *http.Request) string {
 	rctx := chi.RouteContext(r.Context())
@@ -0,0 +1,131 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetter is a message that ran out of retry attempts
+type DeadLetter struct {
+	Exchange       string
+	RoutingKey     string
+	Attempts       int
+	LastError      string
+	DeadLetteredAt string
+	PublishedAt    time.Time
+	Body           []byte
+}
+
+func deadLetterFromDelivery(msg amqp.Delivery) DeadLetter {
+	dl := DeadLetter{
+		Exchange:       HeaderString(msg.Headers, HeaderOriginalExchange),
+		RoutingKey:     HeaderString(msg.Headers, HeaderOriginalRoutingKey),
+		Attempts:       HeaderInt(msg.Headers, HeaderAttempts),
+		LastError:      HeaderString(msg.Headers, HeaderLastError),
+		DeadLetteredAt: HeaderString(msg.Headers, HeaderDeadLetteredAt),
+		PublishedAt:    msg.Timestamp,
+		Body:           msg.Body,
+	}
+	if dl.RoutingKey == "" {
+		dl.RoutingKey = msg.RoutingKey
+	}
+	return dl
+}
+
+// PeekDeadLetters returns up to limit dead-lettered messages of queue without removing them.
+// Messages are read on a separate channel and returned to the queue when it closes.
+func (c *Connection) PeekDeadLetters(queue string, limit int) ([]DeadLetter, error) {
+	ch, err := c.openChannel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	var result []DeadLetter
+	for len(result) < limit {
+		msg, ok, err := ch.Get(DeadLetterQueueName(queue), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dead-letter queue: %w", err)
+		}
+		if !ok {
+			break
+		}
+		result = append(result, deadLetterFromDelivery(msg))
+	}
+	return result, nil
+}
+
+// ReplayDeadLetters moves up to limit dead-lettered messages back into queue with a fresh retry
+// budget. They are published straight to the queue, not to the original exchange, so other
+// consumers of that exchange do not see them twice. Returns how many were replayed.
+func (c *Connection) ReplayDeadLetters(ctx context.Context, queue string, limit int) (int, error) {
+	ch, err := c.openChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	replayed := 0
+	for replayed < limit {
+		msg, ok, err := ch.Get(DeadLetterQueueName(queue), false)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to read dead-letter queue: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		delete(headers, HeaderAttempts)
+		delete(headers, HeaderLastError)
+		delete(headers, HeaderDeadLetteredAt)
+		if _, ok := headers[HeaderOriginalRoutingKey]; !ok {
+			headers[HeaderOriginalRoutingKey] = msg.RoutingKey
+		}
+
+		err = ch.PublishWithContext(ctx, "", queue, false, false, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			MessageId:    msg.MessageId,
+			Timestamp:    msg.Timestamp,
+			Body:         msg.Body,
+			DeliveryMode: amqp.Persistent,
+		})
+		if err != nil {
+			_ = msg.Nack(false, true)
+			return replayed, fmt.Errorf("failed to replay message: %w", err)
+		}
+		if err := msg.Ack(false); err != nil {
+			return replayed, fmt.Errorf("failed to ack replayed message: %w", err)
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// PurgeDeadLetters deletes all dead-lettered messages of queue. Returns how many were deleted.
+func (c *Connection) PurgeDeadLetters(queue string) (int, error) {
+	count, err := c.Channel().QueuePurge(DeadLetterQueueName(queue), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge dead-letter queue: %w", err)
+	}
+	return count, nil
+}
+
+// openChannel opens a channel separate from the shared one, for operations that rely on
+// unacked messages being returned when the channel closes
+func (c *Connection) openChannel() (*amqp.Channel, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	return ch, nil
+}
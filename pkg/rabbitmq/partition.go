@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PartitionKeyFunc returns the key of a message; messages with the same key are handled in order
+type PartitionKeyFunc func(msg amqp.Delivery) string
+
+// WithPartitionKey makes the worker pool route messages with the same key to the same worker,
+// so they are handled in delivery order while different keys are still processed in parallel.
+// Messages with an empty key are spread across workers. A message that fails and goes through
+// a retry queue comes back behind later messages of its key.
+func WithPartitionKey(fn PartitionKeyFunc) ConsumerOption {
+	return func(c *Consumer) {
+		c.partition = fn
+	}
+}
+
+// JSONPartitionKey returns a PartitionKeyFunc that reads the first non-empty of the given
+// top-level string fields of a JSON message body
+func JSONPartitionKey(fields ...string) PartitionKeyFunc {
+	return func(msg amqp.Delivery) string {
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(msg.Body, &body); err != nil {
+			return ""
+		}
+		for _, field := range fields {
+			var value string
+			if err := json.Unmarshal(body[field], &value); err == nil && value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+}
+
+// partitionIndex picks the worker for key. Keyless messages are spread by delivery tag.
+func partitionIndex(key string, tag uint64, workers int) int {
+	if key == "" {
+		return int(tag % uint64(workers))
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(workers))
+}
+
+// consumeWithKeyedWorkers gives every worker its own queue and routes each message to the
+// worker of its partition key
+func (c *Consumer) consumeWithKeyedWorkers(ctx context.Context, msgs <-chan amqp.Delivery, handler MessageHandler) {
+	var wg sync.WaitGroup
+
+	// Each worker can hold up to prefetch messages, so one slow key does not stall the others
+	queues := make([]chan amqp.Delivery, c.numWorkers)
+	for i := range queues {
+		queues[i] = make(chan amqp.Delivery, c.prefetch)
+
+		wg.Add(1)
+		go func(workerID int, queue <-chan amqp.Delivery) {
+			defer wg.Done()
+			logger.Debug("keyed worker started", zap.Int("worker_id", workerID))
+
+			for {
+				select {
+				case <-ctx.Done():
+					logger.Debug("keyed worker stopping", zap.Int("worker_id", workerID))
+					return
+				case msg, ok := <-queue:
+					if !ok {
+						return
+					}
+					c.processMessage(ctx, msg, handler)
+				}
+			}
+		}(i, queues[i])
+	}
+
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				logger.Debug("keyed dispatcher channel closed")
+				return
+			}
+			worker := partitionIndex(c.partition(msg), msg.DeliveryTag, c.numWorkers)
+			select {
+			case queues[worker] <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Publisher errors
+var (
+	ErrPublishNacked     = errors.New("publish nacked by broker")
+	ErrUnroutable        = errors.New("message returned as unroutable")
+	ErrPublishBufferFull = errors.New("publish buffer is full")
+)
+
+const (
+	// defaultConfirmTimeout bounds the wait for a broker confirm when ctx has no deadline
+	defaultConfirmTimeout = 5 * time.Second
+	// bufferRetryInterval is how often buffered messages are retried while disconnected
+	bufferRetryInterval = time.Second
+)
+
+// Publisher publishes JSON events to an exchange.
+//
+// By default it publishes fire-and-forget on the connection's shared channel. WithConfirms
+// switches it to a pool of dedicated confirm-mode channels, so Publish returns only once the
+// broker has taken responsibility for the message. WithPublishBuffer keeps messages in memory
+// while the connection is reconnecting instead of failing them. WithTransient publishes
+// short-lived messages the broker neither writes to disk nor keeps past a TTL.
+type Publisher struct {
+	conn     *Connection
+	exchange string
+
+	confirms   bool
+	poolSize   int
+	mandatory  bool
+	bufferSize int
+	transient  bool
+	ttl        time.Duration
+	metrics    *metrics.RabbitMQMetrics
+
+	pool      chan *publishChannel // Idle channels; nil slots are opened on demand
+	buffer    chan pendingPublish
+	buffered  atomic.Int64 // Buffered messages not yet published, including the one being flushed
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// publishChannel is a confirm-mode channel owned by one Publish call at a time, so confirms and
+// returns on it belong to that call
+type publishChannel struct {
+	ch      *amqp.Channel
+	returns chan amqp.Return // nil unless mandatory
+}
+
+type pendingPublish struct {
+	routingKey string
+	msg        amqp.Publishing
+}
+
+type PublisherOption func(*Publisher)
+
+// WithConfirms publishes on poolSize dedicated confirm-mode channels and waits for the broker
+// ack. Publish fails with ErrPublishNacked when the broker rejects the message.
+func WithConfirms(poolSize int) PublisherOption {
+	return func(p *Publisher) {
+		p.confirms = true
+		p.poolSize = poolSize
+	}
+}
+
+// WithMandatory sets the mandatory flag, so messages no queue is bound for come back from the
+// broker. Publish then fails with ErrUnroutable. Requires WithConfirms, which tells when the
+// return has had time to arrive.
+func WithMandatory() PublisherOption {
+	return func(p *Publisher) {
+		p.mandatory = true
+	}
+}
+
+// WithPublishBuffer keeps up to size messages in memory while the connection is down and
+// publishes them after it is restored. Publish fails with ErrPublishBufferFull when the buffer
+// is full. Buffered messages are lost if the process exits before the connection comes back.
+func WithPublishBuffer(size int) PublisherOption {
+	return func(p *Publisher) {
+		p.bufferSize = size
+	}
+}
+
+// WithTransient publishes non-persistent messages that expire in the queue after ttl, for
+// signals that are worthless once late (typing, speaking). A message that cannot be published
+// is dropped rather than buffered, so WithPublishBuffer is ignored.
+func WithTransient(ttl time.Duration) PublisherOption {
+	return func(p *Publisher) {
+		p.transient = true
+		p.ttl = ttl
+	}
+}
+
+// WithPublisherMetrics records publish, confirm, nack, return and buffer metrics
+func WithPublisherMetrics(m *metrics.RabbitMQMetrics) PublisherOption {
+	return func(p *Publisher) {
+		p.metrics = m
+	}
+}
+
+func NewPublisher(conn *Connection, exchange string, opts ...PublisherOption) *Publisher {
+	p := &Publisher{
+		conn:     conn,
+		exchange: exchange,
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.mandatory && !p.confirms {
+		logger.Warn("mandatory publishing requires confirms, ignoring", zap.String("exchange", exchange))
+		p.mandatory = false
+	}
+	if p.transient && p.bufferSize > 0 {
+		logger.Warn("transient publishing does not buffer, ignoring publish buffer", zap.String("exchange", exchange))
+		p.bufferSize = 0
+	}
+	if p.confirms {
+		if p.poolSize < 1 {
+			p.poolSize = 1
+		}
+		p.pool = make(chan *publishChannel, p.poolSize)
+		for i := 0; i < p.poolSize; i++ {
+			p.pool <- nil
+		}
+	}
+	if p.bufferSize > 0 {
+		p.buffer = make(chan pendingPublish, p.bufferSize)
+		go p.flushBuffer()
+	}
+	return p
+}
+
+func (p *Publisher) Publish(ctx context.Context, routingKey string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		Timestamp:    time.Now(),
+		DeliveryMode: amqp.Persistent,
+	}
+	if p.transient {
+		msg.DeliveryMode = amqp.Transient
+		if p.ttl > 0 {
+			msg.Expiration = strconv.FormatInt(p.ttl.Milliseconds(), 10)
+		}
+	}
+
+	if p.buffer != nil {
+		// Queue behind already buffered messages to keep the publish order
+		if !p.conn.IsConnected() || p.buffered.Load() > 0 {
+			return p.enqueue(routingKey, msg)
+		}
+		if err := p.publish(ctx, routingKey, msg); err != nil {
+			if isConnectionError(err) {
+				return p.enqueue(routingKey, msg)
+			}
+			return err
+		}
+		return nil
+	}
+	return p.publish(ctx, routingKey, msg)
+}
+
+// Close stops flushing the buffer and closes the pooled channels. Messages still buffered are
+// dropped.
+func (p *Publisher) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+
+		if n := p.buffered.Load(); n > 0 {
+			logger.Warn("publisher closed with buffered messages", zap.String("exchange", p.exchange), zap.Int64("dropped", n))
+		}
+		for p.pool != nil {
+			select {
+			case pc := <-p.pool:
+				if pc != nil {
+					pc.ch.Close()
+				}
+			default:
+				return
+			}
+		}
+	})
+}
+
+func (p *Publisher) publish(ctx context.Context, routingKey string, msg amqp.Publishing) error {
+	start := time.Now()
+
+	var err error
+	if p.confirms {
+		err = p.publishConfirmed(ctx, routingKey, msg)
+	} else {
+		err = p.conn.Channel().PublishWithContext(ctx, p.exchange, routingKey, false, false, msg)
+	}
+
+	if p.metrics != nil {
+		p.metrics.PublishDuration.WithLabelValues(p.exchange).Observe(time.Since(start).Seconds())
+		if err == nil {
+			p.metrics.MessagesPublished.WithLabelValues(p.exchange, routingKey).Inc()
+		} else {
+			p.metrics.PublishErrors.WithLabelValues(p.exchange, publishErrorType(err)).Inc()
+		}
+	}
+	return err
+}
+
+func (p *Publisher) publishConfirmed(ctx context.Context, routingKey string, msg amqp.Publishing) (err error) {
+	pc, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A channel left with an outstanding confirm or return would hand them to the next caller
+	defer func() {
+		p.release(pc, err != nil && !errors.Is(err, ErrPublishNacked) && !errors.Is(err, ErrUnroutable))
+	}()
+
+	confirmation, err := pc.ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, routingKey, p.mandatory, false, msg)
+	if err != nil {
+		return err
+	}
+
+	waitCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, defaultConfirmTimeout)
+		defer cancel()
+	}
+	acked, err := confirmation.WaitContext(waitCtx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for publish confirm: %w", err)
+	}
+	if !acked {
+		// Outstanding confirms are resolved as nacks when the channel closes. Only a lost
+		// connection is worth retrying; the broker closing the channel (e.g. a missing
+		// exchange) is not.
+		if pc.ch.IsClosed() {
+			if !p.conn.IsConnected() {
+				return fmt.Errorf("connection lost before publish confirm: %w", amqp.ErrClosed)
+			}
+			return errors.New("channel closed by broker before publish confirm")
+		}
+		if p.metrics != nil {
+			p.metrics.MessagesNacked.WithLabelValues(p.exchange).Inc()
+		}
+		return ErrPublishNacked
+	}
+	if p.metrics != nil {
+		p.metrics.MessagesConfirmed.WithLabelValues(p.exchange).Inc()
+	}
+
+	// The broker sends basic.return before the ack, and the client delivers it before
+	// resolving the confirm, so a return for this message is already waiting
+	if pc.returns != nil {
+		select {
+		case ret, ok := <-pc.returns:
+			if !ok {
+				break
+			}
+			if p.metrics != nil {
+				p.metrics.MessagesReturned.WithLabelValues(p.exchange, routingKey).Inc()
+			}
+			logger.Warn("message returned as unroutable",
+				zap.String("exchange", ret.Exchange),
+				zap.String("routing_key", ret.RoutingKey),
+				zap.String("reply", ret.ReplyText),
+			)
+			return fmt.Errorf("%w: %s %s", ErrUnroutable, ret.Exchange, ret.RoutingKey)
+		default:
+		}
+	}
+	return nil
+}
+
+// acquire takes a channel from the pool, opening a new one when the slot is empty or its
+// channel was closed (e.g. by a reconnect)
+func (p *Publisher) acquire(ctx context.Context) (*publishChannel, error) {
+	var pc *publishChannel
+	select {
+	case pc = <-p.pool:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if pc != nil && !pc.ch.IsClosed() {
+		return pc, nil
+	}
+
+	pc, err := p.openPublishChannel()
+	if err != nil {
+		p.pool <- nil
+		return nil, err
+	}
+	return pc, nil
+}
+
+// release returns a channel to the pool, closing it first when it can't be reused
+func (p *Publisher) release(pc *publishChannel, broken bool) {
+	if broken {
+		pc.ch.Close()
+		pc = nil
+	}
+	p.pool <- pc
+}
+
+func (p *Publisher) openPublishChannel() (*publishChannel, error) {
+	ch, err := p.conn.openChannel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	pc := &publishChannel{ch: ch}
+	if p.mandatory {
+		// One publish is in flight per channel, so at most one return is pending
+		pc.returns = ch.NotifyReturn(make(chan amqp.Return, 1))
+	}
+	return pc, nil
+}
+
+func (p *Publisher) enqueue(routingKey string, msg amqp.Publishing) error {
+	p.buffered.Add(1)
+	select {
+	case p.buffer <- pendingPublish{routingKey: routingKey, msg: msg}:
+		if p.metrics != nil {
+			p.metrics.MessagesBuffered.WithLabelValues(p.exchange).Inc()
+		}
+		return nil
+	default:
+		p.buffered.Add(-1)
+		if p.metrics != nil {
+			p.metrics.PublishErrors.WithLabelValues(p.exchange, publishErrorType(ErrPublishBufferFull)).Inc()
+		}
+		return ErrPublishBufferFull
+	}
+}
+
+// flushBuffer publishes buffered messages in order once the connection is back
+func (p *Publisher) flushBuffer() {
+	for {
+		select {
+		case <-p.done:
+			return
+		case pending := <-p.buffer:
+			p.publishBuffered(pending)
+			p.buffered.Add(-1)
+			if p.metrics != nil {
+				p.metrics.MessagesBuffered.WithLabelValues(p.exchange).Dec()
+			}
+		}
+	}
+}
+
+func (p *Publisher) publishBuffered(pending pendingPublish) {
+	for {
+		if p.conn.IsConnected() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultConfirmTimeout)
+			err := p.publish(ctx, pending.routingKey, pending.msg)
+			cancel()
+			if err == nil {
+				return
+			}
+			if !isConnectionError(err) && !errors.Is(err, context.DeadlineExceeded) {
+				logger.Error("dropping buffered message",
+					zap.Error(err),
+					zap.String("exchange", p.exchange),
+					zap.String("routing_key", pending.routingKey),
+				)
+				return
+			}
+		}
+
+		select {
+		case <-p.done:
+			return
+		case <-time.After(bufferRetryInterval):
+		}
+	}
+}
+
+// isConnectionError reports whether err means the connection or channel is gone, so the
+// message may succeed after a reconnect
+func isConnectionError(err error) bool {
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) {
+		return amqpErr == amqp.ErrClosed
+	}
+	return false
+}
+
+// publishErrorType labels publish errors in metrics
+func publishErrorType(err error) string {
+	switch {
+	case errors.Is(err, ErrPublishNacked):
+		return "nacked"
+	case errors.Is(err, ErrUnroutable):
+		return "unroutable"
+	case errors.Is(err, ErrPublishBufferFull):
+		return "buffer_full"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case isConnectionError(err):
+		return "connection"
+	default:
+		return "publish"
+	}
+}
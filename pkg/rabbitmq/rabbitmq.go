@@ -2,7 +2,6 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -18,11 +17,13 @@ type Config struct {
 }
 
 type Connection struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
-	mu      sync.RWMutex
-	url     string
-	done    chan struct{}
+	conn          *amqp.Connection
+	channel       *amqp.Channel
+	mu            sync.RWMutex
+	url           string
+	done          chan struct{}
+	topology      topology // Declared through this connection, restored on reconnect
+	reconnectSubs []chan struct{}
 }
 
 func NewConnection(cfg Config) (*Connection, error) {
@@ -52,58 +53,113 @@ func NewConnection(cfg Config) (*Connection, error) {
 
 func (c *Connection) handleReconnect() {
 	for {
+		c.mu.RLock()
+		connClosed := c.conn.NotifyClose(make(chan *amqp.Error, 1))
+		chanClosed := c.channel.NotifyClose(make(chan *amqp.Error, 1))
+		c.mu.RUnlock()
+
 		select {
 		case <-c.done:
 			return
-		case err := <-c.conn.NotifyClose(make(chan *amqp.Error)):
-			if err != nil {
-				logger.Error("RabbitMQ connection lost", zap.Error(err))
-				c.reconnect()
+		case err := <-connClosed:
+			if err == nil {
+				return // Closed by Close
+			}
+			logger.Error("RabbitMQ connection lost", zap.Error(err))
+			c.reconnect()
+		case err := <-chanClosed:
+			if err == nil {
+				return // Closed by Close
 			}
+			logger.Error("RabbitMQ channel closed", zap.Error(err))
+			c.reconnect()
 		}
 	}
 }
 
+// reconnect restores the connection (or only the shared channel when the connection survived),
+// re-declares the recorded topology and notifies NotifyReconnect subscribers
 func (c *Connection) reconnect() {
 	for {
 		select {
 		case <-c.done:
 			return
 		default:
+		}
+
+		c.mu.RLock()
+		conn := c.conn
+		c.mu.RUnlock()
+
+		if conn.IsClosed() {
 			logger.Info("attempting to reconnect to RabbitMQ...")
 
-			conn, err := amqp.Dial(c.url)
+			var err error
+			conn, err = amqp.Dial(c.url)
 			if err != nil {
 				logger.Error("failed to reconnect to RabbitMQ", zap.Error(err))
 				time.Sleep(5 * time.Second)
 				continue
 			}
+		}
 
-			ch, err := conn.Channel()
-			if err != nil {
-				conn.Close()
-				logger.Error("failed to open channel", zap.Error(err))
-				time.Sleep(5 * time.Second)
-				continue
-			}
+		ch, err := conn.Channel()
+		if err != nil {
+			conn.Close()
+			logger.Error("failed to open channel", zap.Error(err))
+			time.Sleep(5 * time.Second)
+			continue
+		}
 
-			c.mu.Lock()
-			c.conn = conn
-			c.channel = ch
-			c.mu.Unlock()
+		if err := c.topology.declare(ch); err != nil {
+			ch.Close()
+			conn.Close()
+			logger.Error("failed to restore RabbitMQ topology", zap.Error(err))
+			time.Sleep(5 * time.Second)
+			continue
+		}
 
-			logger.Info("reconnected to RabbitMQ")
-			return
+		c.mu.Lock()
+		c.conn = conn
+		c.channel = ch
+		subscribers := c.reconnectSubs
+		c.mu.Unlock()
+
+		for _, sub := range subscribers {
+			select {
+			case sub <- struct{}{}:
+			default: // A signal is already pending
+			}
 		}
+
+		logger.Info("reconnected to RabbitMQ")
+		return
 	}
 }
 
+// NotifyReconnect returns a channel that receives a signal each time the connection or its
+// shared channel has been restored and the topology re-declared
+func (c *Connection) NotifyReconnect() <-chan struct{} {
+	sub := make(chan struct{}, 1)
+	c.mu.Lock()
+	c.reconnectSubs = append(c.reconnectSubs, sub)
+	c.mu.Unlock()
+	return sub
+}
+
 func (c *Connection) Channel() *amqp.Channel {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.channel
 }
 
+// IsConnected reports whether the connection is up. It is false while reconnecting.
+func (c *Connection) IsConnected() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn != nil && !c.conn.IsClosed()
+}
+
 func (c *Connection) Close() error {
 	close(c.done)
 
@@ -130,15 +186,11 @@ type Exchange struct {
 }
 
 func (c *Connection) DeclareExchange(ex Exchange) error {
-	return c.Channel().ExchangeDeclare(
-		ex.Name,
-		ex.Kind,
-		ex.Durable,
-		ex.AutoDelete,
-		ex.Internal,
-		ex.NoWait,
-		ex.Args,
-	)
+	if err := declareExchange(c.Channel(), ex); err != nil {
+		return err
+	}
+	c.topology.addExchange(ex)
+	return nil
 }
 
 type Queue struct {
@@ -151,36 +203,21 @@ type Queue struct {
 }
 
 func (c *Connection) DeclareQueue(q Queue) (amqp.Queue, error) {
-	return c.Channel().QueueDeclare(
-		q.Name,
-		q.Durable,
-		q.AutoDelete,
-		q.Exclusive,
-		q.NoWait,
-		q.Args,
-	)
+	queue, err := declareQueue(c.Channel(), q)
+	if err != nil {
+		return queue, err
+	}
+	c.topology.addQueue(q)
+	return queue, nil
 }
 
 func (c *Connection) BindQueue(queueName, routingKey, exchangeName string) error {
-	return c.Channel().QueueBind(
-		queueName,
-		routingKey,
-		exchangeName,
-		false,
-		nil,
-	)
-}
-
-type Publisher struct {
-	conn     *Connection
-	exchange string
-}
-
-func NewPublisher(conn *Connection, exchange string) *Publisher {
-	return &Publisher{
-		conn:     conn,
-		exchange: exchange,
+	b := binding{queue: queueName, routingKey: routingKey, exchange: exchangeName}
+	if err := bindQueue(c.Channel(), b); err != nil {
+		return err
 	}
+	c.topology.addBinding(b)
+	return nil
 }
 
 type Event struct {
@@ -189,35 +226,20 @@ type Event struct {
 	Payload   interface{} `json:"payload"`
 }
 
-func (p *Publisher) Publish(ctx context.Context, routingKey string, event interface{}) error {
-	body, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	return p.conn.Channel().PublishWithContext(
-		ctx,
-		p.exchange,
-		routingKey,
-		false,
-		false,
-		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
-			Timestamp:    time.Now(),
-			DeliveryMode: amqp.Persistent,
-		},
-	)
-}
-
 type Consumer struct {
 	conn       *Connection
 	queue      string
 	consumer   string
 	prefetch   int
 	numWorkers int
+	retry      *RetryPolicy // nil = requeue failed messages immediately
+	partition  PartitionKeyFunc
 }
 
+// resubscribeInterval is how long a consumer waits for a reconnect signal before trying to
+// resume consuming on its own
+const resubscribeInterval = 5 * time.Second
+
 type ConsumerOption func(*Consumer)
 
 // WithPrefetch sets the prefetch count (QoS) for the consumer
@@ -250,10 +272,26 @@ func NewConsumer(conn *Connection, queue, consumer string, opts ...ConsumerOptio
 
 type MessageHandler func(ctx context.Context, msg amqp.Delivery) error
 
+// Consume delivers messages to handler until ctx is cancelled. When the connection or channel
+// is lost, it waits for Connection to reconnect and restore the topology, then re-applies QoS
+// and resumes consuming. Only a failure to start consuming the first time is returned.
 func (c *Consumer) Consume(ctx context.Context, handler MessageHandler) error {
+	if c.retry != nil {
+		if err := c.declareRetryTopology(); err != nil {
+			return err
+		}
+	}
+
+	return c.consumeLoop(ctx, c.subscribe, c.conn.NotifyReconnect(), handler)
+}
+
+// subscribe applies QoS and starts consuming on the current shared channel
+func (c *Consumer) subscribe() (<-chan amqp.Delivery, error) {
+	ch := c.conn.Channel()
+
 	// Set QoS (prefetch)
-	if err := c.conn.Channel().Qos(c.prefetch, 0, false); err != nil {
-		return fmt.Errorf("failed to set QoS: %w", err)
+	if err := ch.Qos(c.prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set QoS: %w", err)
 	}
 
 	logger.Info("consumer QoS configured",
@@ -261,7 +299,7 @@ func (c *Consumer) Consume(ctx context.Context, handler MessageHandler) error {
 		zap.Int("workers", c.numWorkers),
 	)
 
-	msgs, err := c.conn.Channel().Consume(
+	msgs, err := ch.Consume(
 		c.queue,
 		c.consumer,
 		false, // auto-ack
@@ -271,29 +309,72 @@ func (c *Consumer) Consume(ctx context.Context, handler MessageHandler) error {
 		nil,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to start consuming: %w", err)
+		return nil, fmt.Errorf("failed to start consuming: %w", err)
+	}
+	return msgs, nil
+}
+
+// consumeLoop runs deliveries from subscribe through the handler and subscribes again after
+// each reconnect signal. A failed re-subscription is retried on the next signal or after
+// resubscribeInterval, whichever comes first.
+func (c *Consumer) consumeLoop(ctx context.Context, subscribe func() (<-chan amqp.Delivery, error), reconnected <-chan struct{}, handler MessageHandler) error {
+	first := true
+	for {
+		msgs, err := subscribe()
+		switch {
+		case err != nil && first:
+			return err
+		case err != nil:
+			logger.Error("failed to resume consuming", zap.Error(err), zap.String("queue", c.queue))
+		default:
+			if !first {
+				logger.Info("consumer resumed after reconnect", zap.String("queue", c.queue))
+			}
+			c.dispatch(ctx, msgs, handler)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn("consumer channel closed, waiting for reconnect", zap.String("queue", c.queue))
+		}
+		first = false
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-reconnected:
+		case <-time.After(resubscribeInterval):
+		}
 	}
+}
 
+// dispatch processes msgs until the delivery channel closes or ctx is cancelled
+func (c *Consumer) dispatch(ctx context.Context, msgs <-chan amqp.Delivery, handler MessageHandler) {
 	// Single worker mode (original behavior)
 	if c.numWorkers <= 1 {
 		for {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return
 			case msg, ok := <-msgs:
 				if !ok {
-					return fmt.Errorf("channel closed")
+					return
 				}
 				c.processMessage(ctx, msg, handler)
 			}
 		}
 	}
 
+	// Keyed worker pool mode
+	if c.partition != nil {
+		c.consumeWithKeyedWorkers(ctx, msgs, handler)
+		return
+	}
+
 	// Worker pool mode
-	return c.consumeWithWorkerPool(ctx, msgs, handler)
+	c.consumeWithWorkerPool(ctx, msgs, handler)
 }
 
-func (c *Consumer) consumeWithWorkerPool(ctx context.Context, msgs <-chan amqp.Delivery, handler MessageHandler) error {
+func (c *Consumer) consumeWithWorkerPool(ctx context.Context, msgs <-chan amqp.Delivery, handler MessageHandler) {
 	var wg sync.WaitGroup
 
 	// Start worker pool
@@ -319,22 +400,32 @@ func (c *Consumer) consumeWithWorkerPool(ctx context.Context, msgs <-chan amqp.D
 		}(i)
 	}
 
-	// Wait for context cancellation
-	<-ctx.Done()
-
-	// Wait for all workers to finish
+	// Wait for cancellation or the delivery channel to close
 	wg.Wait()
-	return ctx.Err()
 }
 
 func (c *Consumer) processMessage(ctx context.Context, msg amqp.Delivery, handler MessageHandler) {
-	if err := handler(ctx, msg); err != nil {
-		logger.Error("failed to handle message",
-			zap.Error(err),
-			zap.String("routing_key", msg.RoutingKey),
-		)
-		msg.Nack(false, true)
-	} else {
+	restoreOriginalRoute(&msg)
+
+	err := handler(ctx, msg)
+	if err == nil {
 		msg.Ack(false)
+		return
+	}
+
+	logger.Error("failed to handle message",
+		zap.Error(err),
+		zap.String("routing_key", msg.RoutingKey),
+		zap.Int("attempt", HeaderInt(msg.Headers, HeaderAttempts)+1),
+	)
+	if c.retry == nil {
+		msg.Nack(false, true)
+		return
+	}
+	if retryErr := c.retryOrDeadLetter(ctx, msg, err); retryErr != nil {
+		logger.Error("failed to schedule retry, requeueing", zap.Error(retryErr), zap.String("routing_key", msg.RoutingKey))
+		msg.Nack(false, true)
+		return
 	}
+	msg.Ack(false)
 }
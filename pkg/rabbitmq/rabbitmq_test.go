@@ -1,8 +1,15 @@
 package rabbitmq
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,5 +51,346 @@ func TestQueue(t *testing.T) {
 	assert.True(t, q.Durable)
 }
 
+func TestRetryPolicyDelay(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	assert.Equal(t, time.Second, p.Delay(1))
+	assert.Equal(t, 2*time.Second, p.Delay(2))
+	assert.Equal(t, 8*time.Second, p.Delay(4))
+	assert.Equal(t, time.Minute, p.Delay(20))
+	assert.Equal(t, time.Second, p.Delay(0))
+
+	constant := RetryPolicy{MaxAttempts: 3, InitialDelay: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, constant.Delay(3))
+}
+
+func TestRetryQueueNames(t *testing.T) {
+	assert.Equal(t, "websocket.events.retry.2s", RetryQueueName("websocket.events", 2*time.Second))
+	assert.Equal(t, "websocket.events.dlx", DeadLetterExchangeName("websocket.events"))
+	assert.Equal(t, "websocket.events.dead", DeadLetterQueueName("websocket.events"))
+}
+
+func TestHeaderInt(t *testing.T) {
+	headers := amqp.Table{
+		"a": int32(3),
+		"b": int64(4),
+		"c": "5",
+	}
+
+	assert.Equal(t, 3, HeaderInt(headers, "a"))
+	assert.Equal(t, 4, HeaderInt(headers, "b"))
+	assert.Equal(t, 0, HeaderInt(headers, "c"))
+	assert.Equal(t, 0, HeaderInt(headers, "missing"))
+	assert.Equal(t, 0, HeaderInt(nil, "a"))
+}
+
+func TestRestoreOriginalRoute(t *testing.T) {
+	msg := amqp.Delivery{
+		Exchange:   "",
+		RoutingKey: "websocket.events",
+		Headers: amqp.Table{
+			HeaderOriginalExchange:   "chat.events",
+			HeaderOriginalRoutingKey: "message.created",
+		},
+	}
+	restoreOriginalRoute(&msg)
+	assert.Equal(t, "chat.events", msg.Exchange)
+	assert.Equal(t, "message.created", msg.RoutingKey)
+
+	first := amqp.Delivery{Exchange: "chat.events", RoutingKey: "typing"}
+	restoreOriginalRoute(&first)
+	assert.Equal(t, "chat.events", first.Exchange)
+	assert.Equal(t, "typing", first.RoutingKey)
+}
+
+func TestDeadLetterFromDelivery(t *testing.T) {
+	dl := deadLetterFromDelivery(amqp.Delivery{
+		RoutingKey: "websocket.events",
+		Headers: amqp.Table{
+			HeaderOriginalExchange:   "chat.events",
+			HeaderOriginalRoutingKey: "message.created",
+			HeaderAttempts:           int32(5),
+			HeaderLastError:          "centrifugo: 400",
+		},
+		Body: []byte(`{}`),
+	})
+
+	assert.Equal(t, "chat.events", dl.Exchange)
+	assert.Equal(t, "message.created", dl.RoutingKey)
+	assert.Equal(t, 5, dl.Attempts)
+	assert.Equal(t, "centrifugo: 400", dl.LastError)
+}
+
 // Integration tests require a running RabbitMQ instance
 // and are skipped by default. Run with: go test -tags=integration ./pkg/rabbitmq/...
+
+func TestNewPublisherOptions(t *testing.T) {
+	p := NewPublisher(nil, "chat.events", WithConfirms(0), WithMandatory())
+	defer p.Close()
+
+	assert.True(t, p.confirms)
+	assert.True(t, p.mandatory)
+	assert.Equal(t, 1, p.poolSize)
+	assert.Len(t, p.pool, 1)
+	assert.Nil(t, p.buffer)
+
+	// Mandatory without confirms is ignored
+	p = NewPublisher(nil, "chat.events", WithMandatory())
+	assert.False(t, p.mandatory)
+	assert.Nil(t, p.pool)
+
+	// Transient messages are never buffered
+	p = NewPublisher(nil, "chat.signals", WithTransient(5*time.Second), WithPublishBuffer(10))
+	assert.True(t, p.transient)
+	assert.Equal(t, 5*time.Second, p.ttl)
+	assert.Nil(t, p.buffer)
+}
+
+func TestPublisherEnqueue(t *testing.T) {
+	p := &Publisher{exchange: "chat.events", buffer: make(chan pendingPublish, 1)}
+
+	assert.NoError(t, p.enqueue("message.created", amqp.Publishing{}))
+	assert.Equal(t, int64(1), p.buffered.Load())
+
+	assert.ErrorIs(t, p.enqueue("message.created", amqp.Publishing{}), ErrPublishBufferFull)
+	assert.Equal(t, int64(1), p.buffered.Load())
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert.True(t, isConnectionError(amqp.ErrClosed))
+	assert.True(t, isConnectionError(fmt.Errorf("failed to open channel: %w", amqp.ErrClosed)))
+	assert.False(t, isConnectionError(&amqp.Error{Code: amqp.NotFound, Reason: "no exchange", Recover: true}))
+	assert.False(t, isConnectionError(errors.New("boom")))
+}
+
+func TestPublishErrorType(t *testing.T) {
+	assert.Equal(t, "nacked", publishErrorType(ErrPublishNacked))
+	assert.Equal(t, "unroutable", publishErrorType(fmt.Errorf("%w: chat.events x", ErrUnroutable)))
+	assert.Equal(t, "buffer_full", publishErrorType(ErrPublishBufferFull))
+	assert.Equal(t, "timeout", publishErrorType(fmt.Errorf("failed to wait for publish confirm: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "connection", publishErrorType(amqp.ErrClosed))
+	assert.Equal(t, "publish", publishErrorType(errors.New("boom")))
+}
+
+func TestTopologyRecordsDeclarations(t *testing.T) {
+	var topo topology
+
+	topo.addExchange(Exchange{Name: "chat.events", Kind: "topic"})
+	topo.addExchange(Exchange{Name: "chat.events", Kind: "topic", Durable: true})
+	topo.addQueue(Queue{Name: "websocket.events"})
+	topo.addQueue(Queue{Name: ""})
+	topo.addBinding(binding{queue: "websocket.events", routingKey: "chat.#", exchange: "chat.events"})
+	topo.addBinding(binding{queue: "websocket.events", routingKey: "chat.#", exchange: "chat.events"})
+	topo.addBinding(binding{queue: "websocket.events", routingKey: "message.#", exchange: "chat.events"})
+
+	assert.Len(t, topo.exchanges, 1)
+	assert.True(t, topo.exchanges[0].Durable)
+	assert.Len(t, topo.queues, 1)
+	assert.Len(t, topo.bindings, 2)
+}
+
+// fakeAcknowledger records acks of deliveries handed to the consumer
+type fakeAcknowledger struct {
+	mu     sync.Mutex
+	acked  []uint64
+	nacked []uint64
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nacked = append(a.nacked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// fakeBroker hands out a new delivery channel per subscription, like a channel after reconnect
+type fakeBroker struct {
+	mu            sync.Mutex
+	subscriptions []chan amqp.Delivery
+	subscribed    chan chan amqp.Delivery
+	rejected      chan error
+	err           error
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{
+		subscribed: make(chan chan amqp.Delivery, 10),
+		rejected:   make(chan error, 10),
+	}
+}
+
+func (b *fakeBroker) subscribe() (<-chan amqp.Delivery, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		b.rejected <- b.err
+		return nil, b.err
+	}
+	msgs := make(chan amqp.Delivery, 10)
+	b.subscriptions = append(b.subscriptions, msgs)
+	b.subscribed <- msgs
+	return msgs, nil
+}
+
+func testConsumerResumes(t *testing.T, workers int) {
+	broker := newFakeBroker()
+	ack := &fakeAcknowledger{}
+	reconnected := make(chan struct{}, 1)
+	handled := make(chan uint64, 10)
+
+	c := NewConsumer(nil, "test.queue", "test", WithWorkers(workers))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.consumeLoop(ctx, broker.subscribe, reconnected, func(ctx context.Context, msg amqp.Delivery) error {
+			handled <- msg.DeliveryTag
+			return nil
+		})
+	}()
+
+	first := <-broker.subscribed
+	first <- amqp.Delivery{Acknowledger: ack, DeliveryTag: 1}
+	assert.Equal(t, uint64(1), <-handled)
+
+	// Connection lost: deliveries stop, then the connection signals it is back
+	close(first)
+	reconnected <- struct{}{}
+
+	second := <-broker.subscribed
+	second <- amqp.Delivery{Acknowledger: ack, DeliveryTag: 2}
+	assert.Equal(t, uint64(2), <-handled)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Len(t, broker.subscriptions, 2)
+
+	ack.mu.Lock()
+	defer ack.mu.Unlock()
+	assert.ElementsMatch(t, []uint64{1, 2}, ack.acked)
+}
+
+func TestConsumerResumesAfterReconnect(t *testing.T) {
+	testConsumerResumes(t, 1)
+}
+
+func TestConsumerWorkerPoolResumesAfterReconnect(t *testing.T) {
+	testConsumerResumes(t, 4)
+}
+
+func TestConsumerReturnsInitialSubscribeError(t *testing.T) {
+	broker := newFakeBroker()
+	broker.err = errors.New("failed to start consuming: queue not found")
+
+	c := NewConsumer(nil, "test.queue", "test")
+	err := c.consumeLoop(context.Background(), broker.subscribe, make(chan struct{}), func(ctx context.Context, msg amqp.Delivery) error {
+		return nil
+	})
+	assert.EqualError(t, err, "failed to start consuming: queue not found")
+}
+
+func TestConsumerRetriesFailedResubscribe(t *testing.T) {
+	broker := newFakeBroker()
+	reconnected := make(chan struct{}, 1)
+
+	c := NewConsumer(nil, "test.queue", "test")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.consumeLoop(ctx, broker.subscribe, reconnected, func(ctx context.Context, msg amqp.Delivery) error {
+			return nil
+		})
+	}()
+
+	first := <-broker.subscribed
+	broker.mu.Lock()
+	broker.err = errors.New("channel/connection is not open")
+	broker.mu.Unlock()
+	close(first)
+	reconnected <- struct{}{}
+	<-broker.rejected
+
+	// The failed attempt is not fatal; the next reconnect resumes consumption
+	broker.mu.Lock()
+	broker.err = nil
+	broker.mu.Unlock()
+	reconnected <- struct{}{}
+
+	<-broker.subscribed
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestPartitionIndex(t *testing.T) {
+	// Same key, same worker regardless of delivery tag
+	first := partitionIndex("chat-1", 1, 10)
+	for tag := uint64(2); tag < 50; tag++ {
+		assert.Equal(t, first, partitionIndex("chat-1", tag, 10))
+	}
+
+	// Keyless messages are spread by delivery tag
+	assert.Equal(t, 3, partitionIndex("", 13, 10))
+	assert.Equal(t, 4, partitionIndex("", 14, 10))
+}
+
+func TestJSONPartitionKey(t *testing.T) {
+	key := JSONPartitionKey("conference_id", "id")
+
+	assert.Equal(t, "conf-1", key(amqp.Delivery{Body: []byte(`{"id":"p-1","conference_id":"conf-1"}`)}))
+	assert.Equal(t, "conf-2", key(amqp.Delivery{Body: []byte(`{"id":"conf-2"}`)}))
+	assert.Equal(t, "conf-3", key(amqp.Delivery{Body: []byte(`{"id":"conf-3","conference_id":""}`)}))
+	assert.Equal(t, "", key(amqp.Delivery{Body: []byte(`{"id":42}`)}))
+	assert.Equal(t, "", key(amqp.Delivery{Body: []byte(`not json`)}))
+}
+
+func TestKeyedWorkersPreserveOrderPerKey(t *testing.T) {
+	c := NewConsumer(nil, "test.queue", "test",
+		WithPrefetch(100),
+		WithWorkers(4),
+		WithPartitionKey(JSONPartitionKey("chat_id")),
+	)
+
+	chats := []string{"chat-a", "chat-b", "chat-c", "chat-d", "chat-e"}
+	msgs := make(chan amqp.Delivery, 100)
+	for i := 0; i < 100; i++ {
+		body := fmt.Sprintf(`{"chat_id":%q,"seq":%d}`, chats[i%len(chats)], i)
+		msgs <- amqp.Delivery{Acknowledger: &fakeAcknowledger{}, DeliveryTag: uint64(i + 1), Body: []byte(body)}
+	}
+	close(msgs)
+
+	var mu sync.Mutex
+	seen := make(map[string][]int)
+	c.dispatch(context.Background(), msgs, func(ctx context.Context, msg amqp.Delivery) error {
+		var event struct {
+			ChatID string `json:"chat_id"`
+			Seq    int    `json:"seq"`
+		}
+		assert.NoError(t, json.Unmarshal(msg.Body, &event))
+		// Uneven handler latency would reorder messages across a plain worker pool
+		time.Sleep(time.Duration(event.Seq%3) * time.Millisecond)
+
+		mu.Lock()
+		seen[event.ChatID] = append(seen[event.ChatID], event.Seq)
+		mu.Unlock()
+		return nil
+	})
+
+	for _, chat := range chats {
+		assert.Len(t, seen[chat], 20)
+		assert.IsIncreasing(t, seen[chat], chat)
+	}
+}
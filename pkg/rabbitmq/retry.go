@@ -0,0 +1,226 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Headers set on retried and dead-lettered messages
+const (
+	HeaderAttempts           = "x-attempts"             // Failed handler attempts so far
+	HeaderLastError          = "x-last-error"           // Error of the last attempt
+	HeaderOriginalExchange   = "x-original-exchange"    // Exchange the message was first published to
+	HeaderOriginalRoutingKey = "x-original-routing-key" // Routing key the message was first published with
+	HeaderDeadLetteredAt     = "x-dead-lettered-at"     // RFC 3339 time the retries ran out
+)
+
+// maxErrorHeaderLength keeps error headers small
+const maxErrorHeaderLength = 1000
+
+// RetryPolicy controls what a Consumer does when the handler fails. Failed messages wait in a
+// delay queue and come back to the consumer queue; after MaxAttempts failures they go to the
+// queue's dead-letter exchange instead of looping forever.
+//
+// Topology for a queue Q:
+//   - Q.retry.<delay>: one queue per backoff step; messages expire back into Q via the default exchange
+//   - Q.dlx: fanout exchange for messages that ran out of attempts
+//   - Q.dead: queue bound to Q.dlx, inspected and replayed with tools/dlq
+type RetryPolicy struct {
+	MaxAttempts  int           // Handler attempts including the first one
+	InitialDelay time.Duration // Delay before the second attempt
+	MaxDelay     time.Duration // Upper bound for the delay
+	Multiplier   float64       // Growth factor between attempts
+}
+
+// DefaultRetryPolicy retries 4 times after 1s, 2s, 4s and 8s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  5,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+	}
+}
+
+// Delay returns how long to wait after the given number of failed attempts (1 = first failure)
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempts-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// WithRetryPolicy enables delayed retries and dead-lettering. Without it, failed messages are
+// requeued immediately.
+func WithRetryPolicy(policy RetryPolicy) ConsumerOption {
+	return func(c *Consumer) {
+		c.retry = &policy
+	}
+}
+
+// RetryQueueName returns the delay queue of queue for the given backoff step. The delay is part
+// of the name so that changing the policy declares new queues instead of conflicting with the
+// TTL of existing ones.
+func RetryQueueName(queue string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", queue, delay)
+}
+
+// DeadLetterExchangeName returns the exchange that receives messages of queue that ran out of attempts
+func DeadLetterExchangeName(queue string) string {
+	return queue + ".dlx"
+}
+
+// DeadLetterQueueName returns the queue that keeps dead-lettered messages of queue
+func DeadLetterQueueName(queue string) string {
+	return queue + ".dead"
+}
+
+// declareRetryTopology declares the delay queues and the dead-letter exchange and queue
+func (c *Consumer) declareRetryTopology() error {
+	declared := make(map[time.Duration]bool)
+	for attempts := 1; attempts < c.retry.MaxAttempts; attempts++ {
+		delay := c.retry.Delay(attempts)
+		if declared[delay] {
+			continue
+		}
+		declared[delay] = true
+
+		_, err := c.conn.DeclareQueue(Queue{
+			Name:    RetryQueueName(c.queue, delay),
+			Durable: true,
+			Args: amqp.Table{
+				"x-message-ttl":             delay.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": c.queue,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare retry queue: %w", err)
+		}
+	}
+
+	if err := c.conn.DeclareExchange(Exchange{
+		Name:    DeadLetterExchangeName(c.queue),
+		Kind:    "fanout",
+		Durable: true,
+	}); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+	if _, err := c.conn.DeclareQueue(Queue{
+		Name:    DeadLetterQueueName(c.queue),
+		Durable: true,
+	}); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err := c.conn.BindQueue(DeadLetterQueueName(c.queue), "", DeadLetterExchangeName(c.queue)); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	logger.Info("consumer retry policy configured",
+		zap.String("queue", c.queue),
+		zap.Int("max_attempts", c.retry.MaxAttempts),
+		zap.Duration("initial_delay", c.retry.InitialDelay),
+	)
+	return nil
+}
+
+// retryOrDeadLetter republishes a failed message to its next delay queue, or to the dead-letter
+// exchange when it ran out of attempts. The caller acks the original on success.
+func (c *Consumer) retryOrDeadLetter(ctx context.Context, msg amqp.Delivery, handlerErr error) error {
+	attempts := HeaderInt(msg.Headers, HeaderAttempts) + 1
+
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[HeaderAttempts] = int32(attempts)
+	headers[HeaderLastError] = truncate(handlerErr.Error(), maxErrorHeaderLength)
+	if _, ok := headers[HeaderOriginalRoutingKey]; !ok {
+		headers[HeaderOriginalExchange] = msg.Exchange
+		headers[HeaderOriginalRoutingKey] = msg.RoutingKey
+	}
+
+	exchange, routingKey := "", RetryQueueName(c.queue, c.retry.Delay(attempts))
+	if attempts >= c.retry.MaxAttempts {
+		exchange, routingKey = DeadLetterExchangeName(c.queue), msg.RoutingKey
+		headers[HeaderDeadLetteredAt] = time.Now().UTC().Format(time.RFC3339)
+		logger.Warn("message dead-lettered",
+			zap.String("queue", c.queue),
+			zap.String("routing_key", msg.RoutingKey),
+			zap.Int("attempts", attempts),
+			zap.Error(handlerErr),
+		)
+	}
+
+	return c.conn.Channel().PublishWithContext(ctx, exchange, routingKey, false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		MessageId:    msg.MessageId,
+		Timestamp:    msg.Timestamp,
+		Body:         msg.Body,
+		DeliveryMode: amqp.Persistent,
+	})
+}
+
+// restoreOriginalRoute gives a message coming back from a delay queue its original exchange and
+// routing key, so handlers see it as it was first published
+func restoreOriginalRoute(msg *amqp.Delivery) {
+	if rk, ok := msg.Headers[HeaderOriginalRoutingKey].(string); ok {
+		msg.RoutingKey = rk
+	}
+	if ex, ok := msg.Headers[HeaderOriginalExchange].(string); ok {
+		msg.Exchange = ex
+	}
+}
+
+// HeaderInt reads an integer header, accepting any of the integer types AMQP tables carry
+func HeaderInt(headers amqp.Table, key string) int {
+	switch v := headers[key].(type) {
+	case int:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint8:
+		return int(v)
+	case uint16:
+		return int(v)
+	case uint32:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// HeaderString reads a string header, returning "" when it is missing
+func HeaderString(headers amqp.Table, key string) string {
+	s, _ := headers[key].(string)
+	return s
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,124 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type binding struct {
+	queue      string
+	routingKey string
+	exchange   string
+}
+
+// topology records the exchanges, queues and bindings declared through a Connection, so they
+// can be declared again on a new channel after a reconnect. Non-durable and auto-delete
+// entities are gone by then, and durable ones may be missing on a fresh cluster node.
+type topology struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+	queues    []Queue
+	bindings  []binding
+}
+
+// addExchange records ex, replacing an earlier declaration of the same name
+func (t *topology) addExchange(ex Exchange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.exchanges {
+		if t.exchanges[i].Name == ex.Name {
+			t.exchanges[i] = ex
+			return
+		}
+	}
+	t.exchanges = append(t.exchanges, ex)
+}
+
+// addQueue records q, replacing an earlier declaration of the same name. Server-named queues
+// are skipped: their name changes with every declaration.
+func (t *topology) addQueue(q Queue) {
+	if q.Name == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.queues {
+		if t.queues[i].Name == q.Name {
+			t.queues[i] = q
+			return
+		}
+	}
+	t.queues = append(t.queues, q)
+}
+
+func (t *topology) addBinding(b binding) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, existing := range t.bindings {
+		if existing == b {
+			return
+		}
+	}
+	t.bindings = append(t.bindings, b)
+}
+
+// declare declares the recorded topology on ch: exchanges first, then queues, then bindings
+func (t *topology) declare(ch *amqp.Channel) error {
+	t.mu.Lock()
+	exchanges := append([]Exchange(nil), t.exchanges...)
+	queues := append([]Queue(nil), t.queues...)
+	bindings := append([]binding(nil), t.bindings...)
+	t.mu.Unlock()
+
+	for _, ex := range exchanges {
+		if err := declareExchange(ch, ex); err != nil {
+			return fmt.Errorf("failed to declare exchange %s: %w", ex.Name, err)
+		}
+	}
+	for _, q := range queues {
+		if _, err := declareQueue(ch, q); err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", q.Name, err)
+		}
+	}
+	for _, b := range bindings {
+		if err := bindQueue(ch, b); err != nil {
+			return fmt.Errorf("failed to bind queue %s to %s: %w", b.queue, b.exchange, err)
+		}
+	}
+	return nil
+}
+
+func declareExchange(ch *amqp.Channel, ex Exchange) error {
+	return ch.ExchangeDeclare(
+		ex.Name,
+		ex.Kind,
+		ex.Durable,
+		ex.AutoDelete,
+		ex.Internal,
+		ex.NoWait,
+		ex.Args,
+	)
+}
+
+func declareQueue(ch *amqp.Channel, q Queue) (amqp.Queue, error) {
+	return ch.QueueDeclare(
+		q.Name,
+		q.Durable,
+		q.AutoDelete,
+		q.Exclusive,
+		q.NoWait,
+		q.Args,
+	)
+}
+
+func bindQueue(ch *amqp.Channel, b binding) error {
+	return ch.QueueBind(
+		b.queue,
+		b.routingKey,
+		b.exchange,
+		false,
+		nil,
+	)
+}
@@ -20,52 +20,99 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ChatService_CreateChat_FullMethodName              = "/chat.ChatService/CreateChat"
-	ChatService_GetChat_FullMethodName                 = "/chat.ChatService/GetChat"
-	ChatService_ListChats_FullMethodName               = "/chat.ChatService/ListChats"
-	ChatService_UpdateChat_FullMethodName              = "/chat.ChatService/UpdateChat"
-	ChatService_DeleteChat_FullMethodName              = "/chat.ChatService/DeleteChat"
-	ChatService_SearchChats_FullMethodName             = "/chat.ChatService/SearchChats"
-	ChatService_AddParticipant_FullMethodName          = "/chat.ChatService/AddParticipant"
-	ChatService_RemoveParticipant_FullMethodName       = "/chat.ChatService/RemoveParticipant"
-	ChatService_UpdateParticipantRole_FullMethodName   = "/chat.ChatService/UpdateParticipantRole"
-	ChatService_ListParticipants_FullMethodName        = "/chat.ChatService/ListParticipants"
-	ChatService_SendMessage_FullMethodName             = "/chat.ChatService/SendMessage"
-	ChatService_SendSystemMessage_FullMethodName       = "/chat.ChatService/SendSystemMessage"
-	ChatService_GetMessage_FullMethodName              = "/chat.ChatService/GetMessage"
-	ChatService_ListMessages_FullMethodName            = "/chat.ChatService/ListMessages"
-	ChatService_SyncMessages_FullMethodName            = "/chat.ChatService/SyncMessages"
-	ChatService_UpdateMessage_FullMethodName           = "/chat.ChatService/UpdateMessage"
-	ChatService_DeleteMessage_FullMethodName           = "/chat.ChatService/DeleteMessage"
-	ChatService_RestoreMessage_FullMethodName          = "/chat.ChatService/RestoreMessage"
-	ChatService_RemoveFromQuote_FullMethodName         = "/chat.ChatService/RemoveFromQuote"
-	ChatService_GetThreadMessages_FullMethodName       = "/chat.ChatService/GetThreadMessages"
-	ChatService_ForwardMessage_FullMethodName          = "/chat.ChatService/ForwardMessage"
-	ChatService_AddReaction_FullMethodName             = "/chat.ChatService/AddReaction"
-	ChatService_RemoveReaction_FullMethodName          = "/chat.ChatService/RemoveReaction"
-	ChatService_ListReactions_FullMethodName           = "/chat.ChatService/ListReactions"
-	ChatService_MarkAsRead_FullMethodName              = "/chat.ChatService/MarkAsRead"
-	ChatService_GetReadStatus_FullMethodName           = "/chat.ChatService/GetReadStatus"
-	ChatService_AddToFavorites_FullMethodName          = "/chat.ChatService/AddToFavorites"
-	ChatService_RemoveFromFavorites_FullMethodName     = "/chat.ChatService/RemoveFromFavorites"
-	ChatService_ArchiveChat_FullMethodName             = "/chat.ChatService/ArchiveChat"
-	ChatService_UnarchiveChat_FullMethodName           = "/chat.ChatService/UnarchiveChat"
-	ChatService_ListArchivedChats_FullMethodName       = "/chat.ChatService/ListArchivedChats"
-	ChatService_CreatePoll_FullMethodName              = "/chat.ChatService/CreatePoll"
-	ChatService_VotePoll_FullMethodName                = "/chat.ChatService/VotePoll"
-	ChatService_FinishPoll_FullMethodName              = "/chat.ChatService/FinishPoll"
-	ChatService_DeletePoll_FullMethodName              = "/chat.ChatService/DeletePoll"
-	ChatService_SendTyping_FullMethodName              = "/chat.ChatService/SendTyping"
-	ChatService_CreateThread_FullMethodName            = "/chat.ChatService/CreateThread"
-	ChatService_GetThread_FullMethodName               = "/chat.ChatService/GetThread"
-	ChatService_ListThreads_FullMethodName             = "/chat.ChatService/ListThreads"
-	ChatService_ArchiveThread_FullMethodName           = "/chat.ChatService/ArchiveThread"
-	ChatService_ListThreadMessages_FullMethodName      = "/chat.ChatService/ListThreadMessages"
-	ChatService_AddThreadParticipant_FullMethodName    = "/chat.ChatService/AddThreadParticipant"
-	ChatService_RemoveThreadParticipant_FullMethodName = "/chat.ChatService/RemoveThreadParticipant"
-	ChatService_ListThreadParticipants_FullMethodName  = "/chat.ChatService/ListThreadParticipants"
-	ChatService_ListSubthreads_FullMethodName          = "/chat.ChatService/ListSubthreads"
-	ChatService_CreateSubthread_FullMethodName         = "/chat.ChatService/CreateSubthread"
+	ChatService_CreateChat_FullMethodName                 = "/chat.ChatService/CreateChat"
+	ChatService_GetChat_FullMethodName                    = "/chat.ChatService/GetChat"
+	ChatService_ListChats_FullMethodName                  = "/chat.ChatService/ListChats"
+	ChatService_UpdateChat_FullMethodName                 = "/chat.ChatService/UpdateChat"
+	ChatService_DeleteChat_FullMethodName                 = "/chat.ChatService/DeleteChat"
+	ChatService_SearchChats_FullMethodName                = "/chat.ChatService/SearchChats"
+	ChatService_GetChatListChanges_FullMethodName         = "/chat.ChatService/GetChatListChanges"
+	ChatService_SetSlowMode_FullMethodName                = "/chat.ChatService/SetSlowMode"
+	ChatService_AddParticipant_FullMethodName             = "/chat.ChatService/AddParticipant"
+	ChatService_RemoveParticipant_FullMethodName          = "/chat.ChatService/RemoveParticipant"
+	ChatService_UpdateParticipantRole_FullMethodName      = "/chat.ChatService/UpdateParticipantRole"
+	ChatService_ListParticipants_FullMethodName           = "/chat.ChatService/ListParticipants"
+	ChatService_SendMessage_FullMethodName                = "/chat.ChatService/SendMessage"
+	ChatService_SendSystemMessage_FullMethodName          = "/chat.ChatService/SendSystemMessage"
+	ChatService_GetMessage_FullMethodName                 = "/chat.ChatService/GetMessage"
+	ChatService_ListMessages_FullMethodName               = "/chat.ChatService/ListMessages"
+	ChatService_SyncMessages_FullMethodName               = "/chat.ChatService/SyncMessages"
+	ChatService_UpdateMessage_FullMethodName              = "/chat.ChatService/UpdateMessage"
+	ChatService_DeleteMessage_FullMethodName              = "/chat.ChatService/DeleteMessage"
+	ChatService_RestoreMessage_FullMethodName             = "/chat.ChatService/RestoreMessage"
+	ChatService_RemoveFromQuote_FullMethodName            = "/chat.ChatService/RemoveFromQuote"
+	ChatService_BulkDeleteMessages_FullMethodName         = "/chat.ChatService/BulkDeleteMessages"
+	ChatService_PurgeUserMessages_FullMethodName          = "/chat.ChatService/PurgeUserMessages"
+	ChatService_GetThreadMessages_FullMethodName          = "/chat.ChatService/GetThreadMessages"
+	ChatService_ForwardMessage_FullMethodName             = "/chat.ChatService/ForwardMessage"
+	ChatService_AddReaction_FullMethodName                = "/chat.ChatService/AddReaction"
+	ChatService_RemoveReaction_FullMethodName             = "/chat.ChatService/RemoveReaction"
+	ChatService_ListReactions_FullMethodName              = "/chat.ChatService/ListReactions"
+	ChatService_MarkAsRead_FullMethodName                 = "/chat.ChatService/MarkAsRead"
+	ChatService_GetReadStatus_FullMethodName              = "/chat.ChatService/GetReadStatus"
+	ChatService_AddToFavorites_FullMethodName             = "/chat.ChatService/AddToFavorites"
+	ChatService_RemoveFromFavorites_FullMethodName        = "/chat.ChatService/RemoveFromFavorites"
+	ChatService_ArchiveChat_FullMethodName                = "/chat.ChatService/ArchiveChat"
+	ChatService_UnarchiveChat_FullMethodName              = "/chat.ChatService/UnarchiveChat"
+	ChatService_ListArchivedChats_FullMethodName          = "/chat.ChatService/ListArchivedChats"
+	ChatService_CreatePoll_FullMethodName                 = "/chat.ChatService/CreatePoll"
+	ChatService_VotePoll_FullMethodName                   = "/chat.ChatService/VotePoll"
+	ChatService_FinishPoll_FullMethodName                 = "/chat.ChatService/FinishPoll"
+	ChatService_DeletePoll_FullMethodName                 = "/chat.ChatService/DeletePoll"
+	ChatService_SendTyping_FullMethodName                 = "/chat.ChatService/SendTyping"
+	ChatService_CreateThread_FullMethodName               = "/chat.ChatService/CreateThread"
+	ChatService_GetThread_FullMethodName                  = "/chat.ChatService/GetThread"
+	ChatService_ListThreads_FullMethodName                = "/chat.ChatService/ListThreads"
+	ChatService_ArchiveThread_FullMethodName              = "/chat.ChatService/ArchiveThread"
+	ChatService_ListThreadMessages_FullMethodName         = "/chat.ChatService/ListThreadMessages"
+	ChatService_MoveMessagesToThread_FullMethodName       = "/chat.ChatService/MoveMessagesToThread"
+	ChatService_AddThreadParticipant_FullMethodName       = "/chat.ChatService/AddThreadParticipant"
+	ChatService_RemoveThreadParticipant_FullMethodName    = "/chat.ChatService/RemoveThreadParticipant"
+	ChatService_ListThreadParticipants_FullMethodName     = "/chat.ChatService/ListThreadParticipants"
+	ChatService_FollowThread_FullMethodName               = "/chat.ChatService/FollowThread"
+	ChatService_UnfollowThread_FullMethodName             = "/chat.ChatService/UnfollowThread"
+	ChatService_ListFollowedThreads_FullMethodName        = "/chat.ChatService/ListFollowedThreads"
+	ChatService_MarkThreadAsRead_FullMethodName           = "/chat.ChatService/MarkThreadAsRead"
+	ChatService_ListSubthreads_FullMethodName             = "/chat.ChatService/ListSubthreads"
+	ChatService_CreateSubthread_FullMethodName            = "/chat.ChatService/CreateSubthread"
+	ChatService_ReportMessage_FullMethodName              = "/chat.ChatService/ReportMessage"
+	ChatService_ListReports_FullMethodName                = "/chat.ChatService/ListReports"
+	ChatService_ResolveReport_FullMethodName              = "/chat.ChatService/ResolveReport"
+	ChatService_ListModerationAuditLog_FullMethodName     = "/chat.ChatService/ListModerationAuditLog"
+	ChatService_BanUser_FullMethodName                    = "/chat.ChatService/BanUser"
+	ChatService_UnbanUser_FullMethodName                  = "/chat.ChatService/UnbanUser"
+	ChatService_MuteUser_FullMethodName                   = "/chat.ChatService/MuteUser"
+	ChatService_UnmuteUser_FullMethodName                 = "/chat.ChatService/UnmuteUser"
+	ChatService_ListChatRestrictions_FullMethodName       = "/chat.ChatService/ListChatRestrictions"
+	ChatService_GetContentPolicy_FullMethodName           = "/chat.ChatService/GetContentPolicy"
+	ChatService_SetContentPolicy_FullMethodName           = "/chat.ChatService/SetContentPolicy"
+	ChatService_DeleteContentPolicy_FullMethodName        = "/chat.ChatService/DeleteContentPolicy"
+	ChatService_ListContentFilterHits_FullMethodName      = "/chat.ChatService/ListContentFilterHits"
+	ChatService_CreateWebhook_FullMethodName              = "/chat.ChatService/CreateWebhook"
+	ChatService_ListWebhooks_FullMethodName               = "/chat.ChatService/ListWebhooks"
+	ChatService_UpdateWebhook_FullMethodName              = "/chat.ChatService/UpdateWebhook"
+	ChatService_DeleteWebhook_FullMethodName              = "/chat.ChatService/DeleteWebhook"
+	ChatService_ListWebhookDeliveries_FullMethodName      = "/chat.ChatService/ListWebhookDeliveries"
+	ChatService_RedeliverWebhook_FullMethodName           = "/chat.ChatService/RedeliverWebhook"
+	ChatService_CreateIncomingWebhook_FullMethodName      = "/chat.ChatService/CreateIncomingWebhook"
+	ChatService_ListIncomingWebhooks_FullMethodName       = "/chat.ChatService/ListIncomingWebhooks"
+	ChatService_RotateIncomingWebhookToken_FullMethodName = "/chat.ChatService/RotateIncomingWebhookToken"
+	ChatService_DeleteIncomingWebhook_FullMethodName      = "/chat.ChatService/DeleteIncomingWebhook"
+	ChatService_ExecuteIncomingWebhook_FullMethodName     = "/chat.ChatService/ExecuteIncomingWebhook"
+	ChatService_ListCommands_FullMethodName               = "/chat.ChatService/ListCommands"
+	ChatService_RegisterBotCommand_FullMethodName         = "/chat.ChatService/RegisterBotCommand"
+	ChatService_ListBotCommands_FullMethodName            = "/chat.ChatService/ListBotCommands"
+	ChatService_DeleteBotCommand_FullMethodName           = "/chat.ChatService/DeleteBotCommand"
+	ChatService_InteractMessage_FullMethodName            = "/chat.ChatService/InteractMessage"
+	ChatService_CreateMessageReminder_FullMethodName      = "/chat.ChatService/CreateMessageReminder"
+	ChatService_ListReminders_FullMethodName              = "/chat.ChatService/ListReminders"
+	ChatService_CancelReminder_FullMethodName             = "/chat.ChatService/CancelReminder"
+	ChatService_CreateCustomEmoji_FullMethodName          = "/chat.ChatService/CreateCustomEmoji"
+	ChatService_ListCustomEmoji_FullMethodName            = "/chat.ChatService/ListCustomEmoji"
+	ChatService_DeleteCustomEmoji_FullMethodName          = "/chat.ChatService/DeleteCustomEmoji"
+	ChatService_SaveDraft_FullMethodName                  = "/chat.ChatService/SaveDraft"
+	ChatService_GetDrafts_FullMethodName                  = "/chat.ChatService/GetDrafts"
+	ChatService_DeleteDraft_FullMethodName                = "/chat.ChatService/DeleteDraft"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -81,6 +128,8 @@ type ChatServiceClient interface {
 	UpdateChat(ctx context.Context, in *UpdateChatRequest, opts ...grpc.CallOption) (*Chat, error)
 	DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SearchChats(ctx context.Context, in *SearchChatsRequest, opts ...grpc.CallOption) (*ListChatsResponse, error)
+	GetChatListChanges(ctx context.Context, in *GetChatListChangesRequest, opts ...grpc.CallOption) (*GetChatListChangesResponse, error)
+	SetSlowMode(ctx context.Context, in *SetSlowModeRequest, opts ...grpc.CallOption) (*Chat, error)
 	// Participant operations
 	AddParticipant(ctx context.Context, in *AddParticipantRequest, opts ...grpc.CallOption) (*ChatParticipant, error)
 	RemoveParticipant(ctx context.Context, in *RemoveParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -96,6 +145,8 @@ type ChatServiceClient interface {
 	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RestoreMessage(ctx context.Context, in *RestoreMessageRequest, opts ...grpc.CallOption) (*Message, error)
 	RemoveFromQuote(ctx context.Context, in *RemoveFromQuoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BulkDeleteMessages(ctx context.Context, in *BulkDeleteMessagesRequest, opts ...grpc.CallOption) (*BulkDeleteMessagesResponse, error)
+	PurgeUserMessages(ctx context.Context, in *PurgeUserMessagesRequest, opts ...grpc.CallOption) (*BulkDeleteMessagesResponse, error)
 	GetThreadMessages(ctx context.Context, in *GetThreadMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
 	ForwardMessage(ctx context.Context, in *ForwardMessageRequest, opts ...grpc.CallOption) (*Message, error)
 	// Reaction operations
@@ -125,13 +176,66 @@ type ChatServiceClient interface {
 	ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error)
 	ArchiveThread(ctx context.Context, in *ArchiveThreadRequest, opts ...grpc.CallOption) (*Thread, error)
 	ListThreadMessages(ctx context.Context, in *ListThreadMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
+	MoveMessagesToThread(ctx context.Context, in *MoveMessagesToThreadRequest, opts ...grpc.CallOption) (*MoveMessagesToThreadResponse, error)
 	// Thread participant operations
 	AddThreadParticipant(ctx context.Context, in *AddThreadParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RemoveThreadParticipant(ctx context.Context, in *RemoveThreadParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListThreadParticipants(ctx context.Context, in *ListThreadParticipantsRequest, opts ...grpc.CallOption) (*ListThreadParticipantsResponse, error)
+	// Thread subscriptions (follow/unfollow, inbox)
+	FollowThread(ctx context.Context, in *FollowThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnfollowThread(ctx context.Context, in *UnfollowThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListFollowedThreads(ctx context.Context, in *ListFollowedThreadsRequest, opts ...grpc.CallOption) (*ListFollowedThreadsResponse, error)
+	MarkThreadAsRead(ctx context.Context, in *MarkThreadAsReadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Subthread operations
 	ListSubthreads(ctx context.Context, in *ListSubthreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error)
 	CreateSubthread(ctx context.Context, in *CreateSubthreadRequest, opts ...grpc.CallOption) (*Thread, error)
+	// Moderation (message reports)
+	ReportMessage(ctx context.Context, in *ReportMessageRequest, opts ...grpc.CallOption) (*MessageReport, error)
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error)
+	ResolveReport(ctx context.Context, in *ResolveReportRequest, opts ...grpc.CallOption) (*MessageReport, error)
+	ListModerationAuditLog(ctx context.Context, in *ListModerationAuditLogRequest, opts ...grpc.CallOption) (*ListModerationAuditLogResponse, error)
+	// Chat restrictions (bans and timed mutes)
+	BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*ChatRestriction, error)
+	UnbanUser(ctx context.Context, in *UnbanUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*ChatRestriction, error)
+	UnmuteUser(ctx context.Context, in *UnmuteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListChatRestrictions(ctx context.Context, in *ListChatRestrictionsRequest, opts ...grpc.CallOption) (*ListChatRestrictionsResponse, error)
+	// Content filters (chat_id unset = global policy)
+	GetContentPolicy(ctx context.Context, in *GetContentPolicyRequest, opts ...grpc.CallOption) (*ContentPolicy, error)
+	SetContentPolicy(ctx context.Context, in *SetContentPolicyRequest, opts ...grpc.CallOption) (*ContentPolicy, error)
+	DeleteContentPolicy(ctx context.Context, in *DeleteContentPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListContentFilterHits(ctx context.Context, in *ListContentFilterHitsRequest, opts ...grpc.CallOption) (*ListContentFilterHitsResponse, error)
+	// Outgoing webhooks (chat_id unset = subscription to all chats)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	// Incoming webhooks (bots posting into a chat)
+	CreateIncomingWebhook(ctx context.Context, in *CreateIncomingWebhookRequest, opts ...grpc.CallOption) (*IncomingWebhook, error)
+	ListIncomingWebhooks(ctx context.Context, in *ListIncomingWebhooksRequest, opts ...grpc.CallOption) (*ListIncomingWebhooksResponse, error)
+	RotateIncomingWebhookToken(ctx context.Context, in *RotateIncomingWebhookTokenRequest, opts ...grpc.CallOption) (*IncomingWebhook, error)
+	DeleteIncomingWebhook(ctx context.Context, in *DeleteIncomingWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExecuteIncomingWebhook(ctx context.Context, in *ExecuteIncomingWebhookRequest, opts ...grpc.CallOption) (*Message, error)
+	// Slash commands and interactive messages (chat_id unset = commands of all chats)
+	ListCommands(ctx context.Context, in *ListCommandsRequest, opts ...grpc.CallOption) (*ListCommandsResponse, error)
+	RegisterBotCommand(ctx context.Context, in *RegisterBotCommandRequest, opts ...grpc.CallOption) (*BotCommand, error)
+	ListBotCommands(ctx context.Context, in *ListBotCommandsRequest, opts ...grpc.CallOption) (*ListBotCommandsResponse, error)
+	DeleteBotCommand(ctx context.Context, in *DeleteBotCommandRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	InteractMessage(ctx context.Context, in *InteractMessageRequest, opts ...grpc.CallOption) (*InteractMessageResponse, error)
+	// Reminders ("remind me about this" and /remind)
+	CreateMessageReminder(ctx context.Context, in *CreateMessageReminderRequest, opts ...grpc.CallOption) (*Reminder, error)
+	ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error)
+	CancelReminder(ctx context.Context, in *CancelReminderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Custom emoji (":name:" reactions)
+	CreateCustomEmoji(ctx context.Context, in *CreateCustomEmojiRequest, opts ...grpc.CallOption) (*CustomEmoji, error)
+	ListCustomEmoji(ctx context.Context, in *ListCustomEmojiRequest, opts ...grpc.CallOption) (*ListCustomEmojiResponse, error)
+	DeleteCustomEmoji(ctx context.Context, in *DeleteCustomEmojiRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Drafts synchronized across the user's devices
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Draft, error)
+	GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error)
+	DeleteDraft(ctx context.Context, in *DeleteDraftRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type chatServiceClient struct {
@@ -202,6 +306,26 @@ func (c *chatServiceClient) SearchChats(ctx context.Context, in *SearchChatsRequ
 	return out, nil
 }
 
+func (c *chatServiceClient) GetChatListChanges(ctx context.Context, in *GetChatListChangesRequest, opts ...grpc.CallOption) (*GetChatListChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChatListChangesResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetChatListChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SetSlowMode(ctx context.Context, in *SetSlowModeRequest, opts ...grpc.CallOption) (*Chat, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Chat)
+	err := c.cc.Invoke(ctx, ChatService_SetSlowMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) AddParticipant(ctx context.Context, in *AddParticipantRequest, opts ...grpc.CallOption) (*ChatParticipant, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChatParticipant)
@@ -332,6 +456,26 @@ func (c *chatServiceClient) RemoveFromQuote(ctx context.Context, in *RemoveFromQ
 	return out, nil
 }
 
+func (c *chatServiceClient) BulkDeleteMessages(ctx context.Context, in *BulkDeleteMessagesRequest, opts ...grpc.CallOption) (*BulkDeleteMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_BulkDeleteMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) PurgeUserMessages(ctx context.Context, in *PurgeUserMessagesRequest, opts ...grpc.CallOption) (*BulkDeleteMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_PurgeUserMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) GetThreadMessages(ctx context.Context, in *GetThreadMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListMessagesResponse)
@@ -552,6 +696,16 @@ func (c *chatServiceClient) ListThreadMessages(ctx context.Context, in *ListThre
 	return out, nil
 }
 
+func (c *chatServiceClient) MoveMessagesToThread(ctx context.Context, in *MoveMessagesToThreadRequest, opts ...grpc.CallOption) (*MoveMessagesToThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveMessagesToThreadResponse)
+	err := c.cc.Invoke(ctx, ChatService_MoveMessagesToThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) AddThreadParticipant(ctx context.Context, in *AddThreadParticipantRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -582,6 +736,46 @@ func (c *chatServiceClient) ListThreadParticipants(ctx context.Context, in *List
 	return out, nil
 }
 
+func (c *chatServiceClient) FollowThread(ctx context.Context, in *FollowThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_FollowThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnfollowThread(ctx context.Context, in *UnfollowThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_UnfollowThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListFollowedThreads(ctx context.Context, in *ListFollowedThreadsRequest, opts ...grpc.CallOption) (*ListFollowedThreadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowedThreadsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListFollowedThreads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) MarkThreadAsRead(ctx context.Context, in *MarkThreadAsReadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_MarkThreadAsRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chatServiceClient) ListSubthreads(ctx context.Context, in *ListSubthreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListThreadsResponse)
@@ -602,1063 +796,2487 @@ func (c *chatServiceClient) CreateSubthread(ctx context.Context, in *CreateSubth
 	return out, nil
 }
 
-// ChatServiceServer is the server API for ChatService service.
-// All implementations must embed UnimplementedChatServiceServer
-// for forward compatibility.
-//
-// Chat service definition
-type ChatServiceServer interface {
-	// Chat operations
-	CreateChat(context.Context, *CreateChatRequest) (*Chat, error)
-	GetChat(context.Context, *GetChatRequest) (*Chat, error)
-	ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error)
-	UpdateChat(context.Context, *UpdateChatRequest) (*Chat, error)
-	DeleteChat(context.Context, *DeleteChatRequest) (*emptypb.Empty, error)
-	SearchChats(context.Context, *SearchChatsRequest) (*ListChatsResponse, error)
-	// Participant operations
-	AddParticipant(context.Context, *AddParticipantRequest) (*ChatParticipant, error)
-	RemoveParticipant(context.Context, *RemoveParticipantRequest) (*emptypb.Empty, error)
-	UpdateParticipantRole(context.Context, *UpdateParticipantRoleRequest) (*ChatParticipant, error)
-	ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error)
-	// Message operations
-	SendMessage(context.Context, *SendMessageRequest) (*Message, error)
-	SendSystemMessage(context.Context, *SendSystemMessageRequest) (*Message, error)
-	GetMessage(context.Context, *GetMessageRequest) (*Message, error)
-	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
-	SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error)
-	UpdateMessage(context.Context, *UpdateMessageRequest) (*Message, error)
-	DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error)
-	RestoreMessage(context.Context, *RestoreMessageRequest) (*Message, error)
-	RemoveFromQuote(context.Context, *RemoveFromQuoteRequest) (*emptypb.Empty, error)
-	GetThreadMessages(context.Context, *GetThreadMessagesRequest) (*ListMessagesResponse, error)
-	ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error)
-	// Reaction operations
-	AddReaction(context.Context, *AddReactionRequest) (*emptypb.Empty, error)
-	RemoveReaction(context.Context, *RemoveReactionRequest) (*emptypb.Empty, error)
-	ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error)
-	// Read status operations
-	MarkAsRead(context.Context, *MarkAsReadRequest) (*emptypb.Empty, error)
-	GetReadStatus(context.Context, *GetReadStatusRequest) (*ReadStatusResponse, error)
-	// Favorites operations
-	AddToFavorites(context.Context, *AddToFavoritesRequest) (*emptypb.Empty, error)
-	RemoveFromFavorites(context.Context, *RemoveFromFavoritesRequest) (*emptypb.Empty, error)
-	// Archive operations
-	ArchiveChat(context.Context, *ArchiveChatRequest) (*emptypb.Empty, error)
-	UnarchiveChat(context.Context, *UnarchiveChatRequest) (*emptypb.Empty, error)
-	ListArchivedChats(context.Context, *ListArchivedChatsRequest) (*ListChatsResponse, error)
-	// Poll operations
-	CreatePoll(context.Context, *CreatePollRequest) (*Poll, error)
-	VotePoll(context.Context, *VotePollRequest) (*emptypb.Empty, error)
-	FinishPoll(context.Context, *FinishPollRequest) (*Poll, error)
-	DeletePoll(context.Context, *DeletePollRequest) (*emptypb.Empty, error)
-	// Typing indicator
-	SendTyping(context.Context, *SendTypingRequest) (*emptypb.Empty, error)
-	// Thread operations
-	CreateThread(context.Context, *CreateThreadRequest) (*Thread, error)
-	GetThread(context.Context, *GetThreadRequest) (*Thread, error)
-	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error)
-	ArchiveThread(context.Context, *ArchiveThreadRequest) (*Thread, error)
-	ListThreadMessages(context.Context, *ListThreadMessagesRequest) (*ListMessagesResponse, error)
-	// Thread participant operations
-	AddThreadParticipant(context.Context, *AddThreadParticipantRequest) (*emptypb.Empty, error)
-	RemoveThreadParticipant(context.Context, *RemoveThreadParticipantRequest) (*emptypb.Empty, error)
-	ListThreadParticipants(context.Context, *ListThreadParticipantsRequest) (*ListThreadParticipantsResponse, error)
-	// Subthread operations
-	ListSubthreads(context.Context, *ListSubthreadsRequest) (*ListThreadsResponse, error)
-	CreateSubthread(context.Context, *CreateSubthreadRequest) (*Thread, error)
-	mustEmbedUnimplementedChatServiceServer()
+func (c *chatServiceClient) ReportMessage(ctx context.Context, in *ReportMessageRequest, opts ...grpc.CallOption) (*MessageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageReport)
+	err := c.cc.Invoke(ctx, ChatService_ReportMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedChatServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedChatServiceServer struct{}
+func (c *chatServiceClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReportsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedChatServiceServer) CreateChat(context.Context, *CreateChatRequest) (*Chat, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateChat not implemented")
+func (c *chatServiceClient) ResolveReport(ctx context.Context, in *ResolveReportRequest, opts ...grpc.CallOption) (*MessageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MessageReport)
+	err := c.cc.Invoke(ctx, ChatService_ResolveReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) GetChat(context.Context, *GetChatRequest) (*Chat, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChat not implemented")
+
+func (c *chatServiceClient) ListModerationAuditLog(ctx context.Context, in *ListModerationAuditLogRequest, opts ...grpc.CallOption) (*ListModerationAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModerationAuditLogResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListModerationAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListChats not implemented")
+
+func (c *chatServiceClient) BanUser(ctx context.Context, in *BanUserRequest, opts ...grpc.CallOption) (*ChatRestriction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatRestriction)
+	err := c.cc.Invoke(ctx, ChatService_BanUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) UpdateChat(context.Context, *UpdateChatRequest) (*Chat, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateChat not implemented")
+
+func (c *chatServiceClient) UnbanUser(ctx context.Context, in *UnbanUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_UnbanUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) DeleteChat(context.Context, *DeleteChatRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteChat not implemented")
+
+func (c *chatServiceClient) MuteUser(ctx context.Context, in *MuteUserRequest, opts ...grpc.CallOption) (*ChatRestriction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatRestriction)
+	err := c.cc.Invoke(ctx, ChatService_MuteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) SearchChats(context.Context, *SearchChatsRequest) (*ListChatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SearchChats not implemented")
+
+func (c *chatServiceClient) UnmuteUser(ctx context.Context, in *UnmuteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_UnmuteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) AddParticipant(context.Context, *AddParticipantRequest) (*ChatParticipant, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddParticipant not implemented")
+
+func (c *chatServiceClient) ListChatRestrictions(ctx context.Context, in *ListChatRestrictionsRequest, opts ...grpc.CallOption) (*ListChatRestrictionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChatRestrictionsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListChatRestrictions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) RemoveParticipant(context.Context, *RemoveParticipantRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveParticipant not implemented")
+
+func (c *chatServiceClient) GetContentPolicy(ctx context.Context, in *GetContentPolicyRequest, opts ...grpc.CallOption) (*ContentPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContentPolicy)
+	err := c.cc.Invoke(ctx, ChatService_GetContentPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) UpdateParticipantRole(context.Context, *UpdateParticipantRoleRequest) (*ChatParticipant, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateParticipantRole not implemented")
+
+func (c *chatServiceClient) SetContentPolicy(ctx context.Context, in *SetContentPolicyRequest, opts ...grpc.CallOption) (*ContentPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContentPolicy)
+	err := c.cc.Invoke(ctx, ChatService_SetContentPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListParticipants not implemented")
+
+func (c *chatServiceClient) DeleteContentPolicy(ctx context.Context, in *DeleteContentPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteContentPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+
+func (c *chatServiceClient) ListContentFilterHits(ctx context.Context, in *ListContentFilterHitsRequest, opts ...grpc.CallOption) (*ListContentFilterHitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContentFilterHitsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListContentFilterHits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) SendSystemMessage(context.Context, *SendSystemMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendSystemMessage not implemented")
+
+func (c *chatServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, ChatService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) GetMessage(context.Context, *GetMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMessage not implemented")
+
+func (c *chatServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMessages not implemented")
-}
-func (UnimplementedChatServiceServer) SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SyncMessages not implemented")
-}
-func (UnimplementedChatServiceServer) UpdateMessage(context.Context, *UpdateMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateMessage not implemented")
-}
-func (UnimplementedChatServiceServer) DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteMessage not implemented")
+
+func (c *chatServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, ChatService_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) RestoreMessage(context.Context, *RestoreMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RestoreMessage not implemented")
+
+func (c *chatServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) RemoveFromQuote(context.Context, *RemoveFromQuoteRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromQuote not implemented")
+
+func (c *chatServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) GetThreadMessages(context.Context, *GetThreadMessagesRequest) (*ListMessagesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetThreadMessages not implemented")
+
+func (c *chatServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, ChatService_RedeliverWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ForwardMessage not implemented")
+
+func (c *chatServiceClient) CreateIncomingWebhook(ctx context.Context, in *CreateIncomingWebhookRequest, opts ...grpc.CallOption) (*IncomingWebhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncomingWebhook)
+	err := c.cc.Invoke(ctx, ChatService_CreateIncomingWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) AddReaction(context.Context, *AddReactionRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddReaction not implemented")
+
+func (c *chatServiceClient) ListIncomingWebhooks(ctx context.Context, in *ListIncomingWebhooksRequest, opts ...grpc.CallOption) (*ListIncomingWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomingWebhooksResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListIncomingWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) RemoveReaction(context.Context, *RemoveReactionRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveReaction not implemented")
+
+func (c *chatServiceClient) RotateIncomingWebhookToken(ctx context.Context, in *RotateIncomingWebhookTokenRequest, opts ...grpc.CallOption) (*IncomingWebhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncomingWebhook)
+	err := c.cc.Invoke(ctx, ChatService_RotateIncomingWebhookToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListReactions not implemented")
+
+func (c *chatServiceClient) DeleteIncomingWebhook(ctx context.Context, in *DeleteIncomingWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteIncomingWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) MarkAsRead(context.Context, *MarkAsReadRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MarkAsRead not implemented")
+
+func (c *chatServiceClient) ExecuteIncomingWebhook(ctx context.Context, in *ExecuteIncomingWebhookRequest, opts ...grpc.CallOption) (*Message, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Message)
+	err := c.cc.Invoke(ctx, ChatService_ExecuteIncomingWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) GetReadStatus(context.Context, *GetReadStatusRequest) (*ReadStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetReadStatus not implemented")
+
+func (c *chatServiceClient) ListCommands(ctx context.Context, in *ListCommandsRequest, opts ...grpc.CallOption) (*ListCommandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommandsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListCommands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) AddToFavorites(context.Context, *AddToFavoritesRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddToFavorites not implemented")
+
+func (c *chatServiceClient) RegisterBotCommand(ctx context.Context, in *RegisterBotCommandRequest, opts ...grpc.CallOption) (*BotCommand, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BotCommand)
+	err := c.cc.Invoke(ctx, ChatService_RegisterBotCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) RemoveFromFavorites(context.Context, *RemoveFromFavoritesRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromFavorites not implemented")
+
+func (c *chatServiceClient) ListBotCommands(ctx context.Context, in *ListBotCommandsRequest, opts ...grpc.CallOption) (*ListBotCommandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBotCommandsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListBotCommands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ArchiveChat(context.Context, *ArchiveChatRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ArchiveChat not implemented")
+
+func (c *chatServiceClient) DeleteBotCommand(ctx context.Context, in *DeleteBotCommandRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteBotCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) UnarchiveChat(context.Context, *UnarchiveChatRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveChat not implemented")
+
+func (c *chatServiceClient) InteractMessage(ctx context.Context, in *InteractMessageRequest, opts ...grpc.CallOption) (*InteractMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InteractMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_InteractMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListArchivedChats(context.Context, *ListArchivedChatsRequest) (*ListChatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedChats not implemented")
+
+func (c *chatServiceClient) CreateMessageReminder(ctx context.Context, in *CreateMessageReminderRequest, opts ...grpc.CallOption) (*Reminder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reminder)
+	err := c.cc.Invoke(ctx, ChatService_CreateMessageReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) CreatePoll(context.Context, *CreatePollRequest) (*Poll, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePoll not implemented")
+
+func (c *chatServiceClient) ListReminders(ctx context.Context, in *ListRemindersRequest, opts ...grpc.CallOption) (*ListRemindersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRemindersResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) VotePoll(context.Context, *VotePollRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VotePoll not implemented")
+
+func (c *chatServiceClient) CancelReminder(ctx context.Context, in *CancelReminderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_CancelReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) FinishPoll(context.Context, *FinishPollRequest) (*Poll, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FinishPoll not implemented")
+
+func (c *chatServiceClient) CreateCustomEmoji(ctx context.Context, in *CreateCustomEmojiRequest, opts ...grpc.CallOption) (*CustomEmoji, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomEmoji)
+	err := c.cc.Invoke(ctx, ChatService_CreateCustomEmoji_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) DeletePoll(context.Context, *DeletePollRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeletePoll not implemented")
+
+func (c *chatServiceClient) ListCustomEmoji(ctx context.Context, in *ListCustomEmojiRequest, opts ...grpc.CallOption) (*ListCustomEmojiResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomEmojiResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListCustomEmoji_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) SendTyping(context.Context, *SendTypingRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendTyping not implemented")
+
+func (c *chatServiceClient) DeleteCustomEmoji(ctx context.Context, in *DeleteCustomEmojiRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteCustomEmoji_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) CreateThread(context.Context, *CreateThreadRequest) (*Thread, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+
+func (c *chatServiceClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*Draft, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Draft)
+	err := c.cc.Invoke(ctx, ChatService_SaveDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) GetThread(context.Context, *GetThreadRequest) (*Thread, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+
+func (c *chatServiceClient) GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDraftsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+
+func (c *chatServiceClient) DeleteDraft(ctx context.Context, in *DeleteDraftRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChatService_DeleteDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedChatServiceServer) ArchiveThread(context.Context, *ArchiveThreadRequest) (*Thread, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ArchiveThread not implemented")
+
+// ChatServiceServer is the server API for ChatService service.
+// All implementations must embed UnimplementedChatServiceServer
+// for forward compatibility.
+//
+// Chat service definition
+type ChatServiceServer interface {
+	// Chat operations
+	CreateChat(context.Context, *CreateChatRequest) (*Chat, error)
+	GetChat(context.Context, *GetChatRequest) (*Chat, error)
+	ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error)
+	UpdateChat(context.Context, *UpdateChatRequest) (*Chat, error)
+	DeleteChat(context.Context, *DeleteChatRequest) (*emptypb.Empty, error)
+	SearchChats(context.Context, *SearchChatsRequest) (*ListChatsResponse, error)
+	GetChatListChanges(context.Context, *GetChatListChangesRequest) (*GetChatListChangesResponse, error)
+	SetSlowMode(context.Context, *SetSlowModeRequest) (*Chat, error)
+	// Participant operations
+	AddParticipant(context.Context, *AddParticipantRequest) (*ChatParticipant, error)
+	RemoveParticipant(context.Context, *RemoveParticipantRequest) (*emptypb.Empty, error)
+	UpdateParticipantRole(context.Context, *UpdateParticipantRoleRequest) (*ChatParticipant, error)
+	ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error)
+	// Message operations
+	SendMessage(context.Context, *SendMessageRequest) (*Message, error)
+	SendSystemMessage(context.Context, *SendSystemMessageRequest) (*Message, error)
+	GetMessage(context.Context, *GetMessageRequest) (*Message, error)
+	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
+	SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error)
+	UpdateMessage(context.Context, *UpdateMessageRequest) (*Message, error)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error)
+	RestoreMessage(context.Context, *RestoreMessageRequest) (*Message, error)
+	RemoveFromQuote(context.Context, *RemoveFromQuoteRequest) (*emptypb.Empty, error)
+	BulkDeleteMessages(context.Context, *BulkDeleteMessagesRequest) (*BulkDeleteMessagesResponse, error)
+	PurgeUserMessages(context.Context, *PurgeUserMessagesRequest) (*BulkDeleteMessagesResponse, error)
+	GetThreadMessages(context.Context, *GetThreadMessagesRequest) (*ListMessagesResponse, error)
+	ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error)
+	// Reaction operations
+	AddReaction(context.Context, *AddReactionRequest) (*emptypb.Empty, error)
+	RemoveReaction(context.Context, *RemoveReactionRequest) (*emptypb.Empty, error)
+	ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error)
+	// Read status operations
+	MarkAsRead(context.Context, *MarkAsReadRequest) (*emptypb.Empty, error)
+	GetReadStatus(context.Context, *GetReadStatusRequest) (*ReadStatusResponse, error)
+	// Favorites operations
+	AddToFavorites(context.Context, *AddToFavoritesRequest) (*emptypb.Empty, error)
+	RemoveFromFavorites(context.Context, *RemoveFromFavoritesRequest) (*emptypb.Empty, error)
+	// Archive operations
+	ArchiveChat(context.Context, *ArchiveChatRequest) (*emptypb.Empty, error)
+	UnarchiveChat(context.Context, *UnarchiveChatRequest) (*emptypb.Empty, error)
+	ListArchivedChats(context.Context, *ListArchivedChatsRequest) (*ListChatsResponse, error)
+	// Poll operations
+	CreatePoll(context.Context, *CreatePollRequest) (*Poll, error)
+	VotePoll(context.Context, *VotePollRequest) (*emptypb.Empty, error)
+	FinishPoll(context.Context, *FinishPollRequest) (*Poll, error)
+	DeletePoll(context.Context, *DeletePollRequest) (*emptypb.Empty, error)
+	// Typing indicator
+	SendTyping(context.Context, *SendTypingRequest) (*emptypb.Empty, error)
+	// Thread operations
+	CreateThread(context.Context, *CreateThreadRequest) (*Thread, error)
+	GetThread(context.Context, *GetThreadRequest) (*Thread, error)
+	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error)
+	ArchiveThread(context.Context, *ArchiveThreadRequest) (*Thread, error)
+	ListThreadMessages(context.Context, *ListThreadMessagesRequest) (*ListMessagesResponse, error)
+	MoveMessagesToThread(context.Context, *MoveMessagesToThreadRequest) (*MoveMessagesToThreadResponse, error)
+	// Thread participant operations
+	AddThreadParticipant(context.Context, *AddThreadParticipantRequest) (*emptypb.Empty, error)
+	RemoveThreadParticipant(context.Context, *RemoveThreadParticipantRequest) (*emptypb.Empty, error)
+	ListThreadParticipants(context.Context, *ListThreadParticipantsRequest) (*ListThreadParticipantsResponse, error)
+	// Thread subscriptions (follow/unfollow, inbox)
+	FollowThread(context.Context, *FollowThreadRequest) (*emptypb.Empty, error)
+	UnfollowThread(context.Context, *UnfollowThreadRequest) (*emptypb.Empty, error)
+	ListFollowedThreads(context.Context, *ListFollowedThreadsRequest) (*ListFollowedThreadsResponse, error)
+	MarkThreadAsRead(context.Context, *MarkThreadAsReadRequest) (*emptypb.Empty, error)
+	// Subthread operations
+	ListSubthreads(context.Context, *ListSubthreadsRequest) (*ListThreadsResponse, error)
+	CreateSubthread(context.Context, *CreateSubthreadRequest) (*Thread, error)
+	// Moderation (message reports)
+	ReportMessage(context.Context, *ReportMessageRequest) (*MessageReport, error)
+	ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error)
+	ResolveReport(context.Context, *ResolveReportRequest) (*MessageReport, error)
+	ListModerationAuditLog(context.Context, *ListModerationAuditLogRequest) (*ListModerationAuditLogResponse, error)
+	// Chat restrictions (bans and timed mutes)
+	BanUser(context.Context, *BanUserRequest) (*ChatRestriction, error)
+	UnbanUser(context.Context, *UnbanUserRequest) (*emptypb.Empty, error)
+	MuteUser(context.Context, *MuteUserRequest) (*ChatRestriction, error)
+	UnmuteUser(context.Context, *UnmuteUserRequest) (*emptypb.Empty, error)
+	ListChatRestrictions(context.Context, *ListChatRestrictionsRequest) (*ListChatRestrictionsResponse, error)
+	// Content filters (chat_id unset = global policy)
+	GetContentPolicy(context.Context, *GetContentPolicyRequest) (*ContentPolicy, error)
+	SetContentPolicy(context.Context, *SetContentPolicyRequest) (*ContentPolicy, error)
+	DeleteContentPolicy(context.Context, *DeleteContentPolicyRequest) (*emptypb.Empty, error)
+	ListContentFilterHits(context.Context, *ListContentFilterHitsRequest) (*ListContentFilterHitsResponse, error)
+	// Outgoing webhooks (chat_id unset = subscription to all chats)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error)
+	// Incoming webhooks (bots posting into a chat)
+	CreateIncomingWebhook(context.Context, *CreateIncomingWebhookRequest) (*IncomingWebhook, error)
+	ListIncomingWebhooks(context.Context, *ListIncomingWebhooksRequest) (*ListIncomingWebhooksResponse, error)
+	RotateIncomingWebhookToken(context.Context, *RotateIncomingWebhookTokenRequest) (*IncomingWebhook, error)
+	DeleteIncomingWebhook(context.Context, *DeleteIncomingWebhookRequest) (*emptypb.Empty, error)
+	ExecuteIncomingWebhook(context.Context, *ExecuteIncomingWebhookRequest) (*Message, error)
+	// Slash commands and interactive messages (chat_id unset = commands of all chats)
+	ListCommands(context.Context, *ListCommandsRequest) (*ListCommandsResponse, error)
+	RegisterBotCommand(context.Context, *RegisterBotCommandRequest) (*BotCommand, error)
+	ListBotCommands(context.Context, *ListBotCommandsRequest) (*ListBotCommandsResponse, error)
+	DeleteBotCommand(context.Context, *DeleteBotCommandRequest) (*emptypb.Empty, error)
+	InteractMessage(context.Context, *InteractMessageRequest) (*InteractMessageResponse, error)
+	// Reminders ("remind me about this" and /remind)
+	CreateMessageReminder(context.Context, *CreateMessageReminderRequest) (*Reminder, error)
+	ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error)
+	CancelReminder(context.Context, *CancelReminderRequest) (*emptypb.Empty, error)
+	// Custom emoji (":name:" reactions)
+	CreateCustomEmoji(context.Context, *CreateCustomEmojiRequest) (*CustomEmoji, error)
+	ListCustomEmoji(context.Context, *ListCustomEmojiRequest) (*ListCustomEmojiResponse, error)
+	DeleteCustomEmoji(context.Context, *DeleteCustomEmojiRequest) (*emptypb.Empty, error)
+	// Drafts synchronized across the user's devices
+	SaveDraft(context.Context, *SaveDraftRequest) (*Draft, error)
+	GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error)
+	DeleteDraft(context.Context, *DeleteDraftRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+// UnimplementedChatServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChatServiceServer struct{}
+
+func (UnimplementedChatServiceServer) CreateChat(context.Context, *CreateChatRequest) (*Chat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChat not implemented")
+}
+func (UnimplementedChatServiceServer) GetChat(context.Context, *GetChatRequest) (*Chat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChat not implemented")
+}
+func (UnimplementedChatServiceServer) ListChats(context.Context, *ListChatsRequest) (*ListChatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChats not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateChat(context.Context, *UpdateChatRequest) (*Chat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChat not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteChat(context.Context, *DeleteChatRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChat not implemented")
+}
+func (UnimplementedChatServiceServer) SearchChats(context.Context, *SearchChatsRequest) (*ListChatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchChats not implemented")
+}
+func (UnimplementedChatServiceServer) GetChatListChanges(context.Context, *GetChatListChangesRequest) (*GetChatListChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChatListChanges not implemented")
+}
+func (UnimplementedChatServiceServer) SetSlowMode(context.Context, *SetSlowModeRequest) (*Chat, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSlowMode not implemented")
+}
+func (UnimplementedChatServiceServer) AddParticipant(context.Context, *AddParticipantRequest) (*ChatParticipant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddParticipant not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveParticipant(context.Context, *RemoveParticipantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveParticipant not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateParticipantRole(context.Context, *UpdateParticipantRoleRequest) (*ChatParticipant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateParticipantRole not implemented")
+}
+func (UnimplementedChatServiceServer) ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParticipants not implemented")
+}
+func (UnimplementedChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedChatServiceServer) SendSystemMessage(context.Context, *SendSystemMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSystemMessage not implemented")
+}
+func (UnimplementedChatServiceServer) GetMessage(context.Context, *GetMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMessages not implemented")
+}
+func (UnimplementedChatServiceServer) SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMessages not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateMessage(context.Context, *UpdateMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMessage not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteMessage(context.Context, *DeleteMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMessage not implemented")
+}
+func (UnimplementedChatServiceServer) RestoreMessage(context.Context, *RestoreMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreMessage not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveFromQuote(context.Context, *RemoveFromQuoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromQuote not implemented")
+}
+func (UnimplementedChatServiceServer) BulkDeleteMessages(context.Context, *BulkDeleteMessagesRequest) (*BulkDeleteMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteMessages not implemented")
+}
+func (UnimplementedChatServiceServer) PurgeUserMessages(context.Context, *PurgeUserMessagesRequest) (*BulkDeleteMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUserMessages not implemented")
+}
+func (UnimplementedChatServiceServer) GetThreadMessages(context.Context, *GetThreadMessagesRequest) (*ListMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadMessages not implemented")
+}
+func (UnimplementedChatServiceServer) ForwardMessage(context.Context, *ForwardMessageRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForwardMessage not implemented")
+}
+func (UnimplementedChatServiceServer) AddReaction(context.Context, *AddReactionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReaction not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveReaction(context.Context, *RemoveReactionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReaction not implemented")
+}
+func (UnimplementedChatServiceServer) ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReactions not implemented")
+}
+func (UnimplementedChatServiceServer) MarkAsRead(context.Context, *MarkAsReadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkAsRead not implemented")
+}
+func (UnimplementedChatServiceServer) GetReadStatus(context.Context, *GetReadStatusRequest) (*ReadStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadStatus not implemented")
+}
+func (UnimplementedChatServiceServer) AddToFavorites(context.Context, *AddToFavoritesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToFavorites not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveFromFavorites(context.Context, *RemoveFromFavoritesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromFavorites not implemented")
+}
+func (UnimplementedChatServiceServer) ArchiveChat(context.Context, *ArchiveChatRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveChat not implemented")
+}
+func (UnimplementedChatServiceServer) UnarchiveChat(context.Context, *UnarchiveChatRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveChat not implemented")
+}
+func (UnimplementedChatServiceServer) ListArchivedChats(context.Context, *ListArchivedChatsRequest) (*ListChatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedChats not implemented")
+}
+func (UnimplementedChatServiceServer) CreatePoll(context.Context, *CreatePollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePoll not implemented")
+}
+func (UnimplementedChatServiceServer) VotePoll(context.Context, *VotePollRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedChatServiceServer) FinishPoll(context.Context, *FinishPollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPoll not implemented")
+}
+func (UnimplementedChatServiceServer) DeletePoll(context.Context, *DeletePollRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePoll not implemented")
+}
+func (UnimplementedChatServiceServer) SendTyping(context.Context, *SendTypingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTyping not implemented")
+}
+func (UnimplementedChatServiceServer) CreateThread(context.Context, *CreateThreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+}
+func (UnimplementedChatServiceServer) GetThread(context.Context, *GetThreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
+func (UnimplementedChatServiceServer) ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+}
+func (UnimplementedChatServiceServer) ArchiveThread(context.Context, *ArchiveThreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveThread not implemented")
+}
+func (UnimplementedChatServiceServer) ListThreadMessages(context.Context, *ListThreadMessagesRequest) (*ListMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreadMessages not implemented")
+}
+func (UnimplementedChatServiceServer) MoveMessagesToThread(context.Context, *MoveMessagesToThreadRequest) (*MoveMessagesToThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveMessagesToThread not implemented")
+}
+func (UnimplementedChatServiceServer) AddThreadParticipant(context.Context, *AddThreadParticipantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddThreadParticipant not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveThreadParticipant(context.Context, *RemoveThreadParticipantRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveThreadParticipant not implemented")
+}
+func (UnimplementedChatServiceServer) ListThreadParticipants(context.Context, *ListThreadParticipantsRequest) (*ListThreadParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreadParticipants not implemented")
+}
+func (UnimplementedChatServiceServer) FollowThread(context.Context, *FollowThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowThread not implemented")
+}
+func (UnimplementedChatServiceServer) UnfollowThread(context.Context, *UnfollowThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowThread not implemented")
+}
+func (UnimplementedChatServiceServer) ListFollowedThreads(context.Context, *ListFollowedThreadsRequest) (*ListFollowedThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowedThreads not implemented")
+}
+func (UnimplementedChatServiceServer) MarkThreadAsRead(context.Context, *MarkThreadAsReadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkThreadAsRead not implemented")
+}
+func (UnimplementedChatServiceServer) ListSubthreads(context.Context, *ListSubthreadsRequest) (*ListThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubthreads not implemented")
+}
+func (UnimplementedChatServiceServer) CreateSubthread(context.Context, *CreateSubthreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubthread not implemented")
+}
+func (UnimplementedChatServiceServer) ReportMessage(context.Context, *ReportMessageRequest) (*MessageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedChatServiceServer) ResolveReport(context.Context, *ResolveReportRequest) (*MessageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveReport not implemented")
+}
+func (UnimplementedChatServiceServer) ListModerationAuditLog(context.Context, *ListModerationAuditLogRequest) (*ListModerationAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModerationAuditLog not implemented")
+}
+func (UnimplementedChatServiceServer) BanUser(context.Context, *BanUserRequest) (*ChatRestriction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanUser not implemented")
+}
+func (UnimplementedChatServiceServer) UnbanUser(context.Context, *UnbanUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanUser not implemented")
+}
+func (UnimplementedChatServiceServer) MuteUser(context.Context, *MuteUserRequest) (*ChatRestriction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteUser not implemented")
+}
+func (UnimplementedChatServiceServer) UnmuteUser(context.Context, *UnmuteUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteUser not implemented")
+}
+func (UnimplementedChatServiceServer) ListChatRestrictions(context.Context, *ListChatRestrictionsRequest) (*ListChatRestrictionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChatRestrictions not implemented")
+}
+func (UnimplementedChatServiceServer) GetContentPolicy(context.Context, *GetContentPolicyRequest) (*ContentPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContentPolicy not implemented")
+}
+func (UnimplementedChatServiceServer) SetContentPolicy(context.Context, *SetContentPolicyRequest) (*ContentPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContentPolicy not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteContentPolicy(context.Context, *DeleteContentPolicyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteContentPolicy not implemented")
+}
+func (UnimplementedChatServiceServer) ListContentFilterHits(context.Context, *ListContentFilterHitsRequest) (*ListContentFilterHitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContentFilterHits not implemented")
+}
+func (UnimplementedChatServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedChatServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) CreateIncomingWebhook(context.Context, *CreateIncomingWebhookRequest) (*IncomingWebhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIncomingWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) ListIncomingWebhooks(context.Context, *ListIncomingWebhooksRequest) (*ListIncomingWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomingWebhooks not implemented")
+}
+func (UnimplementedChatServiceServer) RotateIncomingWebhookToken(context.Context, *RotateIncomingWebhookTokenRequest) (*IncomingWebhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateIncomingWebhookToken not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteIncomingWebhook(context.Context, *DeleteIncomingWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIncomingWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) ExecuteIncomingWebhook(context.Context, *ExecuteIncomingWebhookRequest) (*Message, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteIncomingWebhook not implemented")
+}
+func (UnimplementedChatServiceServer) ListCommands(context.Context, *ListCommandsRequest) (*ListCommandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommands not implemented")
+}
+func (UnimplementedChatServiceServer) RegisterBotCommand(context.Context, *RegisterBotCommandRequest) (*BotCommand, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterBotCommand not implemented")
+}
+func (UnimplementedChatServiceServer) ListBotCommands(context.Context, *ListBotCommandsRequest) (*ListBotCommandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBotCommands not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteBotCommand(context.Context, *DeleteBotCommandRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBotCommand not implemented")
+}
+func (UnimplementedChatServiceServer) InteractMessage(context.Context, *InteractMessageRequest) (*InteractMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InteractMessage not implemented")
+}
+func (UnimplementedChatServiceServer) CreateMessageReminder(context.Context, *CreateMessageReminderRequest) (*Reminder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMessageReminder not implemented")
+}
+func (UnimplementedChatServiceServer) ListReminders(context.Context, *ListRemindersRequest) (*ListRemindersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReminders not implemented")
+}
+func (UnimplementedChatServiceServer) CancelReminder(context.Context, *CancelReminderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReminder not implemented")
+}
+func (UnimplementedChatServiceServer) CreateCustomEmoji(context.Context, *CreateCustomEmojiRequest) (*CustomEmoji, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomEmoji not implemented")
+}
+func (UnimplementedChatServiceServer) ListCustomEmoji(context.Context, *ListCustomEmojiRequest) (*ListCustomEmojiResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomEmoji not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteCustomEmoji(context.Context, *DeleteCustomEmojiRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomEmoji not implemented")
+}
+func (UnimplementedChatServiceServer) SaveDraft(context.Context, *SaveDraftRequest) (*Draft, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedChatServiceServer) GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDrafts not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteDraft(context.Context, *DeleteDraftRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDraft not implemented")
+}
+func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
+func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatServiceServer will
+// result in compilation errors.
+type UnsafeChatServiceServer interface {
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatService_ServiceDesc, srv)
+}
+
+func _ChatService_CreateChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateChat(ctx, req.(*CreateChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChat(ctx, req.(*GetChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListChats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListChats(ctx, req.(*ListChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateChat(ctx, req.(*UpdateChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteChat(ctx, req.(*DeleteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SearchChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SearchChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SearchChats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SearchChats(ctx, req.(*SearchChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetChatListChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatListChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChatListChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChatListChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChatListChanges(ctx, req.(*GetChatListChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SetSlowMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSlowModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SetSlowMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SetSlowMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SetSlowMode(ctx, req.(*SetSlowModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_AddParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_AddParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddParticipant(ctx, req.(*AddParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveParticipant(ctx, req.(*RemoveParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateParticipantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateParticipantRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateParticipantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateParticipantRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateParticipantRole(ctx, req.(*UpdateParticipantRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListParticipants(ctx, req.(*ListParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SendSystemMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSystemMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendSystemMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendSystemMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendSystemMessage(ctx, req.(*SendSystemMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetMessage(ctx, req.(*GetMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SyncMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SyncMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SyncMessages(ctx, req.(*SyncMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateMessage(ctx, req.(*UpdateMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeleteMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RestoreMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RestoreMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RestoreMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RestoreMessage(ctx, req.(*RestoreMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveFromQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveFromQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveFromQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveFromQuote(ctx, req.(*RemoveFromQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_BulkDeleteMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).BulkDeleteMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_BulkDeleteMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).BulkDeleteMessages(ctx, req.(*BulkDeleteMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_PurgeUserMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PurgeUserMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PurgeUserMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PurgeUserMessages(ctx, req.(*PurgeUserMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetThreadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetThreadMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetThreadMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetThreadMessages(ctx, req.(*GetThreadMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ForwardMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ForwardMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ForwardMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ForwardMessage(ctx, req.(*ForwardMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_AddReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddReaction(ctx, req.(*AddReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveReaction(ctx, req.(*RemoveReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListReactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListReactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListReactions(ctx, req.(*ListReactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_MarkAsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkAsReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MarkAsRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MarkAsRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MarkAsRead(ctx, req.(*MarkAsReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetReadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetReadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetReadStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetReadStatus(ctx, req.(*GetReadStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_AddToFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddToFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_AddToFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddToFavorites(ctx, req.(*AddToFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveFromFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveFromFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RemoveFromFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveFromFavorites(ctx, req.(*RemoveFromFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ArchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ArchiveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ArchiveChat(ctx, req.(*ArchiveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UnarchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UnarchiveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UnarchiveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UnarchiveChat(ctx, req.(*UnarchiveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListArchivedChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedChatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListArchivedChats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListArchivedChats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListArchivedChats(ctx, req.(*ListArchivedChatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CreatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreatePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreatePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreatePoll(ctx, req.(*CreatePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).VotePoll(ctx, req.(*VotePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_FinishPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).FinishPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_FinishPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).FinishPoll(ctx, req.(*FinishPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeletePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeletePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DeletePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeletePoll(ctx, req.(*DeletePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) ListThreadMessages(context.Context, *ListThreadMessagesRequest) (*ListMessagesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListThreadMessages not implemented")
+
+func _ChatService_SendTyping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTypingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendTyping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendTyping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendTyping(ctx, req.(*SendTypingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) AddThreadParticipant(context.Context, *AddThreadParticipantRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddThreadParticipant not implemented")
+
+func _ChatService_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CreateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CreateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CreateThread(ctx, req.(*CreateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) RemoveThreadParticipant(context.Context, *RemoveThreadParticipantRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveThreadParticipant not implemented")
+
+func _ChatService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) ListThreadParticipants(context.Context, *ListThreadParticipantsRequest) (*ListThreadParticipantsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListThreadParticipants not implemented")
+
+func _ChatService_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListThreads(ctx, req.(*ListThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) ListSubthreads(context.Context, *ListSubthreadsRequest) (*ListThreadsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSubthreads not implemented")
+
+func _ChatService_ArchiveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ArchiveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ArchiveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ArchiveThread(ctx, req.(*ArchiveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) CreateSubthread(context.Context, *CreateSubthreadRequest) (*Thread, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSubthread not implemented")
+
+func _ChatService_ListThreadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListThreadMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListThreadMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListThreadMessages(ctx, req.(*ListThreadMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
-func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ChatServiceServer will
-// result in compilation errors.
-type UnsafeChatServiceServer interface {
-	mustEmbedUnimplementedChatServiceServer()
+func _ChatService_MoveMessagesToThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveMessagesToThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).MoveMessagesToThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_MoveMessagesToThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).MoveMessagesToThread(ctx, req.(*MoveMessagesToThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
-	// If the following call pancis, it indicates UnimplementedChatServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ChatService_AddThreadParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddThreadParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ChatService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddThreadParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_AddThreadParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddThreadParticipant(ctx, req.(*AddThreadParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateChatRequest)
+func _ChatService_RemoveThreadParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveThreadParticipantRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreateChat(ctx, in)
+		return srv.(ChatServiceServer).RemoveThreadParticipant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreateChat_FullMethodName,
+		FullMethod: ChatService_RemoveThreadParticipant_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreateChat(ctx, req.(*CreateChatRequest))
+		return srv.(ChatServiceServer).RemoveThreadParticipant(ctx, req.(*RemoveThreadParticipantRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChatRequest)
+func _ChatService_ListThreadParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadParticipantsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetChat(ctx, in)
+		return srv.(ChatServiceServer).ListThreadParticipants(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetChat_FullMethodName,
+		FullMethod: ChatService_ListThreadParticipants_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetChat(ctx, req.(*GetChatRequest))
+		return srv.(ChatServiceServer).ListThreadParticipants(ctx, req.(*ListThreadParticipantsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChatsRequest)
+func _ChatService_FollowThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowThreadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListChats(ctx, in)
+		return srv.(ChatServiceServer).FollowThread(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListChats_FullMethodName,
+		FullMethod: ChatService_FollowThread_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListChats(ctx, req.(*ListChatsRequest))
+		return srv.(ChatServiceServer).FollowThread(ctx, req.(*FollowThreadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UpdateChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateChatRequest)
+func _ChatService_UnfollowThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowThreadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UpdateChat(ctx, in)
+		return srv.(ChatServiceServer).UnfollowThread(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UpdateChat_FullMethodName,
+		FullMethod: ChatService_UnfollowThread_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UpdateChat(ctx, req.(*UpdateChatRequest))
+		return srv.(ChatServiceServer).UnfollowThread(ctx, req.(*UnfollowThreadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_DeleteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteChatRequest)
+func _ChatService_ListFollowedThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowedThreadsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).DeleteChat(ctx, in)
+		return srv.(ChatServiceServer).ListFollowedThreads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_DeleteChat_FullMethodName,
+		FullMethod: ChatService_ListFollowedThreads_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).DeleteChat(ctx, req.(*DeleteChatRequest))
+		return srv.(ChatServiceServer).ListFollowedThreads(ctx, req.(*ListFollowedThreadsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SearchChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchChatsRequest)
+func _ChatService_MarkThreadAsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkThreadAsReadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SearchChats(ctx, in)
+		return srv.(ChatServiceServer).MarkThreadAsRead(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SearchChats_FullMethodName,
+		FullMethod: ChatService_MarkThreadAsRead_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SearchChats(ctx, req.(*SearchChatsRequest))
+		return srv.(ChatServiceServer).MarkThreadAsRead(ctx, req.(*MarkThreadAsReadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_AddParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddParticipantRequest)
+func _ChatService_ListSubthreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubthreadsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).AddParticipant(ctx, in)
+		return srv.(ChatServiceServer).ListSubthreads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_AddParticipant_FullMethodName,
+		FullMethod: ChatService_ListSubthreads_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).AddParticipant(ctx, req.(*AddParticipantRequest))
+		return srv.(ChatServiceServer).ListSubthreads(ctx, req.(*ListSubthreadsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RemoveParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveParticipantRequest)
+func _ChatService_CreateSubthread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubthreadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RemoveParticipant(ctx, in)
+		return srv.(ChatServiceServer).CreateSubthread(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RemoveParticipant_FullMethodName,
+		FullMethod: ChatService_CreateSubthread_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RemoveParticipant(ctx, req.(*RemoveParticipantRequest))
+		return srv.(ChatServiceServer).CreateSubthread(ctx, req.(*CreateSubthreadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UpdateParticipantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateParticipantRoleRequest)
+func _ChatService_ReportMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UpdateParticipantRole(ctx, in)
+		return srv.(ChatServiceServer).ReportMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UpdateParticipantRole_FullMethodName,
+		FullMethod: ChatService_ReportMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UpdateParticipantRole(ctx, req.(*UpdateParticipantRoleRequest))
+		return srv.(ChatServiceServer).ReportMessage(ctx, req.(*ReportMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListParticipantsRequest)
+func _ChatService_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListParticipants(ctx, in)
+		return srv.(ChatServiceServer).ListReports(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListParticipants_FullMethodName,
+		FullMethod: ChatService_ListReports_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListParticipants(ctx, req.(*ListParticipantsRequest))
+		return srv.(ChatServiceServer).ListReports(ctx, req.(*ListReportsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendMessageRequest)
+func _ChatService_ResolveReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SendMessage(ctx, in)
+		return srv.(ChatServiceServer).ResolveReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SendMessage_FullMethodName,
+		FullMethod: ChatService_ResolveReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+		return srv.(ChatServiceServer).ResolveReport(ctx, req.(*ResolveReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SendSystemMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendSystemMessageRequest)
+func _ChatService_ListModerationAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModerationAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SendSystemMessage(ctx, in)
+		return srv.(ChatServiceServer).ListModerationAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SendSystemMessage_FullMethodName,
+		FullMethod: ChatService_ListModerationAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SendSystemMessage(ctx, req.(*SendSystemMessageRequest))
+		return srv.(ChatServiceServer).ListModerationAuditLog(ctx, req.(*ListModerationAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMessageRequest)
+func _ChatService_BanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetMessage(ctx, in)
+		return srv.(ChatServiceServer).BanUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetMessage_FullMethodName,
+		FullMethod: ChatService_BanUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetMessage(ctx, req.(*GetMessageRequest))
+		return srv.(ChatServiceServer).BanUser(ctx, req.(*BanUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListMessagesRequest)
+func _ChatService_UnbanUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListMessages(ctx, in)
+		return srv.(ChatServiceServer).UnbanUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListMessages_FullMethodName,
+		FullMethod: ChatService_UnbanUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+		return srv.(ChatServiceServer).UnbanUser(ctx, req.(*UnbanUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SyncMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SyncMessagesRequest)
+func _ChatService_MuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SyncMessages(ctx, in)
+		return srv.(ChatServiceServer).MuteUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SyncMessages_FullMethodName,
+		FullMethod: ChatService_MuteUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SyncMessages(ctx, req.(*SyncMessagesRequest))
+		return srv.(ChatServiceServer).MuteUser(ctx, req.(*MuteUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UpdateMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateMessageRequest)
+func _ChatService_UnmuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmuteUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UpdateMessage(ctx, in)
+		return srv.(ChatServiceServer).UnmuteUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UpdateMessage_FullMethodName,
+		FullMethod: ChatService_UnmuteUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UpdateMessage(ctx, req.(*UpdateMessageRequest))
+		return srv.(ChatServiceServer).UnmuteUser(ctx, req.(*UnmuteUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteMessageRequest)
+func _ChatService_ListChatRestrictions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChatRestrictionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).DeleteMessage(ctx, in)
+		return srv.(ChatServiceServer).ListChatRestrictions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_DeleteMessage_FullMethodName,
+		FullMethod: ChatService_ListChatRestrictions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+		return srv.(ChatServiceServer).ListChatRestrictions(ctx, req.(*ListChatRestrictionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RestoreMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreMessageRequest)
+func _ChatService_GetContentPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContentPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RestoreMessage(ctx, in)
+		return srv.(ChatServiceServer).GetContentPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RestoreMessage_FullMethodName,
+		FullMethod: ChatService_GetContentPolicy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RestoreMessage(ctx, req.(*RestoreMessageRequest))
+		return srv.(ChatServiceServer).GetContentPolicy(ctx, req.(*GetContentPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RemoveFromQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveFromQuoteRequest)
+func _ChatService_SetContentPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContentPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RemoveFromQuote(ctx, in)
+		return srv.(ChatServiceServer).SetContentPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RemoveFromQuote_FullMethodName,
+		FullMethod: ChatService_SetContentPolicy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RemoveFromQuote(ctx, req.(*RemoveFromQuoteRequest))
+		return srv.(ChatServiceServer).SetContentPolicy(ctx, req.(*SetContentPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetThreadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetThreadMessagesRequest)
+func _ChatService_DeleteContentPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteContentPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetThreadMessages(ctx, in)
+		return srv.(ChatServiceServer).DeleteContentPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetThreadMessages_FullMethodName,
+		FullMethod: ChatService_DeleteContentPolicy_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetThreadMessages(ctx, req.(*GetThreadMessagesRequest))
+		return srv.(ChatServiceServer).DeleteContentPolicy(ctx, req.(*DeleteContentPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ForwardMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForwardMessageRequest)
+func _ChatService_ListContentFilterHits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContentFilterHitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ForwardMessage(ctx, in)
+		return srv.(ChatServiceServer).ListContentFilterHits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ForwardMessage_FullMethodName,
+		FullMethod: ChatService_ListContentFilterHits_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ForwardMessage(ctx, req.(*ForwardMessageRequest))
+		return srv.(ChatServiceServer).ListContentFilterHits(ctx, req.(*ListContentFilterHitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddReactionRequest)
+func _ChatService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).AddReaction(ctx, in)
+		return srv.(ChatServiceServer).CreateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_AddReaction_FullMethodName,
+		FullMethod: ChatService_CreateWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).AddReaction(ctx, req.(*AddReactionRequest))
+		return srv.(ChatServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveReactionRequest)
+func _ChatService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RemoveReaction(ctx, in)
+		return srv.(ChatServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RemoveReaction_FullMethodName,
+		FullMethod: ChatService_ListWebhooks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RemoveReaction(ctx, req.(*RemoveReactionRequest))
+		return srv.(ChatServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListReactionsRequest)
+func _ChatService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListReactions(ctx, in)
+		return srv.(ChatServiceServer).UpdateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListReactions_FullMethodName,
+		FullMethod: ChatService_UpdateWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListReactions(ctx, req.(*ListReactionsRequest))
+		return srv.(ChatServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_MarkAsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MarkAsReadRequest)
+func _ChatService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).MarkAsRead(ctx, in)
+		return srv.(ChatServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_MarkAsRead_FullMethodName,
+		FullMethod: ChatService_DeleteWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).MarkAsRead(ctx, req.(*MarkAsReadRequest))
+		return srv.(ChatServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetReadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetReadStatusRequest)
+func _ChatService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetReadStatus(ctx, in)
+		return srv.(ChatServiceServer).ListWebhookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetReadStatus_FullMethodName,
+		FullMethod: ChatService_ListWebhookDeliveries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetReadStatus(ctx, req.(*GetReadStatusRequest))
+		return srv.(ChatServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_AddToFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddToFavoritesRequest)
+func _ChatService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).AddToFavorites(ctx, in)
+		return srv.(ChatServiceServer).RedeliverWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_AddToFavorites_FullMethodName,
+		FullMethod: ChatService_RedeliverWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).AddToFavorites(ctx, req.(*AddToFavoritesRequest))
+		return srv.(ChatServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RemoveFromFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveFromFavoritesRequest)
+func _ChatService_CreateIncomingWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIncomingWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RemoveFromFavorites(ctx, in)
+		return srv.(ChatServiceServer).CreateIncomingWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RemoveFromFavorites_FullMethodName,
+		FullMethod: ChatService_CreateIncomingWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RemoveFromFavorites(ctx, req.(*RemoveFromFavoritesRequest))
+		return srv.(ChatServiceServer).CreateIncomingWebhook(ctx, req.(*CreateIncomingWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ArchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArchiveChatRequest)
+func _ChatService_ListIncomingWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomingWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ArchiveChat(ctx, in)
+		return srv.(ChatServiceServer).ListIncomingWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ArchiveChat_FullMethodName,
+		FullMethod: ChatService_ListIncomingWebhooks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ArchiveChat(ctx, req.(*ArchiveChatRequest))
+		return srv.(ChatServiceServer).ListIncomingWebhooks(ctx, req.(*ListIncomingWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_UnarchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnarchiveChatRequest)
+func _ChatService_RotateIncomingWebhookToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateIncomingWebhookTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).UnarchiveChat(ctx, in)
+		return srv.(ChatServiceServer).RotateIncomingWebhookToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_UnarchiveChat_FullMethodName,
+		FullMethod: ChatService_RotateIncomingWebhookToken_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).UnarchiveChat(ctx, req.(*UnarchiveChatRequest))
+		return srv.(ChatServiceServer).RotateIncomingWebhookToken(ctx, req.(*RotateIncomingWebhookTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListArchivedChats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListArchivedChatsRequest)
+func _ChatService_DeleteIncomingWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIncomingWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListArchivedChats(ctx, in)
+		return srv.(ChatServiceServer).DeleteIncomingWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListArchivedChats_FullMethodName,
+		FullMethod: ChatService_DeleteIncomingWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListArchivedChats(ctx, req.(*ListArchivedChatsRequest))
+		return srv.(ChatServiceServer).DeleteIncomingWebhook(ctx, req.(*DeleteIncomingWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreatePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreatePollRequest)
+func _ChatService_ExecuteIncomingWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteIncomingWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreatePoll(ctx, in)
+		return srv.(ChatServiceServer).ExecuteIncomingWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreatePoll_FullMethodName,
+		FullMethod: ChatService_ExecuteIncomingWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreatePoll(ctx, req.(*CreatePollRequest))
+		return srv.(ChatServiceServer).ExecuteIncomingWebhook(ctx, req.(*ExecuteIncomingWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VotePollRequest)
+func _ChatService_ListCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommandsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).VotePoll(ctx, in)
+		return srv.(ChatServiceServer).ListCommands(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_VotePoll_FullMethodName,
+		FullMethod: ChatService_ListCommands_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).VotePoll(ctx, req.(*VotePollRequest))
+		return srv.(ChatServiceServer).ListCommands(ctx, req.(*ListCommandsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_FinishPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FinishPollRequest)
+func _ChatService_RegisterBotCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterBotCommandRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).FinishPoll(ctx, in)
+		return srv.(ChatServiceServer).RegisterBotCommand(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_FinishPoll_FullMethodName,
+		FullMethod: ChatService_RegisterBotCommand_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).FinishPoll(ctx, req.(*FinishPollRequest))
+		return srv.(ChatServiceServer).RegisterBotCommand(ctx, req.(*RegisterBotCommandRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_DeletePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeletePollRequest)
+func _ChatService_ListBotCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBotCommandsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).DeletePoll(ctx, in)
+		return srv.(ChatServiceServer).ListBotCommands(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_DeletePoll_FullMethodName,
+		FullMethod: ChatService_ListBotCommands_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).DeletePoll(ctx, req.(*DeletePollRequest))
+		return srv.(ChatServiceServer).ListBotCommands(ctx, req.(*ListBotCommandsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_SendTyping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendTypingRequest)
+func _ChatService_DeleteBotCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBotCommandRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).SendTyping(ctx, in)
+		return srv.(ChatServiceServer).DeleteBotCommand(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_SendTyping_FullMethodName,
+		FullMethod: ChatService_DeleteBotCommand_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).SendTyping(ctx, req.(*SendTypingRequest))
+		return srv.(ChatServiceServer).DeleteBotCommand(ctx, req.(*DeleteBotCommandRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateThreadRequest)
+func _ChatService_InteractMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InteractMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreateThread(ctx, in)
+		return srv.(ChatServiceServer).InteractMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreateThread_FullMethodName,
+		FullMethod: ChatService_InteractMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreateThread(ctx, req.(*CreateThreadRequest))
+		return srv.(ChatServiceServer).InteractMessage(ctx, req.(*InteractMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetThreadRequest)
+func _ChatService_CreateMessageReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMessageReminderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).GetThread(ctx, in)
+		return srv.(ChatServiceServer).CreateMessageReminder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_GetThread_FullMethodName,
+		FullMethod: ChatService_CreateMessageReminder_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+		return srv.(ChatServiceServer).CreateMessageReminder(ctx, req.(*CreateMessageReminderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListThreadsRequest)
+func _ChatService_ListReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRemindersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListThreads(ctx, in)
+		return srv.(ChatServiceServer).ListReminders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListThreads_FullMethodName,
+		FullMethod: ChatService_ListReminders_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListThreads(ctx, req.(*ListThreadsRequest))
+		return srv.(ChatServiceServer).ListReminders(ctx, req.(*ListRemindersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ArchiveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArchiveThreadRequest)
+func _ChatService_CancelReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReminderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ArchiveThread(ctx, in)
+		return srv.(ChatServiceServer).CancelReminder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ArchiveThread_FullMethodName,
+		FullMethod: ChatService_CancelReminder_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ArchiveThread(ctx, req.(*ArchiveThreadRequest))
+		return srv.(ChatServiceServer).CancelReminder(ctx, req.(*CancelReminderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListThreadMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListThreadMessagesRequest)
+func _ChatService_CreateCustomEmoji_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCustomEmojiRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListThreadMessages(ctx, in)
+		return srv.(ChatServiceServer).CreateCustomEmoji(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListThreadMessages_FullMethodName,
+		FullMethod: ChatService_CreateCustomEmoji_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListThreadMessages(ctx, req.(*ListThreadMessagesRequest))
+		return srv.(ChatServiceServer).CreateCustomEmoji(ctx, req.(*CreateCustomEmojiRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_AddThreadParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddThreadParticipantRequest)
+func _ChatService_ListCustomEmoji_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomEmojiRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).AddThreadParticipant(ctx, in)
+		return srv.(ChatServiceServer).ListCustomEmoji(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_AddThreadParticipant_FullMethodName,
+		FullMethod: ChatService_ListCustomEmoji_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).AddThreadParticipant(ctx, req.(*AddThreadParticipantRequest))
+		return srv.(ChatServiceServer).ListCustomEmoji(ctx, req.(*ListCustomEmojiRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_RemoveThreadParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveThreadParticipantRequest)
+func _ChatService_DeleteCustomEmoji_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomEmojiRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).RemoveThreadParticipant(ctx, in)
+		return srv.(ChatServiceServer).DeleteCustomEmoji(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_RemoveThreadParticipant_FullMethodName,
+		FullMethod: ChatService_DeleteCustomEmoji_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).RemoveThreadParticipant(ctx, req.(*RemoveThreadParticipantRequest))
+		return srv.(ChatServiceServer).DeleteCustomEmoji(ctx, req.(*DeleteCustomEmojiRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListThreadParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListThreadParticipantsRequest)
+func _ChatService_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListThreadParticipants(ctx, in)
+		return srv.(ChatServiceServer).SaveDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListThreadParticipants_FullMethodName,
+		FullMethod: ChatService_SaveDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListThreadParticipants(ctx, req.(*ListThreadParticipantsRequest))
+		return srv.(ChatServiceServer).SaveDraft(ctx, req.(*SaveDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_ListSubthreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSubthreadsRequest)
+func _ChatService_GetDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDraftsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).ListSubthreads(ctx, in)
+		return srv.(ChatServiceServer).GetDrafts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_ListSubthreads_FullMethodName,
+		FullMethod: ChatService_GetDrafts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).ListSubthreads(ctx, req.(*ListSubthreadsRequest))
+		return srv.(ChatServiceServer).GetDrafts(ctx, req.(*GetDraftsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ChatService_CreateSubthread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSubthreadRequest)
+func _ChatService_DeleteDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDraftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ChatServiceServer).CreateSubthread(ctx, in)
+		return srv.(ChatServiceServer).DeleteDraft(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ChatService_CreateSubthread_FullMethodName,
+		FullMethod: ChatService_DeleteDraft_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChatServiceServer).CreateSubthread(ctx, req.(*CreateSubthreadRequest))
+		return srv.(ChatServiceServer).DeleteDraft(ctx, req.(*DeleteDraftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1694,6 +3312,14 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchChats",
 			Handler:    _ChatService_SearchChats_Handler,
 		},
+		{
+			MethodName: "GetChatListChanges",
+			Handler:    _ChatService_GetChatListChanges_Handler,
+		},
+		{
+			MethodName: "SetSlowMode",
+			Handler:    _ChatService_SetSlowMode_Handler,
+		},
 		{
 			MethodName: "AddParticipant",
 			Handler:    _ChatService_AddParticipant_Handler,
@@ -1746,6 +3372,14 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveFromQuote",
 			Handler:    _ChatService_RemoveFromQuote_Handler,
 		},
+		{
+			MethodName: "BulkDeleteMessages",
+			Handler:    _ChatService_BulkDeleteMessages_Handler,
+		},
+		{
+			MethodName: "PurgeUserMessages",
+			Handler:    _ChatService_PurgeUserMessages_Handler,
+		},
 		{
 			MethodName: "GetThreadMessages",
 			Handler:    _ChatService_GetThreadMessages_Handler,
@@ -1834,6 +3468,10 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListThreadMessages",
 			Handler:    _ChatService_ListThreadMessages_Handler,
 		},
+		{
+			MethodName: "MoveMessagesToThread",
+			Handler:    _ChatService_MoveMessagesToThread_Handler,
+		},
 		{
 			MethodName: "AddThreadParticipant",
 			Handler:    _ChatService_AddThreadParticipant_Handler,
@@ -1846,6 +3484,22 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListThreadParticipants",
 			Handler:    _ChatService_ListThreadParticipants_Handler,
 		},
+		{
+			MethodName: "FollowThread",
+			Handler:    _ChatService_FollowThread_Handler,
+		},
+		{
+			MethodName: "UnfollowThread",
+			Handler:    _ChatService_UnfollowThread_Handler,
+		},
+		{
+			MethodName: "ListFollowedThreads",
+			Handler:    _ChatService_ListFollowedThreads_Handler,
+		},
+		{
+			MethodName: "MarkThreadAsRead",
+			Handler:    _ChatService_MarkThreadAsRead_Handler,
+		},
 		{
 			MethodName: "ListSubthreads",
 			Handler:    _ChatService_ListSubthreads_Handler,
@@ -1854,6 +3508,158 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSubthread",
 			Handler:    _ChatService_CreateSubthread_Handler,
 		},
+		{
+			MethodName: "ReportMessage",
+			Handler:    _ChatService_ReportMessage_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _ChatService_ListReports_Handler,
+		},
+		{
+			MethodName: "ResolveReport",
+			Handler:    _ChatService_ResolveReport_Handler,
+		},
+		{
+			MethodName: "ListModerationAuditLog",
+			Handler:    _ChatService_ListModerationAuditLog_Handler,
+		},
+		{
+			MethodName: "BanUser",
+			Handler:    _ChatService_BanUser_Handler,
+		},
+		{
+			MethodName: "UnbanUser",
+			Handler:    _ChatService_UnbanUser_Handler,
+		},
+		{
+			MethodName: "MuteUser",
+			Handler:    _ChatService_MuteUser_Handler,
+		},
+		{
+			MethodName: "UnmuteUser",
+			Handler:    _ChatService_UnmuteUser_Handler,
+		},
+		{
+			MethodName: "ListChatRestrictions",
+			Handler:    _ChatService_ListChatRestrictions_Handler,
+		},
+		{
+			MethodName: "GetContentPolicy",
+			Handler:    _ChatService_GetContentPolicy_Handler,
+		},
+		{
+			MethodName: "SetContentPolicy",
+			Handler:    _ChatService_SetContentPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteContentPolicy",
+			Handler:    _ChatService_DeleteContentPolicy_Handler,
+		},
+		{
+			MethodName: "ListContentFilterHits",
+			Handler:    _ChatService_ListContentFilterHits_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ChatService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ChatService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _ChatService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ChatService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _ChatService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _ChatService_RedeliverWebhook_Handler,
+		},
+		{
+			MethodName: "CreateIncomingWebhook",
+			Handler:    _ChatService_CreateIncomingWebhook_Handler,
+		},
+		{
+			MethodName: "ListIncomingWebhooks",
+			Handler:    _ChatService_ListIncomingWebhooks_Handler,
+		},
+		{
+			MethodName: "RotateIncomingWebhookToken",
+			Handler:    _ChatService_RotateIncomingWebhookToken_Handler,
+		},
+		{
+			MethodName: "DeleteIncomingWebhook",
+			Handler:    _ChatService_DeleteIncomingWebhook_Handler,
+		},
+		{
+			MethodName: "ExecuteIncomingWebhook",
+			Handler:    _ChatService_ExecuteIncomingWebhook_Handler,
+		},
+		{
+			MethodName: "ListCommands",
+			Handler:    _ChatService_ListCommands_Handler,
+		},
+		{
+			MethodName: "RegisterBotCommand",
+			Handler:    _ChatService_RegisterBotCommand_Handler,
+		},
+		{
+			MethodName: "ListBotCommands",
+			Handler:    _ChatService_ListBotCommands_Handler,
+		},
+		{
+			MethodName: "DeleteBotCommand",
+			Handler:    _ChatService_DeleteBotCommand_Handler,
+		},
+		{
+			MethodName: "InteractMessage",
+			Handler:    _ChatService_InteractMessage_Handler,
+		},
+		{
+			MethodName: "CreateMessageReminder",
+			Handler:    _ChatService_CreateMessageReminder_Handler,
+		},
+		{
+			MethodName: "ListReminders",
+			Handler:    _ChatService_ListReminders_Handler,
+		},
+		{
+			MethodName: "CancelReminder",
+			Handler:    _ChatService_CancelReminder_Handler,
+		},
+		{
+			MethodName: "CreateCustomEmoji",
+			Handler:    _ChatService_CreateCustomEmoji_Handler,
+		},
+		{
+			MethodName: "ListCustomEmoji",
+			Handler:    _ChatService_ListCustomEmoji_Handler,
+		},
+		{
+			MethodName: "DeleteCustomEmoji",
+			Handler:    _ChatService_DeleteCustomEmoji_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _ChatService_SaveDraft_Handler,
+		},
+		{
+			MethodName: "GetDrafts",
+			Handler:    _ChatService_GetDrafts_Handler,
+		},
+		{
+			MethodName: "DeleteDraft",
+			Handler:    _ChatService_DeleteDraft_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/chat/chat.proto",
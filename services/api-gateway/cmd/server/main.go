@@ -121,6 +121,10 @@ func main() {
 
 	log.Info("centrifugo client initialized", "api_url", centrifugoAPIURL)
 
+	// SSE and long-poll clients are served through Centrifugo's unidirectional HTTP stream
+	centrifugoStreamURL := getEnv("CENTRIFUGO_STREAM_URL", "http://localhost:8000/connection/uni_http_stream")
+	centrifugoStreamer := centrifugo.NewStreamer(centrifugoClient, centrifugoStreamURL)
+
 	// Initialize files service client
 	filesServiceURL := getEnv("FILES_SERVICE_URL", "http://localhost:8082")
 	filesClient := files.NewClient(filesServiceURL)
@@ -134,11 +138,12 @@ func main() {
 	usersServiceURL := getEnv("USERS_SERVICE_URL", "http://localhost:8081")
 	authHandler := handler.NewAuthHandler(usersServiceURL, centrifugoClient, log)
 	chatHandler := handler.NewChatHandler(chatClient, filesClient, orgClient, log)
-	centrifugoHandler := handler.NewCentrifugoHandler(centrifugoClient, log)
+	centrifugoHandler := handler.NewCentrifugoHandler(centrifugoClient, chatClient, log)
 	filesHandler := handler.NewFilesHandler(filesServiceURL, log)
 	presenceHandler := handler.NewPresenceHandler(presenceClient, log)
 	voiceHandler := handler.NewVoiceHandler(voiceClient, log)
 	eventsDocsHandler := handler.NewEventsDocsHandler()
+	eventsStreamHandler := handler.NewEventsStreamHandler(centrifugoStreamer, chatClient, log)
 
 	// Create router
 	r := chi.NewRouter()
@@ -149,7 +154,7 @@ func main() {
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
 	r.Use(chimiddleware.RealIP)
-	r.Use(chimiddleware.Logger)
+	r.Use(middleware.Logger) // chi's logger with webhook and access tokens redacted
 	r.Use(chimiddleware.Recoverer)
 	r.Use(chimiddleware.Timeout(60 * time.Second))
 	r.Use(middleware.CORS)
@@ -198,6 +203,19 @@ func main() {
 			})
 		})
 
+		// Incoming webhooks (public, authorized by the X-Webhook-Token header or, for senders that
+		// can only be given a URL, by the token in the path)
+		r.Post("/hooks/{webhookId}", chatHandler.ExecuteIncomingWebhook)
+		r.Post("/hooks/{webhookId}/{token}", chatHandler.ExecuteIncomingWebhook)
+
+		// Bot account routes (protected, moderators only - enforced by users service)
+		r.Route("/bots", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Get("/", authHandler.ListBots)
+			r.Post("/", authHandler.CreateBot)
+			r.Delete("/{botId}", authHandler.DeleteBot)
+		})
+
 		// Chat routes (protected)
 		r.Route("/chats", func(r chi.Router) {
 			r.Use(authMiddleware.Authenticate)
@@ -210,6 +228,12 @@ func main() {
 			r.Mount("/", centrifugoHandler.Routes())
 		})
 
+		// Realtime events over SSE and long-polling, for clients without WebSocket (protected)
+		r.Route("/events", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Mount("/", eventsStreamHandler.Routes())
+		})
+
 		// Files routes (protected)
 		r.Route("/files", func(r chi.Router) {
 			r.Use(authMiddleware.Authenticate)
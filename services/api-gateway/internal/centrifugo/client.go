@@ -44,32 +44,31 @@ func NewClient(apiURL, apiKey, secretKey string) *Client {
 
 // GenerateConnectionToken generates a JWT token for Centrifugo connection
 func (c *Client) GenerateConnectionToken(userID string, exp int64) string {
-	header := base64URLEncode([]byte(`{"typ":"JWT","alg":"HS256"}`))
-
-	payload := map[string]interface{}{
+	return c.generateToken(map[string]interface{}{
 		"sub": userID,
-	}
-	if exp > 0 {
-		payload["exp"] = exp
-	}
-
-	payloadBytes, _ := json.Marshal(payload)
-	payloadEncoded := base64URLEncode(payloadBytes)
-
-	signatureInput := header + "." + payloadEncoded
-	signature := c.sign([]byte(signatureInput))
+	}, exp)
+}
 
-	return signatureInput + "." + base64URLEncode(signature)
+// GenerateServerSideToken generates a connection token that subscribes the connection to
+// channels on the server side, without subscription tokens
+func (c *Client) GenerateServerSideToken(userID string, channels []string, exp int64) string {
+	return c.generateToken(map[string]interface{}{
+		"sub":      userID,
+		"channels": channels,
+	}, exp)
 }
 
 // GenerateSubscriptionToken generates a JWT token for channel subscription
 func (c *Client) GenerateSubscriptionToken(userID, channel string, exp int64) string {
-	header := base64URLEncode([]byte(`{"typ":"JWT","alg":"HS256"}`))
-
-	payload := map[string]interface{}{
+	return c.generateToken(map[string]interface{}{
 		"sub":     userID,
 		"channel": channel,
-	}
+	}, exp)
+}
+
+func (c *Client) generateToken(payload map[string]interface{}, exp int64) string {
+	header := base64URLEncode([]byte(`{"typ":"JWT","alg":"HS256"}`))
+
 	if exp > 0 {
 		payload["exp"] = exp
 	}
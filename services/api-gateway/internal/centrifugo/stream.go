@@ -0,0 +1,289 @@
+package centrifugo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPushSize bounds a single pushed message read from a stream
+const maxPushSize = 1 << 20
+
+var (
+	ErrInvalidPosition = errors.New("invalid stream position")
+	ErrStreamClosed    = errors.New("stream closed by centrifugo")
+)
+
+// Position is a place in a channel's history, used to resume a stream after a reconnect
+type Position struct {
+	Offset uint64
+	Epoch  string
+}
+
+// String encodes the position as "offset:epoch", the form used in cursors
+func (p Position) String() string {
+	return strconv.FormatUint(p.Offset, 10) + ":" + p.Epoch
+}
+
+// ParsePosition decodes a position produced by Position.String
+func ParsePosition(s string) (Position, error) {
+	offset, epoch, ok := strings.Cut(s, ":")
+	if !ok || epoch == "" {
+		return Position{}, ErrInvalidPosition
+	}
+	n, err := strconv.ParseUint(offset, 10, 64)
+	if err != nil {
+		return Position{}, ErrInvalidPosition
+	}
+	return Position{Offset: n, Epoch: epoch}, nil
+}
+
+// Cursor holds a position per channel, so that a stream over several channels can be resumed
+type Cursor map[string]Position
+
+// String encodes the cursor as "channel=offset:epoch" entries separated by commas, sorted by channel
+func (c Cursor) String() string {
+	channels := make([]string, 0, len(c))
+	for channel := range c {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	var b strings.Builder
+	for i, channel := range channels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(channel)
+		b.WriteByte('=')
+		b.WriteString(c[channel].String())
+	}
+	return b.String()
+}
+
+// ParseCursor decodes a cursor produced by Cursor.String
+func ParseCursor(s string) (Cursor, error) {
+	c := make(Cursor)
+	if s == "" {
+		return c, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		channel, position, ok := strings.Cut(entry, "=")
+		if !ok || channel == "" {
+			return nil, ErrInvalidPosition
+		}
+		pos, err := ParsePosition(position)
+		if err != nil {
+			return nil, err
+		}
+		c[channel] = pos
+	}
+	return c, nil
+}
+
+// Publication is an event received from a channel
+type Publication struct {
+	Channel  string
+	Data     json.RawMessage
+	Position Position
+}
+
+// Streamer opens unidirectional HTTP streaming connections to Centrifugo on behalf of users, for
+// clients that cannot use WebSockets. Requires uni_http_stream in the Centrifugo config.
+type Streamer struct {
+	client     *Client
+	url        string
+	httpClient *http.Client
+}
+
+// NewStreamer creates a streamer; url is Centrifugo's /connection/uni_http_stream endpoint
+func NewStreamer(client *Client, url string) *Streamer {
+	return &Streamer{
+		client: client,
+		url:    url,
+		// No overall timeout: streams are long-lived and end with the request context
+		httpClient: &http.Client{},
+	}
+}
+
+// Stream is an open connection subscribed to a user's channels
+type Stream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	epochs  map[string]string
+	pending []Publication
+
+	// Positions holds the current position of every recoverable channel at connect time
+	Positions map[string]Position
+	// Recovered is false when a requested resume failed, because the position is older than the
+	// channel history or its epoch changed; the client must resync through the REST API
+	Recovered bool
+}
+
+type streamSubscribe struct {
+	Recover bool   `json:"recover,omitempty"`
+	Epoch   string `json:"epoch,omitempty"`
+	Offset  uint64 `json:"offset,omitempty"`
+}
+
+type streamPublication struct {
+	Data   json.RawMessage `json:"data"`
+	Offset uint64          `json:"offset"`
+}
+
+type streamPush struct {
+	Channel string             `json:"channel"`
+	Pub     *streamPublication `json:"pub"`
+	Connect *struct {
+		Subs map[string]struct {
+			Recoverable   bool                `json:"recoverable"`
+			Epoch         string              `json:"epoch"`
+			Offset        uint64              `json:"offset"`
+			Publications  []streamPublication `json:"publications"`
+			WasRecovering bool                `json:"was_recovering"`
+			Recovered     bool                `json:"recovered"`
+		} `json:"subs"`
+	} `json:"connect"`
+	Disconnect *struct {
+		Code   int    `json:"code"`
+		Reason string `json:"reason"`
+	} `json:"disconnect"`
+}
+
+// Open connects as userID with server-side subscriptions to channels. Channels with a position
+// in since are recovered from it; the missed publications are returned first by Next.
+func (s *Streamer) Open(ctx context.Context, userID string, channels []string, since map[string]Position) (*Stream, error) {
+	subs := make(map[string]streamSubscribe, len(since))
+	for channel, pos := range since {
+		subs[channel] = streamSubscribe{Recover: true, Epoch: pos.Epoch, Offset: pos.Offset}
+	}
+
+	token := s.client.GenerateServerSideToken(userID, channels, time.Now().Add(time.Hour).Unix())
+	body, err := json.Marshal(map[string]interface{}{
+		"token": token,
+		"subs":  subs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal connect request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to centrifugo: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("centrifugo stream: status %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxPushSize)
+	stream := &Stream{
+		body:      resp.Body,
+		scanner:   scanner,
+		epochs:    make(map[string]string),
+		Positions: make(map[string]Position),
+		Recovered: true,
+	}
+	if err := stream.readConnect(since); err != nil {
+		stream.Close()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// readConnect reads the connect push, which carries channel positions and recovered publications
+func (st *Stream) readConnect(since map[string]Position) error {
+	push, err := st.read()
+	if err != nil {
+		return err
+	}
+	if push.Connect == nil {
+		return fmt.Errorf("centrifugo stream: expected connect push")
+	}
+
+	for channel, sub := range push.Connect.Subs {
+		if !sub.Recoverable {
+			continue
+		}
+		st.epochs[channel] = sub.Epoch
+		st.Positions[channel] = Position{Offset: sub.Offset, Epoch: sub.Epoch}
+		if _, resumed := since[channel]; resumed && !sub.Recovered {
+			st.Recovered = false
+		}
+		for _, pub := range sub.Publications {
+			st.pending = append(st.pending, Publication{
+				Channel:  channel,
+				Data:     pub.Data,
+				Position: Position{Offset: pub.Offset, Epoch: sub.Epoch},
+			})
+		}
+	}
+	return nil
+}
+
+// Next blocks until the next publication. It fails with ErrStreamClosed when Centrifugo ends the
+// connection, or with the context error once the request passed to Open is done.
+func (st *Stream) Next() (Publication, error) {
+	if len(st.pending) > 0 {
+		pub := st.pending[0]
+		st.pending = st.pending[1:]
+		return pub, nil
+	}
+
+	for {
+		push, err := st.read()
+		if err != nil {
+			return Publication{}, err
+		}
+		if push.Disconnect != nil {
+			return Publication{}, fmt.Errorf("%w: %s", ErrStreamClosed, push.Disconnect.Reason)
+		}
+		if push.Pub == nil {
+			continue // Ping or a push that carries no event
+		}
+		return Publication{
+			Channel:  push.Channel,
+			Data:     push.Pub.Data,
+			Position: Position{Offset: push.Pub.Offset, Epoch: st.epochs[push.Channel]},
+		}, nil
+	}
+}
+
+func (st *Stream) read() (*streamPush, error) {
+	for st.scanner.Scan() {
+		line := bytes.TrimSpace(st.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var push streamPush
+		if err := json.Unmarshal(line, &push); err != nil {
+			return nil, fmt.Errorf("failed to decode centrifugo push: %w", err)
+		}
+		return &push, nil
+	}
+	if err := st.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrStreamClosed
+}
+
+// Close ends the connection; a blocked Next returns
+func (st *Stream) Close() error {
+	return st.body.Close()
+}
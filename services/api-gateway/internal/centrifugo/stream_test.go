@@ -0,0 +1,214 @@
+package centrifugo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Position
+		wantErr bool
+	}{
+		{s: "42:abcd", want: Position{Offset: 42, Epoch: "abcd"}},
+		{s: "0:e", want: Position{Offset: 0, Epoch: "e"}},
+		{s: "7:ep:och", want: Position{Offset: 7, Epoch: "ep:och"}},
+		{s: "", wantErr: true},
+		{s: "42", wantErr: true},
+		{s: "42:", wantErr: true},
+		{s: ":abcd", wantErr: true},
+		{s: "-1:abcd", wantErr: true},
+		{s: "x:abcd", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParsePosition(tt.s)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPosition)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.s, got.String())
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	c := Cursor{
+		"user:1": {Offset: 42, Epoch: "ab"},
+		"chat:2": {Offset: 0, Epoch: "e:f"},
+	}
+	assert.Equal(t, "chat:2=0:e:f,user:1=42:ab", c.String())
+
+	got, err := ParseCursor(c.String())
+	require.NoError(t, err)
+	assert.Equal(t, c, got)
+
+	got, err = ParseCursor("")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	for _, s := range []string{"user:1", "=42:ab", "user:1=42", "user:1=42:ab,", "user:1=x:ab"} {
+		_, err := ParseCursor(s)
+		assert.ErrorIs(t, err, ErrInvalidPosition, s)
+	}
+}
+
+// newTestStream returns a stream reading the given newline-separated pushes
+func newTestStream(pushes string) *Stream {
+	body := io.NopCloser(strings.NewReader(pushes))
+	return &Stream{
+		body:      body,
+		scanner:   bufio.NewScanner(body),
+		epochs:    make(map[string]string),
+		Positions: make(map[string]Position),
+		Recovered: true,
+	}
+}
+
+func TestStream_ReadConnect(t *testing.T) {
+	tests := []struct {
+		name      string
+		pushes    string
+		since     map[string]Position
+		positions map[string]Position
+		pending   []Publication
+		recovered bool
+		wantErr   bool
+	}{
+		{
+			name:      "fresh connect records positions",
+			pushes:    `{"connect":{"subs":{"chat:1":{"recoverable":true,"epoch":"e1","offset":5},"presence":{}}}}`,
+			positions: map[string]Position{"chat:1": {Offset: 5, Epoch: "e1"}},
+			recovered: true,
+		},
+		{
+			name: "recovered publications are queued",
+			pushes: "\n" + `{"connect":{"subs":{"chat:1":{"recoverable":true,"epoch":"e1","offset":7,"was_recovering":true,"recovered":true,` +
+				`"publications":[{"data":{"n":6},"offset":6},{"data":{"n":7},"offset":7}]}}}}`,
+			since:     map[string]Position{"chat:1": {Offset: 5, Epoch: "e1"}},
+			positions: map[string]Position{"chat:1": {Offset: 7, Epoch: "e1"}},
+			pending: []Publication{
+				{Channel: "chat:1", Data: json.RawMessage(`{"n":6}`), Position: Position{Offset: 6, Epoch: "e1"}},
+				{Channel: "chat:1", Data: json.RawMessage(`{"n":7}`), Position: Position{Offset: 7, Epoch: "e1"}},
+			},
+			recovered: true,
+		},
+		{
+			name:      "failed recovery requires a resync",
+			pushes:    `{"connect":{"subs":{"chat:1":{"recoverable":true,"epoch":"e2","offset":1,"was_recovering":true}}}}`,
+			since:     map[string]Position{"chat:1": {Offset: 5, Epoch: "e1"}},
+			positions: map[string]Position{"chat:1": {Offset: 1, Epoch: "e2"}},
+			recovered: false,
+		},
+		{
+			name:      "channel that was not resumed does not need recovery",
+			pushes:    `{"connect":{"subs":{"chat:1":{"recoverable":true,"epoch":"e1","offset":3},"chat:2":{"recoverable":true,"epoch":"e2","offset":9,"recovered":true}}}}`,
+			since:     map[string]Position{"chat:2": {Offset: 9, Epoch: "e2"}},
+			positions: map[string]Position{"chat:1": {Offset: 3, Epoch: "e1"}, "chat:2": {Offset: 9, Epoch: "e2"}},
+			recovered: true,
+		},
+		{
+			name:    "first push is not a connect",
+			pushes:  `{"channel":"chat:1","pub":{"data":{},"offset":1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			pushes:  `{"connect":`,
+			wantErr: true,
+		},
+		{
+			name:    "closed before connect",
+			pushes:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newTestStream(tt.pushes)
+			err := st.readConnect(tt.since)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.positions, st.Positions)
+			assert.Equal(t, tt.pending, st.pending)
+			assert.Equal(t, tt.recovered, st.Recovered)
+		})
+	}
+}
+
+func TestStream_Next(t *testing.T) {
+	st := newTestStream(strings.Join([]string{
+		`{"connect":{"subs":{"chat:1":{"recoverable":true,"epoch":"e1","offset":1,"recovered":true,"publications":[{"data":{"n":1},"offset":1}]}}}}`,
+		`{}`,
+		`{"channel":"chat:1","pub":{"data":{"n":2},"offset":2}}`,
+		`{"disconnect":{"code":3005,"reason":"connection expired"}}`,
+	}, "\n"))
+	require.NoError(t, st.readConnect(map[string]Position{"chat:1": {Offset: 0, Epoch: "e1"}}))
+
+	pub, err := st.Next()
+	require.NoError(t, err)
+	assert.Equal(t, Position{Offset: 1, Epoch: "e1"}, pub.Position)
+
+	pub, err = st.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "chat:1", pub.Channel)
+	assert.JSONEq(t, `{"n":2}`, string(pub.Data))
+	assert.Equal(t, Position{Offset: 2, Epoch: "e1"}, pub.Position)
+
+	_, err = st.Next()
+	assert.ErrorIs(t, err, ErrStreamClosed)
+	assert.Contains(t, err.Error(), "connection expired")
+
+	_, err = st.Next()
+	assert.ErrorIs(t, err, ErrStreamClosed)
+}
+
+func TestStreamer_Open(t *testing.T) {
+	var got struct {
+		Token string                     `json:"token"`
+		Subs  map[string]streamSubscribe `json:"subs"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"connect":{"subs":{"user:1":{"recoverable":true,"epoch":"e1","offset":4,"recovered":true}}}}` + "\n"))
+	}))
+	defer srv.Close()
+
+	s := NewStreamer(NewClient("", "", "secret"), srv.URL)
+	st, err := s.Open(context.Background(), "1", []string{"user:1"}, map[string]Position{"user:1": {Offset: 3, Epoch: "e1"}})
+	require.NoError(t, err)
+	defer st.Close()
+
+	assert.NotEmpty(t, got.Token)
+	assert.Equal(t, map[string]streamSubscribe{"user:1": {Recover: true, Epoch: "e1", Offset: 3}}, got.Subs)
+	assert.Equal(t, map[string]Position{"user:1": {Offset: 4, Epoch: "e1"}}, st.Positions)
+	assert.True(t, st.Recovered)
+}
+
+func TestStreamer_OpenBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := NewStreamer(NewClient("", "", "secret"), srv.URL).Open(context.Background(), "1", nil, nil)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrStreamClosed))
+}
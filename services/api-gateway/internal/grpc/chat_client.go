@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/icegreg/chat-smpl/proto/chat"
 )
@@ -103,6 +104,15 @@ func (c *ChatClient) SearchChats(ctx context.Context, userID, query string, page
 	})
 }
 
+// GetChatListChanges returns chat list changes after a version for incremental chat list sync
+func (c *ChatClient) GetChatListChanges(ctx context.Context, userID string, sinceVersion int64, limit int32) (*pb.GetChatListChangesResponse, error) {
+	return c.client.GetChatListChanges(ctx, &pb.GetChatListChangesRequest{
+		UserId:       userID,
+		SinceVersion: sinceVersion,
+		Limit:        limit,
+	})
+}
+
 // Participant operations
 
 func (c *ChatClient) AddParticipant(ctx context.Context, chatID, userID, addedBy string, role pb.ParticipantRole) (*pb.ChatParticipant, error) {
@@ -142,12 +152,13 @@ func (c *ChatClient) ListParticipants(ctx context.Context, chatID string, page,
 
 // Message operations
 
-func (c *ChatClient) SendMessage(ctx context.Context, chatID, senderID, content string, parentID string, fileLinkIDs, replyToIDs []string) (*pb.Message, error) {
+func (c *ChatClient) SendMessage(ctx context.Context, chatID, senderID, content string, parentID, threadID string, fileLinkIDs, replyToIDs []string) (*pb.Message, error) {
 	return c.client.SendMessage(ctx, &pb.SendMessageRequest{
 		ChatId:      chatID,
 		SenderId:    senderID,
 		Content:     content,
 		ParentId:    parentID,
+		ThreadId:    threadID,
 		FileLinkIds: fileLinkIDs,
 		ReplyToIds:  replyToIDs,
 	})
@@ -214,9 +225,10 @@ func (c *ChatClient) RemoveReaction(ctx context.Context, messageID, userID, reac
 	return err
 }
 
-func (c *ChatClient) ListReactions(ctx context.Context, messageID string) (*pb.ListReactionsResponse, error) {
+func (c *ChatClient) ListReactions(ctx context.Context, messageID, userID string) (*pb.ListReactionsResponse, error) {
 	return c.client.ListReactions(ctx, &pb.ListReactionsRequest{
 		MessageId: messageID,
+		UserId:    userID,
 	})
 }
 
@@ -329,11 +341,13 @@ func (c *ChatClient) SendTyping(ctx context.Context, chatID, userID string, isTy
 	return err
 }
 
-// SyncMessages returns messages after a specific sequence number for reliable sync after reconnect
-func (c *ChatClient) SyncMessages(ctx context.Context, chatID, userID string, afterSeqNum int64, limit int32) (*pb.SyncMessagesResponse, error) {
+// SyncMessages returns messages and tombstones after a specific sequence number for reliable sync after reconnect.
+// threadID is optional: when set, the thread is synced instead of the main chat.
+func (c *ChatClient) SyncMessages(ctx context.Context, chatID, userID string, threadID *string, afterSeqNum int64, limit int32) (*pb.SyncMessagesResponse, error) {
 	return c.client.SyncMessages(ctx, &pb.SyncMessagesRequest{
 		ChatId:      chatID,
 		UserId:      userID,
+		ThreadId:    threadID,
 		AfterSeqNum: afterSeqNum,
 		Limit:       limit,
 	})
@@ -398,6 +412,16 @@ func (c *ChatClient) ListThreadMessages(ctx context.Context, threadID, userID st
 	})
 }
 
+func (c *ChatClient) MoveMessagesToThread(ctx context.Context, chatID string, messageIDs []string, targetThreadID *string, newThreadTitle, movedBy string) (*pb.MoveMessagesToThreadResponse, error) {
+	return c.client.MoveMessagesToThread(ctx, &pb.MoveMessagesToThreadRequest{
+		ChatId:         chatID,
+		MessageIds:     messageIDs,
+		TargetThreadId: targetThreadID,
+		NewThreadTitle: newThreadTitle,
+		MovedBy:        movedBy,
+	})
+}
+
 // Thread participant operations
 
 func (c *ChatClient) AddThreadParticipant(ctx context.Context, threadID, userID, addedBy string) error {
@@ -424,6 +448,40 @@ func (c *ChatClient) ListThreadParticipants(ctx context.Context, threadID string
 	})
 }
 
+// Thread subscription operations
+
+func (c *ChatClient) FollowThread(ctx context.Context, threadID, userID string) error {
+	_, err := c.client.FollowThread(ctx, &pb.FollowThreadRequest{
+		ThreadId: threadID,
+		UserId:   userID,
+	})
+	return err
+}
+
+func (c *ChatClient) UnfollowThread(ctx context.Context, threadID, userID string) error {
+	_, err := c.client.UnfollowThread(ctx, &pb.UnfollowThreadRequest{
+		ThreadId: threadID,
+		UserId:   userID,
+	})
+	return err
+}
+
+func (c *ChatClient) ListFollowedThreads(ctx context.Context, userID string, page, count int32) (*pb.ListFollowedThreadsResponse, error) {
+	return c.client.ListFollowedThreads(ctx, &pb.ListFollowedThreadsRequest{
+		UserId: userID,
+		Page:   page,
+		Count:  count,
+	})
+}
+
+func (c *ChatClient) MarkThreadAsRead(ctx context.Context, threadID, userID string) error {
+	_, err := c.client.MarkThreadAsRead(ctx, &pb.MarkThreadAsReadRequest{
+		ThreadId: threadID,
+		UserId:   userID,
+	})
+	return err
+}
+
 // Subthread operations
 
 func (c *ChatClient) ListSubthreads(ctx context.Context, parentThreadID, userID string, page, count int32) (*pb.ListThreadsResponse, error) {
@@ -461,3 +519,379 @@ func (c *ChatClient) RemoveFromQuote(ctx context.Context, messageID, quotedMessa
 	})
 	return err
 }
+
+// BulkDeleteMessages deletes messages by ID list, or by sender within an optional time window (moderator only)
+func (c *ChatClient) BulkDeleteMessages(ctx context.Context, chatID, userID string, messageIDs []string, senderID *string, from, to *time.Time) (*pb.BulkDeleteMessagesResponse, error) {
+	req := &pb.BulkDeleteMessagesRequest{
+		ChatId:     chatID,
+		UserId:     userID,
+		MessageIds: messageIDs,
+		SenderId:   senderID,
+	}
+	if from != nil {
+		req.From = timestamppb.New(*from)
+	}
+	if to != nil {
+		req.To = timestamppb.New(*to)
+	}
+	return c.client.BulkDeleteMessages(ctx, req)
+}
+
+// PurgeUserMessages deletes all messages of a user in a chat (moderator only)
+func (c *ChatClient) PurgeUserMessages(ctx context.Context, chatID, targetUserID, userID string) (*pb.BulkDeleteMessagesResponse, error) {
+	return c.client.PurgeUserMessages(ctx, &pb.PurgeUserMessagesRequest{
+		ChatId:       chatID,
+		TargetUserId: targetUserID,
+		UserId:       userID,
+	})
+}
+
+// Moderation (message reports)
+
+func (c *ChatClient) ReportMessage(ctx context.Context, messageID, reporterID, reason, comment string) (*pb.MessageReport, error) {
+	return c.client.ReportMessage(ctx, &pb.ReportMessageRequest{
+		MessageId:  messageID,
+		ReporterId: reporterID,
+		Reason:     reason,
+		Comment:    comment,
+	})
+}
+
+// ListReports returns the moderation queue of a chat, or the global queue when chatID is nil
+func (c *ChatClient) ListReports(ctx context.Context, userID string, chatID *string, status string, page, count int32) (*pb.ListReportsResponse, error) {
+	return c.client.ListReports(ctx, &pb.ListReportsRequest{
+		UserId: userID,
+		ChatId: chatID,
+		Status: status,
+		Page:   page,
+		Count:  count,
+	})
+}
+
+func (c *ChatClient) ResolveReport(ctx context.Context, reportID, moderatorID, action, note string) (*pb.MessageReport, error) {
+	return c.client.ResolveReport(ctx, &pb.ResolveReportRequest{
+		ReportId:    reportID,
+		ModeratorId: moderatorID,
+		Action:      action,
+		Note:        note,
+	})
+}
+
+// ListModerationAuditLog returns the moderation audit trail of a chat, or of all chats when chatID is nil
+func (c *ChatClient) ListModerationAuditLog(ctx context.Context, userID string, chatID *string, page, count int32) (*pb.ListModerationAuditLogResponse, error) {
+	return c.client.ListModerationAuditLog(ctx, &pb.ListModerationAuditLogRequest{
+		UserId: userID,
+		ChatId: chatID,
+		Page:   page,
+		Count:  count,
+	})
+}
+
+// Chat restrictions (bans and timed mutes)
+
+// BanUser bans a user from the chat; durationSeconds 0 = permanent
+func (c *ChatClient) BanUser(ctx context.Context, chatID, userID, bannedBy, reason string, durationSeconds int64) (*pb.ChatRestriction, error) {
+	return c.client.BanUser(ctx, &pb.BanUserRequest{
+		ChatId:          chatID,
+		UserId:          userID,
+		BannedBy:        bannedBy,
+		Reason:          reason,
+		DurationSeconds: durationSeconds,
+	})
+}
+
+func (c *ChatClient) UnbanUser(ctx context.Context, chatID, userID, unbannedBy string) error {
+	_, err := c.client.UnbanUser(ctx, &pb.UnbanUserRequest{
+		ChatId:     chatID,
+		UserId:     userID,
+		UnbannedBy: unbannedBy,
+	})
+	return err
+}
+
+func (c *ChatClient) MuteUser(ctx context.Context, chatID, userID, mutedBy, reason string, durationSeconds int64) (*pb.ChatRestriction, error) {
+	return c.client.MuteUser(ctx, &pb.MuteUserRequest{
+		ChatId:          chatID,
+		UserId:          userID,
+		MutedBy:         mutedBy,
+		Reason:          reason,
+		DurationSeconds: durationSeconds,
+	})
+}
+
+func (c *ChatClient) UnmuteUser(ctx context.Context, chatID, userID, unmutedBy string) error {
+	_, err := c.client.UnmuteUser(ctx, &pb.UnmuteUserRequest{
+		ChatId:    chatID,
+		UserId:    userID,
+		UnmutedBy: unmutedBy,
+	})
+	return err
+}
+
+func (c *ChatClient) ListChatRestrictions(ctx context.Context, chatID, userID string) (*pb.ListChatRestrictionsResponse, error) {
+	return c.client.ListChatRestrictions(ctx, &pb.ListChatRestrictionsRequest{
+		ChatId: chatID,
+		UserId: userID,
+	})
+}
+
+// SetSlowMode sets the per-participant message interval of a chat (0 disables slow mode)
+func (c *ChatClient) SetSlowMode(ctx context.Context, chatID, userID string, seconds int32) (*pb.Chat, error) {
+	return c.client.SetSlowMode(ctx, &pb.SetSlowModeRequest{
+		ChatId:  chatID,
+		UserId:  userID,
+		Seconds: seconds,
+	})
+}
+
+// Content filters (chatID nil = global policy)
+
+func (c *ChatClient) GetContentPolicy(ctx context.Context, userID string, chatID *string) (*pb.ContentPolicy, error) {
+	return c.client.GetContentPolicy(ctx, &pb.GetContentPolicyRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) SetContentPolicy(ctx context.Context, userID string, chatID *string, rules *pb.ContentPolicyRules) (*pb.ContentPolicy, error) {
+	return c.client.SetContentPolicy(ctx, &pb.SetContentPolicyRequest{
+		UserId: userID,
+		ChatId: chatID,
+		Rules:  rules,
+	})
+}
+
+func (c *ChatClient) DeleteContentPolicy(ctx context.Context, userID string, chatID *string) error {
+	_, err := c.client.DeleteContentPolicy(ctx, &pb.DeleteContentPolicyRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+	return err
+}
+
+func (c *ChatClient) ListContentFilterHits(ctx context.Context, userID string, chatID *string, page, count int32) (*pb.ListContentFilterHitsResponse, error) {
+	return c.client.ListContentFilterHits(ctx, &pb.ListContentFilterHitsRequest{
+		UserId: userID,
+		ChatId: chatID,
+		Page:   page,
+		Count:  count,
+	})
+}
+
+// Outgoing webhooks (chatID nil = subscription to all chats)
+
+func (c *ChatClient) CreateWebhook(ctx context.Context, userID string, chatID *string, url string, eventTypes []string) (*pb.Webhook, error) {
+	return c.client.CreateWebhook(ctx, &pb.CreateWebhookRequest{
+		UserId:     userID,
+		ChatId:     chatID,
+		Url:        url,
+		EventTypes: eventTypes,
+	})
+}
+
+func (c *ChatClient) ListWebhooks(ctx context.Context, userID string, chatID *string) (*pb.ListWebhooksResponse, error) {
+	return c.client.ListWebhooks(ctx, &pb.ListWebhooksRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) UpdateWebhook(ctx context.Context, userID, webhookID, url string, eventTypes []string, isActive bool) (*pb.Webhook, error) {
+	return c.client.UpdateWebhook(ctx, &pb.UpdateWebhookRequest{
+		UserId:     userID,
+		WebhookId:  webhookID,
+		Url:        url,
+		EventTypes: eventTypes,
+		IsActive:   isActive,
+	})
+}
+
+func (c *ChatClient) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	_, err := c.client.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+	})
+	return err
+}
+
+func (c *ChatClient) ListWebhookDeliveries(ctx context.Context, userID, webhookID, status string, page, count int32) (*pb.ListWebhookDeliveriesResponse, error) {
+	return c.client.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+		Status:    status,
+		Page:      page,
+		Count:     count,
+	})
+}
+
+func (c *ChatClient) RedeliverWebhook(ctx context.Context, userID, deliveryID string) (*pb.WebhookDelivery, error) {
+	return c.client.RedeliverWebhook(ctx, &pb.RedeliverWebhookRequest{
+		UserId:     userID,
+		DeliveryId: deliveryID,
+	})
+}
+
+// Incoming webhooks (bots posting into a chat)
+
+func (c *ChatClient) CreateIncomingWebhook(ctx context.Context, userID, chatID, botID, name string, threadID *string, rateLimitPerMinute int32) (*pb.IncomingWebhook, error) {
+	return c.client.CreateIncomingWebhook(ctx, &pb.CreateIncomingWebhookRequest{
+		UserId:             userID,
+		ChatId:             chatID,
+		BotId:              botID,
+		Name:               name,
+		ThreadId:           threadID,
+		RateLimitPerMinute: rateLimitPerMinute,
+	})
+}
+
+func (c *ChatClient) ListIncomingWebhooks(ctx context.Context, userID, chatID string) (*pb.ListIncomingWebhooksResponse, error) {
+	return c.client.ListIncomingWebhooks(ctx, &pb.ListIncomingWebhooksRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) RotateIncomingWebhookToken(ctx context.Context, userID, webhookID string) (*pb.IncomingWebhook, error) {
+	return c.client.RotateIncomingWebhookToken(ctx, &pb.RotateIncomingWebhookTokenRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+	})
+}
+
+func (c *ChatClient) DeleteIncomingWebhook(ctx context.Context, userID, webhookID string) error {
+	_, err := c.client.DeleteIncomingWebhook(ctx, &pb.DeleteIncomingWebhookRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+	})
+	return err
+}
+
+func (c *ChatClient) ExecuteIncomingWebhook(ctx context.Context, req *pb.ExecuteIncomingWebhookRequest) (*pb.Message, error) {
+	return c.client.ExecuteIncomingWebhook(ctx, req)
+}
+
+// Slash commands and interactive messages (chatID nil = commands of all chats)
+
+func (c *ChatClient) ListCommands(ctx context.Context, userID, chatID string) (*pb.ListCommandsResponse, error) {
+	return c.client.ListCommands(ctx, &pb.ListCommandsRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) RegisterBotCommand(ctx context.Context, userID string, chatID *string, botID, name, description, usage, url string) (*pb.BotCommand, error) {
+	return c.client.RegisterBotCommand(ctx, &pb.RegisterBotCommandRequest{
+		UserId:      userID,
+		ChatId:      chatID,
+		BotId:       botID,
+		Name:        name,
+		Description: description,
+		Usage:       usage,
+		Url:         url,
+	})
+}
+
+func (c *ChatClient) ListBotCommands(ctx context.Context, userID string, chatID *string) (*pb.ListBotCommandsResponse, error) {
+	return c.client.ListBotCommands(ctx, &pb.ListBotCommandsRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) DeleteBotCommand(ctx context.Context, userID, commandID string) error {
+	_, err := c.client.DeleteBotCommand(ctx, &pb.DeleteBotCommandRequest{
+		UserId:    userID,
+		CommandId: commandID,
+	})
+	return err
+}
+
+func (c *ChatClient) InteractMessage(ctx context.Context, userID, messageID, actionID, value string) (*pb.InteractMessageResponse, error) {
+	return c.client.InteractMessage(ctx, &pb.InteractMessageRequest{
+		UserId:    userID,
+		MessageId: messageID,
+		ActionId:  actionID,
+		Value:     value,
+	})
+}
+
+// Reminders
+
+func (c *ChatClient) CreateMessageReminder(ctx context.Context, userID, messageID string, remindAt time.Time, note string) (*pb.Reminder, error) {
+	return c.client.CreateMessageReminder(ctx, &pb.CreateMessageReminderRequest{
+		UserId:    userID,
+		MessageId: messageID,
+		RemindAt:  timestamppb.New(remindAt),
+		Note:      note,
+	})
+}
+
+func (c *ChatClient) ListReminders(ctx context.Context, userID string, chatID *string, includeDelivered bool) (*pb.ListRemindersResponse, error) {
+	return c.client.ListReminders(ctx, &pb.ListRemindersRequest{
+		UserId:           userID,
+		ChatId:           chatID,
+		IncludeDelivered: includeDelivered,
+	})
+}
+
+func (c *ChatClient) CancelReminder(ctx context.Context, userID, reminderID string) error {
+	_, err := c.client.CancelReminder(ctx, &pb.CancelReminderRequest{
+		UserId:     userID,
+		ReminderId: reminderID,
+	})
+	return err
+}
+
+// Custom emoji
+
+func (c *ChatClient) CreateCustomEmoji(ctx context.Context, userID, chatID, name, fileLinkID string) (*pb.CustomEmoji, error) {
+	return c.client.CreateCustomEmoji(ctx, &pb.CreateCustomEmojiRequest{
+		UserId:     userID,
+		ChatId:     chatID,
+		Name:       name,
+		FileLinkId: fileLinkID,
+	})
+}
+
+func (c *ChatClient) ListCustomEmoji(ctx context.Context, userID, chatID string) (*pb.ListCustomEmojiResponse, error) {
+	return c.client.ListCustomEmoji(ctx, &pb.ListCustomEmojiRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) DeleteCustomEmoji(ctx context.Context, userID, emojiID string) error {
+	_, err := c.client.DeleteCustomEmoji(ctx, &pb.DeleteCustomEmojiRequest{
+		UserId:  userID,
+		EmojiId: emojiID,
+	})
+	return err
+}
+
+// Drafts
+
+func (c *ChatClient) SaveDraft(ctx context.Context, userID, chatID string, threadID *string, content string, replyToIDs []string) (*pb.Draft, error) {
+	return c.client.SaveDraft(ctx, &pb.SaveDraftRequest{
+		UserId:     userID,
+		ChatId:     chatID,
+		ThreadId:   threadID,
+		Content:    content,
+		ReplyToIds: replyToIDs,
+	})
+}
+
+func (c *ChatClient) GetDrafts(ctx context.Context, userID string, chatID *string) (*pb.GetDraftsResponse, error) {
+	return c.client.GetDrafts(ctx, &pb.GetDraftsRequest{
+		UserId: userID,
+		ChatId: chatID,
+	})
+}
+
+func (c *ChatClient) DeleteDraft(ctx context.Context, userID, chatID string, threadID *string) error {
+	_, err := c.client.DeleteDraft(ctx, &pb.DeleteDraftRequest{
+		UserId:   userID,
+		ChatId:   chatID,
+		ThreadId: threadID,
+	})
+	return err
+}
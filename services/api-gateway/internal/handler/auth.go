@@ -2,16 +2,22 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/icegreg/chat-smpl/pkg/logger"
 	"github.com/icegreg/chat-smpl/services/api-gateway/internal/centrifugo"
+	"github.com/icegreg/chat-smpl/services/api-gateway/internal/grpc"
 	"github.com/icegreg/chat-smpl/services/api-gateway/internal/middleware"
 )
 
@@ -252,6 +258,53 @@ func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	h.copyResponse(w, resp)
 }
 
+// ListBots godoc
+// @Summary List bot accounts
+// @Description Returns all bot accounts (moderators only)
+// @Tags bots
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} map[string]interface{} "Bot accounts"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Moderator role required"
+// @Failure 502 {object} ErrorResponse "Service unavailable"
+// @Router /bots [get]
+func (h *AuthHandler) ListBots(w http.ResponseWriter, r *http.Request) {
+	h.proxyToUsers(w, r, "/api/bots")
+}
+
+// CreateBot godoc
+// @Summary Create bot account
+// @Description Creates a bot account that can post into chats through integrations (moderators only)
+// @Tags bots
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body CreateBotRequest true "Bot data"
+// @Success 201 {object} UserResponse "Created bot"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 403 {object} ErrorResponse "Moderator role required"
+// @Failure 409 {object} ErrorResponse "Username already taken"
+// @Failure 502 {object} ErrorResponse "Service unavailable"
+// @Router /bots [post]
+func (h *AuthHandler) CreateBot(w http.ResponseWriter, r *http.Request) {
+	h.proxyToUsers(w, r, "/api/bots")
+}
+
+// DeleteBot godoc
+// @Summary Delete bot account
+// @Tags bots
+// @Security Bearer
+// @Param botId path string true "Bot ID"
+// @Success 204 "Bot deleted"
+// @Failure 403 {object} ErrorResponse "Moderator role required"
+// @Failure 404 {object} ErrorResponse "Bot not found"
+// @Failure 502 {object} ErrorResponse "Service unavailable"
+// @Router /bots/{botId} [delete]
+func (h *AuthHandler) DeleteBot(w http.ResponseWriter, r *http.Request) {
+	h.proxyToUsers(w, r, "/api/bots/"+chi.URLParam(r, "botId"))
+}
+
 func (h *AuthHandler) proxyToUsers(w http.ResponseWriter, r *http.Request, path string) {
 	// Read original body
 	body, err := io.ReadAll(r.Body)
@@ -314,12 +367,14 @@ func (h *AuthHandler) respondError(w http.ResponseWriter, status int, message st
 // CentrifugoHandler handles Centrifugo token generation
 type CentrifugoHandler struct {
 	centrifugoClient *centrifugo.Client
+	chatClient       *grpc.ChatClient
 	log              logger.Logger
 }
 
-func NewCentrifugoHandler(centrifugoClient *centrifugo.Client, log logger.Logger) *CentrifugoHandler {
+func NewCentrifugoHandler(centrifugoClient *centrifugo.Client, chatClient *grpc.ChatClient, log logger.Logger) *CentrifugoHandler {
 	return &CentrifugoHandler{
 		centrifugoClient: centrifugoClient,
+		chatClient:       chatClient,
 		log:              log,
 	}
 }
@@ -361,7 +416,8 @@ func (h *CentrifugoHandler) GetConnectionToken(w http.ResponseWriter, r *http.Re
 
 // GetSubscriptionToken godoc
 // @Summary Get Centrifugo subscription token
-// @Description Returns a JWT token for subscribing to a specific channel
+// @Description Returns a JWT token for subscribing to a specific channel.
+// @Description user:{id} channels are issued only for the caller's own ID, chat:{id} channels only to chat participants.
 // @Tags centrifugo
 // @Accept json
 // @Produce json
@@ -370,6 +426,7 @@ func (h *CentrifugoHandler) GetConnectionToken(w http.ResponseWriter, r *http.Re
 // @Success 200 {object} SubscriptionTokenResponse "Subscription token"
 // @Failure 400 {object} ErrorResponse "Invalid request body or missing channel"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not allowed to subscribe to the channel"
 // @Router /centrifugo/subscription-token [post]
 func (h *CentrifugoHandler) GetSubscriptionToken(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
@@ -392,6 +449,11 @@ func (h *CentrifugoHandler) GetSubscriptionToken(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if code, err := h.canSubscribe(r.Context(), userID.String(), req.Channel); err != nil {
+		h.respondError(w, code, err.Error())
+		return
+	}
+
 	// Token expires in 1 hour
 	exp := time.Now().Add(time.Hour).Unix()
 	token := h.centrifugoClient.GenerateSubscriptionToken(userID.String(), req.Channel, exp)
@@ -403,6 +465,30 @@ func (h *CentrifugoHandler) GetSubscriptionToken(w http.ResponseWriter, r *http.
 	})
 }
 
+// canSubscribe checks that the user may subscribe to the channel and returns the HTTP status to
+// respond with otherwise
+func (h *CentrifugoHandler) canSubscribe(ctx context.Context, userID, channel string) (int, error) {
+	namespace, id, _ := strings.Cut(channel, ":")
+	switch namespace {
+	case "user":
+		if id != userID {
+			return http.StatusForbidden, errors.New("access denied")
+		}
+	case "chat":
+		// GetChat fails for users that are not participants of the chat
+		if _, err := h.chatClient.GetChat(ctx, id, userID); err != nil {
+			switch status.Code(err) {
+			case codes.PermissionDenied, codes.NotFound, codes.InvalidArgument:
+				return http.StatusForbidden, errors.New("access denied")
+			default:
+				h.log.Error("failed to check chat membership", "error", err, "chat_id", id)
+				return http.StatusInternalServerError, errors.New("failed to check chat membership")
+			}
+		}
+	}
+	return http.StatusOK, nil
+}
+
 func (h *CentrifugoHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/icegreg/chat-smpl/pkg/logger"
 	pb "github.com/icegreg/chat-smpl/proto/chat"
@@ -90,8 +94,10 @@ func (h *ChatHandler) Routes() chi.Router {
 	// Chat routes
 	r.Post("/", h.CreateChat)
 	r.Get("/", h.ListChats)
+	r.Get("/changes", h.GetChatListChanges)
 	r.Get("/{chatId}", h.GetChat)
 	r.Put("/{chatId}", h.UpdateChat)
+	r.Put("/{chatId}/slow-mode", h.SetSlowMode)
 	r.Delete("/{chatId}", h.DeleteChat)
 
 	// Participant routes
@@ -99,17 +105,28 @@ func (h *ChatHandler) Routes() chi.Router {
 	r.Post("/{chatId}/participants", h.AddParticipant)
 	r.Delete("/{chatId}/participants/{userId}", h.RemoveParticipant)
 	r.Put("/{chatId}/participants/{userId}/role", h.UpdateParticipantRole)
+	r.Delete("/{chatId}/participants/{userId}/messages", h.PurgeUserMessages)
+
+	// Bans and timed mutes
+	r.Get("/{chatId}/restrictions", h.ListChatRestrictions)
+	r.Post("/{chatId}/bans", h.BanUser)
+	r.Delete("/{chatId}/bans/{userId}", h.UnbanUser)
+	r.Post("/{chatId}/mutes", h.MuteUser)
+	r.Delete("/{chatId}/mutes/{userId}", h.UnmuteUser)
 
 	// Message routes
 	r.Get("/{chatId}/messages", h.GetMessages)
 	r.Get("/{chatId}/messages/sync", h.SyncMessages)
 	r.Post("/{chatId}/messages", h.SendMessage)
+	r.Post("/{chatId}/messages/move", h.MoveMessagesToThread)
+	r.Post("/{chatId}/messages/bulk-delete", h.BulkDeleteMessages)
 	r.Put("/messages/{messageId}", h.UpdateMessage)
 	r.Delete("/messages/{messageId}", h.DeleteMessage)
 	r.Post("/messages/{messageId}/restore", h.RestoreMessage)
 	r.Delete("/messages/{messageId}/quote/{quotedMessageId}", h.RemoveFromQuote)
 
 	// Reaction routes
+	r.Get("/messages/{messageId}/reactions", h.ListReactions)
 	r.Post("/messages/{messageId}/reactions", h.AddReaction)
 	r.Delete("/messages/{messageId}/reactions/{emoji}", h.RemoveReaction)
 
@@ -122,6 +139,11 @@ func (h *ChatHandler) Routes() chi.Router {
 	r.Post("/threads/{threadId}/participants", h.AddThreadParticipantHandler)
 	r.Delete("/threads/{threadId}/participants/{userId}", h.RemoveThreadParticipantHandler)
 	r.Get("/threads/{threadId}/participants", h.ListThreadParticipantsHandler)
+	// Thread subscriptions
+	r.Get("/threads/followed", h.ListFollowedThreads)
+	r.Post("/threads/{threadId}/follow", h.FollowThread)
+	r.Delete("/threads/{threadId}/follow", h.UnfollowThread)
+	r.Post("/threads/{threadId}/read", h.MarkThreadAsRead)
 	// Subthread routes
 	r.Get("/threads/{threadId}/subthreads", h.ListSubthreads)
 	r.Post("/threads/{threadId}/subthreads", h.CreateSubthread)
@@ -140,6 +162,64 @@ func (h *ChatHandler) Routes() chi.Router {
 	// Forward message
 	r.Post("/messages/{messageId}/forward", h.ForwardMessage)
 
+	// Moderation (message reports)
+	r.Post("/messages/{messageId}/report", h.ReportMessage)
+	r.Get("/reports", h.ListGlobalReports)
+	r.Post("/reports/{reportId}/resolve", h.ResolveReport)
+	r.Get("/{chatId}/reports", h.ListChatReports)
+	r.Get("/moderation/audit", h.ListGlobalModerationAudit)
+	r.Get("/{chatId}/moderation/audit", h.ListChatModerationAudit)
+
+	// Content filters
+	r.Get("/content-policy", h.GetGlobalContentPolicy)
+	r.Put("/content-policy", h.SetGlobalContentPolicy)
+	r.Delete("/content-policy", h.DeleteGlobalContentPolicy)
+	r.Get("/{chatId}/content-policy", h.GetChatContentPolicy)
+	r.Put("/{chatId}/content-policy", h.SetChatContentPolicy)
+	r.Delete("/{chatId}/content-policy", h.DeleteChatContentPolicy)
+	r.Get("/content-filter/hits", h.ListGlobalContentFilterHits)
+	r.Get("/{chatId}/content-filter/hits", h.ListChatContentFilterHits)
+
+	// Outgoing webhooks
+	r.Get("/webhooks", h.ListGlobalWebhooks)
+	r.Post("/webhooks", h.CreateGlobalWebhook)
+	r.Put("/webhooks/{webhookId}", h.UpdateWebhook)
+	r.Delete("/webhooks/{webhookId}", h.DeleteWebhook)
+	r.Get("/webhooks/{webhookId}/deliveries", h.ListWebhookDeliveries)
+	r.Post("/webhooks/deliveries/{deliveryId}/redeliver", h.RedeliverWebhook)
+	r.Get("/{chatId}/webhooks", h.ListChatWebhooks)
+	r.Post("/{chatId}/webhooks", h.CreateChatWebhook)
+
+	// Incoming webhooks (posting happens on the public /api/hooks route)
+	r.Get("/{chatId}/incoming-webhooks", h.ListIncomingWebhooks)
+	r.Post("/{chatId}/incoming-webhooks", h.CreateIncomingWebhook)
+	r.Post("/incoming-webhooks/{webhookId}/rotate", h.RotateIncomingWebhookToken)
+	r.Delete("/incoming-webhooks/{webhookId}", h.DeleteIncomingWebhook)
+
+	// Slash commands and interactive messages
+	r.Get("/{chatId}/commands", h.ListCommands)
+	r.Get("/bot-commands", h.ListGlobalBotCommands)
+	r.Post("/bot-commands", h.RegisterGlobalBotCommand)
+	r.Get("/{chatId}/bot-commands", h.ListChatBotCommands)
+	r.Post("/{chatId}/bot-commands", h.RegisterChatBotCommand)
+	r.Delete("/bot-commands/{commandId}", h.DeleteBotCommand)
+	r.Post("/messages/{messageId}/interactions", h.InteractMessage)
+
+	// Reminders
+	r.Post("/messages/{messageId}/reminders", h.CreateMessageReminder)
+	r.Get("/reminders", h.ListReminders)
+	r.Delete("/reminders/{reminderId}", h.CancelReminder)
+
+	// Custom emoji
+	r.Get("/{chatId}/emoji", h.ListCustomEmoji)
+	r.Post("/{chatId}/emoji", h.CreateCustomEmoji)
+	r.Delete("/emoji/{emojiId}", h.DeleteCustomEmoji)
+
+	// Drafts
+	r.Get("/drafts", h.GetDrafts)
+	r.Put("/{chatId}/draft", h.SaveDraft)
+	r.Delete("/{chatId}/draft", h.DeleteDraft)
+
 	return r
 }
 
@@ -265,6 +345,49 @@ func (h *ChatHandler) ListChats(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetChatListChanges godoc
+// @Summary Get chat list changes
+// @Description Returns changes of the user's chat list (chat created/renamed/deleted, participants, archive, favorites) after a version.
+// @Description If reset_required is true, the client must reload the whole list via GET /chats and continue from current_version.
+// @Tags chats
+// @Produce json
+// @Security Bearer
+// @Param since_version query int false "Version to start after" default(0)
+// @Param limit query int false "Max changes to return" default(100)
+// @Success 200 {object} map[string]interface{} "Changes with current_version, has_more and reset_required"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /chats/changes [get]
+func (h *ChatHandler) GetChatListChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	sinceVersion, _ := strconv.ParseInt(r.URL.Query().Get("since_version"), 10, 64)
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	resp, err := h.chatClient.GetChatListChanges(ctx, userID.String(), sinceVersion, int32(limit))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"changes":         resp.Changes,
+		"current_version": resp.CurrentVersion,
+		"has_more":        resp.HasMore,
+		"reset_required":  resp.ResetRequired,
+	})
+}
+
 // UpdateChat godoc
 // @Summary Update chat
 // @Description Updates chat details (name, description)
@@ -308,6 +431,47 @@ func (h *ChatHandler) UpdateChat(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusOK, chat)
 }
 
+// SetSlowMode godoc
+// @Summary Set chat slow mode
+// @Description Sets the minimum interval between messages of one participant (admins only, admins are exempt).
+// @Description 0 disables slow mode. Senders who hit slow mode get 429 with Retry-After.
+// @Tags chats
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body SetSlowModeRequest true "Slow mode interval"
+// @Success 200 {object} ChatResponse "Updated chat"
+// @Failure 400 {object} ErrorResponse "Invalid interval"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Chat not found"
+// @Router /chats/{chatId}/slow-mode [put]
+func (h *ChatHandler) SetSlowMode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+
+	var req SetSlowModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	chat, err := h.chatClient.SetSlowMode(ctx, chatID, userID.String(), req.Seconds)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, chat)
+}
+
 // DeleteChat godoc
 // @Summary Delete chat
 // @Description Deletes a chat and all its messages
@@ -535,6 +699,8 @@ func (h *ChatHandler) UpdateParticipantRole(w http.ResponseWriter, r *http.Reque
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Access denied (guests cannot send)"
 // @Failure 404 {object} ErrorResponse "Chat not found"
+// @Failure 429 {object} RateLimitResponse "Rate limit or slow mode, see Retry-After"
+// @Failure 422 {object} ContentRejectedResponse "Blocked by a content filter"
 // @Router /chats/{chatId}/messages [post]
 func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -556,6 +722,7 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Content     string   `json:"content"`
 		ParentID    string   `json:"parent_id,omitempty"`
+		ThreadID    string   `json:"thread_id,omitempty"`
 		FileLinkIDs []string `json:"file_link_ids,omitempty"`
 		ReplyToIDs  []string `json:"reply_to_ids,omitempty"`
 	}
@@ -573,7 +740,7 @@ func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	message, err := h.chatClient.SendMessage(ctx, chatID, userID.String(), req.Content, req.ParentID, req.FileLinkIDs, req.ReplyToIDs)
+	message, err := h.chatClient.SendMessage(ctx, chatID, userID.String(), req.Content, req.ParentID, req.ThreadID, req.FileLinkIDs, req.ReplyToIDs)
 	if err != nil {
 		h.handleGRPCError(w, err)
 		return
@@ -655,14 +822,16 @@ func (h *ChatHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
 
 // SyncMessages godoc
 // @Summary Sync messages
-// @Description Returns messages after a specific sequence number for reliable sync after reconnect
+// @Description Returns messages after a specific sequence number for reliable sync after reconnect.
+// @Description Tombstones describe messages edited, deleted, restored or moved since after_seq; use last_seq_num as the next after_seq.
 // @Tags messages
 // @Produce json
 // @Security Bearer
 // @Param chatId path string true "Chat ID"
+// @Param thread_id query string false "Thread ID to sync instead of the main chat"
 // @Param after_seq query int false "Sequence number to start after" default(0)
 // @Param limit query int false "Max messages to return" default(100)
-// @Success 200 {object} map[string]interface{} "Messages with has_more flag"
+// @Success 200 {object} map[string]interface{} "Messages, tombstones, last_seq_num and has_more flag"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 404 {object} ErrorResponse "Chat not found"
 // @Router /chats/{chatId}/messages/sync [get]
@@ -684,7 +853,12 @@ func (h *ChatHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 		limit = 1000
 	}
 
-	resp, err := h.chatClient.SyncMessages(ctx, chatID, userID.String(), afterSeqNum, int32(limit))
+	var threadID *string
+	if t := r.URL.Query().Get("thread_id"); t != "" {
+		threadID = &t
+	}
+
+	resp, err := h.chatClient.SyncMessages(ctx, chatID, userID.String(), threadID, afterSeqNum, int32(limit))
 	if err != nil {
 		h.handleGRPCError(w, err)
 		return
@@ -694,8 +868,10 @@ func (h *ChatHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 	messages := h.enrichMessagesWithFiles(ctx, resp.Messages)
 
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{
-		"messages": messages,
-		"has_more": resp.HasMore,
+		"messages":     messages,
+		"tombstones":   resp.Tombstones,
+		"last_seq_num": resp.LastSeqNum,
+		"has_more":     resp.HasMore,
 	})
 }
 
@@ -713,6 +889,7 @@ func (h *ChatHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Access denied"
 // @Failure 404 {object} ErrorResponse "Message not found"
+// @Failure 422 {object} ContentRejectedResponse "Blocked by a content filter"
 // @Router /chats/messages/{messageId} [put]
 func (h *ChatHandler) UpdateMessage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -773,16 +950,18 @@ func (h *ChatHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 
 // AddReaction godoc
 // @Summary Add reaction to message
-// @Description Adds an emoji reaction to a message
+// @Description Adds an emoji reaction to a message. The reaction is a Unicode emoji or a ":name:" custom emoji
+// @Description of the chat; a user can put at most 20 different reactions on one message.
 // @Tags messages
 // @Accept json
 // @Security Bearer
 // @Param messageId path string true "Message ID"
 // @Param request body AddReactionRequest true "Reaction data"
 // @Success 201 "Reaction added"
-// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 400 {object} ErrorResponse "Invalid request body, unknown emoji or too many reactions"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 404 {object} ErrorResponse "Message not found"
+// @Failure 429 {object} RateLimitResponse "Rate limit or slow mode, see Retry-After"
 // @Router /chats/messages/{messageId}/reactions [post]
 func (h *ChatHandler) AddReaction(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -811,6 +990,36 @@ func (h *ChatHandler) AddReaction(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// ListReactions godoc
+// @Summary List reactions of a message
+// @Description Returns who reacted with what, and per-emoji counts with the caller's own reactions flagged.
+// @Description Message lists already embed the counts in reaction_summary.
+// @Tags messages
+// @Produce json
+// @Security Bearer
+// @Param messageId path string true "Message ID"
+// @Success 200 {object} pb.ListReactionsResponse "Reactions"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Failure 404 {object} ErrorResponse "Message not found"
+// @Router /chats/messages/{messageId}/reactions [get]
+func (h *ChatHandler) ListReactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListReactions(ctx, chi.URLParam(r, "messageId"), userID.String())
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
 // RemoveReaction godoc
 // @Summary Remove reaction from message
 // @Description Removes an emoji reaction from a message
@@ -945,6 +1154,61 @@ func (h *ChatHandler) CreateChatThread(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusCreated, thread)
 }
 
+// MoveMessagesToThread godoc
+// @Summary Move messages to a thread
+// @Description Moves messages into an existing thread, or into a new thread when target_thread_id is omitted (admin only)
+// @Tags threads
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body MoveMessagesRequest true "Messages to move"
+// @Success 200 {object} MoveMessagesResponse "Target thread and number of moved messages"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Chat, thread or message not found"
+// @Router /chats/{chatId}/messages/move [post]
+func (h *ChatHandler) MoveMessagesToThread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+
+	var req struct {
+		MessageIDs     []string `json:"message_ids"`
+		TargetThreadID string   `json:"target_thread_id,omitempty"`
+		NewThreadTitle string   `json:"new_thread_title,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if len(req.MessageIDs) == 0 {
+		h.respondError(w, http.StatusBadRequest, "message_ids is required")
+		return
+	}
+
+	var targetThreadID *string
+	if req.TargetThreadID != "" {
+		targetThreadID = &req.TargetThreadID
+	}
+
+	resp, err := h.chatClient.MoveMessagesToThread(ctx, chatID, req.MessageIDs, targetThreadID, req.NewThreadTitle, userID.String())
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
 // GetThreadByID godoc
 // @Summary Get thread by ID
 // @Description Returns detailed information about a specific thread
@@ -1194,6 +1458,132 @@ func (h *ChatHandler) ListThreadParticipantsHandler(w http.ResponseWriter, r *ht
 	})
 }
 
+// ListFollowedThreads godoc
+// @Summary List followed threads
+// @Description Returns threads followed by the current user sorted by last activity, with unread counts
+// @Tags threads
+// @Produce json
+// @Security Bearer
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Followed threads list"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /chats/threads/followed [get]
+func (h *ChatHandler) ListFollowedThreads(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 20
+	}
+
+	resp, err := h.chatClient.ListFollowedThreads(ctx, userID.String(), int32(page), int32(count))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"threads":    resp.Threads,
+		"pagination": resp.Pagination,
+	})
+}
+
+// FollowThread godoc
+// @Summary Follow a thread
+// @Description Subscribes the current user to thread.reply notifications for a thread
+// @Tags threads
+// @Security Bearer
+// @Param threadId path string true "Thread ID"
+// @Success 204 "Thread followed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Thread not found"
+// @Router /chats/threads/{threadId}/follow [post]
+func (h *ChatHandler) FollowThread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	threadID := chi.URLParam(r, "threadId")
+
+	if err := h.chatClient.FollowThread(ctx, threadID, userID.String()); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnfollowThread godoc
+// @Summary Unfollow a thread
+// @Description Stops thread.reply notifications for a thread and removes it from the followed threads inbox
+// @Tags threads
+// @Security Bearer
+// @Param threadId path string true "Thread ID"
+// @Success 204 "Thread unfollowed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Thread not found"
+// @Router /chats/threads/{threadId}/follow [delete]
+func (h *ChatHandler) UnfollowThread(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	threadID := chi.URLParam(r, "threadId")
+
+	if err := h.chatClient.UnfollowThread(ctx, threadID, userID.String()); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkThreadAsRead godoc
+// @Summary Mark thread as read
+// @Description Resets the unread counter of a followed thread
+// @Tags threads
+// @Security Bearer
+// @Param threadId path string true "Thread ID"
+// @Success 204 "Thread marked as read"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Thread not found"
+// @Router /chats/threads/{threadId}/read [post]
+func (h *ChatHandler) MarkThreadAsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	threadID := chi.URLParam(r, "threadId")
+
+	if err := h.chatClient.MarkThreadAsRead(ctx, threadID, userID.String()); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ListSubthreads godoc
 // @Summary List subthreads
 // @Description Returns paginated list of subthreads within a parent thread
@@ -1412,6 +1802,7 @@ func (h *ChatHandler) UnarchiveChat(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse "Invalid request body"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 404 {object} ErrorResponse "Chat not found"
+// @Failure 429 {object} RateLimitResponse "Rate limit or slow mode, see Retry-After"
 // @Router /chats/{chatId}/typing [post]
 func (h *ChatHandler) SendTypingIndicator(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1455,6 +1846,7 @@ func (h *ChatHandler) SendTypingIndicator(w http.ResponseWriter, r *http.Request
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 403 {object} ErrorResponse "Access denied (guests cannot forward)"
 // @Failure 404 {object} ErrorResponse "Message or target chat not found"
+// @Failure 429 {object} RateLimitResponse "Rate limit or slow mode, see Retry-After"
 // @Router /chats/messages/{messageId}/forward [post]
 func (h *ChatHandler) ForwardMessage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1515,19 +1907,76 @@ func (h *ChatHandler) respondError(w http.ResponseWriter, status int, message st
 func (h *ChatHandler) handleGRPCError(w http.ResponseWriter, err error) {
 	h.log.Error("gRPC error", "error", err)
 	// Parse gRPC status codes and convert to HTTP
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.ResourceExhausted:
+			h.respondRateLimited(w, st)
+			return
+		case codes.InvalidArgument:
+			if h.respondContentRejected(w, st) {
+				return
+			}
+		}
+	}
+
 	errStr := err.Error()
 	switch {
+	case contains(errStr, "is banned"), contains(errStr, "is muted"):
+		// Pass the reason through so clients can show the restriction expiry
+		h.respondError(w, http.StatusForbidden, status.Convert(err).Message())
+	case contains(errStr, "invalid command"), contains(errStr, "invalid reminder"),
+		contains(errStr, "invalid reaction"), contains(errStr, "invalid emoji"), contains(errStr, "invalid draft"):
+		// Slash command, reminder, reaction, emoji and draft validation errors are shown to the user as is
+		h.respondError(w, http.StatusBadRequest, status.Convert(err).Message())
 	case contains(errStr, "not found"):
 		h.respondError(w, http.StatusNotFound, "resource not found")
 	case contains(errStr, "permission denied"), contains(errStr, "access denied"):
 		h.respondError(w, http.StatusForbidden, "access denied")
 	case contains(errStr, "invalid"):
 		h.respondError(w, http.StatusBadRequest, "invalid request")
+	case contains(errStr, "already"):
+		h.respondError(w, http.StatusConflict, "conflict")
 	default:
 		h.respondError(w, http.StatusInternalServerError, "internal error")
 	}
 }
 
+// respondRateLimited answers 429 with Retry-After taken from the RetryInfo detail of the status
+func (h *ChatHandler) respondRateLimited(w http.ResponseWriter, st *status.Status) {
+	retryAfter := time.Second
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			retryAfter = info.RetryDelay.AsDuration()
+		}
+	}
+
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	h.respondJSON(w, http.StatusTooManyRequests, RateLimitResponse{
+		Error:             st.Message(),
+		RetryAfterSeconds: seconds,
+	})
+}
+
+// respondContentRejected answers 422 with the structured content filter rejection, if the status
+// carries one. Returns false for other InvalidArgument errors.
+func (h *ChatHandler) respondContentRejected(w http.ResponseWriter, st *status.Status) bool {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == "CONTENT_REJECTED" {
+			h.respondJSON(w, http.StatusUnprocessableEntity, ContentRejectedResponse{
+				Error:  info.Metadata["reason"],
+				Filter: info.Metadata["filter"],
+				Rule:   info.Metadata["rule"],
+			})
+			return true
+		}
+	}
+	return false
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsImpl(s, substr))
 }
@@ -1680,3 +2129,1696 @@ func (h *ChatHandler) RemoveFromQuote(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// BulkDeleteMessages godoc
+// @Summary Bulk delete messages (moderator only)
+// @Description Deletes messages by ID list, or all messages of a sender within an optional time window.
+// @Description Deleted messages can be restored one by one until restorable_until (retention period).
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body BulkDeleteMessagesRequest true "Messages to delete"
+// @Success 200 {object} BulkDeleteMessagesResponse "Deleted messages"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No permission (moderator required)"
+// @Router /chats/{chatId}/messages/bulk-delete [post]
+func (h *ChatHandler) BulkDeleteMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+
+	var req struct {
+		MessageIDs []string   `json:"message_ids,omitempty"`
+		SenderID   string     `json:"sender_id,omitempty"`
+		From       *time.Time `json:"from,omitempty"`
+		To         *time.Time `json:"to,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if len(req.MessageIDs) == 0 && req.SenderID == "" {
+		h.respondError(w, http.StatusBadRequest, "message_ids or sender_id is required")
+		return
+	}
+
+	var senderID *string
+	if req.SenderID != "" {
+		senderID = &req.SenderID
+	}
+
+	resp, err := h.chatClient.BulkDeleteMessages(ctx, chatID, userID.String(), req.MessageIDs, senderID, req.From, req.To)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, bulkDeleteResponse(resp))
+}
+
+// PurgeUserMessages godoc
+// @Summary Purge all messages of a user (moderator only)
+// @Description Deletes all messages of a user in the chat. The user does not need to be a participant anymore.
+// @Tags messages
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param userId path string true "User whose messages are deleted"
+// @Success 200 {object} BulkDeleteMessagesResponse "Deleted messages"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "No permission (moderator required)"
+// @Router /chats/{chatId}/participants/{userId}/messages [delete]
+func (h *ChatHandler) PurgeUserMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+	targetUserID := chi.URLParam(r, "userId")
+
+	resp, err := h.chatClient.PurgeUserMessages(ctx, chatID, targetUserID, userID.String())
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, bulkDeleteResponse(resp))
+}
+
+func bulkDeleteResponse(resp *pb.BulkDeleteMessagesResponse) BulkDeleteMessagesResponse {
+	result := BulkDeleteMessagesResponse{
+		DeletedMessageIDs: resp.DeletedMessageIds,
+		DeletedCount:      int(resp.DeletedCount),
+	}
+	if result.DeletedMessageIDs == nil {
+		result.DeletedMessageIDs = []string{}
+	}
+	if resp.RestorableUntil != nil {
+		result.RestorableUntil = resp.RestorableUntil.AsTime().Format(time.RFC3339)
+	}
+	return result
+}
+
+// ReportMessage godoc
+// @Summary Report a message
+// @Description Flags a message for moderators. Reasons: spam, abuse, harassment, illegal, other
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param messageId path string true "Message ID"
+// @Param request body ReportMessageRequest true "Report data"
+// @Success 201 {object} pb.MessageReport "Report created"
+// @Failure 400 {object} ErrorResponse "Invalid reason or message cannot be reported"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Message not found"
+// @Failure 409 {object} ErrorResponse "Message already reported"
+// @Router /chats/messages/{messageId}/report [post]
+func (h *ChatHandler) ReportMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	messageID := chi.URLParam(r, "messageId")
+
+	var req struct {
+		Reason  string `json:"reason"`
+		Comment string `json:"comment,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Reason == "" {
+		h.respondError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	report, err := h.chatClient.ReportMessage(ctx, messageID, userID.String(), req.Reason, req.Comment)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, report)
+}
+
+// ListChatReports godoc
+// @Summary List chat moderation queue
+// @Description Returns message reports of a chat (chat admins and global moderators)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param status query string false "Report status (open, resolved); empty = all"
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Reports with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/reports [get]
+func (h *ChatHandler) ListChatReports(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.listReports(w, r, &chatID)
+}
+
+// ListGlobalReports godoc
+// @Summary List global moderation queue
+// @Description Returns message reports of all chats (global owners/moderators only)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Report status (open, resolved); empty = all"
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Reports with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/reports [get]
+func (h *ChatHandler) ListGlobalReports(w http.ResponseWriter, r *http.Request) {
+	h.listReports(w, r, nil)
+}
+
+func (h *ChatHandler) listReports(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 20
+	}
+
+	resp, err := h.chatClient.ListReports(ctx, userID.String(), chatID, r.URL.Query().Get("status"), int32(page), int32(count))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"reports":    resp.Reports,
+		"pagination": resp.Pagination,
+	})
+}
+
+// ResolveReport godoc
+// @Summary Resolve a report
+// @Description Applies a moderator decision: dismiss the report, delete the message, or restrict the sender to read-only
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param reportId path string true "Report ID"
+// @Param request body ResolveReportRequest true "Moderator decision"
+// @Success 200 {object} pb.MessageReport "Resolved report"
+// @Failure 400 {object} ErrorResponse "Invalid action"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Report not found"
+// @Failure 409 {object} ErrorResponse "Report already resolved"
+// @Router /chats/reports/{reportId}/resolve [post]
+func (h *ChatHandler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	reportID := chi.URLParam(r, "reportId")
+
+	var req struct {
+		Action string `json:"action"`
+		Note   string `json:"note,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.chatClient.ResolveReport(ctx, reportID, userID.String(), req.Action, req.Note)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// ListChatModerationAudit godoc
+// @Summary Chat moderation audit trail
+// @Description Returns moderation actions taken in a chat (chat admins and global moderators)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Audit entries with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/moderation/audit [get]
+func (h *ChatHandler) ListChatModerationAudit(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.listModerationAudit(w, r, &chatID)
+}
+
+// ListGlobalModerationAudit godoc
+// @Summary Global moderation audit trail
+// @Description Returns moderation actions across all chats (global owners/moderators only)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Audit entries with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/moderation/audit [get]
+func (h *ChatHandler) ListGlobalModerationAudit(w http.ResponseWriter, r *http.Request) {
+	h.listModerationAudit(w, r, nil)
+}
+
+func (h *ChatHandler) listModerationAudit(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 20
+	}
+
+	resp, err := h.chatClient.ListModerationAuditLog(ctx, userID.String(), chatID, int32(page), int32(count))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries":    resp.Entries,
+		"pagination": resp.Pagination,
+	})
+}
+
+// BanUser godoc
+// @Summary Ban user from chat
+// @Description Removes the user from the chat and prevents adding them back. duration_seconds 0 = permanent.
+// @Description Chat admins can only be banned by global moderators.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body RestrictUserRequest true "Ban data"
+// @Success 201 {object} pb.ChatRestriction "Ban created"
+// @Failure 400 {object} ErrorResponse "Invalid duration"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 409 {object} ErrorResponse "User is already banned"
+// @Router /chats/{chatId}/bans [post]
+func (h *ChatHandler) BanUser(w http.ResponseWriter, r *http.Request) {
+	h.restrictUser(w, r, h.chatClient.BanUser)
+}
+
+// MuteUser godoc
+// @Summary Mute user in chat
+// @Description Makes the participant read-only (no messages or reactions) for duration_seconds.
+// @Description The previous role is restored automatically when the mute expires.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body RestrictUserRequest true "Mute data"
+// @Success 201 {object} pb.ChatRestriction "Mute created"
+// @Failure 400 {object} ErrorResponse "Invalid duration"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 409 {object} ErrorResponse "User is already muted"
+// @Router /chats/{chatId}/mutes [post]
+func (h *ChatHandler) MuteUser(w http.ResponseWriter, r *http.Request) {
+	h.restrictUser(w, r, h.chatClient.MuteUser)
+}
+
+func (h *ChatHandler) restrictUser(w http.ResponseWriter, r *http.Request,
+	restrict func(ctx context.Context, chatID, userID, moderatorID, reason string, durationSeconds int64) (*pb.ChatRestriction, error)) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+
+	var req RestrictUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		h.respondError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	restriction, err := restrict(ctx, chatID, req.UserID, userID.String(), req.Reason, req.DurationSeconds)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, restriction)
+}
+
+// UnbanUser godoc
+// @Summary Lift a chat ban
+// @Description Lifts the active ban. The user is not re-added to the chat.
+// @Tags moderation
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param userId path string true "Banned user ID"
+// @Success 204 "Ban lifted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No active ban"
+// @Router /chats/{chatId}/bans/{userId} [delete]
+func (h *ChatHandler) UnbanUser(w http.ResponseWriter, r *http.Request) {
+	h.liftRestriction(w, r, h.chatClient.UnbanUser)
+}
+
+// UnmuteUser godoc
+// @Summary Lift a chat mute
+// @Description Lifts the active mute and restores the participant's previous role
+// @Tags moderation
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param userId path string true "Muted user ID"
+// @Success 204 "Mute lifted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No active mute"
+// @Router /chats/{chatId}/mutes/{userId} [delete]
+func (h *ChatHandler) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	h.liftRestriction(w, r, h.chatClient.UnmuteUser)
+}
+
+func (h *ChatHandler) liftRestriction(w http.ResponseWriter, r *http.Request,
+	lift func(ctx context.Context, chatID, userID, moderatorID string) error) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+	targetUserID := chi.URLParam(r, "userId")
+
+	if err := lift(ctx, chatID, targetUserID, userID.String()); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListChatRestrictions godoc
+// @Summary List active bans and mutes
+// @Description Returns active restrictions of a chat (chat admins and global moderators)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} map[string]interface{} "Active restrictions"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/restrictions [get]
+func (h *ChatHandler) ListChatRestrictions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatId")
+
+	resp, err := h.chatClient.ListChatRestrictions(ctx, chatID, userID.String())
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"restrictions": resp.Restrictions,
+	})
+}
+
+// GetChatContentPolicy godoc
+// @Summary Get chat content policy
+// @Description Returns the content filter policy of a chat (chat admins and global moderators).
+// @Description 404 means the chat uses the global policy.
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ContentPolicy "Content policy"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No chat policy"
+// @Router /chats/{chatId}/content-policy [get]
+func (h *ChatHandler) GetChatContentPolicy(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.getContentPolicy(w, r, &chatID)
+}
+
+// GetGlobalContentPolicy godoc
+// @Summary Get global content policy
+// @Description Returns the content filter policy used by chats without their own policy (global moderators only)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} pb.ContentPolicy "Content policy"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No global policy"
+// @Router /chats/content-policy [get]
+func (h *ChatHandler) GetGlobalContentPolicy(w http.ResponseWriter, r *http.Request) {
+	h.getContentPolicy(w, r, nil)
+}
+
+func (h *ChatHandler) getContentPolicy(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	policy, err := h.chatClient.GetContentPolicy(ctx, userID.String(), chatID)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, policy)
+}
+
+// SetChatContentPolicy godoc
+// @Summary Set chat content policy
+// @Description Replaces the content filter policy of a chat: blocked words, DLP (card and passport
+// @Description numbers, custom regexes) with mask or block actions, max length and attachment count
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body pb.ContentPolicyRules true "Policy rules"
+// @Success 200 {object} pb.ContentPolicy "Saved policy"
+// @Failure 400 {object} ErrorResponse "Invalid policy"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/content-policy [put]
+func (h *ChatHandler) SetChatContentPolicy(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.setContentPolicy(w, r, &chatID)
+}
+
+// SetGlobalContentPolicy godoc
+// @Summary Set global content policy
+// @Description Replaces the content filter policy used by chats without their own policy (global moderators only)
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body pb.ContentPolicyRules true "Policy rules"
+// @Success 200 {object} pb.ContentPolicy "Saved policy"
+// @Failure 400 {object} ErrorResponse "Invalid policy"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/content-policy [put]
+func (h *ChatHandler) SetGlobalContentPolicy(w http.ResponseWriter, r *http.Request) {
+	h.setContentPolicy(w, r, nil)
+}
+
+func (h *ChatHandler) setContentPolicy(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var rules pb.ContentPolicyRules
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	policy, err := h.chatClient.SetContentPolicy(ctx, userID.String(), chatID, &rules)
+	if err != nil {
+		// Policy validation errors are safe to show to admins
+		if status.Code(err) == codes.InvalidArgument {
+			h.respondError(w, http.StatusBadRequest, status.Convert(err).Message())
+			return
+		}
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, policy)
+}
+
+// DeleteChatContentPolicy godoc
+// @Summary Delete chat content policy
+// @Description Removes the chat's own policy; the global policy applies again
+// @Tags moderation
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 204 "Policy deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No chat policy"
+// @Router /chats/{chatId}/content-policy [delete]
+func (h *ChatHandler) DeleteChatContentPolicy(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.deleteContentPolicy(w, r, &chatID)
+}
+
+// DeleteGlobalContentPolicy godoc
+// @Summary Delete global content policy
+// @Description Removes the global policy (global moderators only)
+// @Tags moderation
+// @Security Bearer
+// @Success 204 "Policy deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "No global policy"
+// @Router /chats/content-policy [delete]
+func (h *ChatHandler) DeleteGlobalContentPolicy(w http.ResponseWriter, r *http.Request) {
+	h.deleteContentPolicy(w, r, nil)
+}
+
+func (h *ChatHandler) deleteContentPolicy(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.DeleteContentPolicy(ctx, userID.String(), chatID); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListChatContentFilterHits godoc
+// @Summary List content filter hits of a chat
+// @Description Returns logged filter matches (masked or blocked messages) for moderators. Matched text is never stored.
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Hits with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/content-filter/hits [get]
+func (h *ChatHandler) ListChatContentFilterHits(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.listContentFilterHits(w, r, &chatID)
+}
+
+// ListGlobalContentFilterHits godoc
+// @Summary List content filter hits of all chats
+// @Description Returns logged filter matches across all chats (global moderators only)
+// @Tags moderation
+// @Produce json
+// @Security Bearer
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Hits with pagination"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/content-filter/hits [get]
+func (h *ChatHandler) ListGlobalContentFilterHits(w http.ResponseWriter, r *http.Request) {
+	h.listContentFilterHits(w, r, nil)
+}
+
+func (h *ChatHandler) listContentFilterHits(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 20
+	}
+
+	resp, err := h.chatClient.ListContentFilterHits(ctx, userID.String(), chatID, int32(page), int32(count))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"hits":       resp.Hits,
+		"pagination": resp.Pagination,
+	})
+}
+
+// ListChatWebhooks godoc
+// @Summary List chat webhooks
+// @Description Returns the outgoing webhook subscriptions of a chat (chat admins and global moderators)
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ListWebhooksResponse "Webhooks"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/webhooks [get]
+func (h *ChatHandler) ListChatWebhooks(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.listWebhooks(w, r, &chatID)
+}
+
+// ListGlobalWebhooks godoc
+// @Summary List global webhooks
+// @Description Returns webhook subscriptions that receive events of all chats (global moderators only)
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} pb.ListWebhooksResponse "Webhooks"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/webhooks [get]
+func (h *ChatHandler) ListGlobalWebhooks(w http.ResponseWriter, r *http.Request) {
+	h.listWebhooks(w, r, nil)
+}
+
+func (h *ChatHandler) listWebhooks(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListWebhooks(ctx, userID.String(), chatID)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateChatWebhook godoc
+// @Summary Create chat webhook
+// @Description Subscribes a URL to events of a chat. Each event is POSTed as its envelope JSON (see /api/docs/events) with
+// @Description X-Webhook-Event, X-Webhook-Delivery, X-Webhook-Attempt and X-Webhook-Signature headers.
+// @Description The signature is "t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">" keyed by the secret,
+// @Description which is only returned in this response. Failed deliveries are retried with exponential
+// @Description backoff and marked dead after the configured number of attempts.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body CreateWebhookRequest true "Webhook"
+// @Success 201 {object} pb.Webhook "Created webhook with secret"
+// @Failure 400 {object} ErrorResponse "Invalid URL or event type"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/webhooks [post]
+func (h *ChatHandler) CreateChatWebhook(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.createWebhook(w, r, &chatID)
+}
+
+// CreateGlobalWebhook godoc
+// @Summary Create global webhook
+// @Description Subscribes a URL to events of all chats (global moderators only). See the chat webhook endpoint for the request format.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body CreateWebhookRequest true "Webhook"
+// @Success 201 {object} pb.Webhook "Created webhook with secret"
+// @Failure 400 {object} ErrorResponse "Invalid URL or event type"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/webhooks [post]
+func (h *ChatHandler) CreateGlobalWebhook(w http.ResponseWriter, r *http.Request) {
+	h.createWebhook(w, r, nil)
+}
+
+func (h *ChatHandler) createWebhook(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	webhook, err := h.chatClient.CreateWebhook(ctx, userID.String(), chatID, req.URL, req.EventTypes)
+	if err != nil {
+		h.handleWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook
+// @Description Replaces the URL and event types of a webhook, or pauses it with is_active=false
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param webhookId path string true "Webhook ID"
+// @Param request body UpdateWebhookRequest true "Webhook"
+// @Success 200 {object} pb.Webhook "Updated webhook"
+// @Failure 400 {object} ErrorResponse "Invalid URL or event type"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Webhook not found"
+// @Router /chats/webhooks/{webhookId} [put]
+func (h *ChatHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	webhook, err := h.chatClient.UpdateWebhook(ctx, userID.String(), chi.URLParam(r, "webhookId"), req.URL, req.EventTypes, isActive)
+	if err != nil {
+		h.handleWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook
+// @Description Removes a webhook subscription together with its delivery log
+// @Tags webhooks
+// @Security Bearer
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "Webhook deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Webhook not found"
+// @Router /chats/webhooks/{webhookId} [delete]
+func (h *ChatHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.DeleteWebhook(ctx, userID.String(), chi.URLParam(r, "webhookId")); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Returns the delivery log of a webhook, newest first: payload, status, attempts and the last
+// @Description response code or error
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Param webhookId path string true "Webhook ID"
+// @Param status query string false "Filter by status" Enums(pending, delivered, dead)
+// @Param page query int false "Page number" default(1)
+// @Param count query int false "Items per page" default(20)
+// @Success 200 {object} map[string]interface{} "Deliveries with pagination"
+// @Failure 400 {object} ErrorResponse "Invalid status"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Webhook not found"
+// @Router /chats/webhooks/{webhookId}/deliveries [get]
+func (h *ChatHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	if page <= 0 {
+		page = 1
+	}
+	if count <= 0 {
+		count = 20
+	}
+
+	resp, err := h.chatClient.ListWebhookDeliveries(ctx, userID.String(), chi.URLParam(r, "webhookId"),
+		r.URL.Query().Get("status"), int32(page), int32(count))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": resp.Deliveries,
+		"pagination": resp.Pagination,
+	})
+}
+
+// RedeliverWebhook godoc
+// @Summary Redeliver webhook event
+// @Description Queues a delivered or dead delivery again with a fresh set of attempts
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} pb.WebhookDelivery "Queued delivery"
+// @Failure 400 {object} ErrorResponse "Delivery is still pending"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Delivery not found"
+// @Router /chats/webhooks/deliveries/{deliveryId}/redeliver [post]
+func (h *ChatHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	delivery, err := h.chatClient.RedeliverWebhook(ctx, userID.String(), chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		h.handleWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, delivery)
+}
+
+// handleWebhookError passes webhook validation errors through, they tell the admin what to fix
+func (h *ChatHandler) handleWebhookError(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.InvalidArgument {
+		h.respondError(w, http.StatusBadRequest, status.Convert(err).Message())
+		return
+	}
+	h.handleGRPCError(w, err)
+}
+
+// Incoming webhooks
+
+const (
+	// maxIncomingWebhookBody limits the size of a message posted to an incoming webhook
+	maxIncomingWebhookBody = 64 << 10
+	// headerWebhookToken carries the incoming webhook token, so that it stays out of URLs and logs
+	headerWebhookToken = "X-Webhook-Token"
+)
+
+// IncomingWebhookResponse is an incoming webhook with its posting URL. Token and URL are only
+// present right after creation or token rotation. The URL embeds the token for senders that cannot
+// set headers; others post to /api/hooks/{id} with the token in X-Webhook-Token.
+type IncomingWebhookResponse struct {
+	*pb.IncomingWebhook
+	URL string `json:"url,omitempty" example:"/api/hooks/550e8400-e29b-41d4-a716-446655440000/ihk_..."`
+}
+
+func toIncomingWebhookResponse(hook *pb.IncomingWebhook) IncomingWebhookResponse {
+	resp := IncomingWebhookResponse{IncomingWebhook: hook}
+	if hook.Token != "" {
+		resp.URL = "/api/hooks/" + hook.Id + "/" + hook.Token
+	}
+	return resp
+}
+
+// ListIncomingWebhooks godoc
+// @Summary List incoming webhooks
+// @Description Returns the incoming webhooks of a chat without their tokens (chat admins and global moderators)
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ListIncomingWebhooksResponse "Incoming webhooks"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/incoming-webhooks [get]
+func (h *ChatHandler) ListIncomingWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListIncomingWebhooks(ctx, userID.String(), chi.URLParam(r, "chatId"))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateIncomingWebhook godoc
+// @Summary Create incoming webhook
+// @Description Creates a URL that external systems POST messages to; they are posted into the chat as
+// @Description the bot account, which is added to the chat if needed. The token and the URL, which contains
+// @Description it, are only returned in this response. Prefer posting to /api/hooks/{id} with the token in X-Webhook-Token.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body CreateIncomingWebhookRequest true "Incoming webhook"
+// @Success 201 {object} IncomingWebhookResponse "Created webhook with URL"
+// @Failure 400 {object} ErrorResponse "Invalid name, bot, thread or rate limit"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/incoming-webhooks [post]
+func (h *ChatHandler) CreateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateIncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	hook, err := h.chatClient.CreateIncomingWebhook(ctx, userID.String(), chi.URLParam(r, "chatId"), req.BotID, req.Name, req.ThreadID, req.RateLimitPerMinute)
+	if err != nil {
+		h.handleWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, toIncomingWebhookResponse(hook))
+}
+
+// RotateIncomingWebhookToken godoc
+// @Summary Rotate incoming webhook token
+// @Description Issues a new URL for the webhook; the previous URL stops working immediately
+// @Tags webhooks
+// @Produce json
+// @Security Bearer
+// @Param webhookId path string true "Incoming webhook ID"
+// @Success 200 {object} IncomingWebhookResponse "Webhook with the new URL"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Webhook not found"
+// @Router /chats/incoming-webhooks/{webhookId}/rotate [post]
+func (h *ChatHandler) RotateIncomingWebhookToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	hook, err := h.chatClient.RotateIncomingWebhookToken(ctx, userID.String(), chi.URLParam(r, "webhookId"))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, toIncomingWebhookResponse(hook))
+}
+
+// DeleteIncomingWebhook godoc
+// @Summary Delete incoming webhook
+// @Tags webhooks
+// @Security Bearer
+// @Param webhookId path string true "Incoming webhook ID"
+// @Success 204 "Webhook deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Webhook not found"
+// @Router /chats/incoming-webhooks/{webhookId} [delete]
+func (h *ChatHandler) DeleteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.DeleteIncomingWebhook(ctx, userID.String(), chi.URLParam(r, "webhookId")); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ExecuteIncomingWebhook godoc
+// @Summary Post a message through an incoming webhook
+// @Description Posts a message into the webhook's chat as its bot. No authentication header is needed,
+// @Description the webhook token in X-Webhook-Token authorizes the request. Senders that can only be given
+// @Description a URL may post to /hooks/{webhookId}/{token} instead. Each webhook has its own rate limit;
+// @Description 429 responses carry Retry-After.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookId path string true "Incoming webhook ID"
+// @Param X-Webhook-Token header string true "Webhook token"
+// @Param request body IncomingWebhookMessage true "Message"
+// @Success 201 {object} pb.Message "Posted message"
+// @Failure 400 {object} ErrorResponse "Empty or invalid message"
+// @Failure 401 {object} ErrorResponse "Invalid webhook URL"
+// @Failure 422 {object} ContentRejectedResponse "Rejected by a content filter"
+// @Failure 429 {object} RateLimitResponse "Rate limit exceeded"
+// @Router /hooks/{webhookId} [post]
+func (h *ChatHandler) ExecuteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(headerWebhookToken)
+	if token == "" {
+		token = chi.URLParam(r, "token")
+	}
+
+	var req IncomingWebhookMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIncomingWebhookBody)).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	execReq := &pb.ExecuteIncomingWebhookRequest{
+		WebhookId:   chi.URLParam(r, "webhookId"),
+		Token:       token,
+		Text:        req.Text,
+		Title:       req.Title,
+		Url:         req.URL,
+		FileLinkIds: req.FileLinkIDs,
+		ThreadId:    req.ThreadID,
+	}
+	for _, f := range req.Fields {
+		execReq.Fields = append(execReq.Fields, &pb.IncomingWebhookField{Name: f.Name, Value: f.Value})
+	}
+
+	message, err := h.chatClient.ExecuteIncomingWebhook(r.Context(), execReq)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			h.respondError(w, http.StatusUnauthorized, "invalid webhook URL")
+		case codes.InvalidArgument:
+			if !h.respondContentRejected(w, status.Convert(err)) {
+				h.respondError(w, http.StatusBadRequest, status.Convert(err).Message())
+			}
+		default:
+			h.handleGRPCError(w, err)
+		}
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, message)
+}
+
+// Slash commands
+
+// ListCommands godoc
+// @Summary List slash commands
+// @Description Returns the commands that can be used in the chat: built-in ones (/help, /poll, /call, /remind)
+// @Description and the ones registered by bots. Messages starting with "/" run the command instead of being
+// @Description posted; start a message with "//" to send it as text.
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ListCommandsResponse "Commands"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Router /chats/{chatId}/commands [get]
+func (h *ChatHandler) ListCommands(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListCommands(ctx, userID.String(), chi.URLParam(r, "chatId"))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// ListChatBotCommands godoc
+// @Summary List chat bot commands
+// @Description Returns the bot commands registered for a chat (chat admins and global moderators)
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ListBotCommandsResponse "Bot commands"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/{chatId}/bot-commands [get]
+func (h *ChatHandler) ListChatBotCommands(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.listBotCommands(w, r, &chatID)
+}
+
+// ListGlobalBotCommands godoc
+// @Summary List global bot commands
+// @Description Returns the bot commands available in all chats (global moderators only)
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} pb.ListBotCommandsResponse "Bot commands"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Router /chats/bot-commands [get]
+func (h *ChatHandler) ListGlobalBotCommands(w http.ResponseWriter, r *http.Request) {
+	h.listBotCommands(w, r, nil)
+}
+
+func (h *ChatHandler) listBotCommands(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListBotCommands(ctx, userID.String(), chatID)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// RegisterChatBotCommand godoc
+// @Summary Register chat bot command
+// @Description Registers a slash command handled by a bot endpoint. Invocations and button clicks are POSTed
+// @Description to the URL as JSON with an X-Command-Signature header in the outgoing webhook format, keyed by
+// @Description the secret that is only returned in this response. The endpoint answers within 5 seconds with
+// @Description {"text", "ephemeral", "buttons", "replace_original"} or an empty body; replies are posted as the bot.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body RegisterBotCommandRequest true "Command"
+// @Success 201 {object} pb.BotCommand "Registered command with secret"
+// @Failure 400 {object} ErrorResponse "Invalid name, bot or URL"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 409 {object} ErrorResponse "Command already registered"
+// @Router /chats/{chatId}/bot-commands [post]
+func (h *ChatHandler) RegisterChatBotCommand(w http.ResponseWriter, r *http.Request) {
+	chatID := chi.URLParam(r, "chatId")
+	h.registerBotCommand(w, r, &chatID)
+}
+
+// RegisterGlobalBotCommand godoc
+// @Summary Register global bot command
+// @Description Registers a slash command available in all chats (global moderators only). A chat command
+// @Description with the same name takes precedence. See the chat endpoint for the request format.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body RegisterBotCommandRequest true "Command"
+// @Success 201 {object} pb.BotCommand "Registered command with secret"
+// @Failure 400 {object} ErrorResponse "Invalid name, bot or URL"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 409 {object} ErrorResponse "Command already registered"
+// @Router /chats/bot-commands [post]
+func (h *ChatHandler) RegisterGlobalBotCommand(w http.ResponseWriter, r *http.Request) {
+	h.registerBotCommand(w, r, nil)
+}
+
+func (h *ChatHandler) registerBotCommand(w http.ResponseWriter, r *http.Request, chatID *string) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req RegisterBotCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd, err := h.chatClient.RegisterBotCommand(ctx, userID.String(), chatID, req.BotID, req.Name, req.Description, req.Usage, req.URL)
+	if err != nil {
+		h.handleWebhookError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, cmd)
+}
+
+// DeleteBotCommand godoc
+// @Summary Delete bot command
+// @Tags commands
+// @Security Bearer
+// @Param commandId path string true "Bot command ID"
+// @Success 204 "Command deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Access denied"
+// @Failure 404 {object} ErrorResponse "Command not found"
+// @Router /chats/bot-commands/{commandId} [delete]
+func (h *ChatHandler) DeleteBotCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.DeleteBotCommand(ctx, userID.String(), chi.URLParam(r, "commandId")); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// InteractMessage godoc
+// @Summary Click an interactive message button
+// @Description Sends a button click to the command that posted the message. Built-in /poll buttons record a
+// @Description vote; bot buttons are forwarded to the bot, which can edit the message or reply. Returns the
+// @Description updated or posted message; ephemeral replies are also delivered over the websocket.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param messageId path string true "Message ID"
+// @Param request body InteractMessageRequest true "Clicked button"
+// @Success 200 {object} pb.InteractMessageResponse "Result"
+// @Failure 400 {object} ErrorResponse "Unknown button"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Failure 404 {object} ErrorResponse "Message not found"
+// @Failure 429 {object} RateLimitResponse "Rate limit exceeded"
+// @Router /chats/messages/{messageId}/interactions [post]
+func (h *ChatHandler) InteractMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req InteractMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.chatClient.InteractMessage(ctx, userID.String(), chi.URLParam(r, "messageId"), req.ActionID, req.Value)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// Reminders
+
+// CreateMessageReminder godoc
+// @Summary Remind me about a message
+// @Description Schedules a private reminder about a message. At remind_at the user gets an ephemeral system
+// @Description message (message.ephemeral event) quoting the message in its chat; nobody else sees it.
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param messageId path string true "Message ID"
+// @Param request body CreateMessageReminderRequest true "Reminder"
+// @Success 201 {object} pb.Reminder "Scheduled reminder"
+// @Failure 400 {object} ErrorResponse "Invalid time or too many pending reminders"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Failure 404 {object} ErrorResponse "Message not found"
+// @Router /chats/messages/{messageId}/reminders [post]
+func (h *ChatHandler) CreateMessageReminder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateMessageReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	remindAt, err := time.Parse(time.RFC3339, req.RemindAt)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "remind_at must be an RFC 3339 timestamp")
+		return
+	}
+
+	reminder, err := h.chatClient.CreateMessageReminder(ctx, userID.String(), chi.URLParam(r, "messageId"), remindAt, req.Note)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, reminder)
+}
+
+// ListReminders godoc
+// @Summary List my reminders
+// @Description Returns the user's pending reminders, soonest first, from "remind me about this" and /remind.
+// @Description With include_delivered, reminders that fired in the last week are listed too (delivered_at set) until cancelled, so clients that were offline at the due time can show them.
+// @Tags reminders
+// @Produce json
+// @Security Bearer
+// @Param chat_id query string false "Only reminders of this chat"
+// @Param include_delivered query bool false "Also list reminders that already fired"
+// @Success 200 {object} pb.ListRemindersResponse "Reminders"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /chats/reminders [get]
+func (h *ChatHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var chatID *string
+	if v := r.URL.Query().Get("chat_id"); v != "" {
+		chatID = &v
+	}
+
+	includeDelivered, _ := strconv.ParseBool(r.URL.Query().Get("include_delivered"))
+
+	resp, err := h.chatClient.ListReminders(ctx, userID.String(), chatID, includeDelivered)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// CancelReminder godoc
+// @Summary Cancel a reminder
+// @Description Cancels a pending reminder or dismisses one that already fired
+// @Tags reminders
+// @Security Bearer
+// @Param reminderId path string true "Reminder ID"
+// @Success 204 "Reminder cancelled"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Reminder not found"
+// @Router /chats/reminders/{reminderId} [delete]
+func (h *ChatHandler) CancelReminder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.CancelReminder(ctx, userID.String(), chi.URLParam(r, "reminderId")); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Custom emoji
+
+// ListCustomEmoji godoc
+// @Summary List custom emoji of a chat
+// @Description Returns the chat's emoji set. Images are served by files-service via file_link_id.
+// @Tags emoji
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Success 200 {object} pb.ListCustomEmojiResponse "Emoji"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Router /chats/{chatId}/emoji [get]
+func (h *ChatHandler) ListCustomEmoji(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.chatClient.ListCustomEmoji(ctx, userID.String(), chi.URLParam(r, "chatId"))
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// CreateCustomEmoji godoc
+// @Summary Add a custom emoji
+// @Description Adds an image uploaded to files-service to the chat's emoji set; it can then be used as a
+// @Description ":name:" reaction. Chat admins only. A chat can have up to 200 custom emoji.
+// @Tags emoji
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body CreateCustomEmojiRequest true "Emoji"
+// @Success 201 {object} pb.CustomEmoji "Created emoji"
+// @Failure 400 {object} ErrorResponse "Invalid name or image"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a chat admin"
+// @Failure 409 {object} ErrorResponse "Name already taken"
+// @Router /chats/{chatId}/emoji [post]
+func (h *ChatHandler) CreateCustomEmoji(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateCustomEmojiRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	e, err := h.chatClient.CreateCustomEmoji(ctx, userID.String(), chi.URLParam(r, "chatId"), req.Name, req.FileLinkID)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, e)
+}
+
+// DeleteCustomEmoji godoc
+// @Summary Delete a custom emoji
+// @Description Removes the emoji from the chat's set. Existing reactions with it are kept. Chat admins only.
+// @Tags emoji
+// @Security Bearer
+// @Param emojiId path string true "Emoji ID"
+// @Success 204 "Emoji deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a chat admin"
+// @Failure 404 {object} ErrorResponse "Emoji not found"
+// @Router /chats/emoji/{emojiId} [delete]
+func (h *ChatHandler) DeleteCustomEmoji(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.chatClient.DeleteCustomEmoji(ctx, userID.String(), chi.URLParam(r, "emojiId")); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Drafts
+
+// GetDrafts godoc
+// @Summary List my drafts
+// @Description Returns the user's unsent drafts, most recently edited first, so that another device can restore them
+// @Tags drafts
+// @Produce json
+// @Security Bearer
+// @Param chat_id query string false "Only drafts of this chat"
+// @Success 200 {object} pb.GetDraftsResponse "Drafts"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /chats/drafts [get]
+func (h *ChatHandler) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var chatID *string
+	if v := r.URL.Query().Get("chat_id"); v != "" {
+		chatID = &v
+	}
+
+	resp, err := h.chatClient.GetDrafts(ctx, userID.String(), chatID)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// SaveDraft godoc
+// @Summary Save a draft
+// @Description Stores the unsent message of the chat (or of a thread with thread_id) and sends draft.updated to the
+// @Description user's other devices. Sending a message clears the draft automatically.
+// @Tags drafts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param request body SaveDraftRequest true "Draft"
+// @Success 200 {object} pb.Draft "Saved draft"
+// @Failure 400 {object} ErrorResponse "Draft too long"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not a participant"
+// @Router /chats/{chatId}/draft [put]
+func (h *ChatHandler) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req SaveDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	draft, err := h.chatClient.SaveDraft(ctx, userID.String(), chi.URLParam(r, "chatId"), req.ThreadID, req.Content, req.ReplyToIDs)
+	if err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, draft)
+}
+
+// DeleteDraft godoc
+// @Summary Discard a draft
+// @Tags drafts
+// @Security Bearer
+// @Param chatId path string true "Chat ID"
+// @Param thread_id query string false "Thread of the draft; omit for the main chat"
+// @Success 204 "Draft deleted"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Router /chats/{chatId}/draft [delete]
+func (h *ChatHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var threadID *string
+	if v := r.URL.Query().Get("thread_id"); v != "" {
+		threadID = &v
+	}
+
+	if err := h.chatClient.DeleteDraft(ctx, userID.String(), chi.URLParam(r, "chatId"), threadID); err != nil {
+		h.handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
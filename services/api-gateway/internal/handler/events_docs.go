@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"reflect"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
 )
 
 // EventDefinition describes a WebSocket event
@@ -58,6 +60,12 @@ var EventRegistry = EventsDocumentation{
 	BaseEvent: BaseEventSchema{
 		Description: "Все события имеют общую структуру-обёртку",
 		Fields: map[string]FieldSchema{
+			"id": {
+				Type:        "string (UUID)",
+				Description: "Уникальный ID события. Повторная доставка приходит с тем же ID, клиент должен её отбросить",
+				Required:    true,
+				Example:     "770e8400-e29b-41d4-a716-446655440002",
+			},
 			"type": {
 				Type:        "string",
 				Description: "Тип события",
@@ -89,227 +97,54 @@ var EventRegistry = EventsDocumentation{
 			},
 		},
 	},
-	Events: []EventDefinition{
-		// Chat events
-		{
-			Type:        "chat.created",
-			Description: "Создан новый чат",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":         {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"name":       {Type: "string", Description: "Название чата", Required: true},
-				"chat_type":  {Type: "string", Description: "Тип чата: direct, group, channel", Required: true},
-				"created_by": {Type: "string (UUID)", Description: "ID создателя", Required: true},
-			},
-		},
-		{
-			Type:        "chat.updated",
-			Description: "Чат обновлён (изменено название или настройки)",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":         {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"name":       {Type: "string", Description: "Новое название чата", Required: true},
-				"chat_type":  {Type: "string", Description: "Тип чата", Required: true},
-				"created_by": {Type: "string (UUID)", Description: "ID создателя", Required: true},
-			},
-		},
-		{
-			Type:        "chat.deleted",
-			Description: "Чат удалён",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"chat_id": {Type: "string (UUID)", Description: "ID удалённого чата", Required: true},
-			},
-		},
-
-		// Message events
-		{
-			Type:        "message.created",
-			Description: "Новое сообщение в чате",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":                  {Type: "string (UUID)", Description: "ID сообщения", Required: true},
-				"chat_id":             {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"sender_id":           {Type: "string (UUID)", Description: "ID отправителя", Required: true},
-				"content":             {Type: "string", Description: "Текст сообщения", Required: true},
-				"sent_at":             {Type: "string (ISO 8601)", Description: "Время отправки", Required: true},
-				"parent_id":           {Type: "string (UUID)", Description: "ID родительского сообщения (для тредов)", Required: false},
-				"sender_username":     {Type: "string", Description: "Username отправителя", Required: false},
-				"sender_display_name": {Type: "string", Description: "Отображаемое имя отправителя", Required: false},
-				"sender_avatar_url":   {Type: "string", Description: "URL аватара отправителя", Required: false},
-				"file_link_ids":       {Type: "array[string]", Description: "ID прикреплённых файлов", Required: false},
-			},
-		},
-		{
-			Type:        "message.updated",
-			Description: "Сообщение отредактировано",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":         {Type: "string (UUID)", Description: "ID сообщения", Required: true},
-				"chat_id":    {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"sender_id":  {Type: "string (UUID)", Description: "ID отправителя", Required: true},
-				"content":    {Type: "string", Description: "Новый текст сообщения", Required: true},
-				"sent_at":    {Type: "string (ISO 8601)", Description: "Время отправки", Required: true},
-				"updated_at": {Type: "string (ISO 8601)", Description: "Время редактирования", Required: true},
-			},
-		},
-		{
-			Type:        "message.deleted",
-			Description: "Сообщение удалено (soft delete)",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"message_id":            {Type: "string (UUID)", Description: "ID удалённого сообщения", Required: true},
-				"chat_id":               {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"is_moderated_deletion": {Type: "boolean", Description: "true если удалено модератором", Required: true},
-			},
-		},
-		{
-			Type:        "message.restored",
-			Description: "Сообщение восстановлено после удаления",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"message_id": {Type: "string (UUID)", Description: "ID восстановленного сообщения", Required: true},
-				"chat_id":    {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"sender_id":  {Type: "string (UUID)", Description: "ID отправителя", Required: true},
-				"content":    {Type: "string", Description: "Текст сообщения", Required: true},
-			},
-		},
-
-		// Typing indicator
-		{
-			Type:        "typing",
-			Description: "Индикатор набора текста",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"user_id":   {Type: "string (UUID)", Description: "ID пользователя, который печатает", Required: true},
-				"is_typing": {Type: "boolean", Description: "true - начал печатать, false - закончил", Required: true},
-			},
-		},
-
-		// Reaction events
-		{
-			Type:        "reaction.added",
-			Description: "Добавлена реакция на сообщение",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"message_id": {Type: "string (UUID)", Description: "ID сообщения", Required: true},
-				"emoji":      {Type: "string", Description: "Эмодзи реакции", Required: true, Example: "👍"},
-				"user_id":    {Type: "string (UUID)", Description: "ID пользователя", Required: true},
-			},
-		},
-		{
-			Type:        "reaction.removed",
-			Description: "Удалена реакция с сообщения",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"message_id": {Type: "string (UUID)", Description: "ID сообщения", Required: true},
-				"emoji":      {Type: "string", Description: "Эмодзи реакции", Required: true},
-				"user_id":    {Type: "string (UUID)", Description: "ID пользователя", Required: true},
-			},
-		},
+	Events: eventDefinitions(),
+}
 
-		// Thread events
-		{
-			Type:        "thread.created",
-			Description: "Создан новый тред",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":                     {Type: "string (UUID)", Description: "ID треда", Required: true},
-				"chat_id":                {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"parent_message_id":      {Type: "string (UUID)", Description: "ID родительского сообщения", Required: false},
-				"thread_type":            {Type: "string", Description: "Тип треда: reply, topic", Required: true},
-				"title":                  {Type: "string", Description: "Заголовок треда", Required: false},
-				"message_count":          {Type: "integer", Description: "Количество сообщений", Required: true},
-				"created_by":             {Type: "string (UUID)", Description: "ID создателя", Required: false},
-				"is_archived":            {Type: "boolean", Description: "Архивирован ли тред", Required: true},
-				"restricted_participants": {Type: "boolean", Description: "Ограничен ли список участников", Required: true},
-			},
-		},
-		{
-			Type:        "thread.archived",
-			Description: "Тред архивирован",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"id":                     {Type: "string (UUID)", Description: "ID треда", Required: true},
-				"chat_id":                {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"parent_message_id":      {Type: "string (UUID)", Description: "ID родительского сообщения", Required: false},
-				"thread_type":            {Type: "string", Description: "Тип треда", Required: true},
-				"title":                  {Type: "string", Description: "Заголовок треда", Required: false},
-				"message_count":          {Type: "integer", Description: "Количество сообщений", Required: true},
-				"created_by":             {Type: "string (UUID)", Description: "ID создателя", Required: false},
-				"is_archived":            {Type: "boolean", Description: "Всегда true", Required: true},
-				"restricted_participants": {Type: "boolean", Description: "Ограничен ли список участников", Required: true},
-			},
-		},
+// eventDefinitions lists the events forwarded to clients, built from the shared event registry
+// so the docs follow the payload types the services publish
+func eventDefinitions() []EventDefinition {
+	var defs []EventDefinition
+	for _, def := range events.Definitions() {
+		if def.Channel == "" {
+			continue
+		}
+		schema := events.SchemaOf(def.Payload)
+		required := make(map[string]bool, len(schema.Required))
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+		payload := make(map[string]FieldSchema, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			payload[name] = FieldSchema{
+				Type:        fieldTypeName(prop),
+				Description: prop.Description,
+				Required:    required[name],
+			}
+		}
+		defs = append(defs, EventDefinition{
+			Type:        def.Type,
+			Description: def.Description,
+			Channel:     def.Channel,
+			Payload:     payload,
+		})
+	}
+	return defs
+}
 
-		// Voice events
-		{
-			Type:        "conference.created",
-			Description: "Создана голосовая конференция",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"conference_id": {Type: "string (UUID)", Description: "ID конференции", Required: true},
-				"chat_id":       {Type: "string (UUID)", Description: "ID чата", Required: true},
-				"name":          {Type: "string", Description: "Название конференции", Required: true},
-				"created_by":    {Type: "string (UUID)", Description: "ID создателя", Required: true},
-			},
-		},
-		{
-			Type:        "conference.ended",
-			Description: "Голосовая конференция завершена",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"conference_id": {Type: "string (UUID)", Description: "ID конференции", Required: true},
-				"chat_id":       {Type: "string (UUID)", Description: "ID чата", Required: true},
-			},
-		},
-		{
-			Type:        "participant.joined",
-			Description: "Участник присоединился к конференции",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"conference_id": {Type: "string (UUID)", Description: "ID конференции", Required: true},
-				"user_id":       {Type: "string (UUID)", Description: "ID пользователя", Required: true},
-				"username":      {Type: "string", Description: "Username пользователя", Required: false},
-			},
-		},
-		{
-			Type:        "participant.left",
-			Description: "Участник покинул конференцию",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"conference_id": {Type: "string (UUID)", Description: "ID конференции", Required: true},
-				"user_id":       {Type: "string (UUID)", Description: "ID пользователя", Required: true},
-			},
-		},
-		{
-			Type:        "call.initiated",
-			Description: "Инициирован звонок",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"call_id":   {Type: "string (UUID)", Description: "ID звонка", Required: true},
-				"caller_id": {Type: "string (UUID)", Description: "ID звонящего", Required: true},
-				"callee_id": {Type: "string (UUID)", Description: "ID вызываемого", Required: true},
-			},
-		},
-		{
-			Type:        "call.answered",
-			Description: "Звонок принят",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"call_id": {Type: "string (UUID)", Description: "ID звонка", Required: true},
-			},
-		},
-		{
-			Type:        "call.ended",
-			Description: "Звонок завершён",
-			Channel:     "user:{userId}",
-			Payload: map[string]FieldSchema{
-				"call_id": {Type: "string (UUID)", Description: "ID звонка", Required: true},
-				"reason":  {Type: "string", Description: "Причина завершения", Required: false},
-			},
-		},
-	},
+// fieldTypeName renders a JSON Schema type the way these docs spell types
+func fieldTypeName(s *events.Schema) string {
+	switch {
+	case s.Format == "uuid":
+		return "string (UUID)"
+	case s.Format == "date-time":
+		return "string (ISO 8601)"
+	case s.Type == "array" && s.Items != nil && s.Items.Type != "object":
+		return "array[" + fieldTypeName(s.Items) + "]"
+	case s.Type == "":
+		return "object"
+	default:
+		return s.Type
+	}
 }
 
 // EventsDocsHandler handles event documentation requests
@@ -794,11 +629,11 @@ export default function () {
             .then(data => {
                 const categories = {
                     'chat-events': ['chat.created', 'chat.updated', 'chat.deleted'],
-                    'message-events': ['message.created', 'message.updated', 'message.deleted', 'message.restored'],
+                    'message-events': ['message.created', 'message.updated', 'message.deleted', 'message.restored', 'message.ephemeral'],
                     'reaction-events': ['reaction.added', 'reaction.removed'],
                     'thread-events': ['thread.created', 'thread.archived'],
                     'voice-events': ['conference.created', 'conference.ended', 'participant.joined', 'participant.left', 'call.initiated', 'call.answered', 'call.ended'],
-                    'other-events': ['typing']
+                    'other-events': ['typing', 'draft.updated']
                 };
 
                 data.events.forEach(event => {
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/services/api-gateway/internal/centrifugo"
+	"github.com/icegreg/chat-smpl/services/api-gateway/internal/grpc"
+	"github.com/icegreg/chat-smpl/services/api-gateway/internal/middleware"
+)
+
+const (
+	// sseMaxDuration ends an SSE response before the server's 60s write timeout; EventSource
+	// reconnects on its own and resumes from Last-Event-ID
+	sseMaxDuration = 50 * time.Second
+	sseHeartbeat   = 15 * time.Second
+	sseRetryMillis = 1000
+
+	defaultPollTimeout = 25 * time.Second
+	maxPollTimeout     = 50 * time.Second
+	// pollBatchWindow collects publications that arrive right after the first one into one response
+	pollBatchWindow = 100 * time.Millisecond
+
+	// maxStreamChats limits the chat channels of a stream to the most recently active chats.
+	// Together with the personal channel it must stay under client_channel_limit of Centrifugo.
+	maxStreamChats = 100
+)
+
+// EventsStreamHandler delivers realtime events over Server-Sent Events and long-polling, for
+// clients whose network blocks WebSockets. Each request opens a Centrifugo connection subscribed
+// on the server side to the user's personal channel and the channels of their chats, so clients
+// get the same event JSON as over WebSocket in either CHAT_EVENTS_DELIVERY mode and resume from
+// the channel history.
+type EventsStreamHandler struct {
+	streamer   *centrifugo.Streamer
+	chatClient *grpc.ChatClient
+	log        logger.Logger
+}
+
+func NewEventsStreamHandler(streamer *centrifugo.Streamer, chatClient *grpc.ChatClient, log logger.Logger) *EventsStreamHandler {
+	return &EventsStreamHandler{
+		streamer:   streamer,
+		chatClient: chatClient,
+		log:        log,
+	}
+}
+
+func (h *EventsStreamHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/stream", h.Stream)
+	r.Get("/poll", h.Poll)
+
+	return r
+}
+
+// Stream godoc
+// @Summary Realtime events over Server-Sent Events
+// @Description Streams events of the user's personal channel and of their 100 most recently active chats as SSE, in the same JSON as the WebSocket.
+// @Description Every event has an id; EventSource resends the last one in Last-Event-ID on reconnect and missed events are replayed.
+// @Description Events can arrive twice, from the personal and the chat channel; drop duplicates by the event id field.
+// @Description The first event is "connected" with data {"recovered": bool}; recovered=false means the resume failed and the client must sync through the REST API.
+// @Description The response ends after about 50 seconds; EventSource reconnects automatically. EventSource cannot set headers, so pass the access token as _token.
+// @Tags events
+// @Produce text/event-stream
+// @Security Bearer
+// @Param _token query string false "Access token (for EventSource)"
+// @Param last_event_id query string false "Position to resume from, if Last-Event-ID is not sent"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} ErrorResponse "Invalid Last-Event-ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 502 {object} ErrorResponse "Realtime server unavailable"
+// @Router /events/stream [get]
+func (h *EventsStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), sseMaxDuration)
+	defer cancel()
+
+	stream, cursor, recovered, err := h.open(ctx, userID.String(), lastEventID)
+	if err != nil {
+		h.respondOpenError(w, err)
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis)
+	connected, _ := json.Marshal(map[string]bool{"recovered": recovered})
+	writeSSE(w, "connected", cursor.String(), connected)
+	if err := rc.Flush(); err != nil {
+		h.log.Error("sse: response cannot be flushed", "error", err)
+		return
+	}
+
+	pubs := pump(ctx, stream)
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case pub, ok := <-pubs:
+			if !ok {
+				return
+			}
+			cursor[pub.Channel] = pub.Position
+			writeSSE(w, "", cursor.String(), pub.Data)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// Poll godoc
+// @Summary Realtime events over long-polling
+// @Description Waits for events of the user's personal channel and of their 100 most recently active chats after cursor and returns them in the same JSON as the WebSocket.
+// @Description Pass the returned cursor to the next request. Without a cursor, waits for new events from now.
+// @Description recovered=false means events after the cursor are no longer available and the client must sync through the REST API.
+// @Tags events
+// @Produce json
+// @Security Bearer
+// @Param cursor query string false "Cursor from the previous response"
+// @Param timeout query int false "Seconds to wait for events (default 25, max 50)"
+// @Success 200 {object} EventsPollResponse "Events after the cursor, empty on timeout"
+// @Failure 400 {object} ErrorResponse "Invalid cursor or timeout"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 502 {object} ErrorResponse "Realtime server unavailable"
+// @Router /events/poll [get]
+func (h *EventsStreamHandler) Poll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	timeout := defaultPollTimeout
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			h.respondError(w, http.StatusBadRequest, "invalid timeout")
+			return
+		}
+		timeout = min(time.Duration(seconds)*time.Second, maxPollTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, cursor, recovered, err := h.open(ctx, userID.String(), r.URL.Query().Get("cursor"))
+	if err != nil {
+		h.respondOpenError(w, err)
+		return
+	}
+	defer stream.Close()
+
+	resp := EventsPollResponse{
+		Events:    []json.RawMessage{},
+		Cursor:    cursor.String(),
+		Recovered: recovered,
+	}
+
+	pubs := pump(ctx, stream)
+	wait := time.NewTimer(timeout)
+	defer wait.Stop()
+	var batch <-chan time.Time // Started by the first publication
+collect:
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-wait.C:
+			break collect
+		case <-batch:
+			break collect
+		case pub, ok := <-pubs:
+			if !ok {
+				break collect
+			}
+			resp.Events = append(resp.Events, pub.Data)
+			cursor[pub.Channel] = pub.Position
+			resp.Cursor = cursor.String()
+			if batch == nil {
+				batch = time.After(pollBatchWindow)
+			}
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// open connects to the user's personal channel and the channels of their chats, resuming after
+// cursor when it is set. It returns the cursor at connect time; recovered is false when a channel
+// could not be resumed, including a chat that was not in the cursor.
+func (h *EventsStreamHandler) open(ctx context.Context, userID, cursor string) (*centrifugo.Stream, centrifugo.Cursor, bool, error) {
+	var resume centrifugo.Cursor
+	if cursor != "" {
+		var err error
+		if resume, err = parseStreamCursor("user:"+userID, cursor); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	channels, err := h.channels(ctx, userID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	since, complete := resumeChannels(resume, channels)
+
+	stream, err := h.streamer.Open(ctx, userID, channels, since)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	current := make(centrifugo.Cursor, len(stream.Positions))
+	for channel, pos := range stream.Positions {
+		current[channel] = pos
+	}
+	return stream, current, stream.Recovered && complete, nil
+}
+
+// channels lists the user's personal channel and the channels of their most recently active chats.
+// Chat channels are listed in both delivery modes: with CHAT_EVENTS_DELIVERY=chat they carry the
+// chat events, and they always carry conference events.
+func (h *EventsStreamHandler) channels(ctx context.Context, userID string) ([]string, error) {
+	resp, err := h.chatClient.ListChats(ctx, userID, maxStreamChats, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chats: %w", err)
+	}
+
+	channels := make([]string, 0, len(resp.Chats)+1)
+	channels = append(channels, "user:"+userID)
+	for _, chat := range resp.Chats {
+		channels = append(channels, "chat:"+chat.Id)
+	}
+	return channels, nil
+}
+
+// parseStreamCursor decodes an SSE event ID or poll cursor. A single "offset:epoch" position, the
+// form used before chat channels were streamed, is a position in the personal channel.
+func parseStreamCursor(userChannel, s string) (centrifugo.Cursor, error) {
+	if !strings.Contains(s, "=") {
+		pos, err := centrifugo.ParsePosition(s)
+		if err != nil {
+			return nil, err
+		}
+		return centrifugo.Cursor{userChannel: pos}, nil
+	}
+	return centrifugo.ParseCursor(s)
+}
+
+// resumeChannels picks the positions of cursor to recover the channels from. Channels the user no
+// longer has are dropped; complete is false when a channel has no position, e.g. a chat the user
+// joined since, as its events in between cannot be recovered.
+func resumeChannels(cursor centrifugo.Cursor, channels []string) (since centrifugo.Cursor, complete bool) {
+	if cursor == nil {
+		return nil, true
+	}
+	since = make(centrifugo.Cursor, len(channels))
+	complete = true
+	for _, channel := range channels {
+		pos, ok := cursor[channel]
+		if !ok {
+			complete = false
+			continue
+		}
+		since[channel] = pos
+	}
+	return since, complete
+}
+
+// pump forwards publications from the stream to a channel that is closed when the stream ends.
+// It stops once ctx is done, so the caller may stop reading.
+func pump(ctx context.Context, stream *centrifugo.Stream) <-chan centrifugo.Publication {
+	pubs := make(chan centrifugo.Publication)
+	go func() {
+		defer close(pubs)
+		for {
+			pub, err := stream.Next()
+			if err != nil {
+				return
+			}
+			select {
+			case pubs <- pub:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return pubs
+}
+
+func writeSSE(w http.ResponseWriter, event, id string, data []byte) {
+	if event != "" {
+		fmt.Fprintf(w, "event: %s\n", event)
+	}
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	// Event JSON is a single line, as SSE data must not contain newlines
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+func (h *EventsStreamHandler) respondOpenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, centrifugo.ErrInvalidPosition) {
+		h.respondError(w, http.StatusBadRequest, "invalid event id")
+		return
+	}
+	h.log.Error("failed to open centrifugo stream", "error", err)
+	h.respondError(w, http.StatusBadGateway, "realtime server unavailable")
+}
+
+func (h *EventsStreamHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if data != nil {
+		json.NewEncoder(w).Encode(data)
+	}
+}
+
+func (h *EventsStreamHandler) respondError(w http.ResponseWriter, status int, message string) {
+	h.respondJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icegreg/chat-smpl/services/api-gateway/internal/centrifugo"
+)
+
+func TestParseStreamCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    centrifugo.Cursor
+		wantErr bool
+	}{
+		{
+			name: "position of the personal channel",
+			s:    "42:ab",
+			want: centrifugo.Cursor{"user:1": {Offset: 42, Epoch: "ab"}},
+		},
+		{
+			name: "positions of several channels",
+			s:    "chat:2=7:cd,user:1=42:ab",
+			want: centrifugo.Cursor{"user:1": {Offset: 42, Epoch: "ab"}, "chat:2": {Offset: 7, Epoch: "cd"}},
+		},
+		{name: "invalid position", s: "42", wantErr: true},
+		{name: "invalid entry", s: "chat:2=7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStreamCursor("user:1", tt.s)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, centrifugo.ErrInvalidPosition)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResumeChannels(t *testing.T) {
+	user := centrifugo.Position{Offset: 42, Epoch: "ab"}
+	chat := centrifugo.Position{Offset: 7, Epoch: "cd"}
+
+	tests := []struct {
+		name     string
+		cursor   centrifugo.Cursor
+		channels []string
+		since    centrifugo.Cursor
+		complete bool
+	}{
+		{
+			name:     "fresh stream",
+			channels: []string{"user:1", "chat:2"},
+			complete: true,
+		},
+		{
+			name:     "every channel is resumed",
+			cursor:   centrifugo.Cursor{"user:1": user, "chat:2": chat},
+			channels: []string{"user:1", "chat:2"},
+			since:    centrifugo.Cursor{"user:1": user, "chat:2": chat},
+			complete: true,
+		},
+		{
+			name:     "chat the user left is dropped",
+			cursor:   centrifugo.Cursor{"user:1": user, "chat:2": chat},
+			channels: []string{"user:1"},
+			since:    centrifugo.Cursor{"user:1": user},
+			complete: true,
+		},
+		{
+			name:     "chat joined since the cursor needs a resync",
+			cursor:   centrifugo.Cursor{"user:1": user},
+			channels: []string{"user:1", "chat:2"},
+			since:    centrifugo.Cursor{"user:1": user},
+			complete: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, complete := resumeChannels(tt.cursor, tt.channels)
+			assert.Equal(t, tt.since, since)
+			assert.Equal(t, tt.complete, complete)
+		})
+	}
+}
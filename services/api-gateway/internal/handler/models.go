@@ -1,5 +1,7 @@
 package handler
 
+import "encoding/json"
+
 // Swagger model definitions for API documentation
 
 // Auth Models
@@ -52,6 +54,12 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" example:"newpassword"`
 }
 
+// CreateBotRequest represents bot account creation request
+type CreateBotRequest struct {
+	Username    string  `json:"username" example:"deploybot"`
+	DisplayName *string `json:"display_name,omitempty" example:"Deploy Bot"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error" example:"error message"`
@@ -97,6 +105,7 @@ type ChatResponse struct {
 	CreatedAt   string   `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt   string   `json:"updated_at" example:"2024-01-15T10:30:00Z"`
 	MemberIDs   []string `json:"member_ids,omitempty"`
+	SlowMode    int32    `json:"slow_mode_seconds" example:"30"`
 }
 
 // ChatListResponse represents a list of chats
@@ -198,6 +207,143 @@ type CreateThreadRequest struct {
 	MessageID string `json:"message_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
+// SetSlowModeRequest represents a slow mode change
+type SetSlowModeRequest struct {
+	Seconds int32 `json:"seconds" example:"30"` // 0 disables slow mode
+}
+
+// RateLimitResponse is returned with 429 Too Many Requests
+type RateLimitResponse struct {
+	Error             string `json:"error" example:"rate limit exceeded: slow mode is enabled in this chat, retry after 12s"`
+	RetryAfterSeconds int    `json:"retry_after_seconds" example:"12"`
+}
+
+// ContentRejectedResponse is returned with 422 when a content filter blocks a message
+type ContentRejectedResponse struct {
+	Error  string `json:"error" example:"message contains sensitive data (card number)"`
+	Filter string `json:"filter" example:"dlp"`         // limits, words, dlp
+	Rule   string `json:"rule" example:"card_number"` // e.g. max_length, max_attachments, blocked_word, custom pattern name
+}
+
+// ReportMessageRequest represents a message report
+type ReportMessageRequest struct {
+	Reason  string `json:"reason" example:"spam"`
+	Comment string `json:"comment,omitempty" example:"Advertising links in every chat"`
+}
+
+// ResolveReportRequest represents a moderator decision on a report
+type ResolveReportRequest struct {
+	Action string `json:"action" example:"delete"`
+	Note   string `json:"note,omitempty" example:"Spam, second warning"`
+}
+
+// CreateWebhookRequest represents a new outgoing webhook subscription
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" example:"https://tools.example.com/hooks/chat"`
+	EventTypes []string `json:"event_types" example:"message.created,thread.created"`
+}
+
+// UpdateWebhookRequest replaces the target and event types of a webhook subscription
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" example:"https://tools.example.com/hooks/chat"`
+	EventTypes []string `json:"event_types" example:"message.created"`
+	IsActive   *bool    `json:"is_active,omitempty" example:"true"` // Defaults to true; false pauses deliveries
+}
+
+// CreateIncomingWebhookRequest represents a new incoming webhook of a chat
+type CreateIncomingWebhookRequest struct {
+	Name               string  `json:"name" example:"CI notifications"`
+	BotID              string  `json:"bot_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ThreadID           *string `json:"thread_id,omitempty"`                          // Default thread, omit for the main chat
+	RateLimitPerMinute int32   `json:"rate_limit_per_minute,omitempty" example:"60"` // Defaults to 60
+}
+
+// IncomingWebhookField is a name/value pair shown under the message title
+type IncomingWebhookField struct {
+	Name  string `json:"name" example:"Status"`
+	Value string `json:"value" example:"passed"`
+}
+
+// IncomingWebhookMessage is the body posted to an incoming webhook URL. At least one of text,
+// title, url, fields or file_link_ids is required.
+type IncomingWebhookMessage struct {
+	Text        string                 `json:"text" example:"Build #512 finished"`
+	Title       string                 `json:"title,omitempty" example:"chat-smpl / main"`
+	URL         string                 `json:"url,omitempty" example:"https://ci.example.com/builds/512"`
+	Fields      []IncomingWebhookField `json:"fields,omitempty"`
+	FileLinkIDs []string               `json:"file_link_ids,omitempty"`
+	ThreadID    *string                `json:"thread_id,omitempty"` // Only for webhooks not bound to a thread
+}
+
+// RegisterBotCommandRequest registers a slash command handled by a bot endpoint
+type RegisterBotCommandRequest struct {
+	Name        string `json:"name" example:"deploy"`
+	Description string `json:"description,omitempty" example:"Deploy a service"`
+	Usage       string `json:"usage,omitempty" example:"/deploy <service> <env>"`
+	BotID       string `json:"bot_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	URL         string `json:"url" example:"https://bots.example.com/commands"`
+}
+
+// InteractMessageRequest is a click on an interactive message button
+type InteractMessageRequest struct {
+	ActionID string `json:"action_id" example:"poll.vote"`
+	Value    string `json:"value,omitempty" example:"1"`
+}
+
+// CreateMessageReminderRequest schedules a private reminder about a message
+type CreateMessageReminderRequest struct {
+	RemindAt string `json:"remind_at" example:"2024-01-15T14:00:00Z"` // RFC 3339, 1 minute to 30 days ahead
+	Note     string `json:"note,omitempty" example:"Reply to this"`
+}
+
+// CreateCustomEmojiRequest adds an uploaded image to the chat's emoji set
+type CreateCustomEmojiRequest struct {
+	Name       string `json:"name" example:"party_parrot"`                                  // Used in reactions as ":name:"
+	FileLinkID string `json:"file_link_id" example:"550e8400-e29b-41d4-a716-446655440000"` // PNG, GIF, WebP or JPEG up to 256 KB
+}
+
+// SaveDraftRequest stores the unsent message of the chat or of one of its threads
+type SaveDraftRequest struct {
+	ThreadID   *string  `json:"thread_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Omit for the main chat
+	Content    string   `json:"content" example:"Let me check and get back"`                        // Empty with no replies deletes the draft
+	ReplyToIDs []string `json:"reply_to_ids,omitempty"`
+}
+
+// RestrictUserRequest represents a chat ban or mute
+type RestrictUserRequest struct {
+	UserID          string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Reason          string `json:"reason,omitempty" example:"Flooding"`
+	DurationSeconds int64  `json:"duration_seconds,omitempty" example:"3600"` // 0 = permanent (bans only)
+}
+
+// BulkDeleteMessagesRequest represents a bulk delete by ID list or by sender within a time window
+type BulkDeleteMessagesRequest struct {
+	MessageIDs []string `json:"message_ids,omitempty"`
+	SenderID   string   `json:"sender_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From       string   `json:"from,omitempty" example:"2024-01-15T10:00:00Z"`
+	To         string   `json:"to,omitempty" example:"2024-01-15T12:00:00Z"`
+}
+
+// BulkDeleteMessagesResponse represents the result of a bulk delete
+type BulkDeleteMessagesResponse struct {
+	DeletedMessageIDs []string `json:"deleted_message_ids"`
+	DeletedCount      int      `json:"deleted_count" example:"42"`
+	RestorableUntil   string   `json:"restorable_until" example:"2024-02-14T10:00:00Z"`
+}
+
+// MoveMessagesRequest represents a request to move messages into a thread
+type MoveMessagesRequest struct {
+	MessageIDs     []string `json:"message_ids"`
+	TargetThreadID string   `json:"target_thread_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	NewThreadTitle string   `json:"new_thread_title,omitempty" example:"Deployment discussion"`
+}
+
+// MoveMessagesResponse represents the result of moving messages
+type MoveMessagesResponse struct {
+	Thread     ThreadResponse `json:"thread"`
+	MovedCount int            `json:"moved_count" example:"5"`
+}
+
 // ThreadResponse represents a thread
 type ThreadResponse struct {
 	ID           string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -626,3 +772,10 @@ type ChatFilesResponse struct {
 	Files []ChatFileResponse `json:"files"`
 	Total int                `json:"total" example:"25"`
 }
+
+// EventsPollResponse represents a batch of realtime events returned by long-polling
+type EventsPollResponse struct {
+	Events    []json.RawMessage `json:"events" swaggertype:"array,object"`
+	Cursor    string            `json:"cursor" example:"chat:6ba7b810-9dad-11d1-80b4-00c04fd430c8=7:XyZw,user:550e8400-e29b-41d4-a716-446655440000=42:AbCd"`
+	Recovered bool              `json:"recovered" example:"true"`
+}
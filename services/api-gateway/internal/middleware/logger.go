@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	incomingWebhookPrefix = "/api/hooks/"
+	redacted              = "REDACTED"
+)
+
+// Logger logs requests like chi's middleware.Logger, with secrets removed from the logged URL:
+// the token of incoming webhooks posted to /api/hooks/{webhookId}/{token} and the access token
+// passed as _token
+var Logger = chimiddleware.RequestLogger(redactingLogFormatter{
+	LogFormatter: &chimiddleware.DefaultLogFormatter{Logger: log.New(os.Stdout, "", log.LstdFlags)},
+})
+
+type redactingLogFormatter struct {
+	chimiddleware.LogFormatter
+}
+
+func (f redactingLogFormatter) NewLogEntry(r *http.Request) chimiddleware.LogEntry {
+	if uri := redactURI(r.RequestURI); uri != r.RequestURI {
+		r = r.WithContext(r.Context())
+		r.RequestURI = uri
+	}
+	return f.LogFormatter.NewLogEntry(r)
+}
+
+// redactURI replaces the secrets in a request URI
+func redactURI(uri string) string {
+	path, query, hasQuery := strings.Cut(uri, "?")
+
+	if rest, ok := strings.CutPrefix(path, incomingWebhookPrefix); ok {
+		if webhookID, _, hasToken := strings.Cut(rest, "/"); hasToken {
+			path = incomingWebhookPrefix + webhookID + "/" + redacted
+		}
+	}
+
+	if hasQuery && strings.Contains(query, "_token=") {
+		values, err := url.ParseQuery(query)
+		switch {
+		case err != nil:
+			query = redacted
+		case values.Has("_token"):
+			values.Set("_token", redacted)
+			query = values.Encode()
+		}
+	}
+
+	if !hasQuery {
+		return path
+	}
+	return path + "?" + query
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{"regular request", "/api/chats?limit=20", "/api/chats?limit=20"},
+		{"webhook with the token in a header", "/api/hooks/550e8400", "/api/hooks/550e8400"},
+		{"webhook token in the path", "/api/hooks/550e8400/ihk_secret", "/api/hooks/550e8400/REDACTED"},
+		{"webhook token and query", "/api/hooks/550e8400/ihk_secret?x=1", "/api/hooks/550e8400/REDACTED?x=1"},
+		{"access token in the query", "/api/events/stream?_token=eyJ.secret&last_event_id=1", "/api/events/stream?_token=REDACTED&last_event_id=1"},
+		{"other token parameter", "/api/files?x_token=1", "/api/files?x_token=1"},
+		{"malformed query with a token", "/api/events/stream?_token=eyJ%zz", "/api/events/stream?REDACTED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactURI(tt.uri))
+		})
+	}
+}
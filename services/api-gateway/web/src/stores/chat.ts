@@ -2,7 +2,7 @@ import { defineStore } from 'pinia'
 import { ref, computed } from 'vue'
 import type { Chat, Message, Participant, CreateChatRequest, SendMessageRequest } from '@/types'
 import { api, ApiError } from '@/api/client'
-import { Centrifuge, Subscription } from 'centrifuge'
+import { Centrifuge, Subscription, UnauthorizedError } from 'centrifuge'
 import { useAuthStore } from './auth'
 import { usePresenceStore } from './presence'
 import { useNetworkStore } from './network'
@@ -10,6 +10,7 @@ import { useVoiceStore } from './voice'
 
 // Event types from websocket-service
 interface ChatEvent {
+  id?: string // Unique event ID, the same on every redelivery
   type: string
   timestamp: string
   actor_id: string
@@ -17,6 +18,9 @@ interface ChatEvent {
   data: unknown
 }
 
+// How many recent event IDs are remembered to drop redelivered events
+const SEEN_EVENT_IDS_LIMIT = 1000
+
 // Storage key for seq_num persistence
 const SEQ_NUM_STORAGE_KEY = 'chat_seq_nums'
 
@@ -42,9 +46,27 @@ export const useChatStore = defineStore('chat', () => {
 
   let centrifuge: Centrifuge | null = null
   let userSubscription: Subscription | null = null
+  // chat:{id} subscriptions. Chat-wide events are published there when websocket-service runs
+  // with CHAT_EVENTS_DELIVERY=chat, voice conference events in any mode.
+  const chatSubscriptions = new Map<string, Subscription>()
 
   const TYPING_DISPLAY_DURATION = 5000 // Hide typing indicator after 5 seconds
 
+  // IDs of handled events. websocket-service may deliver an event twice after a partially
+  // failed broadcast, so each event is applied once.
+  const seenEventIds = new Set<string>()
+
+  function isDuplicateEvent(id: string | undefined): boolean {
+    if (!id) return false
+    if (seenEventIds.has(id)) return true
+    seenEventIds.add(id)
+    if (seenEventIds.size > SEEN_EVENT_IDS_LIMIT) {
+      // Sets iterate in insertion order, so this drops the oldest ID
+      seenEventIds.delete(seenEventIds.values().next().value as string)
+    }
+    return false
+  }
+
   // Load seq_nums from localStorage on init
   function loadSeqNumsFromStorage() {
     try {
@@ -134,6 +156,7 @@ export const useChatStore = defineStore('chat', () => {
         // Subscribe to user's personal channel
         // Recovery will happen automatically via subscription's 'subscribed' event
         subscribeToUserChannel(authStore.user!.id)
+        subscribeToChatChannels()
 
         // Process any pending messages
         networkStore.processPendingMessages()
@@ -212,6 +235,62 @@ export const useChatStore = defineStore('chat', () => {
     console.log('Subscribing to user channel:', channel)
   }
 
+  function subscribeToChatChannels() {
+    chats.value.forEach((chat) => subscribeToChatChannel(chat.id))
+  }
+
+  function subscribeToChatChannel(chatId: string) {
+    if (!centrifuge || chatSubscriptions.has(chatId)) return
+
+    const channel = `chat:${chatId}`
+    const subscription = centrifuge.newSubscription(channel, {
+      getToken: async () => {
+        try {
+          const { token } = await api.getCentrifugoSubscriptionToken(channel)
+          return token
+        } catch (e) {
+          // No longer a participant - stop resubscribing
+          if (e instanceof ApiError && e.status === 403) {
+            throw new UnauthorizedError('not a chat participant')
+          }
+          throw e
+        }
+      },
+      recoverable: true,
+    })
+
+    subscription.on('publication', (ctx) => {
+      handleCentrifugoEvent(ctx.data as ChatEvent)
+    })
+
+    subscription.on('subscribed', (ctx) => {
+      if (ctx.wasRecovering && !ctx.recovered) {
+        console.warn('Recovery failed for chat channel, syncing via API...', channel)
+        syncMessagesAfterReconnect(chatId)
+      }
+    })
+
+    // Unsubscribed by the server after removal from the chat, or refused a token
+    subscription.on('unsubscribed', () => {
+      unsubscribeFromChatChannel(chatId)
+    })
+
+    chatSubscriptions.set(chatId, subscription)
+    subscription.subscribe()
+  }
+
+  function unsubscribeFromChatChannel(chatId: string) {
+    const subscription = chatSubscriptions.get(chatId)
+    if (!subscription) return
+    chatSubscriptions.delete(chatId)
+    subscription.removeAllListeners()
+    centrifuge?.removeSubscription(subscription)
+  }
+
+  function unsubscribeFromChatChannels() {
+    Array.from(chatSubscriptions.keys()).forEach(unsubscribeFromChatChannel)
+  }
+
   function unsubscribeFromUserChannel() {
     if (userSubscription) {
       userSubscription.unsubscribe()
@@ -220,6 +299,10 @@ export const useChatStore = defineStore('chat', () => {
   }
 
   function handleCentrifugoEvent(event: ChatEvent) {
+    if (isDuplicateEvent(event.id)) {
+      console.log('Dropping duplicate event:', event.type, event.id)
+      return
+    }
     console.log('Received event:', event.type, event)
 
     switch (event.type) {
@@ -247,6 +330,12 @@ export const useChatStore = defineStore('chat', () => {
       case 'chat.deleted':
         handleChatDeleted(event.chat_id)
         break
+      case 'chat.member_added':
+        handleMemberAdded(event.chat_id, (event.data as { user_id: string }).user_id)
+        break
+      case 'chat.member_removed':
+        handleMemberRemoved(event.chat_id, (event.data as { user_id: string }).user_id)
+        break
       case 'reaction.added':
         handleReactionAdded(event.data as { message_id: string; emoji: string; user_id: string })
         break
@@ -361,6 +450,7 @@ export const useChatStore = defineStore('chat', () => {
     if (!exists) {
       chats.value.unshift(chatData)
     }
+    subscribeToChatChannel(chatData.id)
   }
 
   function handleChatUpdate(chat: Chat) {
@@ -374,6 +464,7 @@ export const useChatStore = defineStore('chat', () => {
   }
 
   function handleChatDeleted(chatId: string) {
+    unsubscribeFromChatChannel(chatId)
     const index = chats.value.findIndex((c) => c.id === chatId)
     if (index !== -1) {
       chats.value.splice(index, 1)
@@ -385,6 +476,29 @@ export const useChatStore = defineStore('chat', () => {
     }
   }
 
+  async function handleMemberAdded(chatId: string, userId: string) {
+    if (userId !== useAuthStore().user?.id) {
+      return
+    }
+    // Added to an existing chat: it is not in our list and we are not subscribed to it yet
+    try {
+      handleNewChat(await api.getChat(chatId))
+    } catch (e) {
+      console.error('Failed to load chat after being added:', e)
+    }
+  }
+
+  function handleMemberRemoved(chatId: string, userId: string) {
+    // The server has already ended our chat channel subscription; the chat is gone for us
+    if (userId === useAuthStore().user?.id) {
+      handleChatDeleted(chatId)
+      return
+    }
+    if (currentChat.value?.id === chatId) {
+      participants.value = participants.value.filter(p => p.user_id !== userId)
+    }
+  }
+
   function handleReactionAdded(data: { message_id: string; emoji: string; user_id: string }) {
     const message = messages.value.find((m) => m.id === data.message_id)
     if (message) {
@@ -442,8 +556,7 @@ export const useChatStore = defineStore('chat', () => {
       chatsCursor.value = result.next_cursor || ''
       chatsHasMore.value = result.has_more || false
       chatsTotal.value = result.total || 0
-      // No need to subscribe to individual chat channels anymore
-      // Events come through user's personal channel
+      subscribeToChatChannels()
     } catch (e) {
       error.value = e instanceof ApiError ? e.message : 'Failed to fetch chats'
     } finally {
@@ -464,6 +577,7 @@ export const useChatStore = defineStore('chat', () => {
       for (const chat of newChats) {
         if (!chats.value.some(c => c.id === chat.id)) {
           chats.value.push(chat)
+          subscribeToChatChannel(chat.id)
         }
       }
       chatsCursor.value = result.next_cursor || ''
@@ -848,6 +962,7 @@ export const useChatStore = defineStore('chat', () => {
 
   function cleanup() {
     unsubscribeFromUserChannel()
+    unsubscribeFromChatChannels()
     if (centrifuge) {
       centrifuge.disconnect()
       centrifuge = null
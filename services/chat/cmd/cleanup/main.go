@@ -175,8 +175,18 @@ func main() {
 		time.Sleep(100 * time.Millisecond)
 	}
 
+	// Prune the chat list change log; clients with an older version will do a full reload
+	var totalChanges int64
+	if !cfg.DryRun {
+		totalChanges, err = chatRepo.DeleteChatListChangesOlderThan(ctx, cutoffTime)
+		if err != nil {
+			logger.Error("failed to prune chat list changes", zap.Error(err))
+		}
+	}
+
 	logger.Info("cleanup job completed",
 		zap.Int64("messages_deleted", totalMessages),
+		zap.Int64("chat_list_changes_deleted", totalChanges),
 		zap.Int64("file_links_deleted", totalFileLinks),
 		zap.Int64("files_deleted", totalFilesDeleted),
 		zap.Bool("dry_run", cfg.DryRun),
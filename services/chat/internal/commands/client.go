@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+	"github.com/icegreg/chat-smpl/services/chat/internal/safehttp"
+	"github.com/icegreg/chat-smpl/services/chat/internal/webhook"
+	"go.uber.org/zap"
+)
+
+// HeaderSignature carries the request signature, in the same format as outgoing webhooks
+const HeaderSignature = "X-Command-Signature"
+
+// Request types sent to bot endpoints
+const (
+	RequestCommand     = "command"
+	RequestInteraction = "interaction"
+)
+
+// maxResponseBytes limits the size of a bot response
+const maxResponseBytes = 64 << 10
+
+// Request is POSTed to the bot endpoint for a command invocation or a button click
+type Request struct {
+	Type      string `json:"type"`
+	CommandID string `json:"command_id"`
+	Command   string `json:"command"`
+	Text      string `json:"text,omitempty"` // Command arguments
+	ChatID    string `json:"chat_id"`
+	ThreadID  string `json:"thread_id,omitempty"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username,omitempty"`
+	MessageID string `json:"message_id,omitempty"` // Interactions: message whose button was clicked
+	ActionID  string `json:"action_id,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// Response is the bot's answer. An empty body (or 204) means no reply.
+type Response struct {
+	Text            string                `json:"text"`
+	Ephemeral       bool                  `json:"ephemeral,omitempty"` // Only shown to the invoker
+	Buttons         []model.MessageButton `json:"buttons,omitempty"`
+	ReplaceOriginal bool                  `json:"replace_original,omitempty"` // Interactions: edit the clicked message instead of posting
+}
+
+// Error is a failure of the bot endpoint. Its text is safe to show to the invoking user, who
+// is not necessarily the bot's owner; transport details are only logged.
+type Error struct {
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return e.Reason
+}
+
+func endpointError(format string, args ...any) error {
+	return &Error{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Client calls bot endpoints
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(timeout time.Duration) *Client {
+	return &Client{
+		http: safehttp.NewClient(timeout),
+	}
+}
+
+// Send POSTs a signed request to the bot endpoint and returns its response (nil if it has none).
+// Failures of the endpoint are returned as *Error.
+func (c *Client) Send(ctx context.Context, url, secret string, req Request) (*Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode command request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create command request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "chat-smpl-commands/1.0")
+	httpReq.Header.Set(HeaderSignature, webhook.Sign(secret, time.Now(), body))
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		logger.Debug("bot command request failed", zap.Error(err), zap.String("command_id", req.CommandID))
+		return nil, endpointError("bot endpoint unreachable: %s", safehttp.Describe(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, endpointError("bot endpoint returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		logger.Debug("failed to read bot response", zap.Error(err), zap.String("command_id", req.CommandID))
+		return nil, endpointError("failed to read bot response: %s", safehttp.Describe(err))
+	}
+	if len(data) > maxResponseBytes {
+		return nil, endpointError("bot response exceeds %d bytes", maxResponseBytes)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var out Response
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, endpointError("invalid bot response")
+	}
+	return &out, nil
+}
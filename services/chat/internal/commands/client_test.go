@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Send(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *Response
+		wantErr string
+	}{
+		{name: "reply", status: http.StatusOK, body: `{"text":"done","ephemeral":true}`, want: &Response{Text: "done", Ephemeral: true}},
+		{name: "no content", status: http.StatusNoContent},
+		{name: "empty body", status: http.StatusOK, body: "  \n"},
+		{name: "bad status", status: http.StatusBadGateway, body: "upstream db at 10.0.0.5 down", wantErr: "bot endpoint returned status 502"},
+		{name: "invalid json", status: http.StatusOK, body: "<html>", wantErr: "invalid bot response"},
+		{name: "too large", status: http.StatusOK, body: strings.Repeat("x", maxResponseBytes+1), wantErr: "bot response exceeds 65536 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NotEmpty(t, r.Header.Get(HeaderSignature))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			// The address-checking client refuses the loopback test server
+			c := &Client{http: srv.Client()}
+			resp, err := c.Send(context.Background(), srv.URL, "secret", Request{Type: RequestCommand, Command: "deploy", Text: "prod"})
+
+			assert.Equal(t, "deploy", got.Command)
+			if tt.wantErr != "" {
+				var endpointErr *Error
+				require.True(t, errors.As(err, &endpointErr))
+				assert.Equal(t, tt.wantErr, endpointErr.Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
+
+func TestClient_SendRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the internal server")
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(time.Second).Send(context.Background(), srv.URL, "secret", Request{Type: RequestCommand})
+
+	var endpointErr *Error
+	require.True(t, errors.As(err, &endpointErr))
+	assert.Equal(t, "bot endpoint unreachable: destination address is not allowed", endpointErr.Reason)
+}
@@ -0,0 +1,87 @@
+// Package commands parses slash commands ("/name args") and calls external bot endpoints.
+//
+// Built-in commands are implemented by chat-service itself. Commands registered by bots are
+// POSTed to the bot's URL as a signed Request; the bot answers with a Response that is posted
+// into the chat as the bot, or shown only to the invoker when it is ephemeral. Button clicks on
+// messages posted by a command come back to the same endpoint as "interaction" requests.
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// IsValidName reports whether name can be registered as a command (without the leading slash)
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Parse splits a message into command name and arguments. Returns ok=false for messages that are
+// not commands. A message starting with "//" is not a command either; see Unescape.
+func Parse(content string) (name, args string, ok bool) {
+	if !strings.HasPrefix(content, "/") || strings.HasPrefix(content, "//") {
+		return "", "", false
+	}
+	head, rest, _ := strings.Cut(content[1:], " ")
+	name = strings.ToLower(head)
+	if !IsValidName(name) {
+		return "", "", false
+	}
+	return name, strings.TrimSpace(rest), true
+}
+
+// Unescape turns "//text" into "/text" so that users can send messages starting with a slash
+func Unescape(content string) string {
+	if strings.HasPrefix(content, "//") {
+		return content[1:]
+	}
+	return content
+}
+
+// SplitArgs splits command arguments on spaces, keeping "double quoted" parts together
+func SplitArgs(args string) []string {
+	var result []string
+	var cur strings.Builder
+	inQuotes, hasToken := false, false
+	for _, r := range args {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case r == ' ' && !inQuotes:
+			if hasToken {
+				result = append(result, cur.String())
+				cur.Reset()
+				hasToken = false
+			}
+		default:
+			cur.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		result = append(result, cur.String())
+	}
+	return result
+}
+
+// ParseDuration parses a reminder delay such as "90s", "15m", "2h30m" or "3d"
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		content string
+		name    string
+		args    string
+		ok      bool
+	}{
+		{"/remind 10m stand-up", "remind", "10m stand-up", true},
+		{"/Poll", "poll", "", true},
+		{"/poll   \"a b\" c  ", "poll", "\"a b\" c", true},
+		{"/my_bot-cmd x", "my_bot-cmd", "x", true},
+		{"hello /remind", "", "", false},
+		{"//remind 10m", "", "", false},
+		{"/", "", "", false},
+		{"/ remind", "", "", false},
+		{"/_remind", "", "", false},
+		{"/rem!nd", "", "", false},
+		{"/" + strings.Repeat("a", 33), "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			name, args, ok := Parse(tt.content)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	assert.Equal(t, "/remind is a command", Unescape("//remind is a command"))
+	assert.Equal(t, "/remind", Unescape("/remind"))
+	assert.Equal(t, "text", Unescape("text"))
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		args string
+		want []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"a b  c", []string{"a", "b", "c"}},
+		{`"Lunch place?" Pizza "Sushi bar"`, []string{"Lunch place?", "Pizza", "Sushi bar"}},
+		{`"" x`, []string{"", "x"}},
+		{`a"b c"d`, []string{"ab cd"}},
+		{`"unterminated quote`, []string{"unterminated quote"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			assert.Equal(t, tt.want, SplitArgs(tt.args))
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{s: "90s", want: 90 * time.Second},
+		{s: "15m", want: 15 * time.Minute},
+		{s: "2h30m", want: 2*time.Hour + 30*time.Minute},
+		{s: "3d", want: 72 * time.Hour},
+		{s: "0d", wantErr: true},
+		{s: "-1d", wantErr: true},
+		{s: "1.5d", wantErr: true},
+		{s: "0s", wantErr: true},
+		{s: "-5m", wantErr: true},
+		{s: "tomorrow", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDuration(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package emoji validates reaction codes.
+//
+// A reaction is either a Unicode emoji (including ZWJ sequences, skin tones, flags and keycaps)
+// or a custom emoji of the chat written as ":name:". Custom emoji images are stored in
+// files-service; chat-service only keeps the name to file link mapping.
+package emoji
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxUnicodeBytes limits the length of a Unicode reaction (long ZWJ family sequences fit)
+const MaxUnicodeBytes = 32
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]{2,32}$`)
+
+const (
+	zeroWidthJoiner = 0x200D
+	keycap          = 0x20E3
+)
+
+// IsValidName reports whether name can be used for a custom emoji (without the colons)
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// ParseCustom returns the custom emoji name of a ":name:" reaction
+func ParseCustom(reaction string) (name string, ok bool) {
+	if len(reaction) < 2 || !strings.HasPrefix(reaction, ":") || !strings.HasSuffix(reaction, ":") {
+		return "", false
+	}
+	name = reaction[1 : len(reaction)-1]
+	if !IsValidName(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// Code formats a custom emoji name as a reaction code
+func Code(name string) string {
+	return ":" + name + ":"
+}
+
+// IsUnicode reports whether reaction looks like a single Unicode emoji. The check is
+// structural rather than a full emoji table: it rejects text (letters, digits, spaces,
+// punctuation and control characters) while allowing every emoji block and the modifiers
+// used in sequences.
+func IsUnicode(reaction string) bool {
+	if reaction == "" || len(reaction) > MaxUnicodeBytes || !utf8.ValidString(reaction) {
+		return false
+	}
+	isKeycap := strings.HasSuffix(reaction, string(rune(keycap)))
+	hasEmoji := false
+	for _, r := range reaction {
+		switch {
+		case r == zeroWidthJoiner, r == keycap, unicode.Is(unicode.Variation_Selector, r):
+			// Sequence glue, valid only around emoji
+		case isKeycap && (r == '#' || r == '*' || (r >= '0' && r <= '9')):
+			hasEmoji = true
+		case r == 0xA9 || r == 0xAE: // © ®
+			hasEmoji = true
+		case r >= 0x2000 && (unicode.IsSymbol(r) || unicode.Is(unicode.Regional_Indicator, r) || isEmojiPunct(r)):
+			hasEmoji = true
+		default:
+			return false
+		}
+	}
+	return hasEmoji
+}
+
+// isEmojiPunct covers emoji that Unicode classifies as punctuation (‼ ⁉ 〰 〽)
+func isEmojiPunct(r rune) bool {
+	return r == 0x203C || r == 0x2049 || r == 0x3030 || r == 0x303D
+}
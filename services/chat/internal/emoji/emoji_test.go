@@ -0,0 +1,79 @@
+package emoji
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUnicode(t *testing.T) {
+	tests := []struct {
+		name     string
+		reaction string
+		want     bool
+	}{
+		{"simple emoji", "👍", true},
+		{"emoji with variation selector", "❤️", true},
+		{"skin tone", "👍🏽", true},
+		{"zwj family", "👨‍👩‍👧‍👦", true},
+		{"zwj profession with skin tone", "👩🏾‍💻", true},
+		{"flag", "🇫🇷", true},
+		{"keycap digit", "1️⃣", true},
+		{"keycap hash", "#️⃣", true},
+		{"copyright", "©️", true},
+		{"registered", "®", true},
+		{"double exclamation", "‼️", true},
+		{"symbol", "⭐", true},
+
+		{"empty", "", false},
+		{"letter", "a", false},
+		{"word", "ok", false},
+		{"digit", "1", false},
+		{"hash without keycap", "#", false},
+		{"space", " ", false},
+		{"emoji with space", "👍 ", false},
+		{"emoji with text", "👍ok", false},
+		{"cjk", "好", false},
+		{"cyrillic", "д", false},
+		{"punctuation", "!", false},
+		{"zwj alone", "\u200d", false},
+		{"variation selector alone", "\ufe0f", false},
+		{"control character", "\n", false},
+		{"invalid utf-8", "\xff", false},
+		{"too long", strings.Repeat("👍", MaxUnicodeBytes/4+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsUnicode(tt.reaction))
+		})
+	}
+}
+
+func TestParseCustom(t *testing.T) {
+	tests := []struct {
+		reaction string
+		name     string
+		ok       bool
+	}{
+		{":party_parrot:", "party_parrot", true},
+		{":ok:", "ok", true},
+		{"party_parrot", "", false},
+		{":party_parrot", "", false},
+		{"::", "", false},
+		{":a:", "", false},
+		{":Party:", "", false},
+		{":with space:", "", false},
+		{":" + strings.Repeat("a", 33) + ":", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reaction, func(t *testing.T) {
+			name, ok := ParseCustom(tt.reaction)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.name, name)
+			if ok {
+				assert.Equal(t, tt.reaction, Code(name))
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	sharedevents "github.com/icegreg/chat-smpl/pkg/events"
 	"github.com/icegreg/chat-smpl/pkg/logger"
 	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
 	"github.com/icegreg/chat-smpl/services/chat/internal/model"
@@ -13,111 +14,118 @@ import (
 )
 
 const (
-	ExchangeName = "chat.events"
-
-	RoutingKeyChatCreated     = "chat.created"
-	RoutingKeyChatUpdated     = "chat.updated"
-	RoutingKeyChatDeleted     = "chat.deleted"
-	RoutingKeyMessageCreated  = "message.created"
-	RoutingKeyMessageUpdated  = "message.updated"
-	RoutingKeyMessageDeleted  = "message.deleted"
-	RoutingKeyMessageRestored = "message.restored"
-	RoutingKeyTyping          = "typing"
-	RoutingKeyReactionAdded   = "reaction.added"
-	RoutingKeyReactionRemoved = "reaction.removed"
-	RoutingKeyThreadCreated   = "thread.created"
-	RoutingKeyThreadArchived  = "thread.archived"
+	publishChannels   = 4    // Concurrent confirm-mode publishes
+	publishBufferSize = 1000 // Events kept in memory while disconnected
 )
 
-// ChatEvent is the unified event structure for websocket-service consumption
-type ChatEvent struct {
-	Type         string      `json:"type"`
-	Timestamp    time.Time   `json:"timestamp"`
-	ActorID      string      `json:"actor_id"`
-	ChatID       string      `json:"chat_id"`
-	Participants []string    `json:"participants"`
-	Data         interface{} `json:"data"`
-}
-
-// Data payloads for events
-
-type ChatData struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	ChatType  string `json:"chat_type"`
-	CreatedBy string `json:"created_by"`
-}
-
-type MessageData struct {
-	ID                string   `json:"id"`
-	ChatID            string   `json:"chat_id"`
-	SenderID          string   `json:"sender_id"`
-	Content           string   `json:"content"`
-	SentAt            string   `json:"sent_at"`
-	UpdatedAt         *string  `json:"updated_at,omitempty"`
-	ParentID          *string  `json:"parent_id,omitempty"`
-	SenderUsername    *string  `json:"sender_username,omitempty"`
-	SenderDisplayName *string  `json:"sender_display_name,omitempty"`
-	SenderAvatarURL   *string  `json:"sender_avatar_url,omitempty"`
-	FileLinkIDs       []string `json:"file_link_ids,omitempty"`
-}
-
-type MessageDeletedData struct {
-	MessageID           string `json:"message_id"`
-	ChatID              string `json:"chat_id"`
-	IsModeratedDeletion bool   `json:"is_moderated_deletion"`
-}
-
-type MessageRestoredData struct {
-	MessageID string `json:"message_id"`
-	ChatID    string `json:"chat_id"`
-	SenderID  string `json:"sender_id"`
-	Content   string `json:"content"`
-}
+const (
+	ExchangeName = "chat.events"
 
-type TypingData struct {
-	IsTyping bool   `json:"is_typing"`
-	UserID   string `json:"user_id"`
-}
+	RoutingKeyChatCreated        = "chat.created"
+	RoutingKeyChatUpdated        = "chat.updated"
+	RoutingKeyChatDeleted        = "chat.deleted"
+	RoutingKeyChatMemberAdded    = "chat.member_added"
+	RoutingKeyChatMemberRemoved  = "chat.member_removed"
+	RoutingKeyMessageCreated     = "message.created"
+	RoutingKeyMessageUpdated     = "message.updated"
+	RoutingKeyMessageDeleted     = "message.deleted"
+	RoutingKeyMessageRestored    = "message.restored"
+	RoutingKeyMessageMoved       = "message.moved"
+	RoutingKeyMessageBulkDeleted = "message.bulk_deleted"
+	RoutingKeyMessageEphemeral   = "message.ephemeral"
+	RoutingKeyTyping             = "typing"
+	RoutingKeyReactionAdded      = "reaction.added"
+	RoutingKeyReactionRemoved    = "reaction.removed"
+	RoutingKeyThreadCreated      = "thread.created"
+	RoutingKeyThreadArchived     = "thread.archived"
+	RoutingKeyThreadReply        = "thread.reply"
+	RoutingKeyReportCreated      = "report.created"
+	RoutingKeyDraftUpdated       = "draft.updated"
+)
 
-type ReactionData struct {
-	MessageID string `json:"message_id"`
-	Emoji     string `json:"emoji"`
-	UserID    string `json:"user_id"`
-}
+// Reasons of chat.member_removed
+const (
+	MemberRemovedLeft    = "left"
+	MemberRemovedRemoved = "removed"
+	MemberRemovedBanned  = "banned"
+)
 
-type ThreadData struct {
-	ID                     string  `json:"id"`
-	ChatID                 string  `json:"chat_id"`
-	ParentMessageID        *string `json:"parent_message_id,omitempty"`
-	ThreadType             string  `json:"thread_type"`
-	Title                  *string `json:"title,omitempty"`
-	MessageCount           int     `json:"message_count"`
-	CreatedBy              *string `json:"created_by,omitempty"`
-	IsArchived             bool    `json:"is_archived"`
-	RestrictedParticipants bool    `json:"restricted_participants"`
+// chatWideEvents are sent to every participant of the chat. Events of a large chat are published
+// without the participant list (see Config.CompactMinRecipients).
+var chatWideEvents = map[string]bool{
+	RoutingKeyChatUpdated:        true,
+	RoutingKeyMessageCreated:     true,
+	RoutingKeyMessageUpdated:     true,
+	RoutingKeyMessageDeleted:     true,
+	RoutingKeyMessageRestored:    true,
+	RoutingKeyMessageMoved:       true,
+	RoutingKeyMessageBulkDeleted: true,
+	RoutingKeyTyping:             true,
+	RoutingKeyReactionAdded:      true,
+	RoutingKeyReactionRemoved:    true,
+	RoutingKeyThreadCreated:      true,
+	RoutingKeyThreadArchived:     true,
+}
+
+// Config configures the event publisher
+type Config struct {
+	// CompactMinRecipients is the chat size from which chat-wide events are published without
+	// the participant list; websocket-service resolves the participants itself. 0 always sends
+	// the list.
+	CompactMinRecipients int
+	// TypingInterval is how long a repeated typing state of a user in a chat is dropped instead
+	// of published. 0 publishes every signal.
+	TypingInterval time.Duration
+}
+
+// DefaultConfig returns the default publisher configuration
+func DefaultConfig() Config {
+	return Config{
+		CompactMinRecipients: 500,
+		TypingInterval:       3 * time.Second,
+	}
+}
+
+// chatEvent is an event before it is wrapped in a sharedevents.Envelope
+type chatEvent struct {
+	Type         string
+	ActorID      string
+	ChatID       string
+	Participants []string
+	Data         any // Payload type registered in pkg/events for Type
 }
 
 type Publisher interface {
 	PublishChatCreated(ctx context.Context, chat *model.Chat, participants []uuid.UUID) error
 	PublishChatUpdated(ctx context.Context, chat *model.Chat, actorID uuid.UUID, participants []uuid.UUID) error
 	PublishChatDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, participants []uuid.UUID) error
+	PublishChatMemberAdded(ctx context.Context, participant *model.ChatParticipant, addedBy uuid.UUID, participants []uuid.UUID) error
+	PublishChatMemberRemoved(ctx context.Context, chatID, userID, removedBy uuid.UUID, reason string, participants []uuid.UUID) error
 	PublishMessageCreated(ctx context.Context, message *model.Message, participants []uuid.UUID) error
 	PublishMessageUpdated(ctx context.Context, message *model.Message, participants []uuid.UUID) error
 	PublishMessageDeleted(ctx context.Context, messageID, chatID, deletedBy uuid.UUID, isModeratedDeletion bool, participants []uuid.UUID) error
 	PublishMessageRestored(ctx context.Context, message *model.Message) error
+	PublishMessagesMoved(ctx context.Context, chatID, threadID, movedBy uuid.UUID, moved map[uuid.UUID]*uuid.UUID, participants []uuid.UUID) error
+	PublishMessagesBulkDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, senderID *uuid.UUID, messageIDs []uuid.UUID, participants []uuid.UUID) error
 	PublishTyping(ctx context.Context, chatID, userID uuid.UUID, isTyping bool, participants []uuid.UUID) error
 	PublishReactionAdded(ctx context.Context, messageID, chatID, userID uuid.UUID, emoji string, participants []uuid.UUID) error
 	PublishReactionRemoved(ctx context.Context, messageID, chatID, userID uuid.UUID, emoji string, participants []uuid.UUID) error
 	PublishThreadCreated(ctx context.Context, thread *model.Thread, participants []uuid.UUID) error
 	PublishThreadArchived(ctx context.Context, thread *model.Thread, archivedBy uuid.UUID, participants []uuid.UUID) error
+	PublishThreadReply(ctx context.Context, thread *model.Thread, message *model.Message, followers []uuid.UUID) error
+	PublishReportCreated(ctx context.Context, report *model.MessageReport, moderators []uuid.UUID) error
+	PublishEphemeralMessage(ctx context.Context, message *model.Message, userID uuid.UUID) error
+	PublishDraftUpdated(ctx context.Context, draft *model.Draft, deleted bool) error
 }
 
 type publisher struct {
 	rmqPublisher *rabbitmq.Publisher
+	signals      *rabbitmq.Publisher
+	typing       *typingCoalescer
+	cfg          Config
 }
 
-func NewPublisher(conn *rabbitmq.Connection) (Publisher, error) {
+func NewPublisher(conn *rabbitmq.Connection, cfg Config) (Publisher, error) {
 	// Declare exchange
 	err := conn.DeclareExchange(rabbitmq.Exchange{
 		Name:       ExchangeName,
@@ -131,8 +139,30 @@ func NewPublisher(conn *rabbitmq.Connection) (Publisher, error) {
 		return nil, err
 	}
 
+	// The signals exchange is not durable; signals are never replayed after a broker restart
+	err = conn.DeclareExchange(rabbitmq.Exchange{
+		Name:       SignalsExchangeName,
+		Kind:       "topic",
+		Durable:    false,
+		AutoDelete: false,
+		Internal:   false,
+		NoWait:     false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are confirmed by the broker and buffered while RabbitMQ reconnects, so a failover
+	// does not silently drop them
 	return &publisher{
-		rmqPublisher: rabbitmq.NewPublisher(conn, ExchangeName),
+		rmqPublisher: rabbitmq.NewPublisher(conn, ExchangeName,
+			rabbitmq.WithConfirms(publishChannels),
+			rabbitmq.WithMandatory(),
+			rabbitmq.WithPublishBuffer(publishBufferSize),
+		),
+		signals: rabbitmq.NewPublisher(conn, SignalsExchangeName, rabbitmq.WithTransient(SignalTTL)),
+		typing:  newTypingCoalescer(cfg.TypingInterval),
+		cfg:     cfg,
 	}, nil
 }
 
@@ -144,22 +174,80 @@ func uuidSliceToStrings(ids []uuid.UUID) []string {
 	return result
 }
 
+func messageButtons(buttons []model.MessageButton) []sharedevents.MessageButton {
+	if len(buttons) == 0 {
+		return nil
+	}
+	result := make([]sharedevents.MessageButton, len(buttons))
+	for i, b := range buttons {
+		result[i] = sharedevents.MessageButton{ActionID: b.ActionID, Label: b.Label, Value: b.Value, URL: b.URL, Style: b.Style}
+	}
+	return result
+}
+
+// publish wraps the event in an envelope and publishes it as a durable event
+func (p *publisher) publish(ctx context.Context, event chatEvent) error {
+	env, err := p.envelope(event)
+	if err != nil {
+		return err
+	}
+	return p.rmqPublisher.Publish(ctx, event.Type, env)
+}
+
+// publishSignal publishes an ephemeral signal to the signals exchange. Unlike publish it never
+// waits for a confirm or buffers: a signal that cannot be sent now is worthless later.
+func (p *publisher) publishSignal(ctx context.Context, event chatEvent) error {
+	env, err := p.envelope(event)
+	if err != nil {
+		return err
+	}
+	return p.signals.Publish(ctx, event.Type, env)
+}
+
+// envelope wraps the event in an envelope keyed by chat, so consumers keep the order of events
+// within a chat
+func (p *publisher) envelope(event chatEvent) (*sharedevents.Envelope, error) {
+	env, err := sharedevents.New(sharedevents.ProducerChat, event.Type, event.Data)
+	if err != nil {
+		return nil, err
+	}
+	env.ActorID = event.ActorID
+	env.ChatID = event.ChatID
+	env.Recipients = event.Participants
+	env.PartitionKey = event.ChatID
+	if p.compact(event) {
+		env.Audience = sharedevents.AudienceChat
+		env.Recipients = nil
+	}
+	return env, nil
+}
+
+// compact reports whether the event is published without its participant list. Chat-wide events
+// with no list, such as message.restored, are always compact.
+func (p *publisher) compact(event chatEvent) bool {
+	if !chatWideEvents[event.Type] || event.ChatID == "" {
+		return false
+	}
+	n := len(event.Participants)
+	return n == 0 || (p.cfg.CompactMinRecipients > 0 && n >= p.cfg.CompactMinRecipients)
+}
+
 func (p *publisher) PublishChatCreated(ctx context.Context, chat *model.Chat, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyChatCreated,
-		Timestamp:    time.Now(),
 		ActorID:      chat.CreatedBy.String(),
 		ChatID:       chat.ID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: ChatData{
-			ID:        chat.ID.String(),
-			Name:      chat.Name,
-			ChatType:  string(chat.ChatType),
-			CreatedBy: chat.CreatedBy.String(),
+		Data: sharedevents.ChatData{
+			ID:              chat.ID.String(),
+			Name:            chat.Name,
+			ChatType:        string(chat.ChatType),
+			CreatedBy:       chat.CreatedBy.String(),
+			SlowModeSeconds: chat.SlowModeSeconds,
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyChatCreated, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish chat.created event", zap.Error(err), zap.String("chat_id", chat.ID.String()))
 		return err
 	}
@@ -169,21 +257,21 @@ func (p *publisher) PublishChatCreated(ctx context.Context, chat *model.Chat, pa
 }
 
 func (p *publisher) PublishChatUpdated(ctx context.Context, chat *model.Chat, actorID uuid.UUID, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyChatUpdated,
-		Timestamp:    time.Now(),
 		ActorID:      actorID.String(),
 		ChatID:       chat.ID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: ChatData{
-			ID:        chat.ID.String(),
-			Name:      chat.Name,
-			ChatType:  string(chat.ChatType),
-			CreatedBy: chat.CreatedBy.String(),
+		Data: sharedevents.ChatData{
+			ID:              chat.ID.String(),
+			Name:            chat.Name,
+			ChatType:        string(chat.ChatType),
+			CreatedBy:       chat.CreatedBy.String(),
+			SlowModeSeconds: chat.SlowModeSeconds,
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyChatUpdated, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish chat.updated event", zap.Error(err), zap.String("chat_id", chat.ID.String()))
 		return err
 	}
@@ -193,18 +281,17 @@ func (p *publisher) PublishChatUpdated(ctx context.Context, chat *model.Chat, ac
 }
 
 func (p *publisher) PublishChatDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyChatDeleted,
-		Timestamp:    time.Now(),
 		ActorID:      deletedBy.String(),
 		ChatID:       chatID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: map[string]string{
-			"chat_id": chatID.String(),
+		Data: sharedevents.ChatDeletedData{
+			ChatID: chatID.String(),
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyChatDeleted, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish chat.deleted event", zap.Error(err), zap.String("chat_id", chatID.String()))
 		return err
 	}
@@ -213,18 +300,70 @@ func (p *publisher) PublishChatDeleted(ctx context.Context, chatID, deletedBy uu
 	return nil
 }
 
+// PublishChatMemberAdded is sent to the participants including the new one
+func (p *publisher) PublishChatMemberAdded(ctx context.Context, participant *model.ChatParticipant, addedBy uuid.UUID, participants []uuid.UUID) error {
+	event := chatEvent{
+		Type:         RoutingKeyChatMemberAdded,
+		ActorID:      addedBy.String(),
+		ChatID:       participant.ChatID.String(),
+		Participants: uuidSliceToStrings(participants),
+		Data: sharedevents.ChatMemberAddedData{
+			ChatID: participant.ChatID.String(),
+			UserID: participant.UserID.String(),
+			Role:   string(participant.Role),
+		},
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish chat.member_added event", zap.Error(err), zap.String("chat_id", participant.ChatID.String()))
+		return err
+	}
+
+	logger.Debug("published chat.member_added event", zap.String("chat_id", participant.ChatID.String()), zap.String("user_id", participant.UserID.String()))
+	return nil
+}
+
+// PublishChatMemberRemoved is sent to the participants before the removal, so the removed user
+// gets it too. websocket-service also drops the user's subscription to the chat channel on it.
+func (p *publisher) PublishChatMemberRemoved(ctx context.Context, chatID, userID, removedBy uuid.UUID, reason string, participants []uuid.UUID) error {
+	event := chatEvent{
+		Type:         RoutingKeyChatMemberRemoved,
+		ActorID:      removedBy.String(),
+		ChatID:       chatID.String(),
+		Participants: uuidSliceToStrings(participants),
+		Data: sharedevents.ChatMemberRemovedData{
+			ChatID: chatID.String(),
+			UserID: userID.String(),
+			Reason: reason,
+		},
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish chat.member_removed event", zap.Error(err), zap.String("chat_id", chatID.String()))
+		return err
+	}
+
+	logger.Debug("published chat.member_removed event", zap.String("chat_id", chatID.String()), zap.String("user_id", userID.String()))
+	return nil
+}
+
 func (p *publisher) PublishMessageCreated(ctx context.Context, message *model.Message, participants []uuid.UUID) error {
-	msgData := MessageData{
+	msgData := sharedevents.MessageData{
 		ID:       message.ID.String(),
 		ChatID:   message.ChatID.String(),
 		SenderID: message.SenderID.String(),
 		Content:  message.Content,
 		SentAt:   message.SentAt.Format(time.RFC3339),
+		IsSystem: message.IsSystem,
 	}
 	if message.ParentID != nil {
 		parentStr := message.ParentID.String()
 		msgData.ParentID = &parentStr
 	}
+	if message.ThreadID != nil {
+		threadStr := message.ThreadID.String()
+		msgData.ThreadID = &threadStr
+	}
 	if message.SenderUsername != nil {
 		msgData.SenderUsername = message.SenderUsername
 	}
@@ -234,21 +373,22 @@ func (p *publisher) PublishMessageCreated(ctx context.Context, message *model.Me
 	if message.SenderAvatarURL != nil {
 		msgData.SenderAvatarURL = message.SenderAvatarURL
 	}
+	msgData.SenderIsBot = message.SenderIsBot
+	msgData.Buttons = messageButtons(message.Buttons)
 	// Add file link IDs
 	for _, id := range message.FileLinkIDs {
 		msgData.FileLinkIDs = append(msgData.FileLinkIDs, id.String())
 	}
 
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyMessageCreated,
-		Timestamp:    time.Now(),
 		ActorID:      message.SenderID.String(),
 		ChatID:       message.ChatID.String(),
 		Participants: uuidSliceToStrings(participants),
 		Data:         msgData,
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyMessageCreated, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish message.created event", zap.Error(err), zap.String("message_id", message.ID.String()))
 		return err
 	}
@@ -258,28 +398,29 @@ func (p *publisher) PublishMessageCreated(ctx context.Context, message *model.Me
 }
 
 func (p *publisher) PublishMessageUpdated(ctx context.Context, message *model.Message, participants []uuid.UUID) error {
-	msgData := MessageData{
-		ID:       message.ID.String(),
-		ChatID:   message.ChatID.String(),
-		SenderID: message.SenderID.String(),
-		Content:  message.Content,
-		SentAt:   message.SentAt.Format(time.RFC3339),
+	msgData := sharedevents.MessageData{
+		ID:          message.ID.String(),
+		ChatID:      message.ChatID.String(),
+		SenderID:    message.SenderID.String(),
+		Content:     message.Content,
+		SentAt:      message.SentAt.Format(time.RFC3339),
+		SenderIsBot: message.SenderIsBot,
+		Buttons:     messageButtons(message.Buttons),
 	}
 	if message.UpdatedAt != nil {
 		updatedStr := message.UpdatedAt.Format(time.RFC3339)
 		msgData.UpdatedAt = &updatedStr
 	}
 
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyMessageUpdated,
-		Timestamp:    time.Now(),
 		ActorID:      message.SenderID.String(),
 		ChatID:       message.ChatID.String(),
 		Participants: uuidSliceToStrings(participants),
 		Data:         msgData,
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyMessageUpdated, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish message.updated event", zap.Error(err), zap.String("message_id", message.ID.String()))
 		return err
 	}
@@ -289,20 +430,19 @@ func (p *publisher) PublishMessageUpdated(ctx context.Context, message *model.Me
 }
 
 func (p *publisher) PublishMessageDeleted(ctx context.Context, messageID, chatID, deletedBy uuid.UUID, isModeratedDeletion bool, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyMessageDeleted,
-		Timestamp:    time.Now(),
 		ActorID:      deletedBy.String(),
 		ChatID:       chatID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: MessageDeletedData{
+		Data: sharedevents.MessageDeletedData{
 			MessageID:           messageID.String(),
 			ChatID:              chatID.String(),
 			IsModeratedDeletion: isModeratedDeletion,
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyMessageDeleted, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish message.deleted event", zap.Error(err), zap.String("message_id", messageID.String()))
 		return err
 	}
@@ -312,16 +452,12 @@ func (p *publisher) PublishMessageDeleted(ctx context.Context, messageID, chatID
 }
 
 func (p *publisher) PublishMessageRestored(ctx context.Context, message *model.Message) error {
-	// Get participants for this chat - we need repository access for this
-	// For now, we'll just use the sender as the only participant in the event
-	// The actual participant list should be passed from the service layer
-	event := ChatEvent{
-		Type:         RoutingKeyMessageRestored,
-		Timestamp:    time.Now(),
-		ActorID:      message.SenderID.String(),
-		ChatID:       message.ChatID.String(),
-		Participants: []string{}, // Will be populated by the message receiver based on chat
-		Data: MessageRestoredData{
+	// Published without participants; websocket-service resolves the chat's participants
+	event := chatEvent{
+		Type:    RoutingKeyMessageRestored,
+		ActorID: message.SenderID.String(),
+		ChatID:  message.ChatID.String(),
+		Data: sharedevents.MessageRestoredData{
 			MessageID: message.ID.String(),
 			ChatID:    message.ChatID.String(),
 			SenderID:  message.SenderID.String(),
@@ -329,7 +465,7 @@ func (p *publisher) PublishMessageRestored(ctx context.Context, message *model.M
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyMessageRestored, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish message.restored event", zap.Error(err), zap.String("message_id", message.ID.String()))
 		return err
 	}
@@ -338,43 +474,215 @@ func (p *publisher) PublishMessageRestored(ctx context.Context, message *model.M
 	return nil
 }
 
+// PublishReportCreated notifies moderators about a new message report.
+// Participants holds the moderators only, so regular members never see reports.
+func (p *publisher) PublishReportCreated(ctx context.Context, report *model.MessageReport, moderators []uuid.UUID) error {
+	if len(moderators) == 0 {
+		return nil
+	}
+
+	event := chatEvent{
+		Type:         RoutingKeyReportCreated,
+		ActorID:      report.ReporterID.String(),
+		ChatID:       report.ChatID.String(),
+		Participants: uuidSliceToStrings(moderators),
+		Data: sharedevents.ReportCreatedData{
+			ReportID:       report.ID.String(),
+			ChatID:         report.ChatID.String(),
+			MessageID:      report.MessageID.String(),
+			SenderID:       report.SenderID.String(),
+			ReporterID:     report.ReporterID.String(),
+			Reason:         string(report.Reason),
+			Comment:        report.Comment,
+			MessageContent: report.MessageContent,
+		},
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish report.created event", zap.Error(err), zap.String("report_id", report.ID.String()))
+		return err
+	}
+
+	logger.Debug("published report.created event", zap.String("report_id", report.ID.String()), zap.Int("moderators", len(moderators)))
+	return nil
+}
+
+// PublishEphemeralMessage sends a message only to the user's personal channel. It is not stored,
+// so it disappears on reload (command replies, reminders).
+func (p *publisher) PublishEphemeralMessage(ctx context.Context, message *model.Message, userID uuid.UUID) error {
+	event := chatEvent{
+		Type:         RoutingKeyMessageEphemeral,
+		ActorID:      message.SenderID.String(),
+		ChatID:       message.ChatID.String(),
+		Participants: []string{userID.String()},
+		Data:         ephemeralMessageData(message),
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish message.ephemeral event", zap.Error(err), zap.String("user_id", userID.String()))
+		return err
+	}
+
+	logger.Debug("published message.ephemeral event", zap.String("user_id", userID.String()))
+	return nil
+}
+
+// ephemeralMessageData is the payload of message.ephemeral
+func ephemeralMessageData(message *model.Message) sharedevents.MessageData {
+	msgData := sharedevents.MessageData{
+		ID:                message.ID.String(),
+		ChatID:            message.ChatID.String(),
+		SenderID:          message.SenderID.String(),
+		Content:           message.Content,
+		SentAt:            message.SentAt.Format(time.RFC3339),
+		SenderUsername:    message.SenderUsername,
+		SenderDisplayName: message.SenderDisplayName,
+		SenderAvatarURL:   message.SenderAvatarURL,
+		SenderIsBot:       message.SenderIsBot,
+		IsSystem:          message.IsSystem,
+		IsEphemeral:       true,
+		ReplyToIDs:        uuidSliceToStrings(message.ReplyToIDs),
+		Buttons:           messageButtons(message.Buttons),
+	}
+	if message.ThreadID != nil {
+		threadStr := message.ThreadID.String()
+		msgData.ThreadID = &threadStr
+	}
+	return msgData
+}
+
+// PublishDraftUpdated notifies the user's other devices that a draft changed
+func (p *publisher) PublishDraftUpdated(ctx context.Context, draft *model.Draft, deleted bool) error {
+	data := sharedevents.DraftData{
+		ChatID:     draft.ChatID.String(),
+		Content:    draft.Content,
+		ReplyToIDs: uuidSliceToStrings(draft.ReplyToIDs),
+		UpdatedAt:  draft.UpdatedAt.Format(time.RFC3339),
+		Deleted:    deleted,
+	}
+	if draft.ThreadID != nil {
+		threadStr := draft.ThreadID.String()
+		data.ThreadID = &threadStr
+	}
+
+	event := chatEvent{
+		Type:         RoutingKeyDraftUpdated,
+		ActorID:      draft.UserID.String(),
+		ChatID:       draft.ChatID.String(),
+		Participants: []string{draft.UserID.String()},
+		Data:         data,
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish draft.updated event", zap.Error(err), zap.String("user_id", draft.UserID.String()))
+		return err
+	}
+
+	logger.Debug("published draft.updated event", zap.String("user_id", draft.UserID.String()))
+	return nil
+}
+
+func (p *publisher) PublishMessagesBulkDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, senderID *uuid.UUID, messageIDs []uuid.UUID, participants []uuid.UUID) error {
+	data := sharedevents.MessagesBulkDeletedData{
+		ChatID:     chatID.String(),
+		MessageIDs: uuidSliceToStrings(messageIDs),
+		DeletedBy:  deletedBy.String(),
+	}
+	if senderID != nil {
+		senderStr := senderID.String()
+		data.SenderID = &senderStr
+	}
+
+	event := chatEvent{
+		Type:         RoutingKeyMessageBulkDeleted,
+		ActorID:      deletedBy.String(),
+		ChatID:       chatID.String(),
+		Participants: uuidSliceToStrings(participants),
+		Data:         data,
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish message.bulk_deleted event", zap.Error(err), zap.String("chat_id", chatID.String()))
+		return err
+	}
+
+	logger.Debug("published message.bulk_deleted event", zap.String("chat_id", chatID.String()), zap.Int("messages", len(messageIDs)))
+	return nil
+}
+
+func (p *publisher) PublishMessagesMoved(ctx context.Context, chatID, threadID, movedBy uuid.UUID, moved map[uuid.UUID]*uuid.UUID, participants []uuid.UUID) error {
+	data := sharedevents.MessagesMovedData{
+		ChatID:   chatID.String(),
+		ThreadID: threadID.String(),
+		Messages: make([]sharedevents.MovedMessage, 0, len(moved)),
+	}
+	for messageID, fromThreadID := range moved {
+		item := sharedevents.MovedMessage{MessageID: messageID.String()}
+		if fromThreadID != nil {
+			fromStr := fromThreadID.String()
+			item.FromThreadID = &fromStr
+		}
+		data.Messages = append(data.Messages, item)
+	}
+
+	event := chatEvent{
+		Type:         RoutingKeyMessageMoved,
+		ActorID:      movedBy.String(),
+		ChatID:       chatID.String(),
+		Participants: uuidSliceToStrings(participants),
+		Data:         data,
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish message.moved event", zap.Error(err), zap.String("thread_id", threadID.String()))
+		return err
+	}
+
+	logger.Debug("published message.moved event", zap.String("thread_id", threadID.String()), zap.Int("messages", len(moved)))
+	return nil
+}
+
 func (p *publisher) PublishTyping(ctx context.Context, chatID, userID uuid.UUID, isTyping bool, participants []uuid.UUID) error {
-	event := ChatEvent{
+	if !p.typing.allow(chatID, userID, isTyping) {
+		logger.Debug("coalesced typing event", zap.String("chat_id", chatID.String()), zap.Bool("is_typing", isTyping))
+		return nil
+	}
+
+	event := chatEvent{
 		Type:         RoutingKeyTyping,
-		Timestamp:    time.Now(),
 		ActorID:      userID.String(),
 		ChatID:       chatID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: TypingData{
+		Data: sharedevents.TypingData{
 			IsTyping: isTyping,
 			UserID:   userID.String(),
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyTyping, event); err != nil {
+	if err := p.publishSignal(ctx, event); err != nil {
 		logger.Error("failed to publish typing event", zap.Error(err), zap.String("chat_id", chatID.String()))
 		return err
 	}
+	p.typing.record(chatID, userID, isTyping)
 
 	logger.Debug("published typing event", zap.String("chat_id", chatID.String()), zap.Bool("is_typing", isTyping))
 	return nil
 }
 
 func (p *publisher) PublishReactionAdded(ctx context.Context, messageID, chatID, userID uuid.UUID, emoji string, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyReactionAdded,
-		Timestamp:    time.Now(),
 		ActorID:      userID.String(),
 		ChatID:       chatID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: ReactionData{
+		Data: sharedevents.ReactionData{
 			MessageID: messageID.String(),
 			Emoji:     emoji,
 			UserID:    userID.String(),
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyReactionAdded, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish reaction.added event", zap.Error(err), zap.String("message_id", messageID.String()))
 		return err
 	}
@@ -384,20 +692,19 @@ func (p *publisher) PublishReactionAdded(ctx context.Context, messageID, chatID,
 }
 
 func (p *publisher) PublishReactionRemoved(ctx context.Context, messageID, chatID, userID uuid.UUID, emoji string, participants []uuid.UUID) error {
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyReactionRemoved,
-		Timestamp:    time.Now(),
 		ActorID:      userID.String(),
 		ChatID:       chatID.String(),
 		Participants: uuidSliceToStrings(participants),
-		Data: ReactionData{
+		Data: sharedevents.ReactionData{
 			MessageID: messageID.String(),
 			Emoji:     emoji,
 			UserID:    userID.String(),
 		},
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyReactionRemoved, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish reaction.removed event", zap.Error(err), zap.String("message_id", messageID.String()))
 		return err
 	}
@@ -407,7 +714,7 @@ func (p *publisher) PublishReactionRemoved(ctx context.Context, messageID, chatI
 }
 
 func (p *publisher) PublishThreadCreated(ctx context.Context, thread *model.Thread, participants []uuid.UUID) error {
-	threadData := ThreadData{
+	threadData := sharedevents.ThreadData{
 		ID:                     thread.ID.String(),
 		ChatID:                 thread.ChatID.String(),
 		ThreadType:             string(thread.ThreadType),
@@ -432,16 +739,15 @@ func (p *publisher) PublishThreadCreated(ctx context.Context, thread *model.Thre
 		actorID = thread.CreatedBy.String()
 	}
 
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyThreadCreated,
-		Timestamp:    time.Now(),
 		ActorID:      actorID,
 		ChatID:       thread.ChatID.String(),
 		Participants: uuidSliceToStrings(participants),
 		Data:         threadData,
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyThreadCreated, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish thread.created event", zap.Error(err), zap.String("thread_id", thread.ID.String()))
 		return err
 	}
@@ -451,7 +757,7 @@ func (p *publisher) PublishThreadCreated(ctx context.Context, thread *model.Thre
 }
 
 func (p *publisher) PublishThreadArchived(ctx context.Context, thread *model.Thread, archivedBy uuid.UUID, participants []uuid.UUID) error {
-	threadData := ThreadData{
+	threadData := sharedevents.ThreadData{
 		ID:                     thread.ID.String(),
 		ChatID:                 thread.ChatID.String(),
 		ThreadType:             string(thread.ThreadType),
@@ -471,16 +777,15 @@ func (p *publisher) PublishThreadArchived(ctx context.Context, thread *model.Thr
 		threadData.CreatedBy = &createdByStr
 	}
 
-	event := ChatEvent{
+	event := chatEvent{
 		Type:         RoutingKeyThreadArchived,
-		Timestamp:    time.Now(),
 		ActorID:      archivedBy.String(),
 		ChatID:       thread.ChatID.String(),
 		Participants: uuidSliceToStrings(participants),
 		Data:         threadData,
 	}
 
-	if err := p.rmqPublisher.Publish(ctx, RoutingKeyThreadArchived, event); err != nil {
+	if err := p.publish(ctx, event); err != nil {
 		logger.Error("failed to publish thread.archived event", zap.Error(err), zap.String("thread_id", thread.ID.String()))
 		return err
 	}
@@ -489,6 +794,48 @@ func (p *publisher) PublishThreadArchived(ctx context.Context, thread *model.Thr
 	return nil
 }
 
+// PublishThreadReply notifies thread followers about a new message in the thread.
+// Unlike message.created, the event is targeted only to followers.
+func (p *publisher) PublishThreadReply(ctx context.Context, thread *model.Thread, message *model.Message, followers []uuid.UUID) error {
+	msgData := sharedevents.MessageData{
+		ID:                message.ID.String(),
+		ChatID:            message.ChatID.String(),
+		SenderID:          message.SenderID.String(),
+		Content:           message.Content,
+		SentAt:            message.SentAt.Format(time.RFC3339),
+		SenderUsername:    message.SenderUsername,
+		SenderDisplayName: message.SenderDisplayName,
+		SenderAvatarURL:   message.SenderAvatarURL,
+		SenderIsBot:       message.SenderIsBot,
+	}
+	threadStr := thread.ID.String()
+	msgData.ThreadID = &threadStr
+	for _, id := range message.FileLinkIDs {
+		msgData.FileLinkIDs = append(msgData.FileLinkIDs, id.String())
+	}
+
+	event := chatEvent{
+		Type:         RoutingKeyThreadReply,
+		ActorID:      message.SenderID.String(),
+		ChatID:       thread.ChatID.String(),
+		Participants: uuidSliceToStrings(followers),
+		Data: sharedevents.ThreadReplyData{
+			ThreadID:    thread.ID.String(),
+			ChatID:      thread.ChatID.String(),
+			ThreadTitle: thread.Title,
+			Message:     msgData,
+		},
+	}
+
+	if err := p.publish(ctx, event); err != nil {
+		logger.Error("failed to publish thread.reply event", zap.Error(err), zap.String("thread_id", thread.ID.String()))
+		return err
+	}
+
+	logger.Debug("published thread.reply event", zap.String("thread_id", thread.ID.String()), zap.Int("followers", len(followers)))
+	return nil
+}
+
 // NoOpPublisher is a publisher that does nothing (for testing)
 type NoOpPublisher struct{}
 
@@ -508,6 +855,14 @@ func (p *NoOpPublisher) PublishChatDeleted(ctx context.Context, chatID, deletedB
 	return nil
 }
 
+func (p *NoOpPublisher) PublishChatMemberAdded(ctx context.Context, participant *model.ChatParticipant, addedBy uuid.UUID, participants []uuid.UUID) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishChatMemberRemoved(ctx context.Context, chatID, userID, removedBy uuid.UUID, reason string, participants []uuid.UUID) error {
+	return nil
+}
+
 func (p *NoOpPublisher) PublishMessageCreated(ctx context.Context, message *model.Message, participants []uuid.UUID) error {
 	return nil
 }
@@ -524,6 +879,26 @@ func (p *NoOpPublisher) PublishMessageRestored(ctx context.Context, message *mod
 	return nil
 }
 
+func (p *NoOpPublisher) PublishReportCreated(ctx context.Context, report *model.MessageReport, moderators []uuid.UUID) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishEphemeralMessage(ctx context.Context, message *model.Message, userID uuid.UUID) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishDraftUpdated(ctx context.Context, draft *model.Draft, deleted bool) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishMessagesBulkDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, senderID *uuid.UUID, messageIDs []uuid.UUID, participants []uuid.UUID) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishMessagesMoved(ctx context.Context, chatID, threadID, movedBy uuid.UUID, moved map[uuid.UUID]*uuid.UUID, participants []uuid.UUID) error {
+	return nil
+}
+
 func (p *NoOpPublisher) PublishTyping(ctx context.Context, chatID, userID uuid.UUID, isTyping bool, participants []uuid.UUID) error {
 	return nil
 }
@@ -543,3 +918,7 @@ func (p *NoOpPublisher) PublishThreadCreated(ctx context.Context, thread *model.
 func (p *NoOpPublisher) PublishThreadArchived(ctx context.Context, thread *model.Thread, archivedBy uuid.UUID, participants []uuid.UUID) error {
 	return nil
 }
+
+func (p *NoOpPublisher) PublishThreadReply(ctx context.Context, thread *model.Thread, message *model.Message, followers []uuid.UUID) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sharedevents "github.com/icegreg/chat-smpl/pkg/events"
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+func TestEphemeralMessageData(t *testing.T) {
+	threadID := uuid.New()
+	quotedID := uuid.New()
+
+	tests := []struct {
+		name     string
+		message  *model.Message
+		isSystem bool
+	}{
+		{
+			name: "reminder is a system message",
+			message: &model.Message{
+				ID: uuid.New(), ChatID: uuid.New(), ThreadID: &threadID, Content: "Reminder: stand-up",
+				SentAt: time.Now(), IsSystem: true, ReplyToIDs: []uuid.UUID{quotedID},
+			},
+			isSystem: true,
+		},
+		{
+			name: "command reply is not",
+			message: &model.Message{
+				ID: uuid.New(), ChatID: uuid.New(), SenderID: uuid.New(), Content: "pong", SentAt: time.Now(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := sharedevents.New(sharedevents.ProducerChat, RoutingKeyMessageEphemeral, ephemeralMessageData(tt.message))
+			require.NoError(t, err)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(env.Payload, &payload))
+			assert.Equal(t, true, payload["is_ephemeral"])
+			if tt.isSystem {
+				assert.Equal(t, true, payload["is_system"])
+				assert.Equal(t, threadID.String(), payload["thread_id"])
+				assert.Equal(t, []any{quotedID.String()}, payload["reply_to_ids"])
+			} else {
+				assert.NotContains(t, payload, "is_system")
+			}
+		})
+	}
+}
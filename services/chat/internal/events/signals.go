@@ -0,0 +1,85 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ephemeral signals such as typing are not part of chat history. They go to their own
+// non-durable exchange as transient messages that expire after SignalTTL, instead of through
+// the confirmed, persistent chat.events path.
+const (
+	SignalsExchangeName = "chat.signals"
+
+	// SignalTTL matches how long clients show a typing indicator; a later signal is useless
+	SignalTTL = 5 * time.Second
+)
+
+// typingKey identifies the typing state of one user in one chat
+type typingKey struct {
+	chatID uuid.UUID
+	userID uuid.UUID
+}
+
+type typingState struct {
+	isTyping bool
+	sentAt   time.Time
+}
+
+// typingCoalescer drops typing signals that repeat the state last published for the same user
+// and chat within the interval, such as the same user typing on several devices. A change of
+// state always passes, so a stop is never held back.
+type typingCoalescer struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	states map[typingKey]typingState
+	now    func() time.Time
+}
+
+// sweepThreshold is the number of tracked states above which expired ones are dropped
+const sweepThreshold = 10000
+
+func newTypingCoalescer(interval time.Duration) *typingCoalescer {
+	return &typingCoalescer{
+		interval: interval,
+		states:   make(map[typingKey]typingState),
+		now:      time.Now,
+	}
+}
+
+// allow reports whether the signal should be published. The signal is not recorded, so a
+// failed publish does not hold back a retry.
+func (c *typingCoalescer) allow(chatID, userID uuid.UUID, isTyping bool) bool {
+	if c.interval <= 0 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last, ok := c.states[typingKey{chatID: chatID, userID: userID}]
+	return !ok || last.isTyping != isTyping || c.now().Sub(last.sentAt) >= c.interval
+}
+
+// record remembers a published signal, dropping expired states once there are many of them
+func (c *typingCoalescer) record(chatID, userID uuid.UUID, isTyping bool) {
+	if c.interval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.states) >= sweepThreshold {
+		for k, s := range c.states {
+			if now.Sub(s.sentAt) >= c.interval {
+				delete(c.states, k)
+			}
+		}
+	}
+	c.states[typingKey{chatID: chatID, userID: userID}] = typingState{isTyping: isTyping, sentAt: now}
+}
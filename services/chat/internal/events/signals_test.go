@@ -0,0 +1,70 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCoalescer returns a coalescer whose clock is moved by advancing the returned time
+func newTestCoalescer(interval time.Duration) (*typingCoalescer, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	c := newTypingCoalescer(interval)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestTypingCoalescer(t *testing.T) {
+	chatID, userID := uuid.New(), uuid.New()
+
+	t.Run("repeated state is dropped within the interval", func(t *testing.T) {
+		c, now := newTestCoalescer(3 * time.Second)
+		assert.True(t, c.allow(chatID, userID, true))
+		c.record(chatID, userID, true)
+
+		*now = now.Add(time.Second)
+		assert.False(t, c.allow(chatID, userID, true))
+
+		*now = now.Add(2 * time.Second)
+		assert.True(t, c.allow(chatID, userID, true), "the interval has passed")
+	})
+
+	t.Run("state change passes", func(t *testing.T) {
+		c, _ := newTestCoalescer(3 * time.Second)
+		c.record(chatID, userID, true)
+
+		assert.True(t, c.allow(chatID, userID, false), "a stop is never held back")
+		assert.True(t, c.allow(uuid.New(), userID, true), "other chat")
+		assert.True(t, c.allow(chatID, uuid.New(), true), "other user")
+	})
+
+	t.Run("unrecorded signal does not hold back a retry", func(t *testing.T) {
+		c, _ := newTestCoalescer(3 * time.Second)
+		assert.True(t, c.allow(chatID, userID, true))
+		assert.True(t, c.allow(chatID, userID, true))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c, _ := newTestCoalescer(0)
+		c.record(chatID, userID, true)
+		assert.True(t, c.allow(chatID, userID, true))
+		assert.Empty(t, c.states)
+	})
+
+	t.Run("sweep drops expired states", func(t *testing.T) {
+		c, now := newTestCoalescer(3 * time.Second)
+		for i := 0; i < sweepThreshold-1; i++ {
+			c.record(uuid.New(), userID, true)
+		}
+		*now = now.Add(2 * time.Second)
+		c.record(chatID, userID, true)
+		assert.Len(t, c.states, sweepThreshold, "below the threshold nothing is swept")
+
+		*now = now.Add(2 * time.Second)
+		c.record(uuid.New(), userID, true)
+		assert.Len(t, c.states, 2, "only states within the interval remain")
+		assert.False(t, c.allow(chatID, userID, true))
+	})
+}
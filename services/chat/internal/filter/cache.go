@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+// PolicyLoader returns the effective policy rules of a chat (nil if no filters apply)
+type PolicyLoader func(ctx context.Context, chatID uuid.UUID) (*model.ContentPolicyRules, error)
+
+// maxCacheEntries bounds the cache; it is cleared when full
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	pipeline  *Pipeline
+	expiresAt time.Time
+}
+
+// Cache keeps compiled pipelines per chat, so policies are not loaded and compiled on every message.
+// Entries expire after ttl, which bounds how long other replicas use a stale policy.
+type Cache struct {
+	load    PolicyLoader
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[uuid.UUID]cacheEntry
+}
+
+// NewCache creates a pipeline cache
+func NewCache(load PolicyLoader, ttl time.Duration) *Cache {
+	return &Cache{
+		load:    load,
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]cacheEntry),
+	}
+}
+
+// Pipeline returns the compiled pipeline of a chat
+func (c *Cache) Pipeline(ctx context.Context, chatID uuid.UUID) (*Pipeline, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	entry, ok := c.entries[chatID]
+	c.mu.RUnlock()
+	if ok && entry.expiresAt.After(now) {
+		return entry.pipeline, nil
+	}
+
+	rules, err := c.load(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := NewPipeline()
+	if rules != nil {
+		// Policies are validated when saved; a policy that no longer compiles disables filtering
+		// rather than blocking the chat
+		if compiled, err := Compile(*rules); err == nil {
+			pipeline = compiled
+		}
+	}
+
+	c.mu.Lock()
+	if len(c.entries) >= maxCacheEntries {
+		c.entries = make(map[uuid.UUID]cacheEntry)
+	}
+	c.entries[chatID] = cacheEntry{pipeline: pipeline, expiresAt: now.Add(c.ttl)}
+	c.mu.Unlock()
+
+	return pipeline, nil
+}
+
+// Invalidate drops the cached pipeline of a chat, or all pipelines when chatID is nil
+// (the global policy changed)
+func (c *Cache) Invalidate(chatID *uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chatID == nil {
+		c.entries = make(map[uuid.UUID]cacheEntry)
+		return
+	}
+	delete(c.entries, *chatID)
+}
@@ -0,0 +1,122 @@
+// Package filter implements the pre-send content filter pipeline of chat-service:
+// message limits, word lists and regex-based DLP with mask or block actions.
+package filter
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+// Filter names used in hits and rejections
+const (
+	FilterLimits = "limits"
+	FilterWords  = "words"
+	FilterDLP    = "dlp"
+)
+
+// Message is the outgoing message seen by hooks. Mask hooks rewrite Content in place.
+type Message struct {
+	ChatID          uuid.UUID
+	SenderID        uuid.UUID
+	Content         string
+	AttachmentCount int
+}
+
+// Hit is a filter match
+type Hit struct {
+	Filter  string
+	Rule    string
+	Action  model.FilterAction
+	Matches int
+}
+
+// Rejection is the structured reason a message was blocked
+type Rejection struct {
+	Filter string // limits, words, dlp
+	Rule   string // e.g. max_length, blocked_word, card_number
+	Reason string // Human-readable explanation for the sender
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("message rejected by %s filter (%s): %s", r.Filter, r.Rule, r.Reason)
+}
+
+// Hook is a pre-send filter. It may rewrite msg.Content (mask) and returns its hits, plus a
+// rejection if the message must not be sent.
+type Hook interface {
+	Name() string
+	Apply(msg *Message) ([]Hit, *Rejection)
+}
+
+// Result is the outcome of running a pipeline
+type Result struct {
+	Content   string
+	Hits      []Hit
+	Rejection *Rejection
+}
+
+// Pipeline runs hooks in order and stops at the first rejection
+type Pipeline struct {
+	hooks []Hook
+}
+
+// NewPipeline creates a pipeline of the given hooks
+func NewPipeline(hooks ...Hook) *Pipeline {
+	return &Pipeline{hooks: hooks}
+}
+
+// Compile builds the pipeline configured by a content policy: limits first, so oversized
+// messages are rejected before the more expensive matching, then word list, then DLP.
+func Compile(rules model.ContentPolicyRules) (*Pipeline, error) {
+	var hooks []Hook
+
+	if rules.MaxLength > 0 || rules.MaxAttachments > 0 {
+		hooks = append(hooks, &limitsHook{maxLength: rules.MaxLength, maxAttachments: rules.MaxAttachments})
+	}
+
+	if len(rules.BlockedWords) > 0 {
+		hook, err := newWordListHook(rules.BlockedWords, rules.WordAction)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+
+	dlp, err := newDLPHook(rules)
+	if err != nil {
+		return nil, err
+	}
+	if dlp != nil {
+		hooks = append(hooks, dlp)
+	}
+
+	return NewPipeline(hooks...), nil
+}
+
+// Empty reports whether the pipeline has no hooks
+func (p *Pipeline) Empty() bool {
+	return p == nil || len(p.hooks) == 0
+}
+
+// Run applies the hooks to a message
+func (p *Pipeline) Run(msg Message) Result {
+	result := Result{Content: msg.Content}
+	if p.Empty() {
+		return result
+	}
+
+	for _, hook := range p.hooks {
+		hits, rejection := hook.Apply(&msg)
+		result.Hits = append(result.Hits, hits...)
+		if rejection != nil {
+			result.Rejection = rejection
+			return result
+		}
+	}
+
+	result.Content = msg.Content
+	return result
+}
@@ -0,0 +1,222 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+func TestPipeline_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       model.ContentPolicyRules
+		msg         Message
+		content     string
+		hits        []Hit
+		rejectedBy  string
+		rejectedFor string
+	}{
+		{
+			name:    "empty policy passes the message through",
+			msg:     Message{Content: "hello"},
+			content: "hello",
+		},
+		{
+			name:        "too long",
+			rules:       model.ContentPolicyRules{MaxLength: 5},
+			msg:         Message{Content: "привет!"},
+			hits:        []Hit{{Filter: FilterLimits, Rule: "max_length", Action: model.FilterActionBlock, Matches: 1}},
+			rejectedBy:  FilterLimits,
+			rejectedFor: "max_length",
+		},
+		{
+			name:    "length counts characters, not bytes",
+			rules:   model.ContentPolicyRules{MaxLength: 6},
+			msg:     Message{Content: "привет"},
+			content: "привет",
+		},
+		{
+			name:        "too many attachments",
+			rules:       model.ContentPolicyRules{MaxAttachments: 2},
+			msg:         Message{Content: "files", AttachmentCount: 3},
+			hits:        []Hit{{Filter: FilterLimits, Rule: "max_attachments", Action: model.FilterActionBlock, Matches: 1}},
+			rejectedBy:  FilterLimits,
+			rejectedFor: "max_attachments",
+		},
+		{
+			name:    "blocked words are masked whole and case-insensitively",
+			rules:   model.ContentPolicyRules{BlockedWords: []string{"Darn", "блин"}},
+			msg:     Message{Content: "darn it, DARN. Блин! darned"},
+			content: "**** it, ****. ****! darned",
+			hits:    []Hit{{Filter: FilterWords, Rule: "blocked_word", Action: model.FilterActionMask, Matches: 3}},
+		},
+		{
+			name:        "blocked words with block action",
+			rules:       model.ContentPolicyRules{BlockedWords: []string{"darn"}, WordAction: model.FilterActionBlock},
+			msg:         Message{Content: "darn"},
+			hits:        []Hit{{Filter: FilterWords, Rule: "blocked_word", Action: model.FilterActionBlock, Matches: 1}},
+			rejectedBy:  FilterWords,
+			rejectedFor: "blocked_word",
+		},
+		{
+			name:    "valid card number is masked but for the last four digits",
+			rules:   model.ContentPolicyRules{CardNumbers: model.FilterActionMask},
+			msg:     Message{Content: "card 4111 1111 1111 1111 thanks"},
+			content: "card **** **** **** 1111 thanks",
+			hits:    []Hit{{Filter: FilterDLP, Rule: "card_number", Action: model.FilterActionMask, Matches: 1}},
+		},
+		{
+			name:    "number failing the Luhn check is not a card",
+			rules:   model.ContentPolicyRules{CardNumbers: model.FilterActionBlock},
+			msg:     Message{Content: "order 4111 1111 1111 1112"},
+			content: "order 4111 1111 1111 1112",
+		},
+		{
+			name:        "passport number blocked",
+			rules:       model.ContentPolicyRules{PassportNumbers: model.FilterActionBlock},
+			msg:         Message{Content: "паспорт 45 08 123456"},
+			hits:        []Hit{{Filter: FilterDLP, Rule: "passport_number", Action: model.FilterActionBlock, Matches: 1}},
+			rejectedBy:  FilterDLP,
+			rejectedFor: "passport_number",
+		},
+		{
+			name: "custom pattern masks letters and digits",
+			rules: model.ContentPolicyRules{Patterns: []model.ContentPatternRule{
+				{Name: "api_key", Pattern: `sk-[A-Za-z0-9]{8}`, Action: model.FilterActionMask},
+			}},
+			msg:     Message{Content: "key sk-abcd1234"},
+			content: "key **-********",
+			hits:    []Hit{{Filter: FilterDLP, Rule: "api_key", Action: model.FilterActionMask, Matches: 1}},
+		},
+		{
+			name: "hooks run in order and hits accumulate",
+			rules: model.ContentPolicyRules{
+				BlockedWords: []string{"darn"},
+				CardNumbers:  model.FilterActionMask,
+				MaxLength:    100,
+			},
+			msg:     Message{Content: "darn 4111111111111111"},
+			content: "**** ************1111",
+			hits: []Hit{
+				{Filter: FilterWords, Rule: "blocked_word", Action: model.FilterActionMask, Matches: 1},
+				{Filter: FilterDLP, Rule: "card_number", Action: model.FilterActionMask, Matches: 1},
+			},
+		},
+		{
+			name: "a rejection stops the pipeline",
+			rules: model.ContentPolicyRules{
+				MaxLength:    3,
+				BlockedWords: []string{"darn"},
+			},
+			msg:         Message{Content: "darn"},
+			hits:        []Hit{{Filter: FilterLimits, Rule: "max_length", Action: model.FilterActionBlock, Matches: 1}},
+			rejectedBy:  FilterLimits,
+			rejectedFor: "max_length",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := Compile(tt.rules)
+			require.NoError(t, err)
+
+			result := pipeline.Run(tt.msg)
+			assert.Equal(t, tt.hits, result.Hits)
+			if tt.rejectedBy != "" {
+				require.NotNil(t, result.Rejection)
+				assert.Equal(t, tt.rejectedBy, result.Rejection.Filter)
+				assert.Equal(t, tt.rejectedFor, result.Rejection.Rule)
+				assert.NotEmpty(t, result.Rejection.Reason)
+				return
+			}
+			assert.Nil(t, result.Rejection)
+			assert.Equal(t, tt.content, result.Content)
+		})
+	}
+}
+
+func TestCompile_InvalidRules(t *testing.T) {
+	tooManyWords := make([]string, maxBlockedWords+1)
+	for i := range tooManyWords {
+		tooManyWords[i] = "w"
+	}
+
+	tests := []struct {
+		name  string
+		rules model.ContentPolicyRules
+	}{
+		{"phrase in word list", model.ContentPolicyRules{BlockedWords: []string{"two words"}}},
+		{"too many words", model.ContentPolicyRules{BlockedWords: tooManyWords}},
+		{"invalid word action", model.ContentPolicyRules{BlockedWords: []string{"x"}, WordAction: "drop"}},
+		{"invalid card action", model.ContentPolicyRules{CardNumbers: "drop"}},
+		{"invalid regex", model.ContentPolicyRules{Patterns: []model.ContentPatternRule{
+			{Name: "bad", Pattern: "(", Action: model.FilterActionMask},
+		}}},
+		{"unnamed pattern", model.ContentPolicyRules{Patterns: []model.ContentPatternRule{
+			{Pattern: "x", Action: model.FilterActionMask},
+		}}},
+		{"pattern too long", model.ContentPolicyRules{Patterns: []model.ContentPatternRule{
+			{Name: "long", Pattern: strings.Repeat("a", maxPatternLength+1), Action: model.FilterActionMask},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.rules)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	chatID := uuid.New()
+	loads := 0
+	rules := &model.ContentPolicyRules{BlockedWords: []string{"darn"}}
+	cache := NewCache(func(ctx context.Context, id uuid.UUID) (*model.ContentPolicyRules, error) {
+		loads++
+		return rules, nil
+	}, time.Minute)
+
+	p1, err := cache.Pipeline(context.Background(), chatID)
+	require.NoError(t, err)
+	p2, err := cache.Pipeline(context.Background(), chatID)
+	require.NoError(t, err)
+	assert.Same(t, p1, p2)
+	assert.Equal(t, 1, loads)
+
+	cache.Invalidate(&chatID)
+	_, _ = cache.Pipeline(context.Background(), chatID)
+	assert.Equal(t, 2, loads)
+
+	cache.Invalidate(nil)
+	_, _ = cache.Pipeline(context.Background(), chatID)
+	assert.Equal(t, 3, loads)
+}
+
+func TestCache_InvalidPolicyDisablesFiltering(t *testing.T) {
+	cache := NewCache(func(ctx context.Context, id uuid.UUID) (*model.ContentPolicyRules, error) {
+		return &model.ContentPolicyRules{CardNumbers: "drop"}, nil
+	}, time.Minute)
+
+	pipeline, err := cache.Pipeline(context.Background(), uuid.New())
+	require.NoError(t, err)
+	assert.True(t, pipeline.Empty())
+}
+
+func TestCache_LoadError(t *testing.T) {
+	loadErr := errors.New("db down")
+	cache := NewCache(func(ctx context.Context, id uuid.UUID) (*model.ContentPolicyRules, error) {
+		return nil, loadErr
+	}, time.Minute)
+
+	_, err := cache.Pipeline(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, loadErr)
+}
@@ -0,0 +1,258 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+const (
+	maxBlockedWords   = 1000
+	maxCustomPatterns = 20
+	maxPatternLength  = 500
+)
+
+// limitsHook enforces max message length and attachment count
+type limitsHook struct {
+	maxLength      int
+	maxAttachments int
+}
+
+func (h *limitsHook) Name() string { return FilterLimits }
+
+func (h *limitsHook) Apply(msg *Message) ([]Hit, *Rejection) {
+	if h.maxLength > 0 {
+		if length := utf8.RuneCountInString(msg.Content); length > h.maxLength {
+			return []Hit{{Filter: FilterLimits, Rule: "max_length", Action: model.FilterActionBlock, Matches: 1}},
+				&Rejection{Filter: FilterLimits, Rule: "max_length",
+					Reason: fmt.Sprintf("message is %d characters long, the limit is %d", length, h.maxLength)}
+		}
+	}
+	if h.maxAttachments > 0 && msg.AttachmentCount > h.maxAttachments {
+		return []Hit{{Filter: FilterLimits, Rule: "max_attachments", Action: model.FilterActionBlock, Matches: 1}},
+			&Rejection{Filter: FilterLimits, Rule: "max_attachments",
+				Reason: fmt.Sprintf("message has %d attachments, the limit is %d", msg.AttachmentCount, h.maxAttachments)}
+	}
+	return nil, nil
+}
+
+// wordListHook matches whole words case-insensitively. Words are split on anything that is not
+// a letter or digit, so it works for any script (regexp \b is ASCII-only).
+type wordListHook struct {
+	words  map[string]bool
+	action model.FilterAction
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+func newWordListHook(words []string, action model.FilterAction) (*wordListHook, error) {
+	if len(words) > maxBlockedWords {
+		return nil, fmt.Errorf("too many blocked words: %d, max %d", len(words), maxBlockedWords)
+	}
+	if action == "" {
+		action = model.FilterActionMask
+	}
+	if !action.IsValid() {
+		return nil, fmt.Errorf("invalid word action %q", action)
+	}
+
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if wordPattern.FindString(word) != word {
+			return nil, fmt.Errorf("blocked word %q must be a single word; use a pattern for phrases", word)
+		}
+		set[word] = true
+	}
+	return &wordListHook{words: set, action: action}, nil
+}
+
+func (h *wordListHook) Name() string { return FilterWords }
+
+func (h *wordListHook) Apply(msg *Message) ([]Hit, *Rejection) {
+	matches := 0
+	masked := wordPattern.ReplaceAllStringFunc(msg.Content, func(word string) string {
+		if !h.words[strings.ToLower(word)] {
+			return word
+		}
+		matches++
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+	if matches == 0 {
+		return nil, nil
+	}
+
+	hits := []Hit{{Filter: FilterWords, Rule: "blocked_word", Action: h.action, Matches: matches}}
+	if h.action == model.FilterActionBlock {
+		return hits, &Rejection{Filter: FilterWords, Rule: "blocked_word", Reason: "message contains blocked words"}
+	}
+	msg.Content = masked
+	return hits, nil
+}
+
+// dlpRule is a compiled DLP pattern. validate, if set, filters out false positives;
+// mask rewrites a match (defaults to asterisks over letters and digits).
+type dlpRule struct {
+	name     string
+	pattern  *regexp.Regexp
+	action   model.FilterAction
+	validate func(match string) bool
+	mask     func(match string) string
+}
+
+// dlpHook runs the built-in and custom DLP patterns
+type dlpHook struct {
+	rules []dlpRule
+}
+
+var (
+	// 13-19 digits, optionally grouped with spaces or dashes
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// Russian internal passport (series + number) and international passport numbers
+	passportPattern = regexp.MustCompile(`\b\d{2} ?\d{2} ?\d{6}\b|\b[A-Z]{1,2}\d{6,8}\b`)
+)
+
+func newDLPHook(rules model.ContentPolicyRules) (*dlpHook, error) {
+	hook := &dlpHook{}
+
+	if rules.CardNumbers != "" {
+		if !rules.CardNumbers.IsValid() {
+			return nil, fmt.Errorf("invalid card_numbers action %q", rules.CardNumbers)
+		}
+		hook.rules = append(hook.rules, dlpRule{
+			name:     "card_number",
+			pattern:  cardNumberPattern,
+			action:   rules.CardNumbers,
+			validate: luhnValid,
+			mask:     maskCardNumber,
+		})
+	}
+
+	if rules.PassportNumbers != "" {
+		if !rules.PassportNumbers.IsValid() {
+			return nil, fmt.Errorf("invalid passport_numbers action %q", rules.PassportNumbers)
+		}
+		hook.rules = append(hook.rules, dlpRule{
+			name:    "passport_number",
+			pattern: passportPattern,
+			action:  rules.PassportNumbers,
+		})
+	}
+
+	if len(rules.Patterns) > maxCustomPatterns {
+		return nil, fmt.Errorf("too many patterns: %d, max %d", len(rules.Patterns), maxCustomPatterns)
+	}
+	for _, p := range rules.Patterns {
+		if p.Name == "" {
+			return nil, fmt.Errorf("pattern name is required")
+		}
+		if !p.Action.IsValid() {
+			return nil, fmt.Errorf("invalid action %q for pattern %q", p.Action, p.Name)
+		}
+		if len(p.Pattern) > maxPatternLength {
+			return nil, fmt.Errorf("pattern %q is too long", p.Name)
+		}
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p.Name, err)
+		}
+		hook.rules = append(hook.rules, dlpRule{name: p.Name, pattern: re, action: p.Action})
+	}
+
+	if len(hook.rules) == 0 {
+		return nil, nil
+	}
+	return hook, nil
+}
+
+func (h *dlpHook) Name() string { return FilterDLP }
+
+func (h *dlpHook) Apply(msg *Message) ([]Hit, *Rejection) {
+	var hits []Hit
+	for _, rule := range h.rules {
+		matches := 0
+		masked := rule.pattern.ReplaceAllStringFunc(msg.Content, func(match string) string {
+			if rule.validate != nil && !rule.validate(match) {
+				return match
+			}
+			matches++
+			if rule.mask != nil {
+				return rule.mask(match)
+			}
+			return maskAlphanumeric(match)
+		})
+		if matches == 0 {
+			continue
+		}
+
+		hits = append(hits, Hit{Filter: FilterDLP, Rule: rule.name, Action: rule.action, Matches: matches})
+		if rule.action == model.FilterActionBlock {
+			return hits, &Rejection{Filter: FilterDLP, Rule: rule.name,
+				Reason: fmt.Sprintf("message contains sensitive data (%s)", strings.ReplaceAll(rule.name, "_", " "))}
+		}
+		msg.Content = masked
+	}
+	return hits, nil
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// maskCardNumber replaces all digits but the last four, keeping the grouping: "**** **** **** 1111"
+func maskCardNumber(s string) string {
+	digits := 0
+	for _, c := range s {
+		if unicode.IsDigit(c) {
+			digits++
+		}
+	}
+
+	var b strings.Builder
+	seen := 0
+	for _, c := range s {
+		if unicode.IsDigit(c) {
+			seen++
+			if seen <= digits-4 {
+				b.WriteRune('*')
+				continue
+			}
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func maskAlphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return '*'
+		}
+		return r
+	}, s)
+}
@@ -3,14 +3,18 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/icegreg/chat-smpl/proto/chat"
+	"github.com/icegreg/chat-smpl/services/chat/internal/filter"
 	"github.com/icegreg/chat-smpl/services/chat/internal/model"
 	"github.com/icegreg/chat-smpl/services/chat/internal/repository"
 	"github.com/icegreg/chat-smpl/services/chat/internal/service"
@@ -50,6 +54,15 @@ func handleError(err error) error {
 		return nil
 	}
 
+	var rateLimitErr *service.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitStatus(rateLimitErr)
+	}
+	var rejection *filter.Rejection
+	if errors.As(err, &rejection) {
+		return contentRejectedStatus(rejection)
+	}
+
 	switch {
 	case errors.Is(err, repository.ErrChatNotFound):
 		return status.Error(codes.NotFound, "chat not found")
@@ -59,6 +72,32 @@ func handleError(err error) error {
 		return status.Error(codes.NotFound, "participant not found")
 	case errors.Is(err, repository.ErrThreadNotFound):
 		return status.Error(codes.NotFound, "thread not found")
+	case errors.Is(err, repository.ErrReportNotFound):
+		return status.Error(codes.NotFound, "report not found")
+	case errors.Is(err, repository.ErrRestrictionNotFound):
+		return status.Error(codes.NotFound, "restriction not found")
+	case errors.Is(err, repository.ErrContentPolicyNotFound):
+		return status.Error(codes.NotFound, "content policy not found")
+	case errors.Is(err, repository.ErrWebhookNotFound):
+		return status.Error(codes.NotFound, "webhook not found")
+	case errors.Is(err, repository.ErrWebhookDeliveryNotFound):
+		return status.Error(codes.NotFound, "webhook delivery not found")
+	case errors.Is(err, repository.ErrIncomingWebhookNotFound):
+		return status.Error(codes.NotFound, "incoming webhook not found")
+	case errors.Is(err, repository.ErrBotCommandNotFound):
+		return status.Error(codes.NotFound, "bot command not found")
+	case errors.Is(err, repository.ErrInteractionNotFound):
+		return status.Error(codes.FailedPrecondition, "message has no interactive buttons")
+	case errors.Is(err, repository.ErrReminderNotFound):
+		return status.Error(codes.NotFound, "reminder not found")
+	case errors.Is(err, repository.ErrBotCommandExists):
+		return status.Error(codes.AlreadyExists, "command already registered")
+	case errors.Is(err, repository.ErrCustomEmojiNotFound):
+		return status.Error(codes.NotFound, "custom emoji not found")
+	case errors.Is(err, repository.ErrCustomEmojiExists):
+		return status.Error(codes.AlreadyExists, "custom emoji already exists")
+	case errors.Is(err, service.ErrInvalidWebhookToken):
+		return status.Error(codes.Unauthenticated, "invalid webhook token")
 	case errors.Is(err, service.ErrNotParticipant):
 		return status.Error(codes.PermissionDenied, "not a participant")
 	case errors.Is(err, service.ErrAccessDenied):
@@ -69,11 +108,57 @@ func handleError(err error) error {
 		return status.Error(codes.FailedPrecondition, "message is not deleted")
 	case errors.Is(err, service.ErrRetentionExpired):
 		return status.Error(codes.FailedPrecondition, "retention period expired")
+	case errors.Is(err, service.ErrUserBanned), errors.Is(err, service.ErrUserMuted):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrAlreadyRestricted):
+		return status.Error(codes.AlreadyExists, "user already has an active restriction of this type")
+	case errors.Is(err, service.ErrInvalidMove), errors.Is(err, service.ErrInvalidBulkDelete), errors.Is(err, service.ErrInvalidReport),
+		errors.Is(err, service.ErrInvalidRestriction), errors.Is(err, service.ErrInvalidSlowMode),
+		errors.Is(err, service.ErrInvalidContentPolicy), errors.Is(err, service.ErrInvalidWebhook), errors.Is(err, service.ErrInvalidCommand),
+		errors.Is(err, service.ErrInvalidReminder), errors.Is(err, service.ErrInvalidReaction), errors.Is(err, service.ErrInvalidEmoji),
+		errors.Is(err, service.ErrInvalidDraft):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrAlreadyReported):
+		return status.Error(codes.AlreadyExists, "message already reported")
+	case errors.Is(err, service.ErrReportResolved):
+		return status.Error(codes.FailedPrecondition, "report already resolved")
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
 
+// rateLimitStatus builds a ResourceExhausted status carrying the retry delay as RetryInfo,
+// so the gateway can return Retry-After
+func rateLimitStatus(err *service.RateLimitError) error {
+	st := status.New(codes.ResourceExhausted, err.Error())
+	withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(err.RetryAfter),
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// contentRejectedStatus builds an InvalidArgument status carrying the structured rejection
+// reason as ErrorInfo (reason CONTENT_REJECTED, metadata filter/rule)
+func contentRejectedStatus(rejection *filter.Rejection) error {
+	st := status.New(codes.InvalidArgument, rejection.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "CONTENT_REJECTED",
+		Domain: "chat",
+		Metadata: map[string]string{
+			"filter": rejection.Filter,
+			"rule":   rejection.Rule,
+			"reason": rejection.Reason,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
 func toChatType(ct pb.ChatType) model.ChatType {
 	switch ct {
 	case pb.ChatType_CHAT_TYPE_PRIVATE:
@@ -131,12 +216,14 @@ func chatToProto(c *model.Chat) *pb.Chat {
 		return nil
 	}
 	return &pb.Chat{
-		Id:        c.ID.String(),
-		Name:      c.Name,
-		ChatType:  toProtoChatType(c.ChatType),
-		CreatedBy: c.CreatedBy.String(),
-		CreatedAt: timestamppb.New(c.CreatedAt),
-		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		Id:              c.ID.String(),
+		Name:            c.Name,
+		ChatType:        toProtoChatType(c.ChatType),
+		CreatedBy:       c.CreatedBy.String(),
+		CreatedAt:       timestamppb.New(c.CreatedAt),
+		UpdatedAt:       timestamppb.New(c.UpdatedAt),
+		SlowModeSeconds: int32(c.SlowModeSeconds),
+		HasDraft:        c.HasDraft,
 	}
 }
 
@@ -175,6 +262,7 @@ func messageToProto(m *model.Message) *pb.Message {
 	if m.SenderAvatarURL != nil {
 		msg.SenderAvatarUrl = *m.SenderAvatarURL
 	}
+	msg.SenderIsBot = m.SenderIsBot
 	// Add file link IDs
 	for _, id := range m.FileLinkIDs {
 		msg.FileLinkIds = append(msg.FileLinkIds, id.String())
@@ -199,6 +287,18 @@ func messageToProto(m *model.Message) *pb.Message {
 	}
 	// Add is_system flag
 	msg.IsSystem = m.IsSystem
+	// Add interactive buttons
+	for _, b := range m.Buttons {
+		msg.Buttons = append(msg.Buttons, &pb.MessageButton{
+			ActionId: b.ActionID,
+			Label:    b.Label,
+			Value:    b.Value,
+			Url:      b.URL,
+			Style:    b.Style,
+		})
+	}
+	msg.IsEphemeral = m.IsEphemeral
+	msg.ReactionSummary = reactionSummariesToProto(m.Reactions)
 	return msg
 }
 
@@ -225,6 +325,7 @@ func participantToProto(p *model.ChatParticipant) *pb.ChatParticipant {
 	if p.AvatarURL != nil {
 		result.AvatarUrl = *p.AvatarURL
 	}
+	result.IsBot = p.IsBot
 	return result
 }
 
@@ -358,6 +459,24 @@ func (s *ChatServer) UpdateChat(ctx context.Context, req *pb.UpdateChatRequest)
 	return chatToProto(chat), nil
 }
 
+func (s *ChatServer) SetSlowMode(ctx context.Context, req *pb.SetSlowModeRequest) (*pb.Chat, error) {
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	chat, err := s.chatService.SetSlowMode(ctx, chatID, userID, int(req.Seconds))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return chatToProto(chat), nil
+}
+
 func (s *ChatServer) DeleteChat(ctx context.Context, req *pb.DeleteChatRequest) (*emptypb.Empty, error) {
 	chatID, err := parseUUID(req.ChatId)
 	if err != nil {
@@ -375,6 +494,47 @@ func (s *ChatServer) DeleteChat(ctx context.Context, req *pb.DeleteChatRequest)
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ChatServer) GetChatListChanges(ctx context.Context, req *pb.GetChatListChangesRequest) (*pb.GetChatListChangesResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	limit := int(req.Limit)
+	if limit < 1 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	result, err := s.chatService.GetChatListChanges(ctx, userID, req.SinceVersion, limit)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoChanges := make([]*pb.ChatListChange, len(result.Changes))
+	for i, c := range result.Changes {
+		protoChanges[i] = &pb.ChatListChange{
+			Version:    c.Version,
+			ChatId:     c.ChatID.String(),
+			ChangeType: string(c.ChangeType),
+			CreatedAt:  timestamppb.New(c.CreatedAt),
+			Chat:       chatToProto(c.Chat),
+		}
+		if c.ActorID != nil {
+			protoChanges[i].ActorId = c.ActorID.String()
+		}
+	}
+
+	return &pb.GetChatListChangesResponse{
+		Changes:        protoChanges,
+		CurrentVersion: result.CurrentVersion,
+		HasMore:        result.HasMore,
+		ResetRequired:  result.ResetRequired,
+	}, nil
+}
+
 func (s *ChatServer) SearchChats(ctx context.Context, req *pb.SearchChatsRequest) (*pb.ListChatsResponse, error) {
 	userID, err := parseUUID(req.UserId)
 	if err != nil {
@@ -556,7 +716,12 @@ func (s *ChatServer) SendMessage(ctx context.Context, req *pb.SendMessageRequest
 		replyToIDs = append(replyToIDs, replyToID)
 	}
 
-	message, err := s.chatService.SendMessage(ctx, chatID, senderID, req.Content, parseUUIDPtr(req.ParentId), fileLinkIDs, replyToIDs)
+	var message *model.Message
+	if threadID := parseUUIDPtr(req.ThreadId); threadID != nil {
+		message, err = s.chatService.SendMessageToThread(ctx, chatID, senderID, req.Content, parseUUIDPtr(req.ParentId), threadID, fileLinkIDs, replyToIDs, false)
+	} else {
+		message, err = s.chatService.SendMessage(ctx, chatID, senderID, req.Content, parseUUIDPtr(req.ParentId), fileLinkIDs, replyToIDs)
+	}
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -659,22 +824,68 @@ func (s *ChatServer) SyncMessages(ctx context.Context, req *pb.SyncMessagesReque
 		limit = 1000
 	}
 
-	messages, err := s.chatService.SyncMessages(ctx, chatID, userID, req.AfterSeqNum, limit)
+	var threadID *uuid.UUID
+	if req.ThreadId != nil && *req.ThreadId != "" {
+		id, err := parseUUID(*req.ThreadId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+		}
+		threadID = &id
+	}
+
+	messages, tombstones, err := s.chatService.SyncMessages(ctx, chatID, userID, threadID, req.AfterSeqNum, limit)
 	if err != nil {
 		return nil, handleError(err)
 	}
 
-	protoMessages := make([]*pb.Message, len(messages))
-	for i, m := range messages {
-		protoMessages[i] = messageToProto(&m)
+	// hasMore is true if we got exactly limit messages or tombstones (might be more)
+	hasMore := len(messages) == limit || len(tombstones) == limit
+
+	// Messages and tombstones share the chat sequence, so a single cursor covers both.
+	// If one of the lists was truncated, cut the other one at the same point so nothing is skipped.
+	lastSeqNum := req.AfterSeqNum
+	if hasMore {
+		lastSeqNum = -1
+		if len(messages) == limit {
+			lastSeqNum = messages[len(messages)-1].SeqNum
+		}
+		if len(tombstones) == limit {
+			if last := tombstones[len(tombstones)-1].ChangeSeqNum; lastSeqNum == -1 || last < lastSeqNum {
+				lastSeqNum = last
+			}
+		}
+	} else {
+		for _, m := range messages {
+			if m.SeqNum > lastSeqNum {
+				lastSeqNum = m.SeqNum
+			}
+		}
+		for _, t := range tombstones {
+			if t.ChangeSeqNum > lastSeqNum {
+				lastSeqNum = t.ChangeSeqNum
+			}
+		}
+	}
+
+	protoMessages := make([]*pb.Message, 0, len(messages))
+	for i := range messages {
+		if messages[i].SeqNum <= lastSeqNum {
+			protoMessages = append(protoMessages, messageToProto(&messages[i]))
+		}
 	}
 
-	// hasMore is true if we got exactly limit messages (might be more)
-	hasMore := len(messages) == limit
+	protoTombstones := make([]*pb.MessageTombstone, 0, len(tombstones))
+	for i := range tombstones {
+		if tombstones[i].ChangeSeqNum <= lastSeqNum {
+			protoTombstones = append(protoTombstones, tombstoneToProto(&tombstones[i]))
+		}
+	}
 
 	return &pb.SyncMessagesResponse{
-		Messages: protoMessages,
-		HasMore:  hasMore,
+		Messages:   protoMessages,
+		HasMore:    hasMore,
+		Tombstones: protoTombstones,
+		LastSeqNum: lastSeqNum,
 	}, nil
 }
 
@@ -800,7 +1011,12 @@ func (s *ChatServer) ListReactions(ctx context.Context, req *pb.ListReactionsReq
 		return nil, status.Error(codes.InvalidArgument, "invalid message_id")
 	}
 
-	reactions, err := s.chatService.ListReactions(ctx, messageID)
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	reactions, summary, err := s.chatService.ListReactions(ctx, messageID, userID)
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -818,9 +1034,25 @@ func (s *ChatServer) ListReactions(ctx context.Context, req *pb.ListReactionsReq
 
 	return &pb.ListReactionsResponse{
 		Reactions: protoReactions,
+		Summary:   reactionSummariesToProto(summary),
 	}, nil
 }
 
+func reactionSummariesToProto(summaries []model.ReactionSummary) []*pb.ReactionSummary {
+	if len(summaries) == 0 {
+		return nil
+	}
+	result := make([]*pb.ReactionSummary, len(summaries))
+	for i, rs := range summaries {
+		result[i] = &pb.ReactionSummary{
+			Reaction:    rs.Reaction,
+			Count:       int32(rs.Count),
+			ReactedByMe: rs.ReactedByMe,
+		}
+	}
+	return result
+}
+
 // Read status operations
 
 func (s *ChatServer) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*emptypb.Empty, error) {
@@ -1046,6 +1278,25 @@ func toProtoThreadType(tt model.ThreadType) pb.ThreadType {
 	}
 }
 
+func tombstoneToProto(t *model.MessageTombstone) *pb.MessageTombstone {
+	pt := &pb.MessageTombstone{
+		MessageId:    t.MessageID.String(),
+		ChatId:       t.ChatID.String(),
+		ChangeType:   t.ChangeType,
+		ChangeSeqNum: t.ChangeSeqNum,
+		Message:      messageToProto(t.Message),
+	}
+	if t.ThreadID != nil {
+		threadIDStr := t.ThreadID.String()
+		pt.ThreadId = &threadIDStr
+	}
+	if t.PreviousThreadID != nil {
+		prevStr := t.PreviousThreadID.String()
+		pt.PreviousThreadId = &prevStr
+	}
+	return pt
+}
+
 func threadToProto(t *model.Thread) *pb.Thread {
 	if t == nil {
 		return nil
@@ -1264,6 +1515,45 @@ func (s *ChatServer) ListThreadMessages(ctx context.Context, req *pb.ListThreadM
 	}, nil
 }
 
+func (s *ChatServer) MoveMessagesToThread(ctx context.Context, req *pb.MoveMessagesToThreadRequest) (*pb.MoveMessagesToThreadResponse, error) {
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	movedBy, err := parseUUID(req.MovedBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid moved_by")
+	}
+
+	messageIDs := make([]uuid.UUID, 0, len(req.MessageIds))
+	for _, idStr := range req.MessageIds {
+		id, err := parseUUID(idStr)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+		}
+		messageIDs = append(messageIDs, id)
+	}
+
+	var targetThreadID *uuid.UUID
+	if req.TargetThreadId != nil && *req.TargetThreadId != "" {
+		id, err := parseUUID(*req.TargetThreadId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid target_thread_id")
+		}
+		targetThreadID = &id
+	}
+
+	thread, moved, err := s.chatService.MoveMessagesToThread(ctx, chatID, messageIDs, targetThreadID, req.NewThreadTitle, movedBy)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return &pb.MoveMessagesToThreadResponse{
+		Thread:     threadToProto(thread),
+		MovedCount: int32(moved),
+	}, nil
+}
+
 // Thread participant operations
 
 func (s *ChatServer) AddThreadParticipant(ctx context.Context, req *pb.AddThreadParticipantRequest) (*emptypb.Empty, error) {
@@ -1329,6 +1619,111 @@ func (s *ChatServer) ListThreadParticipants(ctx context.Context, req *pb.ListThr
 	}, nil
 }
 
+// Thread subscription operations
+
+func followedThreadToProto(t *model.FollowedThread) *pb.FollowedThread {
+	if t == nil {
+		return nil
+	}
+	return &pb.FollowedThread{
+		Thread:      threadToProto(&t.Thread),
+		UnreadCount: int32(t.UnreadCount),
+		FollowedAt:  timestamppb.New(t.FollowedAt),
+	}
+}
+
+func (s *ChatServer) FollowThread(ctx context.Context, req *pb.FollowThreadRequest) (*emptypb.Empty, error) {
+	threadID, err := parseUUID(req.ThreadId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.chatService.FollowThread(ctx, threadID, userID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) UnfollowThread(ctx context.Context, req *pb.UnfollowThreadRequest) (*emptypb.Empty, error) {
+	threadID, err := parseUUID(req.ThreadId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.chatService.UnfollowThread(ctx, threadID, userID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ListFollowedThreads(ctx context.Context, req *pb.ListFollowedThreadsRequest) (*pb.ListFollowedThreadsResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	count := int(req.Count)
+	if count < 1 {
+		count = 20
+	}
+
+	threads, total, err := s.chatService.ListFollowedThreads(ctx, userID, page, count)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoThreads := make([]*pb.FollowedThread, len(threads))
+	for i, t := range threads {
+		protoThreads[i] = followedThreadToProto(&t)
+	}
+
+	totalPages := int32(total) / int32(count)
+	if int32(total)%int32(count) > 0 {
+		totalPages++
+	}
+
+	return &pb.ListFollowedThreadsResponse{
+		Threads: protoThreads,
+		Pagination: &pb.Pagination{
+			Page:       int32(page),
+			Count:      int32(count),
+			Total:      int32(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *ChatServer) MarkThreadAsRead(ctx context.Context, req *pb.MarkThreadAsReadRequest) (*emptypb.Empty, error) {
+	threadID, err := parseUUID(req.ThreadId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.chatService.MarkThreadAsRead(ctx, threadID, userID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // Subthread operations
 
 func (s *ChatServer) ListSubthreads(ctx context.Context, req *pb.ListSubthreadsRequest) (*pb.ListThreadsResponse, error) {
@@ -1421,4 +1816,1258 @@ func (s *ChatServer) RemoveFromQuote(ctx context.Context, req *pb.RemoveFromQuot
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ChatServer) BulkDeleteMessages(ctx context.Context, req *pb.BulkDeleteMessagesRequest) (*pb.BulkDeleteMessagesResponse, error) {
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	messageIDs := make([]uuid.UUID, 0, len(req.MessageIds))
+	for _, idStr := range req.MessageIds {
+		id, err := parseUUID(idStr)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+		}
+		messageIDs = append(messageIDs, id)
+	}
+
+	var senderID *uuid.UUID
+	if req.SenderId != nil && *req.SenderId != "" {
+		id, err := parseUUID(*req.SenderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid sender_id")
+		}
+		senderID = &id
+	}
+
+	var from, to *time.Time
+	if req.From != nil {
+		t := req.From.AsTime()
+		from = &t
+	}
+	if req.To != nil {
+		t := req.To.AsTime()
+		to = &t
+	}
+
+	result, err := s.chatService.BulkDeleteMessages(ctx, chatID, userID, messageIDs, senderID, from, to)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return bulkDeleteResultToProto(result), nil
+}
+
+func (s *ChatServer) PurgeUserMessages(ctx context.Context, req *pb.PurgeUserMessagesRequest) (*pb.BulkDeleteMessagesResponse, error) {
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	targetUserID, err := parseUUID(req.TargetUserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid target_user_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	result, err := s.chatService.PurgeUserMessages(ctx, chatID, targetUserID, userID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return bulkDeleteResultToProto(result), nil
+}
+
+func bulkDeleteResultToProto(r *model.BulkDeleteResult) *pb.BulkDeleteMessagesResponse {
+	ids := make([]string, len(r.DeletedIDs))
+	for i, id := range r.DeletedIDs {
+		ids[i] = id.String()
+	}
+	return &pb.BulkDeleteMessagesResponse{
+		DeletedMessageIds: ids,
+		DeletedCount:      int32(len(ids)),
+		RestorableUntil:   timestamppb.New(r.RestorableUntil),
+	}
+}
+
+// Moderation (message reports)
+
+func reportToProto(r *model.MessageReport) *pb.MessageReport {
+	pr := &pb.MessageReport{
+		Id:             r.ID.String(),
+		ChatId:         r.ChatID.String(),
+		MessageId:      r.MessageID.String(),
+		SenderId:       r.SenderID.String(),
+		MessageContent: r.MessageContent,
+		ReporterId:     r.ReporterID.String(),
+		Reason:         string(r.Reason),
+		Status:         string(r.Status),
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+	if r.Comment != nil {
+		pr.Comment = *r.Comment
+	}
+	if r.Resolution != nil {
+		pr.Resolution = string(*r.Resolution)
+	}
+	if r.ResolvedBy != nil {
+		pr.ResolvedBy = r.ResolvedBy.String()
+	}
+	if r.ResolvedAt != nil {
+		pr.ResolvedAt = timestamppb.New(*r.ResolvedAt)
+	}
+	return pr
+}
+
+func auditEntryToProto(e *model.ModerationAuditEntry) *pb.ModerationAuditEntry {
+	pe := &pb.ModerationAuditEntry{
+		Id:        e.ID.String(),
+		ChatId:    e.ChatID.String(),
+		ActorId:   e.ActorID.String(),
+		Action:    e.Action,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+	if e.ReportID != nil {
+		pe.ReportId = e.ReportID.String()
+	}
+	if e.MessageID != nil {
+		pe.MessageId = e.MessageID.String()
+	}
+	if e.TargetUserID != nil {
+		pe.TargetUserId = e.TargetUserID.String()
+	}
+	if e.Details != nil {
+		pe.Details = *e.Details
+	}
+	return pe
+}
+
+func parseOptionalUUID(s *string) (*uuid.UUID, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	id, err := parseUUID(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (s *ChatServer) ReportMessage(ctx context.Context, req *pb.ReportMessageRequest) (*pb.MessageReport, error) {
+	messageID, err := parseUUID(req.MessageId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+	}
+	reporterID, err := parseUUID(req.ReporterId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reporter_id")
+	}
+
+	report, err := s.chatService.ReportMessage(ctx, messageID, reporterID, model.ReportReason(req.Reason), req.Comment)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return reportToProto(report), nil
+}
+
+func (s *ChatServer) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.ListReportsResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseOptionalUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	count := int(req.Count)
+	if count < 1 {
+		count = 20
+	}
+
+	reports, total, err := s.chatService.ListReports(ctx, userID, chatID, model.ReportStatus(req.Status), page, count)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoReports := make([]*pb.MessageReport, len(reports))
+	for i := range reports {
+		protoReports[i] = reportToProto(&reports[i])
+	}
+
+	totalPages := int32(total) / int32(count)
+	if int32(total)%int32(count) > 0 {
+		totalPages++
+	}
+
+	return &pb.ListReportsResponse{
+		Reports: protoReports,
+		Pagination: &pb.Pagination{
+			Page:       int32(page),
+			Count:      int32(count),
+			Total:      int32(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *ChatServer) ResolveReport(ctx context.Context, req *pb.ResolveReportRequest) (*pb.MessageReport, error) {
+	reportID, err := parseUUID(req.ReportId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid report_id")
+	}
+	moderatorID, err := parseUUID(req.ModeratorId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid moderator_id")
+	}
+
+	report, err := s.chatService.ResolveReport(ctx, reportID, moderatorID, model.ReportAction(req.Action), req.Note)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return reportToProto(report), nil
+}
+
+func (s *ChatServer) ListModerationAuditLog(ctx context.Context, req *pb.ListModerationAuditLogRequest) (*pb.ListModerationAuditLogResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseOptionalUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	count := int(req.Count)
+	if count < 1 {
+		count = 20
+	}
+
+	entries, total, err := s.chatService.ListModerationAuditLog(ctx, userID, chatID, page, count)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoEntries := make([]*pb.ModerationAuditEntry, len(entries))
+	for i := range entries {
+		protoEntries[i] = auditEntryToProto(&entries[i])
+	}
+
+	totalPages := int32(total) / int32(count)
+	if int32(total)%int32(count) > 0 {
+		totalPages++
+	}
+
+	return &pb.ListModerationAuditLogResponse{
+		Entries: protoEntries,
+		Pagination: &pb.Pagination{
+			Page:       int32(page),
+			Count:      int32(count),
+			Total:      int32(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// Chat restrictions (bans and timed mutes)
+
+func restrictionToProto(r *model.ChatRestriction) *pb.ChatRestriction {
+	pr := &pb.ChatRestriction{
+		Id:        r.ID.String(),
+		ChatId:    r.ChatID.String(),
+		UserId:    r.UserID.String(),
+		Type:      string(r.Type),
+		CreatedBy: r.CreatedBy.String(),
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+	if r.Username != nil {
+		pr.Username = *r.Username
+	}
+	if r.Reason != nil {
+		pr.Reason = *r.Reason
+	}
+	if r.PreviousRole != nil {
+		pr.PreviousRole = string(*r.PreviousRole)
+	}
+	if r.ExpiresAt != nil {
+		pr.ExpiresAt = timestamppb.New(*r.ExpiresAt)
+	}
+	return pr
+}
+
+// parseRestrictionTarget parses the chat, target user and moderator IDs of a restriction request
+func parseRestrictionTarget(chatIDStr, userIDStr, moderatorIDStr string) (chatID, userID, moderatorID uuid.UUID, err error) {
+	if chatID, err = parseUUID(chatIDStr); err != nil {
+		return chatID, userID, moderatorID, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	if userID, err = parseUUID(userIDStr); err != nil {
+		return chatID, userID, moderatorID, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if moderatorID, err = parseUUID(moderatorIDStr); err != nil {
+		return chatID, userID, moderatorID, status.Error(codes.InvalidArgument, "invalid moderator id")
+	}
+	return chatID, userID, moderatorID, nil
+}
+
+func (s *ChatServer) BanUser(ctx context.Context, req *pb.BanUserRequest) (*pb.ChatRestriction, error) {
+	chatID, userID, bannedBy, err := parseRestrictionTarget(req.ChatId, req.UserId, req.BannedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction, err := s.chatService.BanUser(ctx, chatID, userID, bannedBy, req.Reason, time.Duration(req.DurationSeconds)*time.Second)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return restrictionToProto(restriction), nil
+}
+
+func (s *ChatServer) UnbanUser(ctx context.Context, req *pb.UnbanUserRequest) (*emptypb.Empty, error) {
+	chatID, userID, unbannedBy, err := parseRestrictionTarget(req.ChatId, req.UserId, req.UnbannedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chatService.UnbanUser(ctx, chatID, userID, unbannedBy); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) MuteUser(ctx context.Context, req *pb.MuteUserRequest) (*pb.ChatRestriction, error) {
+	chatID, userID, mutedBy, err := parseRestrictionTarget(req.ChatId, req.UserId, req.MutedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction, err := s.chatService.MuteUser(ctx, chatID, userID, mutedBy, req.Reason, time.Duration(req.DurationSeconds)*time.Second)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return restrictionToProto(restriction), nil
+}
+
+func (s *ChatServer) UnmuteUser(ctx context.Context, req *pb.UnmuteUserRequest) (*emptypb.Empty, error) {
+	chatID, userID, unmutedBy, err := parseRestrictionTarget(req.ChatId, req.UserId, req.UnmutedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chatService.UnmuteUser(ctx, chatID, userID, unmutedBy); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ListChatRestrictions(ctx context.Context, req *pb.ListChatRestrictionsRequest) (*pb.ListChatRestrictionsResponse, error) {
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	restrictions, err := s.chatService.ListRestrictions(ctx, chatID, userID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoRestrictions := make([]*pb.ChatRestriction, len(restrictions))
+	for i := range restrictions {
+		protoRestrictions[i] = restrictionToProto(&restrictions[i])
+	}
+
+	return &pb.ListChatRestrictionsResponse{Restrictions: protoRestrictions}, nil
+}
+
+// Content filters
+
+func contentPolicyRulesToProto(r *model.ContentPolicyRules) *pb.ContentPolicyRules {
+	pr := &pb.ContentPolicyRules{
+		BlockedWords:    r.BlockedWords,
+		WordAction:      string(r.WordAction),
+		CardNumbers:     string(r.CardNumbers),
+		PassportNumbers: string(r.PassportNumbers),
+		MaxLength:       int32(r.MaxLength),
+		MaxAttachments:  int32(r.MaxAttachments),
+	}
+	for _, p := range r.Patterns {
+		pr.Patterns = append(pr.Patterns, &pb.ContentPatternRule{
+			Name:    p.Name,
+			Pattern: p.Pattern,
+			Action:  string(p.Action),
+		})
+	}
+	return pr
+}
+
+func contentPolicyRulesFromProto(pr *pb.ContentPolicyRules) model.ContentPolicyRules {
+	if pr == nil {
+		return model.ContentPolicyRules{}
+	}
+	r := model.ContentPolicyRules{
+		BlockedWords:    pr.BlockedWords,
+		WordAction:      model.FilterAction(pr.WordAction),
+		CardNumbers:     model.FilterAction(pr.CardNumbers),
+		PassportNumbers: model.FilterAction(pr.PassportNumbers),
+		MaxLength:       int(pr.MaxLength),
+		MaxAttachments:  int(pr.MaxAttachments),
+	}
+	for _, p := range pr.Patterns {
+		r.Patterns = append(r.Patterns, model.ContentPatternRule{
+			Name:    p.Name,
+			Pattern: p.Pattern,
+			Action:  model.FilterAction(p.Action),
+		})
+	}
+	return r
+}
+
+func contentPolicyToProto(p *model.ContentPolicy) *pb.ContentPolicy {
+	pp := &pb.ContentPolicy{
+		Rules:     contentPolicyRulesToProto(&p.Rules),
+		UpdatedBy: p.UpdatedBy.String(),
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+	if p.ChatID != nil {
+		chatID := p.ChatID.String()
+		pp.ChatId = &chatID
+	}
+	return pp
+}
+
+func contentFilterHitToProto(h *model.ContentFilterHit) *pb.ContentFilterHit {
+	ph := &pb.ContentFilterHit{
+		Id:        h.ID.String(),
+		ChatId:    h.ChatID.String(),
+		SenderId:  h.SenderID.String(),
+		Filter:    h.Filter,
+		Rule:      h.Rule,
+		Action:    string(h.Action),
+		Matches:   int32(h.Matches),
+		CreatedAt: timestamppb.New(h.CreatedAt),
+	}
+	if h.MessageID != nil {
+		ph.MessageId = h.MessageID.String()
+	}
+	return ph
+}
+
+// parsePolicyScope parses the user and optional chat IDs of a request scoped to one chat or to all chats
+func parsePolicyScope(userIDStr string, chatIDStr *string) (uuid.UUID, *uuid.UUID, error) {
+	userID, err := parseUUID(userIDStr)
+	if err != nil {
+		return uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseOptionalUUID(chatIDStr)
+	if err != nil {
+		return uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	return userID, chatID, nil
+}
+
+func (s *ChatServer) GetContentPolicy(ctx context.Context, req *pb.GetContentPolicyRequest) (*pb.ContentPolicy, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.chatService.GetContentPolicy(ctx, userID, chatID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return contentPolicyToProto(policy), nil
+}
+
+func (s *ChatServer) SetContentPolicy(ctx context.Context, req *pb.SetContentPolicyRequest) (*pb.ContentPolicy, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.chatService.SetContentPolicy(ctx, userID, chatID, contentPolicyRulesFromProto(req.Rules))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return contentPolicyToProto(policy), nil
+}
+
+func (s *ChatServer) DeleteContentPolicy(ctx context.Context, req *pb.DeleteContentPolicyRequest) (*emptypb.Empty, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chatService.DeleteContentPolicy(ctx, userID, chatID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ListContentFilterHits(ctx context.Context, req *pb.ListContentFilterHitsRequest) (*pb.ListContentFilterHitsResponse, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	count := int(req.Count)
+	if count < 1 {
+		count = 20
+	}
+
+	hits, total, err := s.chatService.ListContentFilterHits(ctx, userID, chatID, page, count)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoHits := make([]*pb.ContentFilterHit, len(hits))
+	for i := range hits {
+		protoHits[i] = contentFilterHitToProto(&hits[i])
+	}
+
+	totalPages := int32(total) / int32(count)
+	if int32(total)%int32(count) > 0 {
+		totalPages++
+	}
+
+	return &pb.ListContentFilterHitsResponse{
+		Hits: protoHits,
+		Pagination: &pb.Pagination{
+			Page:       int32(page),
+			Count:      int32(count),
+			Total:      int32(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// Outgoing webhooks
+
+func webhookToProto(sub *model.WebhookSubscription) *pb.Webhook {
+	pw := &pb.Webhook{
+		Id:         sub.ID.String(),
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		IsActive:   sub.IsActive,
+		CreatedBy:  sub.CreatedBy.String(),
+		CreatedAt:  timestamppb.New(sub.CreatedAt),
+		UpdatedAt:  timestamppb.New(sub.UpdatedAt),
+	}
+	if sub.ChatID != nil {
+		chatID := sub.ChatID.String()
+		pw.ChatId = &chatID
+	}
+	return pw
+}
+
+func webhookDeliveryToProto(d *model.WebhookDelivery) *pb.WebhookDelivery {
+	pd := &pb.WebhookDelivery{
+		Id:            d.ID.String(),
+		WebhookId:     d.SubscriptionID.String(),
+		EventType:     d.EventType,
+		Payload:       string(d.Payload),
+		Status:        string(d.Status),
+		Attempts:      int32(d.Attempts),
+		NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+	}
+	if d.ChatID != nil {
+		pd.ChatId = d.ChatID.String()
+	}
+	if d.LastStatusCode != nil {
+		pd.LastStatusCode = int32(*d.LastStatusCode)
+	}
+	if d.LastError != nil {
+		pd.LastError = *d.LastError
+	}
+	if d.LastAttemptAt != nil {
+		pd.LastAttemptAt = timestamppb.New(*d.LastAttemptAt)
+	}
+	if d.DeliveredAt != nil {
+		pd.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	return pd
+}
+
+func (s *ChatServer) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.chatService.CreateWebhook(ctx, userID, chatID, req.Url, req.EventTypes)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	pw := webhookToProto(sub)
+	pw.Secret = sub.Secret
+	return pw, nil
+}
+
+func (s *ChatServer) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := s.chatService.ListWebhooks(ctx, userID, chatID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoWebhooks := make([]*pb.Webhook, len(subs))
+	for i := range subs {
+		protoWebhooks[i] = webhookToProto(&subs[i])
+	}
+
+	return &pb.ListWebhooksResponse{Webhooks: protoWebhooks}, nil
+}
+
+func (s *ChatServer) UpdateWebhook(ctx context.Context, req *pb.UpdateWebhookRequest) (*pb.Webhook, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+
+	sub, err := s.chatService.UpdateWebhook(ctx, userID, webhookID, req.Url, req.EventTypes, req.IsActive)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return webhookToProto(sub), nil
+}
+
+func (s *ChatServer) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+
+	if err := s.chatService.DeleteWebhook(ctx, userID, webhookID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	count := int(req.Count)
+	if count < 1 {
+		count = 20
+	}
+
+	deliveries, total, err := s.chatService.ListWebhookDeliveries(ctx, userID, webhookID, model.WebhookDeliveryStatus(req.Status), page, count)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoDeliveries := make([]*pb.WebhookDelivery, len(deliveries))
+	for i := range deliveries {
+		protoDeliveries[i] = webhookDeliveryToProto(&deliveries[i])
+	}
+
+	totalPages := int32(total) / int32(count)
+	if int32(total)%int32(count) > 0 {
+		totalPages++
+	}
+
+	return &pb.ListWebhookDeliveriesResponse{
+		Deliveries: protoDeliveries,
+		Pagination: &pb.Pagination{
+			Page:       int32(page),
+			Count:      int32(count),
+			Total:      int32(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (s *ChatServer) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDelivery, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	deliveryID, err := parseUUID(req.DeliveryId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delivery_id")
+	}
+
+	delivery, err := s.chatService.RedeliverWebhook(ctx, userID, deliveryID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return webhookDeliveryToProto(delivery), nil
+}
+
+// Incoming webhooks
+
+func incomingWebhookToProto(hook *model.IncomingWebhook) *pb.IncomingWebhook {
+	pw := &pb.IncomingWebhook{
+		Id:                 hook.ID.String(),
+		ChatId:             hook.ChatID.String(),
+		BotId:              hook.BotID.String(),
+		Name:               hook.Name,
+		Token:              hook.Token,
+		RateLimitPerMinute: int32(hook.RateLimitPerMinute),
+		CreatedBy:          hook.CreatedBy.String(),
+		CreatedAt:          timestamppb.New(hook.CreatedAt),
+		TokenRotatedAt:     timestamppb.New(hook.TokenRotatedAt),
+	}
+	if hook.ThreadID != nil {
+		pw.ThreadId = hook.ThreadID.String()
+	}
+	if hook.BotUsername != nil {
+		pw.BotUsername = *hook.BotUsername
+	}
+	if hook.LastUsedAt != nil {
+		pw.LastUsedAt = timestamppb.New(*hook.LastUsedAt)
+	}
+	return pw
+}
+
+func (s *ChatServer) CreateIncomingWebhook(ctx context.Context, req *pb.CreateIncomingWebhookRequest) (*pb.IncomingWebhook, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	botID, err := parseUUID(req.BotId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bot_id")
+	}
+	threadID, err := parseOptionalUUID(req.ThreadId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+
+	hook, err := s.chatService.CreateIncomingWebhook(ctx, userID, chatID, botID, req.Name, threadID, int(req.RateLimitPerMinute))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return incomingWebhookToProto(hook), nil
+}
+
+func (s *ChatServer) ListIncomingWebhooks(ctx context.Context, req *pb.ListIncomingWebhooksRequest) (*pb.ListIncomingWebhooksResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+
+	hooks, err := s.chatService.ListIncomingWebhooks(ctx, userID, chatID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoWebhooks := make([]*pb.IncomingWebhook, len(hooks))
+	for i := range hooks {
+		protoWebhooks[i] = incomingWebhookToProto(&hooks[i])
+	}
+
+	return &pb.ListIncomingWebhooksResponse{Webhooks: protoWebhooks}, nil
+}
+
+func (s *ChatServer) RotateIncomingWebhookToken(ctx context.Context, req *pb.RotateIncomingWebhookTokenRequest) (*pb.IncomingWebhook, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+
+	hook, err := s.chatService.RotateIncomingWebhookToken(ctx, userID, webhookID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return incomingWebhookToProto(hook), nil
+}
+
+func (s *ChatServer) DeleteIncomingWebhook(ctx context.Context, req *pb.DeleteIncomingWebhookRequest) (*emptypb.Empty, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook_id")
+	}
+
+	if err := s.chatService.DeleteIncomingWebhook(ctx, userID, webhookID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) ExecuteIncomingWebhook(ctx context.Context, req *pb.ExecuteIncomingWebhookRequest) (*pb.Message, error) {
+	webhookID, err := parseUUID(req.WebhookId)
+	if err != nil {
+		// Same answer as a wrong token, webhook IDs are not revealed
+		return nil, status.Error(codes.Unauthenticated, "invalid webhook token")
+	}
+	threadID, err := parseOptionalUUID(req.ThreadId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+
+	payload := model.IncomingWebhookPayload{
+		Text:     req.Text,
+		Title:    req.Title,
+		URL:      req.Url,
+		ThreadID: threadID,
+	}
+	for _, f := range req.Fields {
+		payload.Fields = append(payload.Fields, model.IncomingWebhookField{Name: f.Name, Value: f.Value})
+	}
+	for _, id := range req.FileLinkIds {
+		fileLinkID, err := parseUUID(id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid file_link_id: "+id)
+		}
+		payload.FileLinkIDs = append(payload.FileLinkIDs, fileLinkID)
+	}
+
+	message, err := s.chatService.ExecuteIncomingWebhook(ctx, webhookID, req.Token, payload)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return messageToProto(message), nil
+}
+
+// Slash commands
+
+func botCommandToProto(cmd *model.BotCommand) *pb.BotCommand {
+	pc := &pb.BotCommand{
+		Id:          cmd.ID.String(),
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Usage:       cmd.Usage,
+		BotId:       cmd.BotID.String(),
+		Url:         cmd.URL,
+		CreatedBy:   cmd.CreatedBy.String(),
+		CreatedAt:   timestamppb.New(cmd.CreatedAt),
+	}
+	if cmd.ChatID != nil {
+		pc.ChatId = cmd.ChatID.String()
+	}
+	return pc
+}
+
+func (s *ChatServer) ListCommands(ctx context.Context, req *pb.ListCommandsRequest) (*pb.ListCommandsResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+
+	cmds, err := s.chatService.ListCommands(ctx, chatID, userID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoCommands := make([]*pb.SlashCommand, len(cmds))
+	for i, cmd := range cmds {
+		protoCommands[i] = &pb.SlashCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Usage:       cmd.Usage,
+		}
+		if cmd.BotCommandID != nil {
+			protoCommands[i].BotCommandId = cmd.BotCommandID.String()
+		}
+	}
+
+	return &pb.ListCommandsResponse{Commands: protoCommands}, nil
+}
+
+func (s *ChatServer) RegisterBotCommand(ctx context.Context, req *pb.RegisterBotCommandRequest) (*pb.BotCommand, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+	botID, err := parseUUID(req.BotId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bot_id")
+	}
+
+	cmd, err := s.chatService.RegisterBotCommand(ctx, userID, chatID, botID, req.Name, req.Description, req.Usage, req.Url)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	pc := botCommandToProto(cmd)
+	pc.Secret = cmd.Secret
+	return pc, nil
+}
+
+func (s *ChatServer) ListBotCommands(ctx context.Context, req *pb.ListBotCommandsRequest) (*pb.ListBotCommandsResponse, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	cmds, err := s.chatService.ListBotCommands(ctx, userID, chatID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoCommands := make([]*pb.BotCommand, len(cmds))
+	for i := range cmds {
+		protoCommands[i] = botCommandToProto(&cmds[i])
+	}
+
+	return &pb.ListBotCommandsResponse{Commands: protoCommands}, nil
+}
+
+func (s *ChatServer) DeleteBotCommand(ctx context.Context, req *pb.DeleteBotCommandRequest) (*emptypb.Empty, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	commandID, err := parseUUID(req.CommandId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid command_id")
+	}
+
+	if err := s.chatService.DeleteBotCommand(ctx, userID, commandID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ChatServer) InteractMessage(ctx context.Context, req *pb.InteractMessageRequest) (*pb.InteractMessageResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	messageID, err := parseUUID(req.MessageId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+	}
+	if req.ActionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "action_id is required")
+	}
+
+	message, err := s.chatService.InteractMessage(ctx, messageID, userID, req.ActionId, req.Value)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return &pb.InteractMessageResponse{Message: messageToProto(message)}, nil
+}
+
+// Reminders
+
+func reminderToProto(r *model.Reminder) *pb.Reminder {
+	pr := &pb.Reminder{
+		Id:        r.ID.String(),
+		ChatId:    r.ChatID.String(),
+		UserId:    r.UserID.String(),
+		Text:      r.Text,
+		RemindAt:  timestamppb.New(r.RemindAt),
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+	if r.ThreadID != nil {
+		pr.ThreadId = r.ThreadID.String()
+	}
+	if r.MessageID != nil {
+		pr.MessageId = r.MessageID.String()
+	}
+	if r.DeliveredAt != nil {
+		pr.DeliveredAt = timestamppb.New(*r.DeliveredAt)
+	}
+	return pr
+}
+
+func (s *ChatServer) CreateMessageReminder(ctx context.Context, req *pb.CreateMessageReminderRequest) (*pb.Reminder, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	messageID, err := parseUUID(req.MessageId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid message_id")
+	}
+	if req.RemindAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "remind_at is required")
+	}
+
+	reminder, err := s.chatService.CreateMessageReminder(ctx, messageID, userID, req.RemindAt.AsTime(), req.Note)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return reminderToProto(reminder), nil
+}
+
+func (s *ChatServer) ListReminders(ctx context.Context, req *pb.ListRemindersRequest) (*pb.ListRemindersResponse, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.chatService.ListReminders(ctx, userID, chatID, req.IncludeDelivered)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoReminders := make([]*pb.Reminder, len(reminders))
+	for i := range reminders {
+		protoReminders[i] = reminderToProto(&reminders[i])
+	}
+
+	return &pb.ListRemindersResponse{Reminders: protoReminders}, nil
+}
+
+func (s *ChatServer) CancelReminder(ctx context.Context, req *pb.CancelReminderRequest) (*emptypb.Empty, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	reminderID, err := parseUUID(req.ReminderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reminder_id")
+	}
+
+	if err := s.chatService.CancelReminder(ctx, reminderID, userID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Custom emoji
+
+func customEmojiToProto(e *model.CustomEmoji) *pb.CustomEmoji {
+	return &pb.CustomEmoji{
+		Id:         e.ID.String(),
+		ChatId:     e.ChatID.String(),
+		Name:       e.Name,
+		FileLinkId: e.FileLinkID.String(),
+		CreatedBy:  e.CreatedBy.String(),
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+}
+
+func (s *ChatServer) CreateCustomEmoji(ctx context.Context, req *pb.CreateCustomEmojiRequest) (*pb.CustomEmoji, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	fileLinkID, err := parseUUID(req.FileLinkId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid file_link_id")
+	}
+
+	e, err := s.chatService.CreateCustomEmoji(ctx, chatID, userID, req.Name, fileLinkID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return customEmojiToProto(e), nil
+}
+
+func (s *ChatServer) ListCustomEmoji(ctx context.Context, req *pb.ListCustomEmojiRequest) (*pb.ListCustomEmojiResponse, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(req.ChatId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+
+	emoji, err := s.chatService.ListCustomEmoji(ctx, chatID, userID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoEmoji := make([]*pb.CustomEmoji, len(emoji))
+	for i := range emoji {
+		protoEmoji[i] = customEmojiToProto(&emoji[i])
+	}
+
+	return &pb.ListCustomEmojiResponse{Emoji: protoEmoji}, nil
+}
+
+func (s *ChatServer) DeleteCustomEmoji(ctx context.Context, req *pb.DeleteCustomEmojiRequest) (*emptypb.Empty, error) {
+	userID, err := parseUUID(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	emojiID, err := parseUUID(req.EmojiId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid emoji_id")
+	}
+
+	if err := s.chatService.DeleteCustomEmoji(ctx, emojiID, userID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Drafts
+
+func draftToProto(d *model.Draft) *pb.Draft {
+	pd := &pb.Draft{
+		Id:         d.ID.String(),
+		UserId:     d.UserID.String(),
+		ChatId:     d.ChatID.String(),
+		Content:    d.Content,
+		ReplyToIds: make([]string, len(d.ReplyToIDs)),
+		UpdatedAt:  timestamppb.New(d.UpdatedAt),
+	}
+	for i, id := range d.ReplyToIDs {
+		pd.ReplyToIds[i] = id.String()
+	}
+	if d.ThreadID != nil {
+		pd.ThreadId = d.ThreadID.String()
+	}
+	return pd
+}
+
+// parseDraftScope parses the user, chat and optional thread of a draft request
+func parseDraftScope(userIDStr, chatIDStr string, threadIDStr *string) (uuid.UUID, uuid.UUID, *uuid.UUID, error) {
+	userID, err := parseUUID(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	chatID, err := parseUUID(chatIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid chat_id")
+	}
+	threadID, err := parseOptionalUUID(threadIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid thread_id")
+	}
+	return userID, chatID, threadID, nil
+}
+
+func (s *ChatServer) SaveDraft(ctx context.Context, req *pb.SaveDraftRequest) (*pb.Draft, error) {
+	userID, chatID, threadID, err := parseDraftScope(req.UserId, req.ChatId, req.ThreadId)
+	if err != nil {
+		return nil, err
+	}
+
+	var replyToIDs []uuid.UUID
+	for _, id := range req.ReplyToIds {
+		replyToID, err := parseUUID(id)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid reply_to_id: "+id)
+		}
+		replyToIDs = append(replyToIDs, replyToID)
+	}
+
+	draft, err := s.chatService.SaveDraft(ctx, userID, chatID, threadID, req.Content, replyToIDs)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return draftToProto(draft), nil
+}
+
+func (s *ChatServer) GetDrafts(ctx context.Context, req *pb.GetDraftsRequest) (*pb.GetDraftsResponse, error) {
+	userID, chatID, err := parsePolicyScope(req.UserId, req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts, err := s.chatService.GetDrafts(ctx, userID, chatID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	protoDrafts := make([]*pb.Draft, len(drafts))
+	for i := range drafts {
+		protoDrafts[i] = draftToProto(&drafts[i])
+	}
+
+	return &pb.GetDraftsResponse{Drafts: protoDrafts}, nil
+}
+
+func (s *ChatServer) DeleteDraft(ctx context.Context, req *pb.DeleteDraftRequest) (*emptypb.Empty, error) {
+	userID, chatID, threadID, err := parseDraftScope(req.UserId, req.ChatId, req.ThreadId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chatService.DeleteDraft(ctx, userID, chatID, threadID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // Poll operations - not implemented yet, using UnimplementedChatServiceServer
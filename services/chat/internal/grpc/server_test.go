@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/repository"
+	"github.com/icegreg/chat-smpl/services/chat/internal/service"
+)
+
+func TestHandleError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"chat not found", repository.ErrChatNotFound, codes.NotFound},
+		{"not a participant", service.ErrNotParticipant, codes.PermissionDenied},
+		{"invalid reminder", fmt.Errorf("%w: too far ahead", service.ErrInvalidReminder), codes.InvalidArgument},
+		{"invalid emoji", fmt.Errorf("%w: unknown", service.ErrInvalidEmoji), codes.InvalidArgument},
+		{"draft too long", fmt.Errorf("%w: drafts are limited to 10000 characters", service.ErrInvalidDraft), codes.InvalidArgument},
+		{"invalid bulk delete", fmt.Errorf("%w: message_ids or sender_id is required", service.ErrInvalidBulkDelete), codes.InvalidArgument},
+		{"report not found", repository.ErrReportNotFound, codes.NotFound},
+		{"already reported", service.ErrAlreadyReported, codes.AlreadyExists},
+		{"report resolved", service.ErrReportResolved, codes.FailedPrecondition},
+		{"banned", fmt.Errorf("%w until 2030-01-02T03:04:05Z", service.ErrUserBanned), codes.PermissionDenied},
+		{"already restricted", service.ErrAlreadyRestricted, codes.AlreadyExists},
+		{"restriction not found", repository.ErrRestrictionNotFound, codes.NotFound},
+		{"rate limited", &service.RateLimitError{RetryAfter: time.Second}, codes.ResourceExhausted},
+		{"unknown", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(handleError(tt.err))
+			assert.Equal(t, tt.want, st.Code())
+		})
+	}
+}
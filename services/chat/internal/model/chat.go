@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -53,6 +54,12 @@ type Chat struct {
 	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// SlowModeSeconds is the minimum interval between messages of one participant (0 = off)
+	SlowModeSeconds int `json:"slow_mode_seconds" db:"slow_mode_seconds"`
+
+	// HasDraft is set in chat lists when the requesting user has an unsent draft in the chat
+	HasDraft bool `json:"has_draft"`
 }
 
 type ChatParticipant struct {
@@ -65,32 +72,63 @@ type ChatParticipant struct {
 	Email       *string         `json:"email,omitempty" db:"email"`
 	DisplayName *string         `json:"display_name,omitempty" db:"display_name"`
 	AvatarURL   *string         `json:"avatar_url,omitempty" db:"avatar_url"`
+	IsBot       bool            `json:"is_bot" db:"is_bot"`
 }
 
 type Message struct {
-	ID                     uuid.UUID   `json:"id" db:"id"`
-	ChatID                 uuid.UUID   `json:"chat_id" db:"chat_id"`
-	ParentID               *uuid.UUID  `json:"parent_id,omitempty" db:"parent_id"`
-	ThreadID               *uuid.UUID  `json:"thread_id,omitempty" db:"thread_id"`
-	SenderID               uuid.UUID   `json:"sender_id" db:"sender_id"`
-	Content                string      `json:"content" db:"content"`
-	SentAt                 time.Time   `json:"sent_at" db:"sent_at"`
-	UpdatedAt              *time.Time  `json:"updated_at,omitempty" db:"updated_at"`
-	IsDeleted              bool        `json:"is_deleted" db:"is_deleted"`
-	DeletedAt              *time.Time  `json:"deleted_at,omitempty" db:"deleted_at"`
-	DeletedBy              *uuid.UUID  `json:"deleted_by,omitempty" db:"deleted_by"`
-	IsModeratedDeletion    bool        `json:"is_moderated_deletion" db:"is_moderated_deletion"`
-	OriginalContent        *string     `json:"-" db:"original_content"` // Not exposed in API, used for restoration
-	IsSystem               bool        `json:"is_system" db:"is_system"`
-	SeqNum                 int64       `json:"seq_num" db:"seq_num"` // Sequence number for reliable sync
-	ForwardedFromMessageID *uuid.UUID  `json:"forwarded_from_message_id,omitempty" db:"forwarded_from_message_id"`
-	ForwardedFromChatID    *uuid.UUID  `json:"forwarded_from_chat_id,omitempty" db:"forwarded_from_chat_id"`
-	SenderUsername         *string     `json:"sender_username,omitempty" db:"sender_username"`
-	SenderDisplayName      *string     `json:"sender_display_name,omitempty" db:"sender_display_name"`
-	SenderAvatarURL        *string     `json:"sender_avatar_url,omitempty" db:"sender_avatar_url"`
-	FileLinkIDs            []uuid.UUID `json:"file_link_ids,omitempty"`    // Loaded from message_file_attachments
-	ReplyToIDs             []uuid.UUID `json:"reply_to_ids,omitempty"`     // IDs of messages this is replying to
-	ReplyToMessages        []Message   `json:"reply_to_messages,omitempty"` // Full message data for replies (loaded)
+	ID                     uuid.UUID         `json:"id" db:"id"`
+	ChatID                 uuid.UUID         `json:"chat_id" db:"chat_id"`
+	ParentID               *uuid.UUID        `json:"parent_id,omitempty" db:"parent_id"`
+	ThreadID               *uuid.UUID        `json:"thread_id,omitempty" db:"thread_id"`
+	SenderID               uuid.UUID         `json:"sender_id" db:"sender_id"`
+	Content                string            `json:"content" db:"content"`
+	SentAt                 time.Time         `json:"sent_at" db:"sent_at"`
+	UpdatedAt              *time.Time        `json:"updated_at,omitempty" db:"updated_at"`
+	IsDeleted              bool              `json:"is_deleted" db:"is_deleted"`
+	DeletedAt              *time.Time        `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy              *uuid.UUID        `json:"deleted_by,omitempty" db:"deleted_by"`
+	IsModeratedDeletion    bool              `json:"is_moderated_deletion" db:"is_moderated_deletion"`
+	OriginalContent        *string           `json:"-" db:"original_content"` // Not exposed in API, used for restoration
+	IsSystem               bool              `json:"is_system" db:"is_system"`
+	SeqNum                 int64             `json:"seq_num" db:"seq_num"` // Sequence number for reliable sync
+	ForwardedFromMessageID *uuid.UUID        `json:"forwarded_from_message_id,omitempty" db:"forwarded_from_message_id"`
+	ForwardedFromChatID    *uuid.UUID        `json:"forwarded_from_chat_id,omitempty" db:"forwarded_from_chat_id"`
+	SenderUsername         *string           `json:"sender_username,omitempty" db:"sender_username"`
+	SenderDisplayName      *string           `json:"sender_display_name,omitempty" db:"sender_display_name"`
+	SenderAvatarURL        *string           `json:"sender_avatar_url,omitempty" db:"sender_avatar_url"`
+	SenderIsBot            bool              `json:"sender_is_bot,omitempty" db:"sender_is_bot"`
+	IsEphemeral            bool              `json:"is_ephemeral,omitempty"`      // Only shown to one user, never stored
+	FileLinkIDs            []uuid.UUID       `json:"file_link_ids,omitempty"`     // Loaded from message_file_attachments
+	ReplyToIDs             []uuid.UUID       `json:"reply_to_ids,omitempty"`      // IDs of messages this is replying to
+	ReplyToMessages        []Message         `json:"reply_to_messages,omitempty"` // Full message data for replies (loaded)
+	Buttons                []MessageButton   `json:"buttons,omitempty"`           // Interactive buttons (loaded from message_interactions)
+	Reactions              []ReactionSummary `json:"reactions,omitempty"`         // Per-emoji counts (loaded from message_reactions)
+}
+
+// Message change types recorded for sync tombstones
+const (
+	MessageChangeEdited   = "edited"
+	MessageChangeDeleted  = "deleted"
+	MessageChangeRestored = "restored"
+	MessageChangeMoved    = "moved"
+)
+
+// MessageTombstone describes a change to an already synced message (edit, delete, restore, move).
+// Message holds the current state for everything except deletions.
+type MessageTombstone struct {
+	MessageID        uuid.UUID  `json:"message_id" db:"id"`
+	ChatID           uuid.UUID  `json:"chat_id" db:"chat_id"`
+	ThreadID         *uuid.UUID `json:"thread_id,omitempty" db:"thread_id"`
+	PreviousThreadID *uuid.UUID `json:"previous_thread_id,omitempty" db:"previous_thread_id"`
+	ChangeType       string     `json:"change_type" db:"change_type"`
+	ChangeSeqNum     int64      `json:"change_seq_num" db:"change_seq_num"`
+	Message          *Message   `json:"message,omitempty"`
+}
+
+// BulkDeleteResult is the outcome of a bulk message deletion
+type BulkDeleteResult struct {
+	DeletedIDs      []uuid.UUID `json:"deleted_ids"`
+	RestorableUntil time.Time   `json:"restorable_until"` // Deleted messages can be restored until retention expires
 }
 
 type Reaction struct {
@@ -101,6 +139,23 @@ type Reaction struct {
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
+// ReactionSummary aggregates the reactions of a message by emoji
+type ReactionSummary struct {
+	Reaction    string `json:"reaction"`
+	Count       int    `json:"count"`
+	ReactedByMe bool   `json:"reacted_by_me"`
+}
+
+// CustomEmoji is an image uploaded to a chat and used as a ":name:" reaction
+type CustomEmoji struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ChatID     uuid.UUID `json:"chat_id" db:"chat_id"`
+	Name       string    `json:"name" db:"name"`
+	FileLinkID uuid.UUID `json:"file_link_id" db:"file_link_id"`
+	CreatedBy  uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
 type MessageReader struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	MessageID uuid.UUID `json:"message_id" db:"message_id"`
@@ -150,6 +205,32 @@ type ArchivedChat struct {
 	ArchivedAt time.Time `json:"archived_at" db:"archived_at"`
 }
 
+// ChatListChangeType describes a mutation of a user's chat list
+type ChatListChangeType string
+
+const (
+	ChatListChangeChatCreated        ChatListChangeType = "chat_created"
+	ChatListChangeChatUpdated        ChatListChangeType = "chat_updated"
+	ChatListChangeChatDeleted        ChatListChangeType = "chat_deleted"
+	ChatListChangeParticipantAdded   ChatListChangeType = "participant_added"
+	ChatListChangeParticipantRemoved ChatListChangeType = "participant_removed"
+	ChatListChangeArchived           ChatListChangeType = "archived"
+	ChatListChangeUnarchived         ChatListChangeType = "unarchived"
+	ChatListChangeFavorited          ChatListChangeType = "favorited"
+	ChatListChangeUnfavorited        ChatListChangeType = "unfavorited"
+)
+
+// ChatListChange is an entry of the per-user chat list change log.
+// Chat holds the current chat state, nil if the chat was deleted or the user is no longer a participant.
+type ChatListChange struct {
+	Version    int64              `json:"version" db:"version"`
+	ChatID     uuid.UUID          `json:"chat_id" db:"chat_id"`
+	ChangeType ChatListChangeType `json:"change_type" db:"change_type"`
+	ActorID    *uuid.UUID         `json:"actor_id,omitempty" db:"actor_id"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+	Chat       *Chat              `json:"chat,omitempty"`
+}
+
 // Thread types
 type ThreadType string
 
@@ -200,6 +281,22 @@ type ThreadParticipant struct {
 	AddedAt  time.Time `json:"added_at" db:"added_at"`
 }
 
+// ThreadSubscription represents a user following a thread
+type ThreadSubscription struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ThreadID   uuid.UUID `json:"thread_id" db:"thread_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	LastReadAt time.Time `json:"last_read_at" db:"last_read_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// FollowedThread is a thread in the user's inbox with its unread counter
+type FollowedThread struct {
+	Thread
+	UnreadCount int       `json:"unread_count" db:"unread_count"`
+	FollowedAt  time.Time `json:"followed_at" db:"followed_at"`
+}
+
 // ChatFileGroupType represents the type of file group associated with a chat
 type ChatFileGroupType string
 
@@ -233,3 +330,341 @@ type ChatFileLink struct {
 	AttachedBy uuid.UUID `json:"attached_by" db:"attached_by"`
 	AttachedAt time.Time `json:"attached_at" db:"attached_at"`
 }
+
+// UserRole is the global role of a user, owned by users-service (con_test.users.role)
+type UserRole string
+
+const (
+	UserRoleOwner     UserRole = "owner"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleUser      UserRole = "user"
+	UserRoleGuest     UserRole = "guest"
+)
+
+// CanModerate mirrors users-service model.Role.CanModerate
+func (r UserRole) CanModerate() bool {
+	return r == UserRoleOwner || r == UserRoleModerator
+}
+
+// ReportReason is why a message was reported
+type ReportReason string
+
+const (
+	ReportReasonSpam       ReportReason = "spam"
+	ReportReasonAbuse      ReportReason = "abuse"
+	ReportReasonHarassment ReportReason = "harassment"
+	ReportReasonIllegal    ReportReason = "illegal"
+	ReportReasonOther      ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonAbuse, ReportReasonHarassment, ReportReasonIllegal, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// ReportStatus is the state of a report in the moderation queue
+type ReportStatus string
+
+const (
+	ReportStatusOpen     ReportStatus = "open"
+	ReportStatusResolved ReportStatus = "resolved"
+)
+
+// ReportAction is the moderator's decision on a report
+type ReportAction string
+
+const (
+	ReportActionDismiss  ReportAction = "dismiss"
+	ReportActionDelete   ReportAction = "delete"   // Delete the reported message
+	ReportActionRestrict ReportAction = "restrict" // Make the sender read-only in the chat
+)
+
+func (a ReportAction) IsValid() bool {
+	switch a {
+	case ReportActionDismiss, ReportActionDelete, ReportActionRestrict:
+		return true
+	}
+	return false
+}
+
+// MessageReport is a member's report of a message
+type MessageReport struct {
+	ID             uuid.UUID     `json:"id" db:"id"`
+	ChatID         uuid.UUID     `json:"chat_id" db:"chat_id"`
+	MessageID      uuid.UUID     `json:"message_id" db:"message_id"`
+	SenderID       uuid.UUID     `json:"sender_id" db:"sender_id"`
+	MessageContent string        `json:"message_content" db:"message_content"` // Snapshot at report time
+	ReporterID     uuid.UUID     `json:"reporter_id" db:"reporter_id"`
+	Reason         ReportReason  `json:"reason" db:"reason"`
+	Comment        *string       `json:"comment,omitempty" db:"comment"`
+	Status         ReportStatus  `json:"status" db:"status"`
+	Resolution     *ReportAction `json:"resolution,omitempty" db:"resolution"`
+	ResolvedBy     *uuid.UUID    `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time    `json:"resolved_at,omitempty" db:"resolved_at"`
+	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+}
+
+// Moderation audit actions
+const (
+	ModerationActionReportCreated   = "report_created"
+	ModerationActionReportDismissed = "report_dismissed"
+	ModerationActionMessageDeleted  = "message_deleted"
+	ModerationActionUserRestricted  = "user_restricted"
+	ModerationActionUserBanned      = "user_banned"
+	ModerationActionUserUnbanned    = "user_unbanned"
+	ModerationActionUserMuted       = "user_muted"
+	ModerationActionUserUnmuted     = "user_unmuted"
+)
+
+// ModerationAuditEntry is a record of the moderation audit trail
+type ModerationAuditEntry struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	ChatID       uuid.UUID  `json:"chat_id" db:"chat_id"`
+	ActorID      uuid.UUID  `json:"actor_id" db:"actor_id"`
+	Action       string     `json:"action" db:"action"`
+	ReportID     *uuid.UUID `json:"report_id,omitempty" db:"report_id"`
+	MessageID    *uuid.UUID `json:"message_id,omitempty" db:"message_id"`
+	TargetUserID *uuid.UUID `json:"target_user_id,omitempty" db:"target_user_id"`
+	Details      *string    `json:"details,omitempty" db:"details"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// RestrictionType is the kind of a chat restriction
+type RestrictionType string
+
+const (
+	RestrictionTypeBan  RestrictionType = "ban"  // Removed from the chat and cannot be added back
+	RestrictionTypeMute RestrictionType = "mute" // Read-only; the previous role is restored when it ends
+)
+
+func (t RestrictionType) IsValid() bool {
+	return t == RestrictionTypeBan || t == RestrictionTypeMute
+}
+
+// ChatRestriction is a ban or mute of a user in a chat
+type ChatRestriction struct {
+	ID           uuid.UUID        `json:"id" db:"id"`
+	ChatID       uuid.UUID        `json:"chat_id" db:"chat_id"`
+	UserID       uuid.UUID        `json:"user_id" db:"user_id"`
+	Type         RestrictionType  `json:"type" db:"type"`
+	Reason       *string          `json:"reason,omitempty" db:"reason"`
+	PreviousRole *ParticipantRole `json:"previous_role,omitempty" db:"previous_role"`
+	CreatedBy    uuid.UUID        `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	ExpiresAt    *time.Time       `json:"expires_at,omitempty" db:"expires_at"` // nil = until lifted
+	LiftedAt     *time.Time       `json:"lifted_at,omitempty" db:"lifted_at"`
+	LiftedBy     *uuid.UUID       `json:"lifted_by,omitempty" db:"lifted_by"`
+
+	// Joined from users
+	Username *string `json:"username,omitempty" db:"username"`
+}
+
+// IsActive reports whether the restriction is in force at the given time
+func (r *ChatRestriction) IsActive(now time.Time) bool {
+	return r.LiftedAt == nil && (r.ExpiresAt == nil || r.ExpiresAt.After(now))
+}
+
+// FilterAction is what a content filter does with a match
+type FilterAction string
+
+const (
+	FilterActionMask  FilterAction = "mask"  // Replace the match with asterisks and send
+	FilterActionBlock FilterAction = "block" // Reject the message
+)
+
+func (a FilterAction) IsValid() bool {
+	return a == FilterActionMask || a == FilterActionBlock
+}
+
+// ContentPatternRule is a custom DLP regular expression
+type ContentPatternRule struct {
+	Name    string       `json:"name"`
+	Pattern string       `json:"pattern"`
+	Action  FilterAction `json:"action"`
+}
+
+// ContentPolicyRules configures the pre-send content filters. Empty actions disable a filter.
+type ContentPolicyRules struct {
+	BlockedWords    []string             `json:"blocked_words,omitempty"`
+	WordAction      FilterAction         `json:"word_action,omitempty"`      // Defaults to mask
+	CardNumbers     FilterAction         `json:"card_numbers,omitempty"`     // Payment card numbers (Luhn-checked)
+	PassportNumbers FilterAction         `json:"passport_numbers,omitempty"` // Passport numbers
+	Patterns        []ContentPatternRule `json:"patterns,omitempty"`
+	MaxLength       int                  `json:"max_length,omitempty"`      // In characters, 0 = no limit
+	MaxAttachments  int                  `json:"max_attachments,omitempty"` // 0 = no limit
+}
+
+// ContentPolicy is the content filter configuration of a chat, or the global one when ChatID is nil.
+// A chat policy replaces the global policy for that chat.
+type ContentPolicy struct {
+	ChatID    *uuid.UUID         `json:"chat_id,omitempty" db:"chat_id"`
+	Rules     ContentPolicyRules `json:"rules" db:"rules"`
+	UpdatedBy uuid.UUID          `json:"updated_by" db:"updated_by"`
+	UpdatedAt time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// ContentFilterHit is a logged content filter match. The matched text itself is not stored.
+type ContentFilterHit struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	ChatID    uuid.UUID    `json:"chat_id" db:"chat_id"`
+	SenderID  uuid.UUID    `json:"sender_id" db:"sender_id"`
+	MessageID *uuid.UUID   `json:"message_id,omitempty" db:"message_id"` // nil when the message was blocked
+	Filter    string       `json:"filter" db:"filter"`                   // limits, words, dlp
+	Rule      string       `json:"rule" db:"rule"`                       // e.g. card_number, max_length, custom pattern name
+	Action    FilterAction `json:"action" db:"action"`
+	Matches   int          `json:"matches" db:"matches"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// WebhookSubscription sends chat events of the listed types to an external URL.
+// A subscription without ChatID receives events of every chat.
+type WebhookSubscription struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	ChatID     *uuid.UUID `json:"chat_id,omitempty" db:"chat_id"`
+	URL        string     `json:"url" db:"url"`
+	Secret     string     `json:"-" db:"secret"` // HMAC key, only returned when the subscription is created
+	EventTypes []string   `json:"event_types" db:"event_types"`
+	IsActive   bool       `json:"is_active" db:"is_active"`
+	CreatedBy  uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for the next attempt
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // Receiver answered 2xx
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // Gave up after the maximum number of attempts
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliveryDelivered || s == WebhookDeliveryDead
+}
+
+// WebhookDelivery is one event queued for one subscription, together with the result of its last attempt
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" db:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" db:"subscription_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	ChatID         *uuid.UUID            `json:"chat_id,omitempty" db:"chat_id"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"` // Event envelope JSON as published to RabbitMQ
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      *string               `json:"last_error,omitempty" db:"last_error"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+
+	// Set when the delivery is claimed for sending
+	URL    string `json:"-" db:"url"`
+	Secret string `json:"-" db:"secret"`
+}
+
+// IncomingWebhook lets an external system post messages into a chat as a bot account.
+// Only the token hash is stored; Token is set right after creation or rotation.
+type IncomingWebhook struct {
+	ID                 uuid.UUID  `json:"id" db:"id"`
+	ChatID             uuid.UUID  `json:"chat_id" db:"chat_id"`
+	ThreadID           *uuid.UUID `json:"thread_id,omitempty" db:"thread_id"` // Default thread for posted messages
+	BotID              uuid.UUID  `json:"bot_id" db:"bot_id"`
+	BotUsername        *string    `json:"bot_username,omitempty" db:"bot_username"`
+	Name               string     `json:"name" db:"name"`
+	TokenHash          string     `json:"-" db:"token_hash"`
+	Token              string     `json:"-"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute" db:"rate_limit_per_minute"`
+	CreatedBy          uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	TokenRotatedAt     time.Time  `json:"token_rotated_at" db:"token_rotated_at"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// IncomingWebhookField is a name/value pair of a structured webhook message
+type IncomingWebhookField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// IncomingWebhookPayload is the message posted to an incoming webhook URL
+type IncomingWebhookPayload struct {
+	Text        string                 `json:"text"`
+	Title       string                 `json:"title,omitempty"`
+	URL         string                 `json:"url,omitempty"`
+	Fields      []IncomingWebhookField `json:"fields,omitempty"`
+	FileLinkIDs []uuid.UUID            `json:"file_link_ids,omitempty"`
+	ThreadID    *uuid.UUID             `json:"thread_id,omitempty"` // Only for webhooks not bound to a thread
+}
+
+// MessageButton is a button under an interactive message. Buttons with ActionID send a callback
+// to the command that posted the message; buttons with URL are plain links.
+type MessageButton struct {
+	ActionID string `json:"action_id,omitempty"`
+	Label    string `json:"label"`
+	Value    string `json:"value,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Style    string `json:"style,omitempty"` // "primary", "danger" or empty
+}
+
+// MessageInteraction routes button callbacks of a message to the command that posted it.
+// BotCommandID is nil for built-in commands.
+type MessageInteraction struct {
+	MessageID    uuid.UUID       `json:"message_id" db:"message_id"`
+	Command      string          `json:"command" db:"command"`
+	BotCommandID *uuid.UUID      `json:"bot_command_id,omitempty" db:"bot_command_id"`
+	Buttons      []MessageButton `json:"buttons" db:"buttons"`
+	State        json.RawMessage `json:"state,omitempty" db:"state"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// BotCommand is an external slash command: invocations and button callbacks are POSTed to URL,
+// signed with Secret, and replies are posted as the bot. A command without ChatID works in every chat.
+type BotCommand struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	ChatID      *uuid.UUID `json:"chat_id,omitempty" db:"chat_id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	Usage       string     `json:"usage" db:"usage"`
+	BotID       uuid.UUID  `json:"bot_id" db:"bot_id"`
+	URL         string     `json:"url" db:"url"`
+	Secret      string     `json:"-" db:"secret"` // Signing key, only returned when the command is registered
+	CreatedBy   uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// SlashCommand describes a command available in a chat, built-in or registered by a bot
+type SlashCommand struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Usage        string     `json:"usage"`
+	BotCommandID *uuid.UUID `json:"bot_command_id,omitempty"` // Nil for built-in commands
+}
+
+// Reminder is a /remind or "remind me about this" reminder, delivered to the user as an
+// ephemeral system message at RemindAt. MessageID is set for reminders about a message.
+type Reminder struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	ChatID      uuid.UUID  `json:"chat_id" db:"chat_id"`
+	ThreadID    *uuid.UUID `json:"thread_id,omitempty" db:"thread_id"`
+	MessageID   *uuid.UUID `json:"message_id,omitempty" db:"message_id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Text        string     `json:"text" db:"text"`
+	RemindAt    time.Time  `json:"remind_at" db:"remind_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+}
+
+// Draft is an unsent message of a user, kept per chat or per thread and synced across devices
+type Draft struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	UserID     uuid.UUID   `json:"user_id" db:"user_id"`
+	ChatID     uuid.UUID   `json:"chat_id" db:"chat_id"`
+	ThreadID   *uuid.UUID  `json:"thread_id,omitempty" db:"thread_id"`
+	Content    string      `json:"content" db:"content"`
+	ReplyToIDs []uuid.UUID `json:"reply_to_ids,omitempty" db:"reply_to_ids"`
+	UpdatedAt  time.Time   `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle buckets and finished cooldowns are dropped
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	idle   time.Duration // Time after which the bucket is full again and can be dropped
+}
+
+// MemoryStore keeps rate limit state in process memory (single replica only)
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	cooldowns map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		cooldowns: make(map[string]time.Time),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens: float64(rule.Burst),
+			last:   now,
+			idle:   time.Duration(float64(rule.Burst) / rule.Rate * float64(time.Second)),
+		}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(rule.Burst), b.tokens+now.Sub(b.last).Seconds()*rule.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+	return time.Duration((1 - b.tokens) / rule.Rate * float64(time.Second)), nil
+}
+
+func (s *MemoryStore) Cooldown(_ context.Context, key string, d time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if until, ok := s.cooldowns[key]; ok && until.After(now) {
+		return until.Sub(now), nil
+	}
+	s.cooldowns[key] = now.Add(d)
+	return 0, nil
+}
+
+// sweep drops state that no longer limits anything. Must be called with mu held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.last) >= b.idle {
+			delete(s.buckets, key)
+		}
+	}
+	for key, until := range s.cooldowns {
+		if !until.After(now) {
+			delete(s.cooldowns, key)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package ratelimit implements per-user token-bucket rate limits and per-chat slow mode
+// for chat-service. State lives in a Store: in memory for a single replica, or in Redis
+// so that all replicas share the same buckets.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rule is a token bucket: up to Burst requests at once, refilled at Rate tokens per second
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled reports whether the rule limits anything
+func (r Rule) Enabled() bool {
+	return r.Rate > 0 && r.Burst > 0
+}
+
+// Action is a rate-limited chat operation
+type Action string
+
+const (
+	ActionSendMessage Action = "message"
+	ActionAddReaction Action = "reaction"
+	ActionSendTyping  Action = "typing"
+)
+
+// Config holds the per-user rules of each action. A zero rule disables the limit.
+type Config struct {
+	Messages  Rule
+	Reactions Rule
+	Typing    Rule
+}
+
+// DefaultConfig returns limits that do not affect normal use but stop floods
+func DefaultConfig() Config {
+	return Config{
+		Messages:  Rule{Rate: 1, Burst: 10},  // 60/min, bursts of 10
+		Reactions: Rule{Rate: 2, Burst: 20},  // 120/min, bursts of 20
+		Typing:    Rule{Rate: 0.5, Burst: 5}, // 30/min, bursts of 5
+	}
+}
+
+// Store keeps rate limit state
+type Store interface {
+	// Take removes a token from the bucket at key. Returns 0 if a token was available,
+	// otherwise how long until the next token.
+	Take(ctx context.Context, key string, rule Rule) (time.Duration, error)
+
+	// Cooldown starts a cooldown of length d at key unless one is already running.
+	// Returns 0 if the cooldown was started, otherwise the remaining time of the running one.
+	Cooldown(ctx context.Context, key string, d time.Duration) (time.Duration, error)
+}
+
+// Limiter applies the configured rules. A nil *Limiter allows everything.
+type Limiter struct {
+	store Store
+	rules map[Action]Rule
+}
+
+// NewLimiter creates a limiter over the given store
+func NewLimiter(store Store, cfg Config) *Limiter {
+	return &Limiter{
+		store: store,
+		rules: map[Action]Rule{
+			ActionSendMessage: cfg.Messages,
+			ActionAddReaction: cfg.Reactions,
+			ActionSendTyping:  cfg.Typing,
+		},
+	}
+}
+
+// Allow takes a token for the user's action. Returns 0 if allowed, otherwise the retry-after delay.
+func (l *Limiter) Allow(ctx context.Context, action Action, userID uuid.UUID) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+	rule, ok := l.rules[action]
+	if !ok || !rule.Enabled() {
+		return 0, nil
+	}
+	return l.store.Take(ctx, fmt.Sprintf("ratelimit:%s:%s", action, userID), rule)
+}
+
+// SlowMode enforces one message per interval for the user in the chat.
+// Returns 0 if the user may send now, otherwise the time left until they may.
+func (l *Limiter) SlowMode(ctx context.Context, chatID, userID uuid.UUID, interval time.Duration) (time.Duration, error) {
+	if l == nil || interval <= 0 {
+		return 0, nil
+	}
+	return l.store.Cooldown(ctx, fmt.Sprintf("slowmode:%s:%s", chatID, userID), interval)
+}
+
+// AllowWebhook takes a token of an incoming webhook's own limit of perMinute messages,
+// allowing bursts of a sixth of that. Returns 0 if allowed, otherwise the retry-after delay.
+func (l *Limiter) AllowWebhook(ctx context.Context, webhookID uuid.UUID, perMinute int) (time.Duration, error) {
+	if l == nil || perMinute <= 0 {
+		return 0, nil
+	}
+	rule := Rule{Rate: float64(perMinute) / 60, Burst: max(1, perMinute/6)}
+	return l.store.Take(ctx, fmt.Sprintf("ratelimit:webhook:%s", webhookID), rule)
+}
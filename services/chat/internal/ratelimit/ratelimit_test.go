@@ -0,0 +1,200 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable clock for MemoryStore
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestStore returns a memory store on a fake clock
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = clock.Now
+	s.lastSweep = clock.now
+	return s, clock
+}
+
+// takeStep advances the clock, then takes a token and expects the given wait
+type takeStep struct {
+	advance time.Duration
+	wait    time.Duration
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	rule := Rule{Rate: 1, Burst: 3}
+
+	tests := []struct {
+		name  string
+		steps []takeStep
+	}{
+		{
+			name: "burst then wait for refill",
+			steps: []takeStep{
+				{0, 0},
+				{0, 0},
+				{0, 0},
+				{0, time.Second},
+				{500 * time.Millisecond, 500 * time.Millisecond},
+				{500 * time.Millisecond, 0},
+			},
+		},
+		{
+			name: "refill is capped at burst",
+			steps: []takeStep{
+				{0, 0},
+				{time.Hour, 0},
+				{0, 0},
+				{0, 0},
+				{0, time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, clock := newTestStore()
+			for i, step := range tt.steps {
+				clock.Advance(step.advance)
+				wait, err := s.Take(context.Background(), "key", rule)
+				require.NoError(t, err)
+				assert.Equal(t, step.wait, wait, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_TakeKeysAreIndependent(t *testing.T) {
+	s, _ := newTestStore()
+	rule := Rule{Rate: 1, Burst: 1}
+
+	wait, err := s.Take(context.Background(), "a", rule)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	wait, err = s.Take(context.Background(), "b", rule)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	wait, err = s.Take(context.Background(), "a", rule)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, wait)
+}
+
+func TestMemoryStore_Cooldown(t *testing.T) {
+	tests := []struct {
+		name    string
+		advance time.Duration
+		wait    time.Duration
+	}{
+		{"running cooldown", 10 * time.Second, 20 * time.Second},
+		{"finished cooldown", 30 * time.Second, 0},
+		{"long after", time.Hour, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, clock := newTestStore()
+			wait, err := s.Cooldown(context.Background(), "key", 30*time.Second)
+			require.NoError(t, err)
+			require.Zero(t, wait)
+
+			clock.Advance(tt.advance)
+			wait, err = s.Cooldown(context.Background(), "key", 30*time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+}
+
+func TestMemoryStore_SweepDropsIdleState(t *testing.T) {
+	s, clock := newTestStore()
+	_, _ = s.Take(context.Background(), "bucket", Rule{Rate: 1, Burst: 2})
+	_, _ = s.Cooldown(context.Background(), "cooldown", time.Second)
+	require.Len(t, s.buckets, 1)
+	require.Len(t, s.cooldowns, 1)
+
+	clock.Advance(sweepInterval)
+	_, _ = s.Cooldown(context.Background(), "other", time.Hour)
+
+	assert.Empty(t, s.buckets)
+	assert.Len(t, s.cooldowns, 1)
+	assert.Contains(t, s.cooldowns, "other")
+}
+
+func TestLimiter(t *testing.T) {
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	t.Run("nil limiter allows everything", func(t *testing.T) {
+		var l *Limiter
+		wait, err := l.Allow(context.Background(), ActionSendMessage, userID)
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		wait, err = l.SlowMode(context.Background(), chatID, userID, time.Minute)
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+	})
+
+	t.Run("disabled rule allows everything", func(t *testing.T) {
+		s, _ := newTestStore()
+		l := NewLimiter(s, Config{Messages: Rule{Rate: 0, Burst: 1}})
+		for i := 0; i < 5; i++ {
+			wait, err := l.Allow(context.Background(), ActionSendMessage, userID)
+			require.NoError(t, err)
+			assert.Zero(t, wait)
+		}
+	})
+
+	t.Run("actions have separate buckets", func(t *testing.T) {
+		s, _ := newTestStore()
+		l := NewLimiter(s, Config{Messages: Rule{Rate: 1, Burst: 1}, Reactions: Rule{Rate: 1, Burst: 1}})
+
+		wait, _ := l.Allow(context.Background(), ActionSendMessage, userID)
+		assert.Zero(t, wait)
+		wait, _ = l.Allow(context.Background(), ActionAddReaction, userID)
+		assert.Zero(t, wait)
+		wait, _ = l.Allow(context.Background(), ActionSendMessage, userID)
+		assert.Equal(t, time.Second, wait)
+	})
+
+	t.Run("slow mode", func(t *testing.T) {
+		s, clock := newTestStore()
+		l := NewLimiter(s, DefaultConfig())
+
+		wait, _ := l.SlowMode(context.Background(), chatID, userID, 10*time.Second)
+		assert.Zero(t, wait)
+		clock.Advance(4 * time.Second)
+		wait, _ = l.SlowMode(context.Background(), chatID, userID, 10*time.Second)
+		assert.Equal(t, 6*time.Second, wait)
+		wait, _ = l.SlowMode(context.Background(), uuid.New(), userID, 10*time.Second)
+		assert.Zero(t, wait, "slow mode is per chat")
+	})
+
+	t.Run("webhook limit", func(t *testing.T) {
+		s, _ := newTestStore()
+		l := NewLimiter(s, DefaultConfig())
+		webhookID := uuid.New()
+
+		// 60 per minute allows bursts of 10
+		for i := 0; i < 10; i++ {
+			wait, _ := l.AllowWebhook(context.Background(), webhookID, 60)
+			require.Zero(t, wait, "message %d", i)
+		}
+		wait, _ := l.AllowWebhook(context.Background(), webhookID, 60)
+		assert.Equal(t, time.Second, wait)
+	})
+}
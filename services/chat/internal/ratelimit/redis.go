@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes from a token bucket stored as a hash {tokens, ts}.
+// Redis TIME is used so replicas with skewed clocks share one timeline.
+// Returns 0 if a token was taken, otherwise the wait in milliseconds.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1]) / 1000
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1]) or burst
+local ts = tonumber(data[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate))
+return wait
+`)
+
+// RedisStore keeps rate limit state in Redis so that all chat-service replicas share it
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a Redis-backed store
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, rule Rule) (time.Duration, error) {
+	wait, err := takeScript.Run(ctx, s.client, []string{key}, rule.Rate, rule.Burst).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func (s *RedisStore) Cooldown(ctx context.Context, key string, d time.Duration) (time.Duration, error) {
+	started, err := s.client.SetNX(ctx, key, 1, d).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start cooldown: %w", err)
+	}
+	if started {
+		return 0, nil
+	}
+
+	remaining, err := s.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cooldown ttl: %w", err)
+	}
+	if remaining < 0 {
+		// Key expired between SETNX and PTTL
+		return 0, nil
+	}
+	return remaining, nil
+}
@@ -53,11 +53,14 @@ type ChatRepository interface {
 	CreateMessage(ctx context.Context, message *model.Message) error
 	GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error)
 	ListMessages(ctx context.Context, chatID uuid.UUID, page, count int, before, after *time.Time) ([]model.Message, int, error)
-	GetMessagesSince(ctx context.Context, chatID uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error)
+	GetMessagesSince(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error)
+	GetMessageTombstonesSince(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.MessageTombstone, error)
 	UpdateMessage(ctx context.Context, message *model.Message) error
 	DeleteMessage(ctx context.Context, id, deletedBy uuid.UUID, isModeratedDeletion bool) error
 	RestoreMessage(ctx context.Context, id uuid.UUID) error
 	GetDeletedMessagesOlderThan(ctx context.Context, cutoffTime time.Time, limit int) ([]uuid.UUID, error)
+	BulkDeleteMessages(ctx context.Context, chatID uuid.UUID, ids []uuid.UUID, deletedBy uuid.UUID) ([]uuid.UUID, error)
+	GetMessageIDsBySender(ctx context.Context, chatID, senderID uuid.UUID, from, to *time.Time, limit int) ([]uuid.UUID, error)
 	GetThreadMessages(ctx context.Context, parentID uuid.UUID, page, count int) ([]model.Message, int, error)
 	GetThreadCount(ctx context.Context, messageID uuid.UUID) (int, error)
 
@@ -69,6 +72,8 @@ type ChatRepository interface {
 	AddReaction(ctx context.Context, reaction *model.Reaction) error
 	RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error
 	ListReactions(ctx context.Context, messageID uuid.UUID) ([]model.Reaction, error)
+	AddReactionWithinLimit(ctx context.Context, reaction *model.Reaction, limit int) (bool, error)
+	GetReactionSummariesBatch(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]model.ReactionSummary, error)
 
 	// Read status
 	MarkAsRead(ctx context.Context, messageID, userID uuid.UUID) error
@@ -85,6 +90,11 @@ type ChatRepository interface {
 	UnarchiveChat(ctx context.Context, chatID, userID uuid.UUID) error
 	ListArchivedChats(ctx context.Context, userID uuid.UUID, page, count int) ([]model.Chat, int, error)
 
+	// Chat list change log (incremental chat list sync)
+	RecordChatListChange(ctx context.Context, userIDs []uuid.UUID, chatID uuid.UUID, changeType model.ChatListChangeType, actorID *uuid.UUID) error
+	GetChatListChanges(ctx context.Context, userID uuid.UUID, sinceVersion int64, limit int) (*ChatListChangesResult, error)
+	DeleteChatListChangesOlderThan(ctx context.Context, cutoffTime time.Time) (int64, error)
+
 	// Thread operations
 	CreateThread(ctx context.Context, thread *model.Thread) error
 	GetThread(ctx context.Context, id uuid.UUID) (*model.Thread, error)
@@ -102,6 +112,8 @@ type ChatRepository interface {
 
 	// Thread messages
 	ListThreadMessages(ctx context.Context, threadID uuid.UUID, page, count int) ([]model.Message, int, error)
+	MoveMessagesToThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, threadID uuid.UUID) (map[uuid.UUID]*uuid.UUID, error)
+	MoveMessagesToNewThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, thread *model.Thread) (map[uuid.UUID]*uuid.UUID, error)
 
 	// Thread access (cascading permissions)
 	HasThreadAccess(ctx context.Context, threadID, userID uuid.UUID) (bool, error)
@@ -109,6 +121,15 @@ type ChatRepository interface {
 	ListThreadsForUser(ctx context.Context, chatID, userID uuid.UUID, page, count int) ([]model.Thread, int, error)
 	ListSubthreads(ctx context.Context, parentThreadID uuid.UUID, userID uuid.UUID, page, count int) ([]model.Thread, int, error)
 
+	// Thread subscriptions (follow/unfollow)
+	FollowThread(ctx context.Context, threadID, userID uuid.UUID) error
+	UnfollowThread(ctx context.Context, threadID, userID uuid.UUID) error
+	IsFollowingThread(ctx context.Context, threadID, userID uuid.UUID) (bool, error)
+	GetThreadFollowerIDs(ctx context.Context, threadID uuid.UUID) ([]uuid.UUID, error)
+	ListFollowedThreads(ctx context.Context, userID uuid.UUID, page, count int) ([]model.FollowedThread, int, error)
+	MarkThreadAsRead(ctx context.Context, threadID, userID uuid.UUID) error
+	GetParticipantIDsByUsernames(ctx context.Context, chatID uuid.UUID, usernames []string) ([]uuid.UUID, error)
+
 	// Reply operations
 	SaveMessageReplies(ctx context.Context, messageID uuid.UUID, replyToIDs []uuid.UUID) error
 	GetMessageReplies(ctx context.Context, messageID uuid.UUID) ([]uuid.UUID, error)
@@ -128,6 +149,89 @@ type ChatRepository interface {
 	CreateChatFileLink(ctx context.Context, link *model.ChatFileLink) error
 	GetChatFileLinks(ctx context.Context, chatID uuid.UUID) ([]model.ChatFileLink, error)
 	DeleteChatFileLink(ctx context.Context, chatID uuid.UUID, fileLinkID uuid.UUID) error
+
+	// Moderation (message reports and audit trail)
+	GetUserRole(ctx context.Context, userID uuid.UUID) (model.UserRole, error)
+	GetGlobalModeratorIDs(ctx context.Context) ([]uuid.UUID, error)
+	GetChatModeratorIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error)
+	CreateMessageReport(ctx context.Context, report *model.MessageReport) error
+	GetMessageReport(ctx context.Context, id uuid.UUID) (*model.MessageReport, error)
+	ListMessageReports(ctx context.Context, chatID *uuid.UUID, status model.ReportStatus, page, count int) ([]model.MessageReport, int, error)
+	ResolveMessageReports(ctx context.Context, messageID uuid.UUID, action model.ReportAction, resolvedBy uuid.UUID) (int64, error)
+	AddModerationAuditEntry(ctx context.Context, entry *model.ModerationAuditEntry) error
+	ListModerationAuditLog(ctx context.Context, chatID *uuid.UUID, page, count int) ([]model.ModerationAuditEntry, int, error)
+
+	// Slow mode
+	SetChatSlowMode(ctx context.Context, chatID uuid.UUID, seconds int) error
+
+	// Content filter policies and hit log
+	GetContentPolicy(ctx context.Context, chatID *uuid.UUID) (*model.ContentPolicy, error)
+	UpsertContentPolicy(ctx context.Context, policy *model.ContentPolicy) error
+	DeleteContentPolicy(ctx context.Context, chatID *uuid.UUID) error
+	AddContentFilterHits(ctx context.Context, hits []model.ContentFilterHit) error
+	ListContentFilterHits(ctx context.Context, chatID *uuid.UUID, page, count int) ([]model.ContentFilterHit, int, error)
+
+	// Outgoing webhooks (subscriptions and delivery queue)
+	CreateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, chatID *uuid.UUID) ([]model.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+	EnqueueWebhookDeliveries(ctx context.Context, eventType string, chatID *uuid.UUID, payload []byte) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (*model.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, status model.WebhookDeliveryStatus, page, count int) ([]model.WebhookDelivery, int, error)
+
+	// Incoming webhooks and bot accounts
+	GetBotAccount(ctx context.Context, userID uuid.UUID) (isBot bool, ownerID *uuid.UUID, err error)
+	CreateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) error
+	GetIncomingWebhook(ctx context.Context, id uuid.UUID) (*model.IncomingWebhook, error)
+	ListIncomingWebhooks(ctx context.Context, chatID uuid.UUID) ([]model.IncomingWebhook, error)
+	UpdateIncomingWebhookToken(ctx context.Context, id uuid.UUID, tokenHash string) error
+	TouchIncomingWebhook(ctx context.Context, id uuid.UUID) error
+	DeleteIncomingWebhook(ctx context.Context, id uuid.UUID) error
+
+	// Slash commands, interactive messages and reminders
+	CreateBotCommand(ctx context.Context, cmd *model.BotCommand) error
+	GetBotCommand(ctx context.Context, id uuid.UUID) (*model.BotCommand, error)
+	FindBotCommand(ctx context.Context, chatID uuid.UUID, name string) (*model.BotCommand, error)
+	ListBotCommands(ctx context.Context, chatID *uuid.UUID) ([]model.BotCommand, error)
+	ListAvailableBotCommands(ctx context.Context, chatID uuid.UUID) ([]model.BotCommand, error)
+	DeleteBotCommand(ctx context.Context, id uuid.UUID) error
+	CreateMessageInteraction(ctx context.Context, interaction *model.MessageInteraction) error
+	GetMessageInteraction(ctx context.Context, messageID uuid.UUID) (*model.MessageInteraction, error)
+	UpdateMessageInteractionButtons(ctx context.Context, messageID uuid.UUID, buttons []model.MessageButton) error
+	GetMessageButtons(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID][]model.MessageButton, error)
+	UpsertPollVote(ctx context.Context, messageID, userID uuid.UUID, optionIndex int) error
+	CountPollVotes(ctx context.Context, messageID uuid.UUID) (map[int]int, error)
+	CreateReminder(ctx context.Context, reminder *model.Reminder) error
+	GetReminder(ctx context.Context, id uuid.UUID) (*model.Reminder, error)
+	ListReminders(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, deliveredSince *time.Time) ([]model.Reminder, error)
+	CountPendingReminders(ctx context.Context, userID uuid.UUID) (int, error)
+	DeleteReminder(ctx context.Context, id uuid.UUID) error
+	ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Reminder, error)
+	MarkReminderDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error
+
+	// Custom emoji
+	CreateCustomEmoji(ctx context.Context, e *model.CustomEmoji) error
+	GetCustomEmoji(ctx context.Context, id uuid.UUID) (*model.CustomEmoji, error)
+	CustomEmojiExists(ctx context.Context, chatID uuid.UUID, name string) (bool, error)
+	ListCustomEmoji(ctx context.Context, chatID uuid.UUID) ([]model.CustomEmoji, error)
+	CountCustomEmoji(ctx context.Context, chatID uuid.UUID) (int, error)
+	DeleteCustomEmoji(ctx context.Context, id uuid.UUID) error
+
+	// Drafts
+	UpsertDraft(ctx context.Context, draft *model.Draft) error
+	ListDrafts(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.Draft, error)
+	DeleteDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) (bool, error)
+
+	// Chat restrictions (bans and timed mutes)
+	CreateChatRestriction(ctx context.Context, restriction *model.ChatRestriction) error
+	GetActiveChatRestriction(ctx context.Context, chatID, userID uuid.UUID, restrictionType model.RestrictionType) (*model.ChatRestriction, error)
+	ListActiveChatRestrictions(ctx context.Context, chatID uuid.UUID) ([]model.ChatRestriction, error)
+	LiftChatRestriction(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error)
+	GetExpiredChatRestrictions(ctx context.Context, now time.Time, limit int) ([]model.ChatRestriction, error)
 }
 
 type chatRepository struct {
@@ -163,14 +267,14 @@ func (r *chatRepository) CreateChat(ctx context.Context, chat *model.Chat) error
 
 func (r *chatRepository) GetChat(ctx context.Context, id uuid.UUID) (*model.Chat, error) {
 	query := `
-		SELECT id, name, chat_type, created_by, created_at, updated_at
+		SELECT id, name, chat_type, created_by, created_at, updated_at, slow_mode_seconds
 		FROM con_test.chats
 		WHERE id = $1
 	`
 
 	var chat model.Chat
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt,
+		&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt, &chat.SlowModeSeconds,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -202,7 +306,8 @@ func (r *chatRepository) ListChats(ctx context.Context, userID uuid.UUID, page,
 	}
 
 	query := `
-		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at
+		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds,
+			EXISTS(SELECT 1 FROM con_test.message_drafts d WHERE d.user_id = $1 AND d.chat_id = c.id) AS has_draft
 		FROM con_test.chats c
 		JOIN con_test.chat_participants cp ON c.id = cp.chat_id
 		WHERE cp.user_id = $1
@@ -219,7 +324,7 @@ func (r *chatRepository) ListChats(ctx context.Context, userID uuid.UUID, page,
 	var chats []model.Chat
 	for rows.Next() {
 		var chat model.Chat
-		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt); err != nil {
+		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt, &chat.SlowModeSeconds, &chat.HasDraft); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan chat: %w", err)
 		}
 		chats = append(chats, chat)
@@ -253,7 +358,8 @@ func (r *chatRepository) ListChatsCursor(ctx context.Context, userID uuid.UUID,
 	if cursor == "" {
 		// First page - no cursor
 		query := `
-			SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at
+			SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds,
+				EXISTS(SELECT 1 FROM con_test.message_drafts d WHERE d.user_id = $1 AND d.chat_id = c.id) AS has_draft
 			FROM con_test.chats c
 			JOIN con_test.chat_participants cp ON c.id = cp.chat_id
 			WHERE cp.user_id = $1
@@ -281,7 +387,8 @@ func (r *chatRepository) ListChatsCursor(ctx context.Context, userID uuid.UUID,
 		// Keyset pagination: get chats after the cursor
 		// (updated_at, id) < (cursor_updated_at, cursor_id)
 		query := `
-			SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at
+			SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds,
+				EXISTS(SELECT 1 FROM con_test.message_drafts d WHERE d.user_id = $1 AND d.chat_id = c.id) AS has_draft
 			FROM con_test.chats c
 			JOIN con_test.chat_participants cp ON c.id = cp.chat_id
 			WHERE cp.user_id = $1
@@ -300,7 +407,7 @@ func (r *chatRepository) ListChatsCursor(ctx context.Context, userID uuid.UUID,
 	var chats []model.Chat
 	for rows.Next() {
 		var chat model.Chat
-		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt); err != nil {
+		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt, &chat.SlowModeSeconds, &chat.HasDraft); err != nil {
 			return nil, fmt.Errorf("failed to scan chat: %w", err)
 		}
 		chats = append(chats, chat)
@@ -379,7 +486,8 @@ func (r *chatRepository) SearchChats(ctx context.Context, userID uuid.UUID, quer
 	}
 
 	sqlQuery := `
-		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at
+		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds,
+			EXISTS(SELECT 1 FROM con_test.message_drafts d WHERE d.user_id = $1 AND d.chat_id = c.id) AS has_draft
 		FROM con_test.chats c
 		JOIN con_test.chat_participants cp ON c.id = cp.chat_id
 		WHERE cp.user_id = $1 AND c.name ILIKE $2
@@ -396,7 +504,7 @@ func (r *chatRepository) SearchChats(ctx context.Context, userID uuid.UUID, quer
 	var chats []model.Chat
 	for rows.Next() {
 		var chat model.Chat
-		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt); err != nil {
+		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt, &chat.SlowModeSeconds, &chat.HasDraft); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan chat: %w", err)
 		}
 		chats = append(chats, chat)
@@ -429,7 +537,7 @@ func (r *chatRepository) AddParticipant(ctx context.Context, participant *model.
 func (r *chatRepository) GetParticipant(ctx context.Context, chatID, userID uuid.UUID) (*model.ChatParticipant, error) {
 	query := `
 		SELECT cp.id, cp.chat_id, cp.user_id, cp.role, cp.joined_at,
-		       u.username, u.email, u.display_name, u.avatar_url
+		       u.username, u.email, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.chat_participants cp
 		LEFT JOIN con_test.users u ON cp.user_id = u.id
 		WHERE cp.chat_id = $1 AND cp.user_id = $2
@@ -438,7 +546,7 @@ func (r *chatRepository) GetParticipant(ctx context.Context, chatID, userID uuid
 	var p model.ChatParticipant
 	err := r.pool.QueryRow(ctx, query, chatID, userID).Scan(
 		&p.ID, &p.ChatID, &p.UserID, &p.Role, &p.JoinedAt,
-		&p.Username, &p.Email, &p.DisplayName, &p.AvatarURL,
+		&p.Username, &p.Email, &p.DisplayName, &p.AvatarURL, &p.IsBot,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -465,7 +573,7 @@ func (r *chatRepository) ListParticipants(ctx context.Context, chatID uuid.UUID,
 
 	query := `
 		SELECT cp.id, cp.chat_id, cp.user_id, cp.role, cp.joined_at,
-		       u.username, u.email, u.display_name, u.avatar_url
+		       u.username, u.email, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.chat_participants cp
 		LEFT JOIN con_test.users u ON cp.user_id = u.id
 		WHERE cp.chat_id = $1
@@ -483,7 +591,7 @@ func (r *chatRepository) ListParticipants(ctx context.Context, chatID uuid.UUID,
 	for rows.Next() {
 		var p model.ChatParticipant
 		if err := rows.Scan(&p.ID, &p.ChatID, &p.UserID, &p.Role, &p.JoinedAt,
-			&p.Username, &p.Email, &p.DisplayName, &p.AvatarURL); err != nil {
+			&p.Username, &p.Email, &p.DisplayName, &p.AvatarURL, &p.IsBot); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan participant: %w", err)
 		}
 		participants = append(participants, p)
@@ -523,15 +631,36 @@ func (r *chatRepository) UpdateParticipantRole(ctx context.Context, chatID, user
 	return nil
 }
 
+// RemoveParticipant removes the user from the chat together with their thread subscriptions
+// in it, so a removed or banned user no longer gets thread.reply events
 func (r *chatRepository) RemoveParticipant(ctx context.Context, chatID, userID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `DELETE FROM con_test.chat_participants WHERE chat_id = $1 AND user_id = $2`
-	result, err := r.pool.Exec(ctx, query, chatID, userID)
+	result, err := tx.Exec(ctx, query, chatID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to remove participant: %w", err)
 	}
 	if result.RowsAffected() == 0 {
 		return ErrParticipantNotFound
 	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM con_test.thread_subscriptions ts
+		USING con_test.threads t
+		WHERE t.id = ts.thread_id AND t.chat_id = $1 AND ts.user_id = $2
+	`, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove thread subscriptions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -619,7 +748,7 @@ func (r *chatRepository) CreateMessage(ctx context.Context, message *model.Messa
 
 func (r *chatRepository) GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error) {
 	query := `
-		SELECT id, chat_id, parent_id, sender_id, content, sent_at, updated_at, is_deleted, seq_num,
+		SELECT id, chat_id, parent_id, thread_id, sender_id, content, sent_at, updated_at, is_deleted, seq_num,
 		       forwarded_from_message_id, forwarded_from_chat_id
 		FROM con_test.messages
 		WHERE id = $1
@@ -627,7 +756,7 @@ func (r *chatRepository) GetMessage(ctx context.Context, id uuid.UUID) (*model.M
 
 	var msg model.Message
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&msg.ID, &msg.ChatID, &msg.ParentID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.SeqNum,
+		&msg.ID, &msg.ChatID, &msg.ParentID, &msg.ThreadID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.SeqNum,
 		&msg.ForwardedFromMessageID, &msg.ForwardedFromChatID,
 	)
 	if err != nil {
@@ -676,7 +805,7 @@ func (r *chatRepository) ListMessages(ctx context.Context, chatID uuid.UUID, pag
 
 	query := `
 		SELECT m.id, m.chat_id, m.parent_id, m.sender_id, m.content, m.sent_at, m.updated_at, m.is_deleted, m.seq_num,
-		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url
+		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.messages m
 		LEFT JOIN con_test.users u ON m.sender_id = u.id
 		WHERE m.chat_id = $1 AND m.parent_id IS NULL AND m.thread_id IS NULL
@@ -694,7 +823,7 @@ func (r *chatRepository) ListMessages(ctx context.Context, chatID uuid.UUID, pag
 	for rows.Next() {
 		var msg model.Message
 		if err := rows.Scan(&msg.ID, &msg.ChatID, &msg.ParentID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.SeqNum,
-			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL); err != nil {
+			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL, &msg.SenderIsBot); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan message: %w", err)
 		}
 		messages = append(messages, msg)
@@ -735,23 +864,23 @@ func (r *chatRepository) ListMessages(ctx context.Context, chatID uuid.UUID, pag
 }
 
 // GetMessagesSince returns messages with seq_num > afterSeqNum, ordered by seq_num ASC
-// Used for syncing missed messages after reconnect
-func (r *chatRepository) GetMessagesSince(ctx context.Context, chatID uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error) {
+// Used for syncing missed messages after reconnect. threadID = nil syncs the main chat.
+func (r *chatRepository) GetMessagesSince(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error) {
 	if limit < 1 || limit > 1000 {
 		limit = 100
 	}
 
 	query := `
 		SELECT m.id, m.chat_id, m.parent_id, m.sender_id, m.content, m.sent_at, m.updated_at, m.is_deleted, m.seq_num,
-		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url
+		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.messages m
 		LEFT JOIN con_test.users u ON m.sender_id = u.id
-		WHERE m.chat_id = $1 AND m.seq_num > $2 AND m.thread_id IS NULL
+		WHERE m.chat_id = $1 AND m.seq_num > $2 AND m.thread_id IS NOT DISTINCT FROM $4
 		ORDER BY m.seq_num ASC
 		LIMIT $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, chatID, afterSeqNum, limit)
+	rows, err := r.pool.Query(ctx, query, chatID, afterSeqNum, limit, threadID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages since seq_num: %w", err)
 	}
@@ -761,7 +890,7 @@ func (r *chatRepository) GetMessagesSince(ctx context.Context, chatID uuid.UUID,
 	for rows.Next() {
 		var msg model.Message
 		if err := rows.Scan(&msg.ID, &msg.ChatID, &msg.ParentID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.SeqNum,
-			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL); err != nil {
+			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL, &msg.SenderIsBot); err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
 		messages = append(messages, msg)
@@ -801,6 +930,69 @@ func (r *chatRepository) GetMessagesSince(ctx context.Context, chatID uuid.UUID,
 	return messages, nil
 }
 
+// GetMessageTombstonesSince returns messages edited, deleted, restored or moved after afterSeqNum,
+// ordered by change_seq_num ASC. threadID = nil returns changes for the main chat. Messages moved
+// out of the thread (or main chat) are included so clients can drop them from their view. The
+// trigger overwrites change_type on every later change, so they are matched by was_moved and
+// previous_thread_id and always reported as moved.
+func (r *chatRepository) GetMessageTombstonesSince(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.MessageTombstone, error) {
+	if limit < 1 || limit > 1000 {
+		limit = 100
+	}
+
+	query := `
+		SELECT m.id, m.chat_id, m.thread_id, m.previous_thread_id, m.change_type, m.change_seq_num,
+		       m.parent_id, m.sender_id, m.content, m.sent_at, m.updated_at, m.is_deleted, m.is_system, m.seq_num,
+		       u.username, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
+		FROM con_test.messages m
+		LEFT JOIN con_test.users u ON m.sender_id = u.id
+		WHERE m.chat_id = $1 AND m.change_seq_num > $2
+		  AND (m.thread_id IS NOT DISTINCT FROM $4
+		       OR (m.was_moved AND m.previous_thread_id IS NOT DISTINCT FROM $4))
+		ORDER BY m.change_seq_num ASC
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, chatID, afterSeqNum, limit, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	var tombstones []model.MessageTombstone
+	for rows.Next() {
+		var t model.MessageTombstone
+		var msg model.Message
+		if err := rows.Scan(&t.MessageID, &t.ChatID, &t.ThreadID, &t.PreviousThreadID, &t.ChangeType, &t.ChangeSeqNum,
+			&msg.ParentID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.IsSystem, &msg.SeqNum,
+			&msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL, &msg.SenderIsBot); err != nil {
+			return nil, fmt.Errorf("failed to scan message tombstone: %w", err)
+		}
+		// Moved out and possibly edited since; for this thread it is still a move
+		if !sameThread(t.ThreadID, threadID) {
+			t.ChangeType = "moved"
+		}
+		// Deleted messages carry no content, everything else carries the current state
+		if !msg.IsDeleted {
+			msg.ID = t.MessageID
+			msg.ChatID = t.ChatID
+			msg.ThreadID = t.ThreadID
+			t.Message = &msg
+		}
+		tombstones = append(tombstones, t)
+	}
+
+	return tombstones, nil
+}
+
+// sameThread reports whether two thread IDs are equal, nil being the main chat
+func sameThread(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 func (r *chatRepository) UpdateMessage(ctx context.Context, message *model.Message) error {
 	query := `UPDATE con_test.messages SET content = $2, updated_at = $3 WHERE id = $1`
 	now := time.Now()
@@ -902,6 +1094,74 @@ func (r *chatRepository) GetDeletedMessagesOlderThan(ctx context.Context, cutoff
 	return messageIDs, nil
 }
 
+// BulkDeleteMessages soft-deletes a batch of chat messages with the same metadata as DeleteMessage.
+// Already deleted and system messages are skipped; deletions of other users' messages are marked
+// as moderated. Returns the IDs of the messages that were actually deleted.
+func (r *chatRepository) BulkDeleteMessages(ctx context.Context, chatID uuid.UUID, ids []uuid.UUID, deletedBy uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE con_test.messages
+		SET is_deleted = true,
+			deleted_at = $3,
+			deleted_by = $4,
+			is_moderated_deletion = (sender_id <> $4),
+			original_content = content,
+			content = '',
+			updated_at = $3
+		WHERE id = ANY($1) AND chat_id = $2 AND is_deleted = false AND is_system = false
+		RETURNING id
+	`
+	rows, err := r.pool.Query(ctx, query, ids, chatID, time.Now(), deletedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk delete messages: %w", err)
+	}
+	defer rows.Close()
+
+	var deleted []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan message id: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+
+	return deleted, rows.Err()
+}
+
+// GetMessageIDsBySender returns IDs of non-deleted, non-system messages of a sender in a chat,
+// optionally limited to the [from, to] time window, oldest first
+func (r *chatRepository) GetMessageIDsBySender(ctx context.Context, chatID, senderID uuid.UUID, from, to *time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM con_test.messages
+		WHERE chat_id = $1 AND sender_id = $2 AND is_deleted = false AND is_system = false
+		  AND ($3::timestamptz IS NULL OR sent_at >= $3)
+		  AND ($4::timestamptz IS NULL OR sent_at <= $4)
+		ORDER BY sent_at ASC
+		LIMIT $5
+	`
+	rows, err := r.pool.Query(ctx, query, chatID, senderID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages by sender: %w", err)
+	}
+	defer rows.Close()
+
+	var messageIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan message id: %w", err)
+		}
+		messageIDs = append(messageIDs, id)
+	}
+
+	return messageIDs, nil
+}
+
 func (r *chatRepository) RemoveMessageFromReplies(ctx context.Context, quotingMessageID, quotedMessageID uuid.UUID) error {
 	query := `DELETE FROM con_test.message_replies WHERE message_id = $1 AND reply_to_message_id = $2`
 	result, err := r.pool.Exec(ctx, query, quotingMessageID, quotedMessageID)
@@ -1077,6 +1337,79 @@ func (r *chatRepository) ListReactions(ctx context.Context, messageID uuid.UUID)
 	return reactions, nil
 }
 
+// AddReactionWithinLimit adds the reaction unless the user already put limit other reactions on
+// the message, and reports whether it did. The count and the insert run under a transaction lock
+// of the message and user, so concurrent requests cannot exceed the limit.
+func (r *chatRepository) AddReactionWithinLimit(ctx context.Context, reaction *model.Reaction, limit int) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1::text || $2::text, 0))`,
+		reaction.MessageID, reaction.UserID); err != nil {
+		return false, fmt.Errorf("failed to lock reactions: %w", err)
+	}
+
+	var others int
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM con_test.message_reactions WHERE message_id = $1 AND user_id = $2 AND reaction <> $3
+	`, reaction.MessageID, reaction.UserID, reaction.Reaction).Scan(&others); err != nil {
+		return false, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	if others >= limit {
+		return false, nil
+	}
+
+	reaction.ID = uuid.New()
+	reaction.CreatedAt = time.Now()
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO con_test.message_reactions (id, message_id, user_id, reaction, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (message_id, user_id, reaction) DO NOTHING
+	`, reaction.ID, reaction.MessageID, reaction.UserID, reaction.Reaction, reaction.CreatedAt); err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// GetReactionSummariesBatch aggregates reactions of several messages in one query. Emoji are
+// ordered by their first use; ReactedByMe is set for reactions of userID.
+func (r *chatRepository) GetReactionSummariesBatch(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID][]model.ReactionSummary, error) {
+	result := make(map[uuid.UUID][]model.ReactionSummary)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT message_id, reaction, COUNT(*), BOOL_OR(user_id = $2)
+		FROM con_test.message_reactions
+		WHERE message_id = ANY($1)
+		GROUP BY message_id, reaction
+		ORDER BY message_id, MIN(created_at)
+	`
+	rows, err := r.pool.Query(ctx, query, messageIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reaction summaries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID uuid.UUID
+		var summary model.ReactionSummary
+		if err := rows.Scan(&messageID, &summary.Reaction, &summary.Count, &summary.ReactedByMe); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction summary: %w", err)
+		}
+		result[messageID] = append(result[messageID], summary)
+	}
+	return result, rows.Err()
+}
+
 // Read status
 
 func (r *chatRepository) MarkAsRead(ctx context.Context, messageID, userID uuid.UUID) error {
@@ -1197,7 +1530,8 @@ func (r *chatRepository) ListArchivedChats(ctx context.Context, userID uuid.UUID
 	}
 
 	query := `
-		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at
+		SELECT c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds,
+			EXISTS(SELECT 1 FROM con_test.message_drafts d WHERE d.user_id = $1 AND d.chat_id = c.id) AS has_draft
 		FROM con_test.chats c
 		JOIN con_test.archived_chats ac ON c.id = ac.chat_id
 		WHERE ac.user_id = $1
@@ -1214,7 +1548,7 @@ func (r *chatRepository) ListArchivedChats(ctx context.Context, userID uuid.UUID
 	var chats []model.Chat
 	for rows.Next() {
 		var chat model.Chat
-		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt); err != nil {
+		if err := rows.Scan(&chat.ID, &chat.Name, &chat.ChatType, &chat.CreatedBy, &chat.CreatedAt, &chat.UpdatedAt, &chat.SlowModeSeconds, &chat.HasDraft); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan chat: %w", err)
 		}
 		chats = append(chats, chat)
@@ -1223,6 +1557,154 @@ func (r *chatRepository) ListArchivedChats(ctx context.Context, userID uuid.UUID
 	return chats, total, nil
 }
 
+// Chat list change log
+
+// ChatListChangesResult contains chat list changes since a version
+type ChatListChangesResult struct {
+	Changes        []model.ChatListChange
+	CurrentVersion int64
+	HasMore        bool
+	ResetRequired  bool // sinceVersion is older than the retained log, client must reload the whole list
+}
+
+// RecordChatListChange appends a change to the chat list log of every given user. The versions
+// are bumped and the changes inserted in one statement; the upsert holds each version row lock
+// until commit, so concurrent changes of a user get distinct, gapless versions. Users are locked
+// in ID order so concurrent changes for overlapping users cannot deadlock.
+func (r *chatRepository) RecordChatListChange(ctx context.Context, userIDs []uuid.UUID, chatID uuid.UUID, changeType model.ChatListChangeType, actorID *uuid.UUID) error {
+	// Deduplicate: a user can only be bumped once per statement
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	unique := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	query := `
+		WITH bumped AS (
+			INSERT INTO con_test.user_chat_list_versions (user_id, version)
+			SELECT user_id, 1 FROM unnest($1::uuid[]) AS u(user_id) ORDER BY user_id
+			ON CONFLICT (user_id) DO UPDATE SET version = con_test.user_chat_list_versions.version + 1
+			RETURNING user_id, version
+		)
+		INSERT INTO con_test.chat_list_changes (user_id, version, chat_id, change_type, actor_id, created_at)
+		SELECT user_id, version, $2, $3, $4, NOW() FROM bumped
+	`
+
+	if _, err := r.pool.Exec(ctx, query, unique, chatID, string(changeType), actorID); err != nil {
+		return fmt.Errorf("failed to record chat list change: %w", err)
+	}
+	return nil
+}
+
+// GetChatListChanges returns the user's chat list changes with version > sinceVersion, ordered by version ASC
+func (r *chatRepository) GetChatListChanges(ctx context.Context, userID uuid.UUID, sinceVersion int64, limit int) (*ChatListChangesResult, error) {
+	if limit < 1 || limit > 1000 {
+		limit = 100
+	}
+
+	// The version and the changes are read from one snapshot, so a change committed in between
+	// cannot show up past CurrentVersion
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &ChatListChangesResult{}
+
+	var minVersion *int64
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(v.version, 0), (SELECT MIN(version) FROM con_test.chat_list_changes WHERE user_id = $1)
+		FROM (SELECT 1) AS one
+		LEFT JOIN con_test.user_chat_list_versions v ON v.user_id = $1
+	`, userID).Scan(&result.CurrentVersion, &minVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat list version: %w", err)
+	}
+
+	if sinceVersion >= result.CurrentVersion {
+		return result, nil
+	}
+
+	// Entries up to sinceVersion+1 must still be in the log, otherwise the client missed pruned changes
+	oldestRetained := result.CurrentVersion + 1
+	if minVersion != nil {
+		oldestRetained = *minVersion
+	}
+	if sinceVersion < oldestRetained-1 {
+		result.ResetRequired = true
+		return result, nil
+	}
+
+	query := `
+		SELECT ch.version, ch.chat_id, ch.change_type, ch.actor_id, ch.created_at,
+		       c.id, c.name, c.chat_type, c.created_by, c.created_at, c.updated_at, c.slow_mode_seconds
+		FROM con_test.chat_list_changes ch
+		LEFT JOIN con_test.chats c ON c.id = ch.chat_id
+			AND EXISTS (SELECT 1 FROM con_test.chat_participants cp WHERE cp.chat_id = c.id AND cp.user_id = ch.user_id)
+		WHERE ch.user_id = $1 AND ch.version > $2
+		ORDER BY ch.version ASC
+		LIMIT $3
+	`
+
+	rows, err := tx.Query(ctx, query, userID, sinceVersion, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat list changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change model.ChatListChange
+		var chatID *uuid.UUID
+		var name *string
+		var chatType *model.ChatType
+		var createdBy *uuid.UUID
+		var createdAt, updatedAt *time.Time
+		var slowModeSeconds *int
+		if err := rows.Scan(&change.Version, &change.ChatID, &change.ChangeType, &change.ActorID, &change.CreatedAt,
+			&chatID, &name, &chatType, &createdBy, &createdAt, &updatedAt, &slowModeSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan chat list change: %w", err)
+		}
+		if chatID != nil {
+			change.Chat = &model.Chat{
+				ID:        *chatID,
+				Name:      *name,
+				ChatType:  *chatType,
+				CreatedBy: *createdBy,
+				CreatedAt: *createdAt,
+				UpdatedAt: *updatedAt,
+			}
+			if slowModeSeconds != nil {
+				change.Chat.SlowModeSeconds = *slowModeSeconds
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if len(result.Changes) > limit {
+		result.Changes = result.Changes[:limit]
+		result.HasMore = true
+	}
+
+	return result, nil
+}
+
+// DeleteChatListChangesOlderThan prunes the chat list change log. Per-user versions are kept,
+// so clients with an older version get ResetRequired instead of an incomplete diff.
+func (r *chatRepository) DeleteChatListChangesOlderThan(ctx context.Context, cutoffTime time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.chat_list_changes WHERE created_at < $1`, cutoffTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete chat list changes: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
 // File attachments
 
 func (r *chatRepository) GetAllFileLinkIDsForChat(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
@@ -1283,24 +1765,28 @@ func (r *chatRepository) GetMessageAttachmentsBatch(ctx context.Context, message
 
 // Thread operations
 
-func (r *chatRepository) CreateThread(ctx context.Context, thread *model.Thread) error {
-	query := `
-		INSERT INTO con_test.threads (id, chat_id, parent_message_id, parent_thread_id, thread_type, title, message_count, last_message_at, created_by, created_at, updated_at, is_archived, restricted_participants)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`
+const insertThreadQuery = `
+	INSERT INTO con_test.threads (id, chat_id, parent_message_id, parent_thread_id, thread_type, title, message_count, last_message_at, created_by, created_at, updated_at, is_archived, restricted_participants)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+`
 
+// newThreadArgs assigns the ID and timestamps of a new thread and returns the arguments of insertThreadQuery.
+// Depth is set by database trigger based on parent_thread_id.
+func newThreadArgs(thread *model.Thread) []any {
 	thread.ID = uuid.New()
 	now := time.Now()
 	thread.CreatedAt = now
 	thread.UpdatedAt = now
-	// Depth is set by database trigger based on parent_thread_id
 
-	_, err := r.pool.Exec(ctx, query,
+	return []any{
 		thread.ID, thread.ChatID, thread.ParentMessageID, thread.ParentThreadID, thread.ThreadType, thread.Title,
 		thread.MessageCount, thread.LastMessageAt, thread.CreatedBy, thread.CreatedAt,
 		thread.UpdatedAt, thread.IsArchived, thread.RestrictedParticipants,
-	)
-	if err != nil {
+	}
+}
+
+func (r *chatRepository) CreateThread(ctx context.Context, thread *model.Thread) error {
+	if _, err := r.pool.Exec(ctx, insertThreadQuery, newThreadArgs(thread)...); err != nil {
 		return fmt.Errorf("failed to create thread: %w", err)
 	}
 
@@ -1474,15 +1960,31 @@ func (r *chatRepository) AddThreadParticipant(ctx context.Context, participant *
 	return nil
 }
 
+// RemoveThreadParticipant removes the user from the thread and unsubscribes them from it
 func (r *chatRepository) RemoveThreadParticipant(ctx context.Context, threadID, userID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `DELETE FROM con_test.thread_participants WHERE thread_id = $1 AND user_id = $2`
-	result, err := r.pool.Exec(ctx, query, threadID, userID)
+	result, err := tx.Exec(ctx, query, threadID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to remove thread participant: %w", err)
 	}
 	if result.RowsAffected() == 0 {
 		return ErrParticipantNotFound
 	}
+
+	query = `DELETE FROM con_test.thread_subscriptions WHERE thread_id = $1 AND user_id = $2`
+	if _, err := tx.Exec(ctx, query, threadID, userID); err != nil {
+		return fmt.Errorf("failed to remove thread subscription: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -1538,7 +2040,7 @@ func (r *chatRepository) ListThreadMessages(ctx context.Context, threadID uuid.U
 
 	query := `
 		SELECT m.id, m.chat_id, m.parent_id, m.thread_id, m.sender_id, m.content, m.sent_at, m.updated_at, m.is_deleted, m.is_system, m.seq_num,
-		       u.username, u.display_name, u.avatar_url
+		       u.username, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.messages m
 		LEFT JOIN con_test.users u ON m.sender_id = u.id
 		WHERE m.thread_id = $1
@@ -1558,7 +2060,7 @@ func (r *chatRepository) ListThreadMessages(ctx context.Context, threadID uuid.U
 		if err := rows.Scan(
 			&msg.ID, &msg.ChatID, &msg.ParentID, &msg.ThreadID, &msg.SenderID, &msg.Content,
 			&msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.IsSystem, &msg.SeqNum,
-			&msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL,
+			&msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL, &msg.SenderIsBot,
 		); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan thread message: %w", err)
 		}
@@ -1568,6 +2070,111 @@ func (r *chatRepository) ListThreadMessages(ctx context.Context, threadID uuid.U
 	return messages, total, nil
 }
 
+// MoveMessagesToThread moves messages of a chat into the target thread in a single transaction.
+// seq_num and sent_at are left untouched so sync ordering is preserved; thread counters
+// (message_count, last_message_at) are recalculated for the source and target threads.
+// Returns the previous thread of every moved message (nil = main chat).
+func (r *chatRepository) MoveMessagesToThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, threadID uuid.UUID) (map[uuid.UUID]*uuid.UUID, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := moveMessages(ctx, tx, chatID, messageIDs, threadID)
+	if err != nil || len(previous) == 0 {
+		return previous, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return previous, nil
+}
+
+// MoveMessagesToNewThread creates the thread and moves messages of the chat into it in a single
+// transaction, like MoveMessagesToThread. The thread is not created when no message was moved.
+func (r *chatRepository) MoveMessagesToNewThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, thread *model.Thread) (map[uuid.UUID]*uuid.UUID, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, insertThreadQuery, newThreadArgs(thread)...); err != nil {
+		return nil, fmt.Errorf("failed to create thread: %w", err)
+	}
+	previous, err := moveMessages(ctx, tx, chatID, messageIDs, thread.ID)
+	if err != nil || len(previous) == 0 {
+		return previous, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return previous, nil
+}
+
+// moveMessages moves messages in tx and recalculates the counters of the affected threads.
+// Returns the previous thread of every moved message; messages already in the thread are skipped.
+func moveMessages(ctx context.Context, tx pgx.Tx, chatID uuid.UUID, messageIDs []uuid.UUID, threadID uuid.UUID) (map[uuid.UUID]*uuid.UUID, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, thread_id FROM con_test.messages
+		WHERE chat_id = $1 AND id = ANY($2)
+		FOR UPDATE
+	`, chatID, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock messages: %w", err)
+	}
+
+	previous := make(map[uuid.UUID]*uuid.UUID, len(messageIDs))
+	affectedThreads := []uuid.UUID{threadID}
+	for rows.Next() {
+		var id uuid.UUID
+		var fromThreadID *uuid.UUID
+		if err := rows.Scan(&id, &fromThreadID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+		if fromThreadID != nil && *fromThreadID == threadID {
+			continue // already in target thread
+		}
+		previous[id] = fromThreadID
+		if fromThreadID != nil {
+			affectedThreads = append(affectedThreads, *fromThreadID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read messages: %w", err)
+	}
+
+	if len(previous) == 0 {
+		return previous, nil
+	}
+
+	movedIDs := make([]uuid.UUID, 0, len(previous))
+	for id := range previous {
+		movedIDs = append(movedIDs, id)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE con_test.messages SET thread_id = $2 WHERE id = ANY($1)`, movedIDs, threadID); err != nil {
+		return nil, fmt.Errorf("failed to move messages: %w", err)
+	}
+
+	// Counters are maintained by triggers only on INSERT/DELETE, recalculate them here
+	countersQuery := `
+		UPDATE con_test.threads t
+		SET message_count = (SELECT COUNT(*) FROM con_test.messages m WHERE m.thread_id = t.id),
+		    last_message_at = (SELECT MAX(m.sent_at) FROM con_test.messages m WHERE m.thread_id = t.id),
+		    updated_at = $2
+		WHERE t.id = ANY($1)
+	`
+	if _, err := tx.Exec(ctx, countersQuery, affectedThreads, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to update thread counters: %w", err)
+	}
+
+	return previous, nil
+}
+
 // HasThreadAccess checks if user has access to a thread using cascading permission check
 // Uses PostgreSQL function check_thread_access for efficient recursive check
 func (r *chatRepository) HasThreadAccess(ctx context.Context, threadID, userID uuid.UUID) (bool, error) {
@@ -1699,17 +2306,183 @@ func (r *chatRepository) ListSubthreads(ctx context.Context, parentThreadID uuid
 	return threads, total, nil
 }
 
-// Reply operations
-
-func (r *chatRepository) SaveMessageReplies(ctx context.Context, messageID uuid.UUID, replyToIDs []uuid.UUID) error {
-	if len(replyToIDs) == 0 {
-		return nil
-	}
+// Thread subscriptions
 
+func (r *chatRepository) FollowThread(ctx context.Context, threadID, userID uuid.UUID) error {
 	query := `
-		INSERT INTO con_test.message_replies (id, message_id, reply_to_message_id, created_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (message_id, reply_to_message_id) DO NOTHING
+		INSERT INTO con_test.thread_subscriptions (id, thread_id, user_id, last_read_at, created_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (thread_id, user_id) DO NOTHING
+	`
+	_, err := r.pool.Exec(ctx, query, uuid.New(), threadID, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to follow thread: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) UnfollowThread(ctx context.Context, threadID, userID uuid.UUID) error {
+	query := `DELETE FROM con_test.thread_subscriptions WHERE thread_id = $1 AND user_id = $2`
+	if _, err := r.pool.Exec(ctx, query, threadID, userID); err != nil {
+		return fmt.Errorf("failed to unfollow thread: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) IsFollowingThread(ctx context.Context, threadID, userID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM con_test.thread_subscriptions WHERE thread_id = $1 AND user_id = $2)`
+	var exists bool
+	if err := r.pool.QueryRow(ctx, query, threadID, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check thread subscription: %w", err)
+	}
+	return exists, nil
+}
+
+// GetThreadFollowerIDs returns followers that may still read the thread: participants of its
+// chat and, for a restricted thread, of the thread itself
+func (r *chatRepository) GetThreadFollowerIDs(ctx context.Context, threadID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT ts.user_id
+		FROM con_test.thread_subscriptions ts
+		JOIN con_test.threads t ON t.id = ts.thread_id
+		JOIN con_test.chat_participants cp ON cp.chat_id = t.chat_id AND cp.user_id = ts.user_id
+		WHERE ts.thread_id = $1
+		  AND (t.restricted_participants = false OR EXISTS (
+		      SELECT 1 FROM con_test.thread_participants tp
+		      WHERE tp.thread_id = t.id AND tp.user_id = ts.user_id))
+	`
+	rows, err := r.pool.Query(ctx, query, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread followers: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan thread follower: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ListFollowedThreads returns threads followed by the user ordered by last activity.
+// Only threads the user may still read are returned: in chats the user participates in and,
+// for restricted threads, that the user participates in.
+func (r *chatRepository) ListFollowedThreads(ctx context.Context, userID uuid.UUID, page, count int) ([]model.FollowedThread, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 20
+	}
+	offset := (page - 1) * count
+
+	countQuery := `
+		SELECT COUNT(*) FROM con_test.thread_subscriptions ts
+		JOIN con_test.threads t ON t.id = ts.thread_id
+		JOIN con_test.chat_participants cp ON cp.chat_id = t.chat_id AND cp.user_id = ts.user_id
+		WHERE ts.user_id = $1 AND t.is_archived = false
+		  AND (t.restricted_participants = false OR EXISTS (
+		      SELECT 1 FROM con_test.thread_participants tp
+		      WHERE tp.thread_id = t.id AND tp.user_id = ts.user_id))
+	`
+	var total int
+	if err := r.pool.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count followed threads: %w", err)
+	}
+
+	query := `
+		SELECT t.id, t.chat_id, t.parent_message_id, t.parent_thread_id, t.depth, t.thread_type, t.title, t.message_count, t.last_message_at,
+		       t.created_by, t.created_at, t.updated_at, t.is_archived, t.restricted_participants,
+		       ts.created_at,
+		       (SELECT COUNT(*) FROM con_test.messages m
+		        WHERE m.thread_id = t.id AND m.sent_at > ts.last_read_at
+		        AND m.sender_id != ts.user_id AND m.is_deleted = false) AS unread_count
+		FROM con_test.thread_subscriptions ts
+		JOIN con_test.threads t ON t.id = ts.thread_id
+		JOIN con_test.chat_participants cp ON cp.chat_id = t.chat_id AND cp.user_id = ts.user_id
+		WHERE ts.user_id = $1 AND t.is_archived = false
+		  AND (t.restricted_participants = false OR EXISTS (
+		      SELECT 1 FROM con_test.thread_participants tp
+		      WHERE tp.thread_id = t.id AND tp.user_id = ts.user_id))
+		ORDER BY COALESCE(t.last_message_at, t.created_at) DESC, t.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, count, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list followed threads: %w", err)
+	}
+	defer rows.Close()
+
+	var threads []model.FollowedThread
+	for rows.Next() {
+		var t model.FollowedThread
+		if err := rows.Scan(
+			&t.ID, &t.ChatID, &t.ParentMessageID, &t.ParentThreadID, &t.Depth,
+			&t.ThreadType, &t.Title, &t.MessageCount, &t.LastMessageAt, &t.CreatedBy,
+			&t.CreatedAt, &t.UpdatedAt, &t.IsArchived, &t.RestrictedParticipants,
+			&t.FollowedAt, &t.UnreadCount,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan followed thread: %w", err)
+		}
+		threads = append(threads, t)
+	}
+
+	return threads, total, nil
+}
+
+func (r *chatRepository) MarkThreadAsRead(ctx context.Context, threadID, userID uuid.UUID) error {
+	query := `UPDATE con_test.thread_subscriptions SET last_read_at = $3 WHERE thread_id = $1 AND user_id = $2`
+	if _, err := r.pool.Exec(ctx, query, threadID, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark thread as read: %w", err)
+	}
+	return nil
+}
+
+// GetParticipantIDsByUsernames resolves usernames (e.g. from @mentions) to chat participant IDs
+func (r *chatRepository) GetParticipantIDsByUsernames(ctx context.Context, chatID uuid.UUID, usernames []string) ([]uuid.UUID, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT cp.user_id
+		FROM con_test.chat_participants cp
+		JOIN con_test.users u ON cp.user_id = u.id
+		WHERE cp.chat_id = $1 AND u.username = ANY($2)
+	`
+	rows, err := r.pool.Query(ctx, query, chatID, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants by usernames: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan participant ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Reply operations
+
+func (r *chatRepository) SaveMessageReplies(ctx context.Context, messageID uuid.UUID, replyToIDs []uuid.UUID) error {
+	if len(replyToIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO con_test.message_replies (id, message_id, reply_to_message_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id, reply_to_message_id) DO NOTHING
 	`
 
 	now := time.Now()
@@ -1786,7 +2559,7 @@ func (r *chatRepository) GetMessagesById(ctx context.Context, ids []uuid.UUID) (
 
 	query := `
 		SELECT m.id, m.chat_id, m.parent_id, m.sender_id, m.content, m.sent_at, m.updated_at, m.is_deleted, m.seq_num,
-		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url
+		       m.is_system, m.thread_id, u.username, u.display_name, u.avatar_url, COALESCE(u.is_bot, false)
 		FROM con_test.messages m
 		LEFT JOIN con_test.users u ON m.sender_id = u.id
 		WHERE m.id = ANY($1)
@@ -1803,7 +2576,7 @@ func (r *chatRepository) GetMessagesById(ctx context.Context, ids []uuid.UUID) (
 		var msg model.Message
 		if err := rows.Scan(
 			&msg.ID, &msg.ChatID, &msg.ParentID, &msg.SenderID, &msg.Content, &msg.SentAt, &msg.UpdatedAt, &msg.IsDeleted, &msg.SeqNum,
-			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL,
+			&msg.IsSystem, &msg.ThreadID, &msg.SenderUsername, &msg.SenderDisplayName, &msg.SenderAvatarURL, &msg.SenderIsBot,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
@@ -1948,3 +2721,1329 @@ func (r *chatRepository) DeleteChatFileLink(ctx context.Context, chatID uuid.UUI
 	}
 	return nil
 }
+
+// Moderation (message reports and audit trail)
+
+var ErrReportNotFound = errors.New("report not found")
+
+// GetUserRole returns the global users-service role of a user (empty if the user is unknown)
+func (r *chatRepository) GetUserRole(ctx context.Context, userID uuid.UUID) (model.UserRole, error) {
+	var role model.UserRole
+	err := r.pool.QueryRow(ctx, `SELECT role FROM con_test.users WHERE id = $1`, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+	return role, nil
+}
+
+// GetGlobalModeratorIDs returns users with the owner or moderator global role
+func (r *chatRepository) GetGlobalModeratorIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query := `SELECT id FROM con_test.users WHERE role IN ('owner', 'moderator')`
+	return r.queryUUIDs(ctx, query)
+}
+
+// GetChatModeratorIDs returns participants who can moderate the chat
+func (r *chatRepository) GetChatModeratorIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT user_id FROM con_test.chat_participants WHERE chat_id = $1 AND role = 'admin'`
+	return r.queryUUIDs(ctx, query, chatID)
+}
+
+func (r *chatRepository) queryUUIDs(ctx context.Context, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// CreateMessageReport stores a report. Returns ErrAlreadyExists if the user already reported the message.
+func (r *chatRepository) CreateMessageReport(ctx context.Context, report *model.MessageReport) error {
+	query := `
+		INSERT INTO con_test.message_reports (id, chat_id, message_id, sender_id, message_content, reporter_id, reason, comment, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (message_id, reporter_id) DO NOTHING
+	`
+
+	report.ID = uuid.New()
+	report.Status = model.ReportStatusOpen
+	report.CreatedAt = time.Now()
+
+	result, err := r.pool.Exec(ctx, query, report.ID, report.ChatID, report.MessageID, report.SenderID, report.MessageContent,
+		report.ReporterID, report.Reason, report.Comment, report.Status, report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create message report: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+const messageReportColumns = `id, chat_id, message_id, sender_id, message_content, reporter_id, reason, comment,
+	status, resolution, resolved_by, resolved_at, created_at`
+
+func scanMessageReport(row pgx.Row, report *model.MessageReport) error {
+	return row.Scan(&report.ID, &report.ChatID, &report.MessageID, &report.SenderID, &report.MessageContent,
+		&report.ReporterID, &report.Reason, &report.Comment, &report.Status, &report.Resolution,
+		&report.ResolvedBy, &report.ResolvedAt, &report.CreatedAt)
+}
+
+func (r *chatRepository) GetMessageReport(ctx context.Context, id uuid.UUID) (*model.MessageReport, error) {
+	query := `SELECT ` + messageReportColumns + ` FROM con_test.message_reports WHERE id = $1`
+
+	var report model.MessageReport
+	if err := scanMessageReport(r.pool.QueryRow(ctx, query, id), &report); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get message report: %w", err)
+	}
+	return &report, nil
+}
+
+// ListMessageReports returns reports of a chat (or of all chats when chatID is nil), newest first.
+// An empty status returns reports in every status.
+func (r *chatRepository) ListMessageReports(ctx context.Context, chatID *uuid.UUID, status model.ReportStatus, page, count int) ([]model.MessageReport, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 20
+	}
+	offset := (page - 1) * count
+
+	where := `WHERE ($1::uuid IS NULL OR chat_id = $1) AND ($2 = '' OR status = $2)`
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM con_test.message_reports `+where, chatID, string(status)).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count message reports: %w", err)
+	}
+
+	query := `SELECT ` + messageReportColumns + ` FROM con_test.message_reports ` + where + `
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.pool.Query(ctx, query, chatID, string(status), count, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list message reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.MessageReport
+	for rows.Next() {
+		var report model.MessageReport
+		if err := scanMessageReport(rows, &report); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan message report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, total, nil
+}
+
+// ResolveMessageReports resolves every open report of a message with the given action
+func (r *chatRepository) ResolveMessageReports(ctx context.Context, messageID uuid.UUID, action model.ReportAction, resolvedBy uuid.UUID) (int64, error) {
+	query := `
+		UPDATE con_test.message_reports
+		SET status = $2, resolution = $3, resolved_by = $4, resolved_at = NOW()
+		WHERE message_id = $1 AND status = $5
+	`
+	result, err := r.pool.Exec(ctx, query, messageID, model.ReportStatusResolved, action, resolvedBy, model.ReportStatusOpen)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve message reports: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+func (r *chatRepository) AddModerationAuditEntry(ctx context.Context, entry *model.ModerationAuditEntry) error {
+	query := `
+		INSERT INTO con_test.moderation_audit_log (id, chat_id, actor_id, action, report_id, message_id, target_user_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now()
+
+	_, err := r.pool.Exec(ctx, query, entry.ID, entry.ChatID, entry.ActorID, entry.Action, entry.ReportID,
+		entry.MessageID, entry.TargetUserID, entry.Details, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add moderation audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListModerationAuditLog returns the audit trail of a chat (or of all chats when chatID is nil), newest first
+func (r *chatRepository) ListModerationAuditLog(ctx context.Context, chatID *uuid.UUID, page, count int) ([]model.ModerationAuditEntry, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 20
+	}
+	offset := (page - 1) * count
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM con_test.moderation_audit_log WHERE ($1::uuid IS NULL OR chat_id = $1)`, chatID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count moderation audit log: %w", err)
+	}
+
+	query := `
+		SELECT id, chat_id, actor_id, action, report_id, message_id, target_user_id, details, created_at
+		FROM con_test.moderation_audit_log
+		WHERE ($1::uuid IS NULL OR chat_id = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, chatID, count, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list moderation audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []model.ModerationAuditEntry
+	for rows.Next() {
+		var e model.ModerationAuditEntry
+		if err := rows.Scan(&e.ID, &e.ChatID, &e.ActorID, &e.Action, &e.ReportID, &e.MessageID, &e.TargetUserID, &e.Details, &e.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan moderation audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, total, nil
+}
+
+// Chat restrictions (bans and timed mutes)
+
+var ErrRestrictionNotFound = errors.New("restriction not found")
+
+// CreateChatRestriction stores a ban or mute. Returns ErrAlreadyExists if the user already has
+// an active restriction of the same type in the chat.
+func (r *chatRepository) CreateChatRestriction(ctx context.Context, restriction *model.ChatRestriction) error {
+	query := `
+		INSERT INTO con_test.chat_restrictions (id, chat_id, user_id, type, reason, previous_role, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chat_id, user_id, type) WHERE lifted_at IS NULL DO NOTHING
+	`
+
+	restriction.ID = uuid.New()
+	restriction.CreatedAt = time.Now()
+
+	result, err := r.pool.Exec(ctx, query, restriction.ID, restriction.ChatID, restriction.UserID, restriction.Type,
+		restriction.Reason, restriction.PreviousRole, restriction.CreatedBy, restriction.CreatedAt, restriction.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create chat restriction: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+const chatRestrictionColumns = `cr.id, cr.chat_id, cr.user_id, cr.type, cr.reason, cr.previous_role, cr.created_by,
+	cr.created_at, cr.expires_at, cr.lifted_at, cr.lifted_by, u.username`
+
+func scanChatRestriction(row pgx.Row, restriction *model.ChatRestriction) error {
+	return row.Scan(&restriction.ID, &restriction.ChatID, &restriction.UserID, &restriction.Type, &restriction.Reason,
+		&restriction.PreviousRole, &restriction.CreatedBy, &restriction.CreatedAt, &restriction.ExpiresAt,
+		&restriction.LiftedAt, &restriction.LiftedBy, &restriction.Username)
+}
+
+// GetActiveChatRestriction returns the restriction of the given type that is currently in force.
+// Restrictions past their expiry are not returned even if the expiry ticker has not lifted them yet.
+func (r *chatRepository) GetActiveChatRestriction(ctx context.Context, chatID, userID uuid.UUID, restrictionType model.RestrictionType) (*model.ChatRestriction, error) {
+	query := `
+		SELECT ` + chatRestrictionColumns + `
+		FROM con_test.chat_restrictions cr
+		LEFT JOIN con_test.users u ON cr.user_id = u.id
+		WHERE cr.chat_id = $1 AND cr.user_id = $2 AND cr.type = $3
+		  AND cr.lifted_at IS NULL AND (cr.expires_at IS NULL OR cr.expires_at > NOW())
+	`
+
+	var restriction model.ChatRestriction
+	if err := scanChatRestriction(r.pool.QueryRow(ctx, query, chatID, userID, restrictionType), &restriction); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRestrictionNotFound
+		}
+		return nil, fmt.Errorf("failed to get chat restriction: %w", err)
+	}
+	return &restriction, nil
+}
+
+func (r *chatRepository) ListActiveChatRestrictions(ctx context.Context, chatID uuid.UUID) ([]model.ChatRestriction, error) {
+	query := `
+		SELECT ` + chatRestrictionColumns + `
+		FROM con_test.chat_restrictions cr
+		LEFT JOIN con_test.users u ON cr.user_id = u.id
+		WHERE cr.chat_id = $1 AND cr.lifted_at IS NULL AND (cr.expires_at IS NULL OR cr.expires_at > NOW())
+		ORDER BY cr.created_at DESC
+	`
+	return r.queryChatRestrictions(ctx, query, chatID)
+}
+
+// LiftChatRestriction ends an active restriction. liftedBy is nil when the restriction expired.
+// Returns false if the restriction was already lifted, so concurrent expiry runs act only once.
+func (r *chatRepository) LiftChatRestriction(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error) {
+	query := `
+		UPDATE con_test.chat_restrictions
+		SET lifted_at = NOW(), lifted_by = $2
+		WHERE id = $1 AND lifted_at IS NULL
+	`
+	result, err := r.pool.Exec(ctx, query, id, liftedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to lift chat restriction: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// GetExpiredChatRestrictions returns restrictions whose expiry has passed but that are not lifted yet
+func (r *chatRepository) GetExpiredChatRestrictions(ctx context.Context, now time.Time, limit int) ([]model.ChatRestriction, error) {
+	query := `
+		SELECT ` + chatRestrictionColumns + `
+		FROM con_test.chat_restrictions cr
+		LEFT JOIN con_test.users u ON cr.user_id = u.id
+		WHERE cr.lifted_at IS NULL AND cr.expires_at IS NOT NULL AND cr.expires_at <= $1
+		ORDER BY cr.expires_at
+		LIMIT $2
+	`
+	return r.queryChatRestrictions(ctx, query, now, limit)
+}
+
+func (r *chatRepository) queryChatRestrictions(ctx context.Context, query string, args ...interface{}) ([]model.ChatRestriction, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chat restrictions: %w", err)
+	}
+	defer rows.Close()
+
+	var restrictions []model.ChatRestriction
+	for rows.Next() {
+		var restriction model.ChatRestriction
+		if err := scanChatRestriction(rows, &restriction); err != nil {
+			return nil, fmt.Errorf("failed to scan chat restriction: %w", err)
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions, nil
+}
+
+// Slow mode
+
+func (r *chatRepository) SetChatSlowMode(ctx context.Context, chatID uuid.UUID, seconds int) error {
+	query := `UPDATE con_test.chats SET slow_mode_seconds = $2, updated_at = NOW() WHERE id = $1`
+	result, err := r.pool.Exec(ctx, query, chatID, seconds)
+	if err != nil {
+		return fmt.Errorf("failed to set slow mode: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrChatNotFound
+	}
+	return nil
+}
+
+// Content filter policies and hit log
+
+var ErrContentPolicyNotFound = errors.New("content policy not found")
+
+// GetContentPolicy returns the policy of a chat, or the global policy when chatID is nil
+func (r *chatRepository) GetContentPolicy(ctx context.Context, chatID *uuid.UUID) (*model.ContentPolicy, error) {
+	query := `
+		SELECT chat_id, rules, updated_by, updated_at
+		FROM con_test.content_policies
+		WHERE chat_id IS NOT DISTINCT FROM $1
+	`
+
+	var policy model.ContentPolicy
+	err := r.pool.QueryRow(ctx, query, chatID).Scan(&policy.ChatID, &policy.Rules, &policy.UpdatedBy, &policy.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrContentPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get content policy: %w", err)
+	}
+	return &policy, nil
+}
+
+func (r *chatRepository) UpsertContentPolicy(ctx context.Context, policy *model.ContentPolicy) error {
+	query := `
+		INSERT INTO con_test.content_policies (chat_id, rules, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ((COALESCE(chat_id, '00000000-0000-0000-0000-000000000000'::uuid)))
+		DO UPDATE SET rules = EXCLUDED.rules, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+	`
+
+	policy.UpdatedAt = time.Now()
+
+	if _, err := r.pool.Exec(ctx, query, policy.ChatID, policy.Rules, policy.UpdatedBy, policy.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save content policy: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) DeleteContentPolicy(ctx context.Context, chatID *uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.content_policies WHERE chat_id IS NOT DISTINCT FROM $1`, chatID)
+	if err != nil {
+		return fmt.Errorf("failed to delete content policy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrContentPolicyNotFound
+	}
+	return nil
+}
+
+func (r *chatRepository) AddContentFilterHits(ctx context.Context, hits []model.ContentFilterHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO con_test.content_filter_hits (id, chat_id, sender_id, message_id, filter, rule, action, matches, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	batch := &pgx.Batch{}
+	now := time.Now()
+	for i := range hits {
+		hits[i].ID = uuid.New()
+		hits[i].CreatedAt = now
+		h := hits[i]
+		batch.Queue(query, h.ID, h.ChatID, h.SenderID, h.MessageID, h.Filter, h.Rule, h.Action, h.Matches, h.CreatedAt)
+	}
+
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to add content filter hits: %w", err)
+	}
+	return nil
+}
+
+// ListContentFilterHits returns filter hits of a chat (or of all chats when chatID is nil), newest first
+func (r *chatRepository) ListContentFilterHits(ctx context.Context, chatID *uuid.UUID, page, count int) ([]model.ContentFilterHit, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 20
+	}
+	offset := (page - 1) * count
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM con_test.content_filter_hits WHERE ($1::uuid IS NULL OR chat_id = $1)`, chatID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count content filter hits: %w", err)
+	}
+
+	query := `
+		SELECT id, chat_id, sender_id, message_id, filter, rule, action, matches, created_at
+		FROM con_test.content_filter_hits
+		WHERE ($1::uuid IS NULL OR chat_id = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, chatID, count, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list content filter hits: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []model.ContentFilterHit
+	for rows.Next() {
+		var h model.ContentFilterHit
+		if err := rows.Scan(&h.ID, &h.ChatID, &h.SenderID, &h.MessageID, &h.Filter, &h.Rule, &h.Action, &h.Matches, &h.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan content filter hit: %w", err)
+		}
+		hits = append(hits, h)
+	}
+
+	return hits, total, nil
+}
+
+// Outgoing webhooks
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+const webhookSubscriptionColumns = `id, chat_id, url, secret, event_types, is_active, created_by, created_at, updated_at`
+
+func scanWebhookSubscription(row pgx.Row, sub *model.WebhookSubscription) error {
+	return row.Scan(&sub.ID, &sub.ChatID, &sub.URL, &sub.Secret, &sub.EventTypes, &sub.IsActive,
+		&sub.CreatedBy, &sub.CreatedAt, &sub.UpdatedAt)
+}
+
+func (r *chatRepository) CreateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	query := `
+		INSERT INTO con_test.webhook_subscriptions (id, chat_id, url, secret, event_types, is_active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	sub.ID = uuid.New()
+	sub.CreatedAt = time.Now()
+	sub.UpdatedAt = sub.CreatedAt
+
+	_, err := r.pool.Exec(ctx, query, sub.ID, sub.ChatID, sub.URL, sub.Secret, sub.EventTypes, sub.IsActive,
+		sub.CreatedBy, sub.CreatedAt, sub.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM con_test.webhook_subscriptions WHERE id = $1`
+
+	var sub model.WebhookSubscription
+	if err := scanWebhookSubscription(r.pool.QueryRow(ctx, query, id), &sub); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return &sub, nil
+}
+
+// ListWebhookSubscriptions returns the subscriptions of a chat, or the global ones when chatID is nil
+func (r *chatRepository) ListWebhookSubscriptions(ctx context.Context, chatID *uuid.UUID) ([]model.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM con_test.webhook_subscriptions
+		WHERE chat_id IS NOT DISTINCT FROM $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []model.WebhookSubscription
+	for rows.Next() {
+		var sub model.WebhookSubscription
+		if err := scanWebhookSubscription(rows, &sub); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// UpdateWebhookSubscription saves the URL, event types and active flag of a subscription
+func (r *chatRepository) UpdateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	query := `
+		UPDATE con_test.webhook_subscriptions
+		SET url = $2, event_types = $3, is_active = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	sub.UpdatedAt = time.Now()
+
+	result, err := r.pool.Exec(ctx, query, sub.ID, sub.URL, sub.EventTypes, sub.IsActive, sub.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *chatRepository) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues an event for every active subscription of the chat (and every
+// global subscription) that listens to the event type. Returns the number of queued deliveries.
+func (r *chatRepository) EnqueueWebhookDeliveries(ctx context.Context, eventType string, chatID *uuid.UUID, payload []byte) (int64, error) {
+	query := `
+		INSERT INTO con_test.webhook_deliveries (subscription_id, event_type, chat_id, payload)
+		SELECT id, $1, $2, $3
+		FROM con_test.webhook_subscriptions
+		WHERE is_active AND $1 = ANY(event_types) AND (chat_id IS NULL OR chat_id = $2)
+	`
+
+	result, err := r.pool.Exec(ctx, query, eventType, chatID, payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_type, d.chat_id, d.payload, d.status, d.attempts,
+	d.last_status_code, d.last_error, d.last_attempt_at, d.next_attempt_at, d.delivered_at, d.created_at`
+
+func scanWebhookDelivery(row pgx.Row, d *model.WebhookDelivery, extra ...interface{}) error {
+	dest := []interface{}{&d.ID, &d.SubscriptionID, &d.EventType, &d.ChatID, &d.Payload, &d.Status, &d.Attempts,
+		&d.LastStatusCode, &d.LastError, &d.LastAttemptAt, &d.NextAttemptAt, &d.DeliveredAt, &d.CreatedAt}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// ClaimWebhookDeliveries picks due pending deliveries and pushes their next attempt back by lease,
+// so other replicas skip them while they are being sent. If the sender dies, they become due again
+// once the lease runs out.
+func (r *chatRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM con_test.webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE con_test.webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM due, con_test.webhook_subscriptions s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING ` + webhookDeliveryColumns + `, s.url, s.secret
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &d, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *chatRepository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (*model.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM con_test.webhook_deliveries d WHERE d.id = $1`
+
+	var d model.WebhookDelivery
+	if err := scanWebhookDelivery(r.pool.QueryRow(ctx, query, id), &d); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &d, nil
+}
+
+// UpdateWebhookDelivery saves the state and last attempt result of a delivery
+func (r *chatRepository) UpdateWebhookDelivery(ctx context.Context, d *model.WebhookDelivery) error {
+	query := `
+		UPDATE con_test.webhook_deliveries
+		SET status = $2, attempts = $3, last_status_code = $4, last_error = $5, last_attempt_at = $6,
+		    next_attempt_at = $7, delivered_at = $8
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, d.ID, d.Status, d.Attempts, d.LastStatusCode, d.LastError, d.LastAttemptAt,
+		d.NextAttemptAt, d.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a subscription, newest first.
+// An empty status returns deliveries in every status.
+func (r *chatRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, status model.WebhookDeliveryStatus, page, count int) ([]model.WebhookDelivery, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 20
+	}
+	offset := (page - 1) * count
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM con_test.webhook_deliveries WHERE subscription_id = $1 AND ($2 = '' OR status = $2)`
+	if err := r.pool.QueryRow(ctx, countQuery, subscriptionID, status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM con_test.webhook_deliveries d
+		WHERE d.subscription_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY d.created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, subscriptionID, status, count, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &d); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, total, nil
+}
+
+// Incoming webhooks and bot accounts
+
+var ErrIncomingWebhookNotFound = errors.New("incoming webhook not found")
+
+// GetBotAccount reports whether the user is a bot account (false if the user is unknown) and
+// who owns it
+func (r *chatRepository) GetBotAccount(ctx context.Context, userID uuid.UUID) (bool, *uuid.UUID, error) {
+	var isBot bool
+	var ownerID *uuid.UUID
+	err := r.pool.QueryRow(ctx, `SELECT is_bot, bot_owner_id FROM con_test.users WHERE id = $1`, userID).Scan(&isBot, &ownerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return isBot, ownerID, nil
+}
+
+const incomingWebhookColumns = `w.id, w.chat_id, w.thread_id, w.bot_id, u.username, w.name, w.token_hash,
+	w.rate_limit_per_minute, w.created_by, w.created_at, w.token_rotated_at, w.last_used_at`
+
+func scanIncomingWebhook(row pgx.Row, hook *model.IncomingWebhook) error {
+	return row.Scan(&hook.ID, &hook.ChatID, &hook.ThreadID, &hook.BotID, &hook.BotUsername, &hook.Name, &hook.TokenHash,
+		&hook.RateLimitPerMinute, &hook.CreatedBy, &hook.CreatedAt, &hook.TokenRotatedAt, &hook.LastUsedAt)
+}
+
+func (r *chatRepository) CreateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) error {
+	query := `
+		INSERT INTO con_test.incoming_webhooks (id, chat_id, thread_id, bot_id, name, token_hash, rate_limit_per_minute, created_by, created_at, token_rotated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	hook.ID = uuid.New()
+	hook.CreatedAt = time.Now()
+	hook.TokenRotatedAt = hook.CreatedAt
+
+	_, err := r.pool.Exec(ctx, query, hook.ID, hook.ChatID, hook.ThreadID, hook.BotID, hook.Name, hook.TokenHash,
+		hook.RateLimitPerMinute, hook.CreatedBy, hook.CreatedAt, hook.TokenRotatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create incoming webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) GetIncomingWebhook(ctx context.Context, id uuid.UUID) (*model.IncomingWebhook, error) {
+	query := `
+		SELECT ` + incomingWebhookColumns + `
+		FROM con_test.incoming_webhooks w
+		LEFT JOIN con_test.users u ON w.bot_id = u.id
+		WHERE w.id = $1
+	`
+
+	var hook model.IncomingWebhook
+	if err := scanIncomingWebhook(r.pool.QueryRow(ctx, query, id), &hook); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIncomingWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get incoming webhook: %w", err)
+	}
+	return &hook, nil
+}
+
+func (r *chatRepository) ListIncomingWebhooks(ctx context.Context, chatID uuid.UUID) ([]model.IncomingWebhook, error) {
+	query := `
+		SELECT ` + incomingWebhookColumns + `
+		FROM con_test.incoming_webhooks w
+		LEFT JOIN con_test.users u ON w.bot_id = u.id
+		WHERE w.chat_id = $1
+		ORDER BY w.created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incoming webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []model.IncomingWebhook
+	for rows.Next() {
+		var hook model.IncomingWebhook
+		if err := scanIncomingWebhook(rows, &hook); err != nil {
+			return nil, fmt.Errorf("failed to scan incoming webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// UpdateIncomingWebhookToken replaces the token hash; the previous token stops working immediately
+func (r *chatRepository) UpdateIncomingWebhookToken(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE con_test.incoming_webhooks SET token_hash = $2, token_rotated_at = NOW() WHERE id = $1`,
+		id, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to rotate incoming webhook token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrIncomingWebhookNotFound
+	}
+	return nil
+}
+
+// TouchIncomingWebhook records that the webhook was just used
+func (r *chatRepository) TouchIncomingWebhook(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE con_test.incoming_webhooks SET last_used_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to update incoming webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) DeleteIncomingWebhook(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.incoming_webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete incoming webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrIncomingWebhookNotFound
+	}
+	return nil
+}
+
+// Slash commands, interactive messages and reminders
+
+var (
+	ErrBotCommandNotFound  = errors.New("bot command not found")
+	ErrBotCommandExists    = errors.New("command already registered")
+	ErrInteractionNotFound = errors.New("message has no interactive buttons")
+	ErrReminderNotFound    = errors.New("reminder not found")
+)
+
+const botCommandColumns = `id, chat_id, name, description, usage, bot_id, url, secret, created_by, created_at`
+
+func scanBotCommand(row pgx.Row, cmd *model.BotCommand) error {
+	return row.Scan(&cmd.ID, &cmd.ChatID, &cmd.Name, &cmd.Description, &cmd.Usage, &cmd.BotID, &cmd.URL, &cmd.Secret,
+		&cmd.CreatedBy, &cmd.CreatedAt)
+}
+
+// CreateBotCommand registers a command. Returns ErrBotCommandExists if the name is taken in the scope.
+func (r *chatRepository) CreateBotCommand(ctx context.Context, cmd *model.BotCommand) error {
+	query := `
+		INSERT INTO con_test.bot_commands (id, chat_id, name, description, usage, bot_id, url, secret, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT DO NOTHING
+	`
+
+	cmd.ID = uuid.New()
+	cmd.CreatedAt = time.Now()
+
+	result, err := r.pool.Exec(ctx, query, cmd.ID, cmd.ChatID, cmd.Name, cmd.Description, cmd.Usage, cmd.BotID, cmd.URL,
+		cmd.Secret, cmd.CreatedBy, cmd.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create bot command: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrBotCommandExists
+	}
+	return nil
+}
+
+func (r *chatRepository) GetBotCommand(ctx context.Context, id uuid.UUID) (*model.BotCommand, error) {
+	query := `SELECT ` + botCommandColumns + ` FROM con_test.bot_commands WHERE id = $1`
+
+	var cmd model.BotCommand
+	if err := scanBotCommand(r.pool.QueryRow(ctx, query, id), &cmd); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBotCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to get bot command: %w", err)
+	}
+	return &cmd, nil
+}
+
+// FindBotCommand returns the command with the name in the chat, falling back to the global one
+func (r *chatRepository) FindBotCommand(ctx context.Context, chatID uuid.UUID, name string) (*model.BotCommand, error) {
+	query := `
+		SELECT ` + botCommandColumns + `
+		FROM con_test.bot_commands
+		WHERE name = $2 AND (chat_id = $1 OR chat_id IS NULL)
+		ORDER BY chat_id NULLS LAST
+		LIMIT 1
+	`
+
+	var cmd model.BotCommand
+	if err := scanBotCommand(r.pool.QueryRow(ctx, query, chatID, name), &cmd); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBotCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to find bot command: %w", err)
+	}
+	return &cmd, nil
+}
+
+// ListBotCommands returns the commands registered for a chat, or the global ones when chatID is nil
+func (r *chatRepository) ListBotCommands(ctx context.Context, chatID *uuid.UUID) ([]model.BotCommand, error) {
+	query := `
+		SELECT ` + botCommandColumns + `
+		FROM con_test.bot_commands
+		WHERE chat_id IS NOT DISTINCT FROM $1
+		ORDER BY name
+	`
+	return r.queryBotCommands(ctx, query, chatID)
+}
+
+// ListAvailableBotCommands returns the commands usable in a chat: its own and the global ones
+// that it does not override
+func (r *chatRepository) ListAvailableBotCommands(ctx context.Context, chatID uuid.UUID) ([]model.BotCommand, error) {
+	query := `
+		SELECT DISTINCT ON (name) ` + botCommandColumns + `
+		FROM con_test.bot_commands
+		WHERE chat_id = $1 OR chat_id IS NULL
+		ORDER BY name, chat_id NULLS LAST
+	`
+	return r.queryBotCommands(ctx, query, chatID)
+}
+
+func (r *chatRepository) queryBotCommands(ctx context.Context, query string, args ...any) ([]model.BotCommand, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bot commands: %w", err)
+	}
+	defer rows.Close()
+
+	var cmds []model.BotCommand
+	for rows.Next() {
+		var cmd model.BotCommand
+		if err := scanBotCommand(rows, &cmd); err != nil {
+			return nil, fmt.Errorf("failed to scan bot command: %w", err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, nil
+}
+
+func (r *chatRepository) DeleteBotCommand(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.bot_commands WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete bot command: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrBotCommandNotFound
+	}
+	return nil
+}
+
+func (r *chatRepository) CreateMessageInteraction(ctx context.Context, interaction *model.MessageInteraction) error {
+	query := `
+		INSERT INTO con_test.message_interactions (message_id, command, bot_command_id, buttons, state, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	interaction.CreatedAt = time.Now()
+
+	_, err := r.pool.Exec(ctx, query, interaction.MessageID, interaction.Command, interaction.BotCommandID,
+		interaction.Buttons, interaction.State, interaction.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create message interaction: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) GetMessageInteraction(ctx context.Context, messageID uuid.UUID) (*model.MessageInteraction, error) {
+	query := `
+		SELECT message_id, command, bot_command_id, buttons, state, created_at
+		FROM con_test.message_interactions
+		WHERE message_id = $1
+	`
+
+	var mi model.MessageInteraction
+	err := r.pool.QueryRow(ctx, query, messageID).Scan(&mi.MessageID, &mi.Command, &mi.BotCommandID, &mi.Buttons,
+		&mi.State, &mi.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInteractionNotFound
+		}
+		return nil, fmt.Errorf("failed to get message interaction: %w", err)
+	}
+	return &mi, nil
+}
+
+func (r *chatRepository) UpdateMessageInteractionButtons(ctx context.Context, messageID uuid.UUID, buttons []model.MessageButton) error {
+	result, err := r.pool.Exec(ctx, `UPDATE con_test.message_interactions SET buttons = $2 WHERE message_id = $1`, messageID, buttons)
+	if err != nil {
+		return fmt.Errorf("failed to update message buttons: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrInteractionNotFound
+	}
+	return nil
+}
+
+// GetMessageButtons loads the buttons of interactive messages among messageIDs
+func (r *chatRepository) GetMessageButtons(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID][]model.MessageButton, error) {
+	result := make(map[uuid.UUID][]model.MessageButton)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT message_id, buttons FROM con_test.message_interactions WHERE message_id = ANY($1)`, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message buttons: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID uuid.UUID
+		var buttons []model.MessageButton
+		if err := rows.Scan(&messageID, &buttons); err != nil {
+			return nil, fmt.Errorf("failed to scan message buttons: %w", err)
+		}
+		result[messageID] = buttons
+	}
+	return result, nil
+}
+
+// UpsertPollVote records the user's choice in a /poll, replacing an earlier vote
+func (r *chatRepository) UpsertPollVote(ctx context.Context, messageID, userID uuid.UUID, optionIndex int) error {
+	query := `
+		INSERT INTO con_test.command_poll_votes (message_id, user_id, option_index, voted_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (message_id, user_id) DO UPDATE SET option_index = EXCLUDED.option_index, voted_at = EXCLUDED.voted_at
+	`
+	if _, err := r.pool.Exec(ctx, query, messageID, userID, optionIndex); err != nil {
+		return fmt.Errorf("failed to save poll vote: %w", err)
+	}
+	return nil
+}
+
+// CountPollVotes returns the number of votes per option index
+func (r *chatRepository) CountPollVotes(ctx context.Context, messageID uuid.UUID) (map[int]int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT option_index, COUNT(*) FROM con_test.command_poll_votes
+		WHERE message_id = $1
+		GROUP BY option_index
+	`, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count poll votes: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var option, count int
+		if err := rows.Scan(&option, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan poll votes: %w", err)
+		}
+		counts[option] = count
+	}
+	return counts, nil
+}
+
+const reminderColumns = `id, chat_id, thread_id, message_id, user_id, text, remind_at, created_at, delivered_at`
+
+func scanReminder(row pgx.Row, rm *model.Reminder) error {
+	return row.Scan(&rm.ID, &rm.ChatID, &rm.ThreadID, &rm.MessageID, &rm.UserID, &rm.Text, &rm.RemindAt, &rm.CreatedAt,
+		&rm.DeliveredAt)
+}
+
+func (r *chatRepository) CreateReminder(ctx context.Context, reminder *model.Reminder) error {
+	query := `
+		INSERT INTO con_test.chat_reminders (id, chat_id, thread_id, message_id, user_id, text, remind_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	reminder.ID = uuid.New()
+	reminder.CreatedAt = time.Now()
+
+	_, err := r.pool.Exec(ctx, query, reminder.ID, reminder.ChatID, reminder.ThreadID, reminder.MessageID, reminder.UserID,
+		reminder.Text, reminder.RemindAt, reminder.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create reminder: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) GetReminder(ctx context.Context, id uuid.UUID) (*model.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM con_test.chat_reminders WHERE id = $1`
+
+	var rm model.Reminder
+	if err := scanReminder(r.pool.QueryRow(ctx, query, id), &rm); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReminderNotFound
+		}
+		return nil, fmt.Errorf("failed to get reminder: %w", err)
+	}
+	return &rm, nil
+}
+
+// ListReminders returns the user's undelivered reminders, optionally of one chat, soonest first.
+// With deliveredSince set, reminders delivered since then are included as well.
+func (r *chatRepository) ListReminders(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, deliveredSince *time.Time) ([]model.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM con_test.chat_reminders
+		WHERE user_id = $1 AND ($2::uuid IS NULL OR chat_id = $2)
+		  AND (delivered_at IS NULL OR delivered_at >= $3)
+		ORDER BY remind_at
+	`
+	return r.queryReminders(ctx, query, userID, chatID, deliveredSince)
+}
+
+func (r *chatRepository) CountPendingReminders(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM con_test.chat_reminders WHERE user_id = $1 AND delivered_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reminders: %w", err)
+	}
+	return count, nil
+}
+
+func (r *chatRepository) DeleteReminder(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.chat_reminders WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// ClaimDueReminders claims up to limit undelivered reminders due at now for the lease and
+// returns them. Concurrent callers never claim the same reminder; one that is not marked
+// delivered before its lease runs out is claimed again.
+func (r *chatRepository) ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Reminder, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM con_test.chat_reminders
+			WHERE delivered_at IS NULL AND remind_at <= $1
+			  AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY remind_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE con_test.chat_reminders cr
+		SET claimed_until = $3
+		FROM due
+		WHERE cr.id = due.id
+		RETURNING cr.id, cr.chat_id, cr.thread_id, cr.message_id, cr.user_id, cr.text, cr.remind_at, cr.created_at, cr.delivered_at
+	`
+	return r.queryReminders(ctx, query, now, limit, now.Add(lease))
+}
+
+func (r *chatRepository) MarkReminderDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `UPDATE con_test.chat_reminders SET delivered_at = $2 WHERE id = $1`, id, deliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *chatRepository) queryReminders(ctx context.Context, query string, args ...any) ([]model.Reminder, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []model.Reminder
+	for rows.Next() {
+		var rm model.Reminder
+		if err := scanReminder(rows, &rm); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+		reminders = append(reminders, rm)
+	}
+	return reminders, rows.Err()
+}
+
+// Custom emoji
+
+var (
+	ErrCustomEmojiNotFound = errors.New("custom emoji not found")
+	ErrCustomEmojiExists   = errors.New("custom emoji already exists")
+)
+
+const customEmojiColumns = `id, chat_id, name, file_link_id, created_by, created_at`
+
+func scanCustomEmoji(row pgx.Row, e *model.CustomEmoji) error {
+	return row.Scan(&e.ID, &e.ChatID, &e.Name, &e.FileLinkID, &e.CreatedBy, &e.CreatedAt)
+}
+
+// CreateCustomEmoji adds an emoji to the chat. Returns ErrCustomEmojiExists if the name is taken.
+func (r *chatRepository) CreateCustomEmoji(ctx context.Context, e *model.CustomEmoji) error {
+	query := `
+		INSERT INTO con_test.chat_custom_emoji (id, chat_id, name, file_link_id, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (chat_id, name) DO NOTHING
+	`
+
+	e.ID = uuid.New()
+	e.CreatedAt = time.Now()
+
+	result, err := r.pool.Exec(ctx, query, e.ID, e.ChatID, e.Name, e.FileLinkID, e.CreatedBy, e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create custom emoji: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCustomEmojiExists
+	}
+	return nil
+}
+
+func (r *chatRepository) GetCustomEmoji(ctx context.Context, id uuid.UUID) (*model.CustomEmoji, error) {
+	query := `SELECT ` + customEmojiColumns + ` FROM con_test.chat_custom_emoji WHERE id = $1`
+
+	var e model.CustomEmoji
+	if err := scanCustomEmoji(r.pool.QueryRow(ctx, query, id), &e); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCustomEmojiNotFound
+		}
+		return nil, fmt.Errorf("failed to get custom emoji: %w", err)
+	}
+	return &e, nil
+}
+
+func (r *chatRepository) CustomEmojiExists(ctx context.Context, chatID uuid.UUID, name string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM con_test.chat_custom_emoji WHERE chat_id = $1 AND name = $2)
+	`, chatID, name).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check custom emoji: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *chatRepository) ListCustomEmoji(ctx context.Context, chatID uuid.UUID) ([]model.CustomEmoji, error) {
+	query := `SELECT ` + customEmojiColumns + ` FROM con_test.chat_custom_emoji WHERE chat_id = $1 ORDER BY name`
+	rows, err := r.pool.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom emoji: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.CustomEmoji
+	for rows.Next() {
+		var e model.CustomEmoji
+		if err := scanCustomEmoji(rows, &e); err != nil {
+			return nil, fmt.Errorf("failed to scan custom emoji: %w", err)
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
+
+func (r *chatRepository) CountCustomEmoji(ctx context.Context, chatID uuid.UUID) (int, error) {
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM con_test.chat_custom_emoji WHERE chat_id = $1`, chatID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count custom emoji: %w", err)
+	}
+	return count, nil
+}
+
+func (r *chatRepository) DeleteCustomEmoji(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM con_test.chat_custom_emoji WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom emoji: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCustomEmojiNotFound
+	}
+	return nil
+}
+
+// Drafts
+
+const draftColumns = `id, user_id, chat_id, thread_id, content, reply_to_ids, updated_at`
+
+func scanDraft(row pgx.Row, d *model.Draft) error {
+	return row.Scan(&d.ID, &d.UserID, &d.ChatID, &d.ThreadID, &d.Content, &d.ReplyToIDs, &d.UpdatedAt)
+}
+
+// UpsertDraft saves the draft of the user in the chat or thread, replacing the previous one
+func (r *chatRepository) UpsertDraft(ctx context.Context, draft *model.Draft) error {
+	query := `
+		INSERT INTO con_test.message_drafts (id, user_id, chat_id, thread_id, content, reply_to_ids, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, chat_id, COALESCE(thread_id, '00000000-0000-0000-0000-000000000000'::uuid))
+		DO UPDATE SET content = EXCLUDED.content, reply_to_ids = EXCLUDED.reply_to_ids, updated_at = EXCLUDED.updated_at
+		RETURNING id
+	`
+
+	draft.UpdatedAt = time.Now()
+	replyToIDs := draft.ReplyToIDs
+	if replyToIDs == nil {
+		replyToIDs = []uuid.UUID{}
+	}
+
+	err := r.pool.QueryRow(ctx, query, uuid.New(), draft.UserID, draft.ChatID, draft.ThreadID, draft.Content,
+		replyToIDs, draft.UpdatedAt).Scan(&draft.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	return nil
+}
+
+// ListDrafts returns the user's drafts, most recently edited first, optionally of one chat
+func (r *chatRepository) ListDrafts(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.Draft, error) {
+	query := `
+		SELECT ` + draftColumns + `
+		FROM con_test.message_drafts
+		WHERE user_id = $1 AND ($2::uuid IS NULL OR chat_id = $2)
+		ORDER BY updated_at DESC
+	`
+	rows, err := r.pool.Query(ctx, query, userID, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+	defer rows.Close()
+
+	var drafts []model.Draft
+	for rows.Next() {
+		var d model.Draft
+		if err := scanDraft(rows, &d); err != nil {
+			return nil, fmt.Errorf("failed to scan draft: %w", err)
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, rows.Err()
+}
+
+// DeleteDraft removes the draft of the user in the chat (threadID nil) or thread.
+// Returns false if there was none.
+func (r *chatRepository) DeleteDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM con_test.message_drafts
+		WHERE user_id = $1 AND chat_id = $2 AND thread_id IS NOT DISTINCT FROM $3
+	`, userID, chatID, threadID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete draft: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
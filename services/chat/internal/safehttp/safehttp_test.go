@@ -0,0 +1,102 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{url: "https://example.com/hook"},
+		{url: "http://example.com:8080/hook"},
+		{url: "https://8.8.8.8/hook"},
+		{url: "ftp://example.com", invalid: true},
+		{url: "/relative", invalid: true},
+		{url: "https://", invalid: true},
+		{url: "http://localhost:8080", forbidden: true},
+		{url: "http://LOCALHOST.", forbidden: true},
+		{url: "http://api.localhost", forbidden: true},
+		{url: "http://127.0.0.1:5432", forbidden: true},
+		{url: "http://[::1]/", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data/", forbidden: true},
+		{url: "http://10.0.0.5", forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateURL(tt.url)
+			switch {
+			case tt.forbidden:
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+			case tt.invalid:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrForbiddenAddress)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	var hit bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer srv.Close()
+
+	// The URL names a loopback address, as a rebound DNS name would resolve to
+	_, err := NewClient(time.Second).Post(srv.URL, "application/json", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.False(t, hit)
+	assert.Equal(t, ErrForbiddenAddress.Error(), Describe(err))
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, "request timed out", Describe(fmt.Errorf("post: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "connection failed", Describe(errors.New("dial tcp 203.0.113.5:5432: connect: connection refused")))
+}
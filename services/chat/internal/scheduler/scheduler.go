@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/service"
+	"github.com/icegreg/chat-smpl/services/chat/internal/webhook"
+)
+
+// Scheduler runs periodic chat-service tasks like lifting expired bans and mutes,
+// sending queued webhook deliveries and /remind reminders
+type Scheduler struct {
+	chatService service.ChatService
+	webhooks    *webhook.Dispatcher
+	logger      *zap.Logger
+
+	// Configuration
+	restrictionCheckInterval time.Duration
+	webhookPollInterval      time.Duration
+	reminderPollInterval     time.Duration
+}
+
+// NewScheduler creates a new scheduler instance. webhooks may be nil to disable webhook delivery.
+func NewScheduler(chatService service.ChatService, webhooks *webhook.Dispatcher, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		chatService:              chatService,
+		webhooks:                 webhooks,
+		logger:                   logger,
+		restrictionCheckInterval: 30 * time.Second,
+		webhookPollInterval:      2 * time.Second,
+		reminderPollInterval:     15 * time.Second,
+	}
+}
+
+// Start begins the scheduler routines
+func (s *Scheduler) Start(ctx context.Context) {
+	s.logger.Info("starting scheduler")
+
+	// Start restriction expiry processor
+	go s.runRestrictionExpiry(ctx)
+
+	// Start reminder delivery
+	go s.runReminderDelivery(ctx)
+
+	// Start webhook delivery
+	if s.webhooks != nil {
+		go s.runWebhookDelivery(ctx)
+	}
+}
+
+// runRestrictionExpiry lifts expired bans and mutes every restrictionCheckInterval
+func (s *Scheduler) runRestrictionExpiry(ctx context.Context) {
+	ticker := time.NewTicker(s.restrictionCheckInterval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	s.processExpiredRestrictions(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("restriction expiry processor stopped")
+			return
+		case <-ticker.C:
+			s.processExpiredRestrictions(ctx)
+		}
+	}
+}
+
+// processExpiredRestrictions lifts expired restrictions in batches until none are left
+func (s *Scheduler) processExpiredRestrictions(ctx context.Context) {
+	for {
+		count, err := s.chatService.ExpireRestrictions(ctx, time.Now())
+		if err != nil {
+			s.logger.Error("failed to expire chat restrictions", zap.Error(err))
+			return
+		}
+		if count == 0 {
+			return
+		}
+		s.logger.Info("expired chat restrictions", zap.Int("count", count))
+	}
+}
+
+// runWebhookDelivery sends due webhook deliveries every webhookPollInterval
+func (s *Scheduler) runWebhookDelivery(ctx context.Context) {
+	ticker := time.NewTicker(s.webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("webhook delivery stopped")
+			return
+		case <-ticker.C:
+			s.processWebhookDeliveries(ctx)
+		}
+	}
+}
+
+// processWebhookDeliveries sends batches of due deliveries until none are left
+func (s *Scheduler) processWebhookDeliveries(ctx context.Context) {
+	for ctx.Err() == nil {
+		count, err := s.webhooks.DeliverDue(ctx)
+		if err != nil {
+			s.logger.Error("failed to deliver webhooks", zap.Error(err))
+			return
+		}
+		if count == 0 {
+			return
+		}
+		s.logger.Debug("attempted webhook deliveries", zap.Int("count", count))
+	}
+}
+
+// runReminderDelivery sends due reminders every reminderPollInterval
+func (s *Scheduler) runReminderDelivery(ctx context.Context) {
+	ticker := time.NewTicker(s.reminderPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("reminder delivery stopped")
+			return
+		case <-ticker.C:
+			s.processDueReminders(ctx)
+		}
+	}
+}
+
+// processDueReminders sends batches of due reminders until none are left
+func (s *Scheduler) processDueReminders(ctx context.Context) {
+	for ctx.Err() == nil {
+		count, err := s.chatService.DeliverDueReminders(ctx, time.Now())
+		if err != nil {
+			s.logger.Error("failed to deliver reminders", zap.Error(err))
+			return
+		}
+		if count == 0 {
+			return
+		}
+		s.logger.Debug("delivered reminders", zap.Int("count", count))
+	}
+}
@@ -2,37 +2,100 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
 	filesPb "github.com/icegreg/chat-smpl/proto/files"
+	"github.com/icegreg/chat-smpl/services/chat/internal/commands"
+	"github.com/icegreg/chat-smpl/services/chat/internal/emoji"
 	"github.com/icegreg/chat-smpl/services/chat/internal/events"
+	"github.com/icegreg/chat-smpl/services/chat/internal/filter"
 	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+	"github.com/icegreg/chat-smpl/services/chat/internal/ratelimit"
 	"github.com/icegreg/chat-smpl/services/chat/internal/repository"
+	"github.com/icegreg/chat-smpl/services/chat/internal/safehttp"
+	"github.com/icegreg/chat-smpl/services/chat/internal/webhook"
 )
 
 var (
-	ErrAccessDenied      = errors.New("access denied")
-	ErrNotParticipant    = errors.New("not a participant")
-	ErrCannotWriteChat   = errors.New("cannot write to this chat")
-	ErrMessageNotDeleted = errors.New("message is not deleted")
-	ErrRetentionExpired  = errors.New("message cannot be restored: retention period expired")
+	ErrAccessDenied         = errors.New("access denied")
+	ErrNotParticipant       = errors.New("not a participant")
+	ErrCannotWriteChat      = errors.New("cannot write to this chat")
+	ErrMessageNotDeleted    = errors.New("message is not deleted")
+	ErrRetentionExpired     = errors.New("message cannot be restored: retention period expired")
+	ErrInvalidMove          = errors.New("invalid message move")
+	ErrInvalidBulkDelete    = errors.New("invalid bulk delete")
+	ErrInvalidReport        = errors.New("invalid report")
+	ErrAlreadyReported      = errors.New("message already reported")
+	ErrReportResolved       = errors.New("report already resolved")
+	ErrInvalidRestriction   = errors.New("invalid restriction")
+	ErrUserBanned           = errors.New("user is banned from this chat")
+	ErrUserMuted            = errors.New("user is muted in this chat")
+	ErrAlreadyRestricted    = errors.New("user already has an active restriction of this type")
+	ErrInvalidSlowMode      = errors.New("invalid slow mode interval")
+	ErrRateLimited          = errors.New("rate limit exceeded")
+	ErrInvalidContentPolicy = errors.New("invalid content policy")
+	ErrInvalidWebhook       = errors.New("invalid webhook")
+	ErrInvalidWebhookToken  = errors.New("invalid webhook token")
+	ErrInvalidCommand       = errors.New("invalid command")
+	ErrInvalidReminder      = errors.New("invalid reminder")
+	ErrInvalidReaction      = errors.New("invalid reaction")
+	ErrInvalidEmoji         = errors.New("invalid emoji")
+	ErrInvalidDraft         = errors.New("invalid draft")
 )
 
+// RateLimitError is returned when a rate limit or the chat's slow mode rejects a request.
+// It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrRateLimited, e.Reason, e.RetryAfter.Round(time.Millisecond))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// maxSlowModeSeconds caps the slow mode interval
+const maxSlowModeSeconds = 6 * 60 * 60
+
+// contentPolicyCacheTTL is how long compiled content filter pipelines are reused
+const contentPolicyCacheTTL = 30 * time.Second
+
+const (
+	// maxRestrictionDuration caps timed bans and mutes
+	maxRestrictionDuration = 365 * 24 * time.Hour
+	// expireRestrictionsBatchSize limits how many expired restrictions one expiry run handles
+	expireRestrictionsBatchSize = 100
+)
+
+// maxMoveMessages limits how many messages can be moved to a thread in one request
+const maxMoveMessages = 500
+
 type ChatService interface {
 	// Chat operations
 	CreateChat(ctx context.Context, name string, chatType model.ChatType, createdBy uuid.UUID, participantIDs []uuid.UUID) (*model.Chat, error)
 	GetChat(ctx context.Context, chatID, userID uuid.UUID) (*model.Chat, error)
 	ListChats(ctx context.Context, userID uuid.UUID, page, count int) ([]model.Chat, int, error)
 	ListChatsCursor(ctx context.Context, userID uuid.UUID, limit int, cursor string) (*repository.ListChatsResult, error)
+	GetChatListChanges(ctx context.Context, userID uuid.UUID, sinceVersion int64, limit int) (*repository.ChatListChangesResult, error)
 	UpdateChat(ctx context.Context, chatID uuid.UUID, name string, userID uuid.UUID) (*model.Chat, error)
 	DeleteChat(ctx context.Context, chatID, userID uuid.UUID) error
 	SearchChats(ctx context.Context, userID uuid.UUID, query string, page, count int) ([]model.Chat, int, error)
+	SetSlowMode(ctx context.Context, chatID, userID uuid.UUID, seconds int) (*model.Chat, error)
 
 	// Participant operations
 	AddParticipant(ctx context.Context, chatID, userID, addedBy uuid.UUID, role model.ParticipantRole) (*model.ChatParticipant, error)
@@ -44,7 +107,7 @@ type ChatService interface {
 	SendMessage(ctx context.Context, chatID, senderID uuid.UUID, content string, parentID *uuid.UUID, fileLinkIDs, replyToIDs []uuid.UUID) (*model.Message, error)
 	GetMessage(ctx context.Context, messageID, userID uuid.UUID) (*model.Message, error)
 	ListMessages(ctx context.Context, chatID, userID uuid.UUID, page, count int) ([]model.Message, int, error)
-	SyncMessages(ctx context.Context, chatID, userID uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error)
+	SyncMessages(ctx context.Context, chatID, userID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, []model.MessageTombstone, error)
 	UpdateMessage(ctx context.Context, messageID, userID uuid.UUID, content string) (*model.Message, error)
 	DeleteMessage(ctx context.Context, messageID, userID uuid.UUID) error
 	GetThreadMessages(ctx context.Context, parentID, userID uuid.UUID, page, count int) ([]model.Message, int, error)
@@ -52,7 +115,7 @@ type ChatService interface {
 	// Reaction operations
 	AddReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error
 	RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error
-	ListReactions(ctx context.Context, messageID uuid.UUID) ([]model.Reaction, error)
+	ListReactions(ctx context.Context, messageID, userID uuid.UUID) ([]model.Reaction, []model.ReactionSummary, error)
 
 	// Read status
 	MarkAsRead(ctx context.Context, chatID, messageID, userID uuid.UUID) error
@@ -79,12 +142,19 @@ type ChatService interface {
 	ListThreads(ctx context.Context, chatID, userID uuid.UUID, page, count int) ([]model.Thread, int, error)
 	ArchiveThread(ctx context.Context, threadID, userID uuid.UUID) (*model.Thread, error)
 	ListThreadMessages(ctx context.Context, threadID, userID uuid.UUID, page, count int) ([]model.Message, int, error)
+	MoveMessagesToThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, targetThreadID *uuid.UUID, newThreadTitle string, movedBy uuid.UUID) (*model.Thread, int, error)
 
 	// Thread participant operations (for restricted threads)
 	AddThreadParticipant(ctx context.Context, threadID, userID, addedBy uuid.UUID) error
 	RemoveThreadParticipant(ctx context.Context, threadID, userID, removedBy uuid.UUID) error
 	ListThreadParticipants(ctx context.Context, threadID uuid.UUID) ([]model.ThreadParticipant, error)
 
+	// Thread subscriptions (follow/unfollow, inbox)
+	FollowThread(ctx context.Context, threadID, userID uuid.UUID) error
+	UnfollowThread(ctx context.Context, threadID, userID uuid.UUID) error
+	ListFollowedThreads(ctx context.Context, userID uuid.UUID, page, count int) ([]model.FollowedThread, int, error)
+	MarkThreadAsRead(ctx context.Context, threadID, userID uuid.UUID) error
+
 	// SendMessage with thread support (overloaded via optional threadID)
 	SendMessageToThread(ctx context.Context, chatID, senderID uuid.UUID, content string, parentID, threadID *uuid.UUID, fileLinkIDs, replyToIDs []uuid.UUID, isSystem bool) (*model.Message, error)
 
@@ -107,20 +177,92 @@ type ChatService interface {
 	// Message deletion/restoration
 	RestoreMessage(ctx context.Context, messageID, userID uuid.UUID) (*model.Message, error)
 	RemoveFromQuote(ctx context.Context, quotingMessageID, quotedMessageID, userID uuid.UUID) error
+	BulkDeleteMessages(ctx context.Context, chatID, moderatorID uuid.UUID, messageIDs []uuid.UUID, senderID *uuid.UUID, from, to *time.Time) (*model.BulkDeleteResult, error)
+	PurgeUserMessages(ctx context.Context, chatID, targetUserID, moderatorID uuid.UUID) (*model.BulkDeleteResult, error)
+
+	// Moderation (message reports)
+	ReportMessage(ctx context.Context, messageID, reporterID uuid.UUID, reason model.ReportReason, comment string) (*model.MessageReport, error)
+	ListReports(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, status model.ReportStatus, page, count int) ([]model.MessageReport, int, error)
+	ResolveReport(ctx context.Context, reportID, moderatorID uuid.UUID, action model.ReportAction, note string) (*model.MessageReport, error)
+	ListModerationAuditLog(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, page, count int) ([]model.ModerationAuditEntry, int, error)
+
+	// Chat restrictions (bans and timed mutes)
+	BanUser(ctx context.Context, chatID, userID, bannedBy uuid.UUID, reason string, duration time.Duration) (*model.ChatRestriction, error)
+	UnbanUser(ctx context.Context, chatID, userID, unbannedBy uuid.UUID) error
+	MuteUser(ctx context.Context, chatID, userID, mutedBy uuid.UUID, reason string, duration time.Duration) (*model.ChatRestriction, error)
+	UnmuteUser(ctx context.Context, chatID, userID, unmutedBy uuid.UUID) error
+	ListRestrictions(ctx context.Context, chatID, userID uuid.UUID) ([]model.ChatRestriction, error)
+	ExpireRestrictions(ctx context.Context, now time.Time) (int, error)
+
+	// Content filters (chatID nil = global policy)
+	GetContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) (*model.ContentPolicy, error)
+	SetContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, rules model.ContentPolicyRules) (*model.ContentPolicy, error)
+	DeleteContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) error
+	ListContentFilterHits(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, page, count int) ([]model.ContentFilterHit, int, error)
+
+	// Outgoing webhooks (chatID nil = subscription to all chats)
+	CreateWebhook(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, targetURL string, eventTypes []string) (*model.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, userID, webhookID uuid.UUID, targetURL string, eventTypes []string, isActive bool) (*model.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID uuid.UUID) error
+	ListWebhookDeliveries(ctx context.Context, userID, webhookID uuid.UUID, status model.WebhookDeliveryStatus, page, count int) ([]model.WebhookDelivery, int, error)
+	RedeliverWebhook(ctx context.Context, userID, deliveryID uuid.UUID) (*model.WebhookDelivery, error)
+
+	// Incoming webhooks (bots posting into a chat)
+	CreateIncomingWebhook(ctx context.Context, userID, chatID, botID uuid.UUID, name string, threadID *uuid.UUID, rateLimitPerMinute int) (*model.IncomingWebhook, error)
+	ListIncomingWebhooks(ctx context.Context, userID, chatID uuid.UUID) ([]model.IncomingWebhook, error)
+	RotateIncomingWebhookToken(ctx context.Context, userID, webhookID uuid.UUID) (*model.IncomingWebhook, error)
+	DeleteIncomingWebhook(ctx context.Context, userID, webhookID uuid.UUID) error
+	ExecuteIncomingWebhook(ctx context.Context, webhookID uuid.UUID, token string, payload model.IncomingWebhookPayload) (*model.Message, error)
+
+	// Slash commands and interactive messages (chatID nil = commands of all chats)
+	ListCommands(ctx context.Context, chatID, userID uuid.UUID) ([]model.SlashCommand, error)
+	RegisterBotCommand(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, botID uuid.UUID, name, description, usage, targetURL string) (*model.BotCommand, error)
+	ListBotCommands(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.BotCommand, error)
+	DeleteBotCommand(ctx context.Context, userID, commandID uuid.UUID) error
+	InteractMessage(ctx context.Context, messageID, userID uuid.UUID, actionID, value string) (*model.Message, error)
+
+	// Reminders ("remind me about this" and /remind)
+	CreateMessageReminder(ctx context.Context, messageID, userID uuid.UUID, remindAt time.Time, note string) (*model.Reminder, error)
+	ListReminders(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, includeDelivered bool) ([]model.Reminder, error)
+	CancelReminder(ctx context.Context, reminderID, userID uuid.UUID) error
+	DeliverDueReminders(ctx context.Context, now time.Time) (int, error)
+
+	// Custom emoji
+	CreateCustomEmoji(ctx context.Context, chatID, userID uuid.UUID, name string, fileLinkID uuid.UUID) (*model.CustomEmoji, error)
+	ListCustomEmoji(ctx context.Context, chatID, userID uuid.UUID) ([]model.CustomEmoji, error)
+	DeleteCustomEmoji(ctx context.Context, emojiID, userID uuid.UUID) error
+
+	// Drafts (threadID nil = the main chat)
+	SaveDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID, content string, replyToIDs []uuid.UUID) (*model.Draft, error)
+	GetDrafts(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.Draft, error)
+	DeleteDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) error
 }
 
 type chatService struct {
 	repo        repository.ChatRepository
 	publisher   events.Publisher
 	filesClient filesPb.FilesServiceClient
+	limiter     *ratelimit.Limiter
+	filters     *filter.Cache
+	commands    *commands.Client
 }
 
-func NewChatService(repo repository.ChatRepository, publisher events.Publisher, filesClient filesPb.FilesServiceClient) ChatService {
-	return &chatService{
+// NewChatService creates the chat service. limiter may be nil: rate limits are then disabled,
+// and slow mode is tracked in process memory.
+func NewChatService(repo repository.ChatRepository, publisher events.Publisher, filesClient filesPb.FilesServiceClient, limiter *ratelimit.Limiter) ChatService {
+	if limiter == nil {
+		limiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Config{})
+	}
+	s := &chatService{
 		repo:        repo,
 		publisher:   publisher,
 		filesClient: filesClient,
+		limiter:     limiter,
+		commands:    commands.NewClient(commandRequestTimeout),
 	}
+	s.filters = filter.NewCache(s.loadContentPolicy, contentPolicyCacheTTL)
+	return s
 }
 
 // Chat operations
@@ -174,6 +316,7 @@ func (s *chatService) CreateChat(ctx context.Context, name string, chatType mode
 
 	// Publish event
 	_ = s.publisher.PublishChatCreated(ctx, chat, participants)
+	s.recordChatListChange(ctx, participants, chat.ID, model.ChatListChangeChatCreated, createdBy)
 
 	// Create system Activity thread for logging participant changes and events
 	activityTitle := "Activity"
@@ -211,6 +354,17 @@ func (s *chatService) ListChatsCursor(ctx context.Context, userID uuid.UUID, lim
 	return s.repo.ListChatsCursor(ctx, userID, limit, cursor)
 }
 
+// GetChatListChanges returns what changed in the user's chat list since sinceVersion
+func (s *chatService) GetChatListChanges(ctx context.Context, userID uuid.UUID, sinceVersion int64, limit int) (*repository.ChatListChangesResult, error) {
+	return s.repo.GetChatListChanges(ctx, userID, sinceVersion, limit)
+}
+
+// recordChatListChange appends a change to the chat list log of the given users.
+// Failures are ignored: the change log is a sync optimization, not the source of truth.
+func (s *chatService) recordChatListChange(ctx context.Context, userIDs []uuid.UUID, chatID uuid.UUID, changeType model.ChatListChangeType, actorID uuid.UUID) {
+	_ = s.repo.RecordChatListChange(ctx, userIDs, chatID, changeType, &actorID)
+}
+
 func (s *chatService) UpdateChat(ctx context.Context, chatID uuid.UUID, name string, userID uuid.UUID) (*model.Chat, error) {
 	participant, err := s.repo.GetParticipant(ctx, chatID, userID)
 	if err != nil {
@@ -237,6 +391,7 @@ func (s *chatService) UpdateChat(ctx context.Context, chatID uuid.UUID, name str
 	// Publish event to all participants
 	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
 	_ = s.publisher.PublishChatUpdated(ctx, chat, userID, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeChatUpdated, userID)
 
 	return chat, nil
 }
@@ -262,10 +417,53 @@ func (s *chatService) DeleteChat(ctx context.Context, chatID, userID uuid.UUID)
 	}
 
 	_ = s.publisher.PublishChatDeleted(ctx, chatID, userID, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeChatDeleted, userID)
 
 	return nil
 }
 
+// SetSlowMode sets the minimum interval between messages of one participant (0 disables slow mode).
+// Chat admins are not affected by slow mode.
+func (s *chatService) SetSlowMode(ctx context.Context, chatID, userID uuid.UUID, seconds int) (*model.Chat, error) {
+	if seconds < 0 || seconds > maxSlowModeSeconds {
+		return nil, fmt.Errorf("%w: must be between 0 and %d seconds", ErrInvalidSlowMode, maxSlowModeSeconds)
+	}
+
+	participant, err := s.repo.GetParticipant(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrNotParticipant
+		}
+		return nil, err
+	}
+
+	if !participant.Role.CanModerate() {
+		return nil, ErrAccessDenied
+	}
+
+	if err := s.repo.SetChatSlowMode(ctx, chatID, seconds); err != nil {
+		return nil, err
+	}
+
+	chat, err := s.repo.GetChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	_ = s.publisher.PublishChatUpdated(ctx, chat, userID, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeChatUpdated, userID)
+
+	username := shortUsername(userID, participant.Username)
+	if seconds > 0 {
+		_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s enabled slow mode: one message every %s", username, time.Duration(seconds)*time.Second), false)
+	} else {
+		_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s disabled slow mode", username), false)
+	}
+
+	return chat, nil
+}
+
 func (s *chatService) SearchChats(ctx context.Context, userID uuid.UUID, query string, page, count int) ([]model.Chat, int, error) {
 	return s.repo.SearchChats(ctx, userID, query, page, count)
 }
@@ -285,6 +483,10 @@ func (s *chatService) AddParticipant(ctx context.Context, chatID, userID, addedB
 		return nil, ErrAccessDenied
 	}
 
+	if err := s.checkNotRestricted(ctx, chatID, userID, model.RestrictionTypeBan); err != nil {
+		return nil, err
+	}
+
 	newParticipant := &model.ChatParticipant{
 		ChatID: chatID,
 		UserID: userID,
@@ -298,6 +500,10 @@ func (s *chatService) AddParticipant(ctx context.Context, chatID, userID, addedB
 	// Sync participant to file groups (this grants access to all files in the chat)
 	_ = s.SyncParticipantToFileGroups(ctx, chatID, userID, role)
 
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	_ = s.publisher.PublishChatMemberAdded(ctx, newParticipant, addedBy, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeParticipantAdded, addedBy)
+
 	// Send system message to Activity thread
 	username := userID.String()[:8] // Fallback to short UUID if no username
 	if newParticipant.Username != nil {
@@ -335,10 +541,20 @@ func (s *chatService) RemoveParticipant(ctx context.Context, chatID, userID, rem
 	// Remove participant from file groups (this revokes access to all files in the chat)
 	_ = s.RemoveParticipantFromFileGroups(ctx, chatID, userID)
 
+	// Get participants BEFORE removing, so the removed user gets the change too
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+
 	if err := s.repo.RemoveParticipant(ctx, chatID, userID); err != nil {
 		return err
 	}
 
+	reason := events.MemberRemovedRemoved
+	if userID == removedBy {
+		reason = events.MemberRemovedLeft
+	}
+	_ = s.publisher.PublishChatMemberRemoved(ctx, chatID, userID, removedBy, reason, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeParticipantRemoved, removedBy)
+
 	// Send system message to Activity thread
 	username := userID.String()[:8] // Fallback to short UUID if no username
 	if leavingParticipant.Username != nil {
@@ -370,6 +586,13 @@ func (s *chatService) UpdateParticipantRole(ctx context.Context, chatID, userID,
 		return nil, err
 	}
 
+	// Granting write access explicitly overrides an active mute
+	if role.CanWrite() {
+		if mute, err := s.repo.GetActiveChatRestriction(ctx, chatID, userID, model.RestrictionTypeMute); err == nil {
+			_, _ = s.repo.LiftChatRestriction(ctx, mute.ID, &updatedBy)
+		}
+	}
+
 	return s.repo.GetParticipant(ctx, chatID, userID)
 }
 
@@ -389,7 +612,30 @@ func (s *chatService) SendMessage(ctx context.Context, chatID, senderID uuid.UUI
 	}
 
 	if !participant.Role.CanWrite() {
-		return nil, ErrCannotWriteChat
+		return nil, s.cannotWriteError(ctx, chatID, senderID)
+	}
+
+	// Slash commands run instead of being posted (attachments make it a regular message)
+	if !participant.IsBot {
+		if len(fileLinkIDs) == 0 {
+			if reply, ok, err := s.runCommand(ctx, participant, nil, content); ok {
+				if err == nil {
+					s.clearDraft(ctx, senderID, chatID, nil)
+				}
+				return reply, err
+			}
+		}
+		content = commands.Unescape(content)
+	}
+
+	content, filterHits, err := s.applyContentFilters(ctx, chatID, senderID, content, len(fileLinkIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a message that passed the filters takes the rate limit and slow mode token
+	if err := s.checkSendRate(ctx, participant); err != nil {
+		return nil, err
 	}
 
 	message := &model.Message{
@@ -405,6 +651,9 @@ func (s *chatService) SendMessage(ctx context.Context, chatID, senderID uuid.UUI
 		return nil, err
 	}
 
+	s.logContentFilterHits(ctx, chatID, senderID, &message.ID, filterHits)
+	s.clearDraft(ctx, senderID, chatID, nil)
+
 	// Attach file links to chat (adds them to file groups)
 	for _, linkID := range fileLinkIDs {
 		_ = s.AttachFileLinkToChat(ctx, chatID, linkID, senderID)
@@ -444,6 +693,8 @@ func (s *chatService) GetMessage(ctx context.Context, messageID, userID uuid.UUI
 		return nil, ErrNotParticipant
 	}
 
+	s.attachMessageExtras(ctx, userID, message)
+
 	return message, nil
 }
 
@@ -456,19 +707,64 @@ func (s *chatService) ListMessages(ctx context.Context, chatID, userID uuid.UUID
 		return nil, 0, ErrNotParticipant
 	}
 
-	return s.repo.ListMessages(ctx, chatID, page, count, nil, nil)
+	messages, total, err := s.repo.ListMessages(ctx, chatID, page, count, nil, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachMessageExtras(ctx, userID, messagePointers(messages)...)
+	return messages, total, nil
 }
 
-func (s *chatService) SyncMessages(ctx context.Context, chatID, userID uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, error) {
+// SyncMessages returns new messages and tombstones (edits, deletions, restores, moves) after afterSeqNum.
+// When threadID is set, only the thread is synced; otherwise the main chat.
+func (s *chatService) SyncMessages(ctx context.Context, chatID, userID uuid.UUID, threadID *uuid.UUID, afterSeqNum int64, limit int) ([]model.Message, []model.MessageTombstone, error) {
 	isParticipant, err := s.repo.IsParticipant(ctx, chatID, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !isParticipant {
-		return nil, ErrNotParticipant
+		return nil, nil, ErrNotParticipant
+	}
+
+	if threadID != nil {
+		thread, err := s.repo.GetThread(ctx, *threadID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if thread.ChatID != chatID {
+			return nil, nil, repository.ErrThreadNotFound
+		}
+		// For restricted threads, check thread participation
+		if thread.RestrictedParticipants {
+			isThreadParticipant, err := s.repo.IsThreadParticipant(ctx, *threadID, userID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !isThreadParticipant {
+				return nil, nil, ErrAccessDenied
+			}
+		}
+	}
+
+	messages, err := s.repo.GetMessagesSince(ctx, chatID, threadID, afterSeqNum, limit)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return s.repo.GetMessagesSince(ctx, chatID, afterSeqNum, limit)
+	tombstones, err := s.repo.GetMessageTombstonesSince(ctx, chatID, threadID, afterSeqNum, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extras := messagePointers(messages)
+	for _, t := range tombstones {
+		if t.Message != nil {
+			extras = append(extras, t.Message)
+		}
+	}
+	s.attachMessageExtras(ctx, userID, extras...)
+
+	return messages, tombstones, nil
 }
 
 func (s *chatService) UpdateMessage(ctx context.Context, messageID, userID uuid.UUID, content string) (*model.Message, error) {
@@ -481,10 +777,17 @@ func (s *chatService) UpdateMessage(ctx context.Context, messageID, userID uuid.
 		return nil, ErrAccessDenied
 	}
 
+	// Edits go through the same filters, otherwise they would be a way around them
+	content, filterHits, err := s.applyContentFilters(ctx, message.ChatID, userID, content, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	message.Content = content
 	if err := s.repo.UpdateMessage(ctx, message); err != nil {
 		return nil, err
 	}
+	s.logContentFilterHits(ctx, message.ChatID, userID, &message.ID, filterHits)
 
 	// Get participants for event
 	participants, _ := s.repo.GetParticipantIDs(ctx, message.ChatID)
@@ -557,11 +860,19 @@ func (s *chatService) GetThreadMessages(ctx context.Context, parentID, userID uu
 		return nil, 0, ErrNotParticipant
 	}
 
-	return s.repo.GetThreadMessages(ctx, parentID, page, count)
+	messages, total, err := s.repo.GetThreadMessages(ctx, parentID, page, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachMessageExtras(ctx, userID, messagePointers(messages)...)
+	return messages, total, nil
 }
 
 // Reaction operations
 
+// maxReactionsPerUser caps the distinct reactions one user can put on a message
+const maxReactionsPerUser = 20
+
 func (s *chatService) AddReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error {
 	message, err := s.repo.GetMessage(ctx, messageID)
 	if err != nil {
@@ -576,13 +887,29 @@ func (s *chatService) AddReaction(ctx context.Context, messageID, userID uuid.UU
 		return ErrNotParticipant
 	}
 
-	if err := s.repo.AddReaction(ctx, &model.Reaction{
+	if err := s.checkNotRestricted(ctx, message.ChatID, userID, model.RestrictionTypeMute); err != nil {
+		return err
+	}
+
+	if err := s.validateReaction(ctx, message.ChatID, reaction); err != nil {
+		return err
+	}
+
+	if err := s.checkRate(ctx, ratelimit.ActionAddReaction, userID, "too many reactions"); err != nil {
+		return err
+	}
+
+	added, err := s.repo.AddReactionWithinLimit(ctx, &model.Reaction{
 		MessageID: messageID,
 		UserID:    userID,
 		Reaction:  reaction,
-	}); err != nil {
+	}, maxReactionsPerUser)
+	if err != nil {
 		return err
 	}
+	if !added {
+		return fmt.Errorf("%w: at most %d reactions per message", ErrInvalidReaction, maxReactionsPerUser)
+	}
 
 	// Publish reaction event
 	participants, _ := s.repo.GetParticipantIDs(ctx, message.ChatID)
@@ -608,8 +935,48 @@ func (s *chatService) RemoveReaction(ctx context.Context, messageID, userID uuid
 	return nil
 }
 
-func (s *chatService) ListReactions(ctx context.Context, messageID uuid.UUID) ([]model.Reaction, error) {
-	return s.repo.ListReactions(ctx, messageID)
+// ListReactions returns the individual reactions of a message and their per-emoji summary
+func (s *chatService) ListReactions(ctx context.Context, messageID, userID uuid.UUID) ([]model.Reaction, []model.ReactionSummary, error) {
+	message, err := s.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isParticipant, err := s.repo.IsParticipant(ctx, message.ChatID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isParticipant {
+		return nil, nil, ErrNotParticipant
+	}
+
+	reactions, err := s.repo.ListReactions(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	summaries, err := s.repo.GetReactionSummariesBatch(ctx, []uuid.UUID{messageID}, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reactions, summaries[messageID], nil
+}
+
+// validateReaction accepts a Unicode emoji or a ":name:" custom emoji of the chat
+func (s *chatService) validateReaction(ctx context.Context, chatID uuid.UUID, reaction string) error {
+	if name, ok := emoji.ParseCustom(reaction); ok {
+		exists, err := s.repo.CustomEmojiExists(ctx, chatID, name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: the chat has no emoji %s", ErrInvalidReaction, reaction)
+		}
+		return nil
+	}
+	if !emoji.IsUnicode(reaction) {
+		return fmt.Errorf("%w: must be an emoji or a :name: custom emoji of the chat", ErrInvalidReaction)
+	}
+	return nil
 }
 
 // Read status
@@ -645,11 +1012,21 @@ func (s *chatService) AddToFavorites(ctx context.Context, chatID, userID uuid.UU
 		return ErrNotParticipant
 	}
 
-	return s.repo.AddToFavorites(ctx, chatID, userID)
+	if err := s.repo.AddToFavorites(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	s.recordChatListChange(ctx, []uuid.UUID{userID}, chatID, model.ChatListChangeFavorited, userID)
+	return nil
 }
 
 func (s *chatService) RemoveFromFavorites(ctx context.Context, chatID, userID uuid.UUID) error {
-	return s.repo.RemoveFromFavorites(ctx, chatID, userID)
+	if err := s.repo.RemoveFromFavorites(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	s.recordChatListChange(ctx, []uuid.UUID{userID}, chatID, model.ChatListChangeUnfavorited, userID)
+	return nil
 }
 
 // Archive
@@ -663,11 +1040,21 @@ func (s *chatService) ArchiveChat(ctx context.Context, chatID, userID uuid.UUID)
 		return ErrNotParticipant
 	}
 
-	return s.repo.ArchiveChat(ctx, chatID, userID)
+	if err := s.repo.ArchiveChat(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	s.recordChatListChange(ctx, []uuid.UUID{userID}, chatID, model.ChatListChangeArchived, userID)
+	return nil
 }
 
 func (s *chatService) UnarchiveChat(ctx context.Context, chatID, userID uuid.UUID) error {
-	return s.repo.UnarchiveChat(ctx, chatID, userID)
+	if err := s.repo.UnarchiveChat(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	s.recordChatListChange(ctx, []uuid.UUID{userID}, chatID, model.ChatListChangeUnarchived, userID)
+	return nil
 }
 
 func (s *chatService) ListArchivedChats(ctx context.Context, userID uuid.UUID, page, count int) ([]model.Chat, int, error) {
@@ -686,6 +1073,10 @@ func (s *chatService) SendTyping(ctx context.Context, chatID, userID uuid.UUID,
 		return ErrNotParticipant
 	}
 
+	if err := s.checkRate(ctx, ratelimit.ActionSendTyping, userID, "too many typing events"); err != nil {
+		return err
+	}
+
 	// Get participants for event
 	participants, err := s.repo.GetParticipantIDs(ctx, chatID)
 	if err != nil {
@@ -709,6 +1100,9 @@ func (s *chatService) ForwardMessage(ctx context.Context, messageID, targetChatI
 	if !participant.Role.CanWrite() {
 		return nil, ErrCannotWriteChat
 	}
+	if err := s.checkSendRate(ctx, participant); err != nil {
+		return nil, err
+	}
 
 	// 2. Get original message
 	original, err := s.repo.GetMessage(ctx, messageID)
@@ -830,6 +1224,11 @@ func (s *chatService) CreateThread(ctx context.Context, chatID uuid.UUID, parent
 		return nil, fmt.Errorf("failed to create thread: %w", err)
 	}
 
+	// Thread creator follows the thread
+	if createdBy != nil {
+		_ = s.repo.FollowThread(ctx, thread.ID, *createdBy)
+	}
+
 	// Publish thread created event
 	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
 	_ = s.publisher.PublishThreadCreated(ctx, thread, participants)
@@ -937,7 +1336,131 @@ func (s *chatService) ListThreadMessages(ctx context.Context, threadID, userID u
 		}
 	}
 
-	return s.repo.ListThreadMessages(ctx, threadID, page, count)
+	messages, total, err := s.repo.ListThreadMessages(ctx, threadID, page, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachMessageExtras(ctx, userID, messagePointers(messages)...)
+	return messages, total, nil
+}
+
+// uniqueIDs returns ids without repeats, keeping the first occurrence of each
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// MoveMessagesToThread moves a set of chat messages into an existing thread or into a new
+// thread (when targetThreadID is nil). Only moderators can move messages.
+func (s *chatService) MoveMessagesToThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, targetThreadID *uuid.UUID, newThreadTitle string, movedBy uuid.UUID) (*model.Thread, int, error) {
+	participant, err := s.repo.GetParticipant(ctx, chatID, movedBy)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, 0, ErrNotParticipant
+		}
+		return nil, 0, err
+	}
+	if !participant.Role.CanModerate() {
+		return nil, 0, ErrAccessDenied
+	}
+
+	messageIDs = uniqueIDs(messageIDs)
+	if len(messageIDs) == 0 {
+		return nil, 0, fmt.Errorf("%w: no messages to move", ErrInvalidMove)
+	}
+	if len(messageIDs) > maxMoveMessages {
+		return nil, 0, fmt.Errorf("%w: cannot move more than %d messages at once", ErrInvalidMove, maxMoveMessages)
+	}
+
+	// All messages must belong to the chat; system (Activity) messages stay where they are
+	messages, err := s.repo.GetMessagesById(ctx, messageIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(messages) != len(messageIDs) {
+		return nil, 0, repository.ErrMessageNotFound
+	}
+	for _, msg := range messages {
+		if msg.ChatID != chatID {
+			return nil, 0, fmt.Errorf("%w: message %s does not belong to this chat", ErrInvalidMove, msg.ID)
+		}
+		if msg.IsSystem {
+			return nil, 0, fmt.Errorf("%w: system messages cannot be moved", ErrInvalidMove)
+		}
+	}
+
+	var thread *model.Thread
+	var moved map[uuid.UUID]*uuid.UUID
+	if targetThreadID != nil {
+		thread, err = s.repo.GetThread(ctx, *targetThreadID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if thread.ChatID != chatID {
+			return nil, 0, fmt.Errorf("%w: thread does not belong to this chat", ErrInvalidMove)
+		}
+		if thread.IsArchived || thread.ThreadType == model.ThreadTypeSystem {
+			return nil, 0, fmt.Errorf("%w: cannot move messages to an archived or system thread", ErrInvalidMove)
+		}
+		moved, err = s.repo.MoveMessagesToThread(ctx, chatID, messageIDs, thread.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(moved) == 0 {
+			return thread, 0, nil
+		}
+	} else {
+		title := newThreadTitle
+		if title == "" {
+			title = "Moved messages"
+		}
+		thread = &model.Thread{
+			ChatID:     chatID,
+			ThreadType: model.ThreadTypeUser,
+			Title:      &title,
+			CreatedBy:  &movedBy,
+		}
+		// The thread is created in the move transaction, so a failed move leaves no empty thread
+		moved, err = s.repo.MoveMessagesToNewThread(ctx, chatID, messageIDs, thread)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(moved) == 0 {
+			return nil, 0, fmt.Errorf("%w: no messages to move", ErrInvalidMove)
+		}
+		_ = s.repo.FollowThread(ctx, thread.ID, movedBy)
+	}
+
+	// Reload thread to get updated counters
+	if updated, err := s.repo.GetThread(ctx, thread.ID); err == nil {
+		thread = updated
+	}
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	if targetThreadID == nil {
+		_ = s.publisher.PublishThreadCreated(ctx, thread, participants)
+	}
+	_ = s.publisher.PublishMessagesMoved(ctx, chatID, thread.ID, movedBy, moved, participants)
+
+	// Log the move to the Activity thread
+	username := movedBy.String()[:8] // Fallback to short UUID if no username
+	if participant.Username != nil {
+		username = *participant.Username
+	}
+	threadTitle := thread.ID.String()[:8]
+	if thread.Title != nil {
+		threadTitle = *thread.Title
+	}
+	_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s moved %d message(s) to thread \"%s\"", username, len(moved), threadTitle), false)
+
+	return thread, len(moved), nil
 }
 
 // Thread participant operations
@@ -1007,35 +1530,126 @@ func (s *chatService) ListThreadParticipants(ctx context.Context, threadID uuid.
 	return s.repo.ListThreadParticipants(ctx, threadID)
 }
 
-// SendMessageToThread sends a message with optional thread support
-func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID uuid.UUID, content string, parentID, threadID *uuid.UUID, fileLinkIDs, replyToIDs []uuid.UUID, isSystem bool) (*model.Message, error) {
-	var participant *model.ChatParticipant
-	var err error
+// Thread subscriptions
 
-	// System messages don't require participant check
-	if !isSystem {
-		participant, err = s.repo.GetParticipant(ctx, chatID, senderID)
-		if err != nil {
-			if errors.Is(err, repository.ErrParticipantNotFound) {
-				return nil, ErrNotParticipant
-			}
-			return nil, err
-		}
+func (s *chatService) FollowThread(ctx context.Context, threadID, userID uuid.UUID) error {
+	// GetThread checks chat participation and restricted thread access
+	if _, err := s.GetThread(ctx, threadID, userID); err != nil {
+		return err
+	}
+	return s.repo.FollowThread(ctx, threadID, userID)
+}
 
-		if !participant.Role.CanWrite() {
-			return nil, ErrCannotWriteChat
-		}
+func (s *chatService) UnfollowThread(ctx context.Context, threadID, userID uuid.UUID) error {
+	if _, err := s.repo.GetThread(ctx, threadID); err != nil {
+		return err
 	}
+	return s.repo.UnfollowThread(ctx, threadID, userID)
+}
 
-	// If threadID provided, validate it belongs to this chat
-	if threadID != nil {
-		thread, err := s.repo.GetThread(ctx, *threadID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get thread: %w", err)
-		}
-		if thread.ChatID != chatID {
-			return nil, fmt.Errorf("thread does not belong to this chat")
-		}
+func (s *chatService) ListFollowedThreads(ctx context.Context, userID uuid.UUID, page, count int) ([]model.FollowedThread, int, error) {
+	return s.repo.ListFollowedThreads(ctx, userID, page, count)
+}
+
+func (s *chatService) MarkThreadAsRead(ctx context.Context, threadID, userID uuid.UUID) error {
+	if _, err := s.GetThread(ctx, threadID, userID); err != nil {
+		return err
+	}
+	return s.repo.MarkThreadAsRead(ctx, threadID, userID)
+}
+
+// followThreadOnReply subscribes the sender and mentioned participants to the thread
+// and notifies all other followers with a thread.reply event
+func (s *chatService) followThreadOnReply(ctx context.Context, thread *model.Thread, message *model.Message) {
+	_ = s.repo.FollowThread(ctx, thread.ID, message.SenderID)
+	_ = s.repo.MarkThreadAsRead(ctx, thread.ID, message.SenderID)
+
+	if usernames := extractMentions(message.Content); len(usernames) > 0 {
+		mentioned, err := s.repo.GetParticipantIDsByUsernames(ctx, thread.ChatID, usernames)
+		if err == nil {
+			for _, userID := range mentioned {
+				if userID == message.SenderID {
+					continue
+				}
+				// Mentioned users outside a restricted thread are not subscribed
+				if thread.RestrictedParticipants {
+					isThreadParticipant, err := s.repo.IsThreadParticipant(ctx, thread.ID, userID)
+					if err != nil || !isThreadParticipant {
+						continue
+					}
+				}
+				_ = s.repo.FollowThread(ctx, thread.ID, userID)
+			}
+		}
+	}
+
+	followers, err := s.repo.GetThreadFollowerIDs(ctx, thread.ID)
+	if err != nil {
+		return
+	}
+	recipients := make([]uuid.UUID, 0, len(followers))
+	for _, id := range followers {
+		if id != message.SenderID {
+			recipients = append(recipients, id)
+		}
+	}
+	if len(recipients) > 0 {
+		_ = s.publisher.PublishThreadReply(ctx, thread, message, recipients)
+	}
+}
+
+// mentionPattern matches @username mentions in message content
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.\-]{3,50})`)
+
+// extractMentions returns unique usernames mentioned in content
+func extractMentions(content string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(matches))
+	usernames := make([]string, 0, len(matches))
+	for _, m := range matches {
+		username := strings.TrimRight(m[1], ".-")
+		if _, ok := seen[username]; ok || username == "" {
+			continue
+		}
+		seen[username] = struct{}{}
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// SendMessageToThread sends a message with optional thread support
+func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID uuid.UUID, content string, parentID, threadID *uuid.UUID, fileLinkIDs, replyToIDs []uuid.UUID, isSystem bool) (*model.Message, error) {
+	var participant *model.ChatParticipant
+	var err error
+
+	// System messages don't require participant check
+	if !isSystem {
+		participant, err = s.repo.GetParticipant(ctx, chatID, senderID)
+		if err != nil {
+			if errors.Is(err, repository.ErrParticipantNotFound) {
+				return nil, ErrNotParticipant
+			}
+			return nil, err
+		}
+
+		if !participant.Role.CanWrite() {
+			return nil, s.cannotWriteError(ctx, chatID, senderID)
+		}
+	}
+
+	// If threadID provided, validate it belongs to this chat
+	var thread *model.Thread
+	if threadID != nil {
+		thread, err = s.repo.GetThread(ctx, *threadID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get thread: %w", err)
+		}
+		if thread.ChatID != chatID {
+			return nil, fmt.Errorf("thread does not belong to this chat")
+		}
 
 		// For restricted threads, check thread participation (unless system message)
 		if thread.RestrictedParticipants && !isSystem {
@@ -1049,6 +1663,33 @@ func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID
 		}
 	}
 
+	// Slash commands run instead of being posted (attachments make it a regular message)
+	if !isSystem && !participant.IsBot {
+		if len(fileLinkIDs) == 0 {
+			if reply, ok, err := s.runCommand(ctx, participant, threadID, content); ok {
+				if err == nil {
+					s.clearDraft(ctx, senderID, chatID, threadID)
+				}
+				return reply, err
+			}
+		}
+		content = commands.Unescape(content)
+	}
+
+	// Pre-send content filters (system messages are trusted)
+	var filterHits []filter.Hit
+	if !isSystem {
+		content, filterHits, err = s.applyContentFilters(ctx, chatID, senderID, content, len(fileLinkIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		// Only a message that passed the filters takes the rate limit and slow mode token
+		if err := s.checkSendRate(ctx, participant); err != nil {
+			return nil, err
+		}
+	}
+
 	message := &model.Message{
 		ChatID:      chatID,
 		SenderID:    senderID,
@@ -1063,6 +1704,10 @@ func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID
 	if err := s.repo.CreateMessage(ctx, message); err != nil {
 		return nil, err
 	}
+	s.logContentFilterHits(ctx, chatID, senderID, &message.ID, filterHits)
+	if !isSystem {
+		s.clearDraft(ctx, senderID, chatID, threadID)
+	}
 
 	// Attach file links to chat (adds them to file groups)
 	for _, linkID := range fileLinkIDs {
@@ -1074,6 +1719,7 @@ func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID
 		message.SenderUsername = participant.Username
 		message.SenderDisplayName = participant.DisplayName
 		message.SenderAvatarURL = participant.AvatarURL
+		message.SenderIsBot = participant.IsBot
 	}
 
 	// Load reply_to_messages if any
@@ -1088,6 +1734,11 @@ func (s *chatService) SendMessageToThread(ctx context.Context, chatID, senderID
 	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
 	_ = s.publisher.PublishMessageCreated(ctx, message, participants)
 
+	// Auto-follow on reply/mention and notify thread followers
+	if thread != nil && !isSystem {
+		s.followThreadOnReply(ctx, thread, message)
+	}
+
 	return message, nil
 }
 
@@ -1485,6 +2136,170 @@ func (s *chatService) RemoveFromQuote(ctx context.Context, quotingMessageID, quo
 	return nil
 }
 
+// bulkDeleteBatchSize is the number of messages soft-deleted per database round trip
+const bulkDeleteBatchSize = 200
+
+// maxBulkDeleteMessages limits the size of an explicit message ID list
+const maxBulkDeleteMessages = 1000
+
+// BulkDeleteMessages soft-deletes messages in a chat (moderator only), either by explicit ID list
+// or by sender within an optional time window. Deletions follow DeleteMessage semantics, so every
+// message stays restorable via RestoreMessage until the retention period expires.
+func (s *chatService) BulkDeleteMessages(ctx context.Context, chatID, moderatorID uuid.UUID, messageIDs []uuid.UUID, senderID *uuid.UUID, from, to *time.Time) (*model.BulkDeleteResult, error) {
+	moderator, err := s.getModerator(ctx, chatID, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []uuid.UUID
+	switch {
+	case len(messageIDs) > 0:
+		if len(messageIDs) > maxBulkDeleteMessages {
+			return nil, fmt.Errorf("%w: cannot delete more than %d messages at once", ErrInvalidBulkDelete, maxBulkDeleteMessages)
+		}
+		for start := 0; start < len(messageIDs); start += bulkDeleteBatchSize {
+			end := start + bulkDeleteBatchSize
+			if end > len(messageIDs) {
+				end = len(messageIDs)
+			}
+			batch, err := s.deleteMessageBatch(ctx, chatID, messageIDs[start:end], moderatorID)
+			if err != nil {
+				return nil, err
+			}
+			deleted = append(deleted, batch...)
+		}
+	case senderID != nil:
+		if from != nil && to != nil && to.Before(*from) {
+			return nil, fmt.Errorf("%w: time window end is before start", ErrInvalidBulkDelete)
+		}
+		deleted, err = s.deleteSenderMessages(ctx, chatID, *senderID, from, to, moderatorID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: message_ids or sender_id is required", ErrInvalidBulkDelete)
+	}
+
+	return s.finishBulkDelete(ctx, chatID, moderator, deleted, senderID), nil
+}
+
+// PurgeUserMessages soft-deletes all messages of a user in a chat (moderator only).
+// The user does not need to be a participant anymore.
+func (s *chatService) PurgeUserMessages(ctx context.Context, chatID, targetUserID, moderatorID uuid.UUID) (*model.BulkDeleteResult, error) {
+	moderator, err := s.getModerator(ctx, chatID, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.deleteSenderMessages(ctx, chatID, targetUserID, nil, nil, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.finishBulkDelete(ctx, chatID, moderator, deleted, &targetUserID), nil
+}
+
+// getModerator returns the participant if they can moderate the chat
+func (s *chatService) getModerator(ctx context.Context, chatID, userID uuid.UUID) (*model.ChatParticipant, error) {
+	participant, err := s.repo.GetParticipant(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrNotParticipant
+		}
+		return nil, err
+	}
+	if !participant.Role.CanModerate() {
+		return nil, ErrAccessDenied
+	}
+	return participant, nil
+}
+
+// deleteSenderMessages deletes a sender's messages batch by batch until none are left
+func (s *chatService) deleteSenderMessages(ctx context.Context, chatID, senderID uuid.UUID, from, to *time.Time, deletedBy uuid.UUID) ([]uuid.UUID, error) {
+	var deleted []uuid.UUID
+	for {
+		ids, err := s.repo.GetMessageIDsBySender(ctx, chatID, senderID, from, to, bulkDeleteBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		batch, err := s.deleteMessageBatch(ctx, chatID, ids, deletedBy)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, batch...)
+
+		// Nothing deleted means a concurrent delete raced us; stop instead of spinning
+		if len(batch) == 0 || len(ids) < bulkDeleteBatchSize {
+			break
+		}
+	}
+	return deleted, nil
+}
+
+// deleteMessageBatch soft-deletes one batch and marks its file links as deleted
+func (s *chatService) deleteMessageBatch(ctx context.Context, chatID uuid.UUID, ids []uuid.UUID, deletedBy uuid.UUID) ([]uuid.UUID, error) {
+	deleted, err := s.repo.BulkDeleteMessages(ctx, chatID, ids, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	if len(deleted) == 0 || s.filesClient == nil {
+		return deleted, nil
+	}
+
+	// Mark file links as deleted via Files Service
+	attachments, err := s.repo.GetMessageAttachmentsBatch(ctx, deleted)
+	if err != nil {
+		return deleted, nil
+	}
+	var linkIDStrings []string
+	for _, linkIDs := range attachments {
+		for _, id := range linkIDs {
+			linkIDStrings = append(linkIDStrings, id.String())
+		}
+	}
+	if len(linkIDStrings) > 0 {
+		_, _ = s.filesClient.MarkLinksDeleted(ctx, &filesPb.MarkLinksDeletedRequest{
+			LinkIds: linkIDStrings,
+		})
+	}
+
+	return deleted, nil
+}
+
+// finishBulkDelete publishes a single aggregated event and logs the deletion to the Activity thread
+func (s *chatService) finishBulkDelete(ctx context.Context, chatID uuid.UUID, moderator *model.ChatParticipant, deleted []uuid.UUID, senderID *uuid.UUID) *model.BulkDeleteResult {
+	result := &model.BulkDeleteResult{
+		DeletedIDs:      deleted,
+		RestorableUntil: time.Now().AddDate(0, 0, getRetentionDays()),
+	}
+	if len(deleted) == 0 {
+		return result
+	}
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	_ = s.publisher.PublishMessagesBulkDeleted(ctx, chatID, moderator.UserID, senderID, deleted, participants)
+
+	username := moderator.UserID.String()[:8] // Fallback to short UUID if no username
+	if moderator.Username != nil {
+		username = *moderator.Username
+	}
+	if senderID != nil {
+		senderName := senderID.String()[:8]
+		if sender, err := s.repo.GetParticipant(ctx, chatID, *senderID); err == nil && sender.Username != nil {
+			senderName = *sender.Username
+		}
+		_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s deleted %d message(s) from %s", username, len(deleted), senderName), false)
+	} else {
+		_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s deleted %d message(s)", username, len(deleted)), false)
+	}
+
+	return result
+}
+
 // getRetentionDays returns the message retention period in days from environment
 func getRetentionDays() int {
 	daysStr := os.Getenv("MESSAGE_RETENTION_DAYS")
@@ -1497,3 +2312,2018 @@ func getRetentionDays() int {
 	}
 	return days
 }
+
+// Moderation (message reports)
+
+// canModerateChat reports whether the user can moderate the chat: chat admins and
+// global moderators (users-service owner/moderator roles)
+func (s *chatService) canModerateChat(ctx context.Context, chatID, userID uuid.UUID) (bool, error) {
+	participant, err := s.repo.GetParticipant(ctx, chatID, userID)
+	if err == nil && participant.Role.CanModerate() {
+		return true, nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrParticipantNotFound) {
+		return false, err
+	}
+	return s.isGlobalModerator(ctx, userID)
+}
+
+func (s *chatService) isGlobalModerator(ctx context.Context, userID uuid.UUID) (bool, error) {
+	role, err := s.repo.GetUserRole(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return role.CanModerate(), nil
+}
+
+// ReportMessage flags a message for moderators. Each member can report a message once.
+func (s *chatService) ReportMessage(ctx context.Context, messageID, reporterID uuid.UUID, reason model.ReportReason, comment string) (*model.MessageReport, error) {
+	if !reason.IsValid() {
+		return nil, fmt.Errorf("%w: unknown reason %q", ErrInvalidReport, reason)
+	}
+
+	message, err := s.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	isParticipant, err := s.repo.IsParticipant(ctx, message.ChatID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, ErrNotParticipant
+	}
+
+	if message.IsSystem || message.IsDeleted {
+		return nil, fmt.Errorf("%w: message cannot be reported", ErrInvalidReport)
+	}
+	if message.SenderID == reporterID {
+		return nil, fmt.Errorf("%w: cannot report your own message", ErrInvalidReport)
+	}
+
+	report := &model.MessageReport{
+		ChatID:         message.ChatID,
+		MessageID:      message.ID,
+		SenderID:       message.SenderID,
+		MessageContent: message.Content,
+		ReporterID:     reporterID,
+		Reason:         reason,
+	}
+	if comment != "" {
+		report.Comment = &comment
+	}
+
+	if err := s.repo.CreateMessageReport(ctx, report); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrAlreadyReported
+		}
+		return nil, err
+	}
+
+	s.addModerationAudit(ctx, report, reporterID, model.ModerationActionReportCreated, string(reason))
+
+	// Notify chat admins and global moderators only; the reported sender never gets the event
+	chatModerators, _ := s.repo.GetChatModeratorIDs(ctx, message.ChatID)
+	globalModerators, _ := s.repo.GetGlobalModeratorIDs(ctx)
+	recipients := make([]uuid.UUID, 0, len(chatModerators)+len(globalModerators))
+	seen := map[uuid.UUID]bool{message.SenderID: true}
+	for _, id := range append(chatModerators, globalModerators...) {
+		if !seen[id] {
+			seen[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+	_ = s.publisher.PublishReportCreated(ctx, report, recipients)
+
+	return report, nil
+}
+
+// ListReports returns the moderation queue of a chat, or the global queue when chatID is nil
+// (global moderators only)
+func (s *chatService) ListReports(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, status model.ReportStatus, page, count int) ([]model.MessageReport, int, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListMessageReports(ctx, chatID, status, page, count)
+}
+
+// ListModerationAuditLog returns the moderation audit trail of a chat, or of all chats when chatID is nil
+// (global moderators only)
+func (s *chatService) ListModerationAuditLog(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, page, count int) ([]model.ModerationAuditEntry, int, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListModerationAuditLog(ctx, chatID, page, count)
+}
+
+func (s *chatService) checkModerationAccess(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) error {
+	var allowed bool
+	var err error
+	if chatID != nil {
+		allowed, err = s.canModerateChat(ctx, *chatID, userID)
+	} else {
+		allowed, err = s.isGlobalModerator(ctx, userID)
+	}
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// ResolveReport applies a moderator decision to a report. All open reports of the same message
+// are resolved together.
+func (s *chatService) ResolveReport(ctx context.Context, reportID, moderatorID uuid.UUID, action model.ReportAction, note string) (*model.MessageReport, error) {
+	if !action.IsValid() {
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidReport, action)
+	}
+
+	report, err := s.repo.GetMessageReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.canModerateChat(ctx, report.ChatID, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrAccessDenied
+	}
+
+	if report.Status != model.ReportStatusOpen {
+		return nil, ErrReportResolved
+	}
+
+	switch action {
+	case model.ReportActionDismiss:
+		s.addModerationAudit(ctx, report, moderatorID, model.ModerationActionReportDismissed, note)
+
+	case model.ReportActionDelete:
+		deleted, err := s.deleteMessageBatch(ctx, report.ChatID, []uuid.UUID{report.MessageID}, moderatorID)
+		if err != nil {
+			return nil, err
+		}
+		if len(deleted) > 0 {
+			participants, _ := s.repo.GetParticipantIDs(ctx, report.ChatID)
+			_ = s.publisher.PublishMessageDeleted(ctx, report.MessageID, report.ChatID, moderatorID, true, participants)
+		}
+		s.addModerationAudit(ctx, report, moderatorID, model.ModerationActionMessageDeleted, note)
+
+	case model.ReportActionRestrict:
+		sender, err := s.repo.GetParticipant(ctx, report.ChatID, report.SenderID)
+		if err != nil && !errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, err
+		}
+		if sender != nil && sender.Role != model.ParticipantRoleReadonly {
+			// Chat admins can only be restricted by global moderators
+			if sender.Role.CanModerate() {
+				isGlobal, err := s.isGlobalModerator(ctx, moderatorID)
+				if err != nil {
+					return nil, err
+				}
+				if !isGlobal {
+					return nil, ErrAccessDenied
+				}
+			}
+			if err := s.repo.UpdateParticipantRole(ctx, report.ChatID, report.SenderID, model.ParticipantRoleReadonly); err != nil {
+				return nil, err
+			}
+
+			username := report.SenderID.String()[:8] // Fallback to short UUID if no username
+			if sender.Username != nil {
+				username = *sender.Username
+			}
+			_, _ = s.SendSystemMessage(ctx, report.ChatID, fmt.Sprintf("%s was restricted to read-only by a moderator", username), false)
+		}
+		s.addModerationAudit(ctx, report, moderatorID, model.ModerationActionUserRestricted, note)
+	}
+
+	if _, err := s.repo.ResolveMessageReports(ctx, report.MessageID, action, moderatorID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMessageReport(ctx, reportID)
+}
+
+// addModerationAudit writes an audit trail entry for a report. Failures are ignored so the
+// moderation action itself is not lost.
+func (s *chatService) addModerationAudit(ctx context.Context, report *model.MessageReport, actorID uuid.UUID, action, details string) {
+	entry := &model.ModerationAuditEntry{
+		ChatID:       report.ChatID,
+		ActorID:      actorID,
+		Action:       action,
+		ReportID:     &report.ID,
+		MessageID:    &report.MessageID,
+		TargetUserID: &report.SenderID,
+	}
+	if details != "" {
+		entry.Details = &details
+	}
+	_ = s.repo.AddModerationAuditEntry(ctx, entry)
+}
+
+// Chat restrictions (bans and timed mutes)
+
+// checkNotRestricted returns ErrUserBanned/ErrUserMuted (with the expiry, if any) when the user
+// has an active restriction of the given type in the chat
+func (s *chatService) checkNotRestricted(ctx context.Context, chatID, userID uuid.UUID, restrictionType model.RestrictionType) error {
+	restriction, err := s.repo.GetActiveChatRestriction(ctx, chatID, userID, restrictionType)
+	if err != nil {
+		if errors.Is(err, repository.ErrRestrictionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	restrictionErr := ErrUserBanned
+	if restrictionType == model.RestrictionTypeMute {
+		restrictionErr = ErrUserMuted
+	}
+	if restriction.ExpiresAt != nil {
+		return fmt.Errorf("%w until %s", restrictionErr, restriction.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	return restrictionErr
+}
+
+// cannotWriteError explains why a read-only participant cannot write: a muted user gets
+// ErrUserMuted with the mute expiry instead of the generic ErrCannotWriteChat
+func (s *chatService) cannotWriteError(ctx context.Context, chatID, userID uuid.UUID) error {
+	if err := s.checkNotRestricted(ctx, chatID, userID, model.RestrictionTypeMute); err != nil {
+		return err
+	}
+	return ErrCannotWriteChat
+}
+
+// checkRestrictionTarget verifies the moderator can restrict the target user.
+// Chat admins can only be restricted by global moderators.
+func (s *chatService) checkRestrictionTarget(ctx context.Context, chatID, targetID, moderatorID uuid.UUID) (*model.ChatParticipant, error) {
+	if targetID == moderatorID {
+		return nil, fmt.Errorf("%w: cannot restrict yourself", ErrInvalidRestriction)
+	}
+
+	allowed, err := s.canModerateChat(ctx, chatID, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrAccessDenied
+	}
+
+	target, err := s.repo.GetParticipant(ctx, chatID, targetID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if target.Role.CanModerate() {
+		isGlobal, err := s.isGlobalModerator(ctx, moderatorID)
+		if err != nil {
+			return nil, err
+		}
+		if !isGlobal {
+			return nil, ErrAccessDenied
+		}
+	}
+	return target, nil
+}
+
+// newRestriction validates the duration (0 = until lifted) and builds a restriction
+func newRestriction(chatID, userID, createdBy uuid.UUID, restrictionType model.RestrictionType, reason string, duration time.Duration) (*model.ChatRestriction, error) {
+	if duration < 0 || duration > maxRestrictionDuration {
+		return nil, fmt.Errorf("%w: duration must be between 0 and %s", ErrInvalidRestriction, maxRestrictionDuration)
+	}
+
+	restriction := &model.ChatRestriction{
+		ChatID:    chatID,
+		UserID:    userID,
+		Type:      restrictionType,
+		CreatedBy: createdBy,
+	}
+	if reason != "" {
+		restriction.Reason = &reason
+	}
+	if duration > 0 {
+		expiresAt := time.Now().Add(duration)
+		restriction.ExpiresAt = &expiresAt
+	}
+	return restriction, nil
+}
+
+// BanUser removes the user from the chat and prevents adding them back until the ban is lifted
+// or expires. Users who are not participants can be banned in advance. duration 0 = permanent.
+func (s *chatService) BanUser(ctx context.Context, chatID, userID, bannedBy uuid.UUID, reason string, duration time.Duration) (*model.ChatRestriction, error) {
+	target, err := s.checkRestrictionTarget(ctx, chatID, userID, bannedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction, err := newRestriction(chatID, userID, bannedBy, model.RestrictionTypeBan, reason, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateChatRestriction(ctx, restriction); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrAlreadyRestricted
+		}
+		return nil, err
+	}
+
+	username := shortUsername(userID, nil)
+	if target != nil {
+		username = shortUsername(userID, target.Username)
+
+		// A ban supersedes a mute: the user is no longer a participant
+		if mute, err := s.repo.GetActiveChatRestriction(ctx, chatID, userID, model.RestrictionTypeMute); err == nil {
+			_, _ = s.repo.LiftChatRestriction(ctx, mute.ID, &bannedBy)
+		}
+
+		_ = s.RemoveParticipantFromFileGroups(ctx, chatID, userID)
+
+		// Get participants BEFORE removing, so the banned user gets the change too
+		participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+		if err := s.repo.RemoveParticipant(ctx, chatID, userID); err != nil {
+			return nil, err
+		}
+		_ = s.publisher.PublishChatMemberRemoved(ctx, chatID, userID, bannedBy, events.MemberRemovedBanned, participants)
+		s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeParticipantRemoved, bannedBy)
+	}
+
+	_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s was banned from the chat%s", username, restrictionSuffix(restriction)), false)
+	s.addRestrictionAudit(ctx, restriction, bannedBy, model.ModerationActionUserBanned, reason)
+
+	return restriction, nil
+}
+
+// UnbanUser lifts an active ban. The user is not re-added to the chat.
+func (s *chatService) UnbanUser(ctx context.Context, chatID, userID, unbannedBy uuid.UUID) error {
+	if _, err := s.checkRestrictionTarget(ctx, chatID, userID, unbannedBy); err != nil {
+		return err
+	}
+
+	ban, err := s.repo.GetActiveChatRestriction(ctx, chatID, userID, model.RestrictionTypeBan)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.repo.LiftChatRestriction(ctx, ban.ID, &unbannedBy); err != nil {
+		return err
+	}
+
+	_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s was unbanned", shortUsername(userID, ban.Username)), false)
+	s.addRestrictionAudit(ctx, ban, unbannedBy, model.ModerationActionUserUnbanned, "")
+
+	return nil
+}
+
+// MuteUser makes the participant read-only (no messages or reactions) for the given duration.
+// The previous role is restored when the mute expires or is lifted.
+func (s *chatService) MuteUser(ctx context.Context, chatID, userID, mutedBy uuid.UUID, reason string, duration time.Duration) (*model.ChatRestriction, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("%w: mute duration is required", ErrInvalidRestriction)
+	}
+
+	target, err := s.checkRestrictionTarget(ctx, chatID, userID, mutedBy)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrNotParticipant
+	}
+
+	restriction, err := newRestriction(chatID, userID, mutedBy, model.RestrictionTypeMute, reason, duration)
+	if err != nil {
+		return nil, err
+	}
+	previousRole := target.Role
+	restriction.PreviousRole = &previousRole
+	restriction.Username = target.Username
+
+	if err := s.repo.CreateChatRestriction(ctx, restriction); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrAlreadyRestricted
+		}
+		return nil, err
+	}
+
+	if target.Role != model.ParticipantRoleReadonly {
+		if err := s.repo.UpdateParticipantRole(ctx, chatID, userID, model.ParticipantRoleReadonly); err != nil {
+			_, _ = s.repo.LiftChatRestriction(ctx, restriction.ID, &mutedBy)
+			return nil, err
+		}
+	}
+
+	_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s was muted%s", shortUsername(userID, target.Username), restrictionSuffix(restriction)), false)
+	s.addRestrictionAudit(ctx, restriction, mutedBy, model.ModerationActionUserMuted, reason)
+
+	return restriction, nil
+}
+
+// UnmuteUser lifts an active mute and restores the participant's previous role
+func (s *chatService) UnmuteUser(ctx context.Context, chatID, userID, unmutedBy uuid.UUID) error {
+	if _, err := s.checkRestrictionTarget(ctx, chatID, userID, unmutedBy); err != nil {
+		return err
+	}
+
+	mute, err := s.repo.GetActiveChatRestriction(ctx, chatID, userID, model.RestrictionTypeMute)
+	if err != nil {
+		return err
+	}
+
+	lifted, err := s.repo.LiftChatRestriction(ctx, mute.ID, &unmutedBy)
+	if err != nil || !lifted {
+		return err
+	}
+	s.restorePreviousRole(ctx, mute)
+
+	_, _ = s.SendSystemMessage(ctx, chatID, fmt.Sprintf("%s was unmuted", shortUsername(userID, mute.Username)), false)
+	s.addRestrictionAudit(ctx, mute, unmutedBy, model.ModerationActionUserUnmuted, "")
+
+	return nil
+}
+
+// ListRestrictions returns the active bans and mutes of a chat (moderators only)
+func (s *chatService) ListRestrictions(ctx context.Context, chatID, userID uuid.UUID) ([]model.ChatRestriction, error) {
+	allowed, err := s.canModerateChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrAccessDenied
+	}
+	return s.repo.ListActiveChatRestrictions(ctx, chatID)
+}
+
+// ExpireRestrictions lifts restrictions whose expiry has passed, restores the role of muted users
+// and posts an Activity message for each. Called periodically by the scheduler; safe to run
+// from several replicas since each restriction is lifted only once.
+func (s *chatService) ExpireRestrictions(ctx context.Context, now time.Time) (int, error) {
+	expired, err := s.repo.GetExpiredChatRestrictions(ctx, now, expireRestrictionsBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range expired {
+		restriction := &expired[i]
+
+		lifted, err := s.repo.LiftChatRestriction(ctx, restriction.ID, nil)
+		if err != nil {
+			return count, err
+		}
+		if !lifted {
+			continue
+		}
+		count++
+
+		username := shortUsername(restriction.UserID, restriction.Username)
+		if restriction.Type == model.RestrictionTypeMute {
+			s.restorePreviousRole(ctx, restriction)
+			_, _ = s.SendSystemMessage(ctx, restriction.ChatID, fmt.Sprintf("Mute of %s has expired", username), false)
+		} else {
+			_, _ = s.SendSystemMessage(ctx, restriction.ChatID, fmt.Sprintf("Ban of %s has expired", username), false)
+		}
+	}
+
+	return count, nil
+}
+
+// restorePreviousRole reverts a muted participant to the role they had before the mute.
+// Nothing is changed if the user left the chat or an admin changed the role in the meantime.
+func (s *chatService) restorePreviousRole(ctx context.Context, mute *model.ChatRestriction) {
+	if mute.PreviousRole == nil {
+		return
+	}
+	participant, err := s.repo.GetParticipant(ctx, mute.ChatID, mute.UserID)
+	if err != nil || participant.Role != model.ParticipantRoleReadonly {
+		return
+	}
+	_ = s.repo.UpdateParticipantRole(ctx, mute.ChatID, mute.UserID, *mute.PreviousRole)
+}
+
+func (s *chatService) addRestrictionAudit(ctx context.Context, restriction *model.ChatRestriction, actorID uuid.UUID, action, details string) {
+	entry := &model.ModerationAuditEntry{
+		ChatID:       restriction.ChatID,
+		ActorID:      actorID,
+		Action:       action,
+		TargetUserID: &restriction.UserID,
+	}
+	if details != "" {
+		entry.Details = &details
+	}
+	_ = s.repo.AddModerationAuditEntry(ctx, entry)
+}
+
+func shortUsername(userID uuid.UUID, username *string) string {
+	if username != nil {
+		return *username
+	}
+	return userID.String()[:8] // Fallback to short UUID if no username
+}
+
+// restrictionSuffix describes the restriction length for Activity messages, e.g. " for 2h"
+func restrictionSuffix(restriction *model.ChatRestriction) string {
+	if restriction.ExpiresAt == nil {
+		return ""
+	}
+	d := time.Until(*restriction.ExpiresAt).Round(time.Minute)
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf(" for %dd", d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf(" for %dh", d/time.Hour)
+	default:
+		return fmt.Sprintf(" for %dm", d/time.Minute)
+	}
+}
+
+// Rate limiting and slow mode
+
+// checkRate takes a token of the user's rate limit for the action. Limiter store errors are
+// ignored so that a Redis outage does not block chatting.
+func (s *chatService) checkRate(ctx context.Context, action ratelimit.Action, userID uuid.UUID, reason string) error {
+	wait, err := s.limiter.Allow(ctx, action, userID)
+	if err != nil || wait <= 0 {
+		return nil
+	}
+	return &RateLimitError{Reason: reason, RetryAfter: wait}
+}
+
+// checkSendRate applies the message rate limit and the chat's slow mode to a sender.
+// Chat admins are exempt from slow mode. Bots are limited per incoming webhook instead.
+func (s *chatService) checkSendRate(ctx context.Context, participant *model.ChatParticipant) error {
+	if participant.IsBot {
+		return nil
+	}
+
+	if err := s.checkRate(ctx, ratelimit.ActionSendMessage, participant.UserID, "too many messages"); err != nil {
+		return err
+	}
+
+	if participant.Role.CanModerate() {
+		return nil
+	}
+
+	chat, err := s.repo.GetChat(ctx, participant.ChatID)
+	if err != nil || chat.SlowModeSeconds <= 0 {
+		return err
+	}
+
+	wait, err := s.limiter.SlowMode(ctx, participant.ChatID, participant.UserID, time.Duration(chat.SlowModeSeconds)*time.Second)
+	if err != nil || wait <= 0 {
+		return nil
+	}
+	return &RateLimitError{Reason: "slow mode is enabled in this chat", RetryAfter: wait}
+}
+
+// Content filters
+
+// loadContentPolicy returns the chat's content policy, falling back to the global one
+func (s *chatService) loadContentPolicy(ctx context.Context, chatID uuid.UUID) (*model.ContentPolicyRules, error) {
+	policy, err := s.repo.GetContentPolicy(ctx, &chatID)
+	if errors.Is(err, repository.ErrContentPolicyNotFound) {
+		policy, err = s.repo.GetContentPolicy(ctx, nil)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrContentPolicyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy.Rules, nil
+}
+
+// applyContentFilters runs the chat's filter pipeline over an outgoing message. Returns the
+// (possibly masked) content and the hits to log once the message is stored. A blocked message
+// returns a *filter.Rejection; its hits are logged right away.
+func (s *chatService) applyContentFilters(ctx context.Context, chatID, senderID uuid.UUID, content string, attachmentCount int) (string, []filter.Hit, error) {
+	pipeline, err := s.filters.Pipeline(ctx, chatID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := pipeline.Run(filter.Message{
+		ChatID:          chatID,
+		SenderID:        senderID,
+		Content:         content,
+		AttachmentCount: attachmentCount,
+	})
+	if result.Rejection != nil {
+		s.logContentFilterHits(ctx, chatID, senderID, nil, result.Hits)
+		return "", nil, result.Rejection
+	}
+	return result.Content, result.Hits, nil
+}
+
+// logContentFilterHits records filter hits for the moderation team. Failures are ignored so
+// that sending is not affected.
+func (s *chatService) logContentFilterHits(ctx context.Context, chatID, senderID uuid.UUID, messageID *uuid.UUID, hits []filter.Hit) {
+	if len(hits) == 0 {
+		return
+	}
+
+	records := make([]model.ContentFilterHit, len(hits))
+	for i, hit := range hits {
+		records[i] = model.ContentFilterHit{
+			ChatID:    chatID,
+			SenderID:  senderID,
+			MessageID: messageID,
+			Filter:    hit.Filter,
+			Rule:      hit.Rule,
+			Action:    hit.Action,
+			Matches:   hit.Matches,
+		}
+	}
+	_ = s.repo.AddContentFilterHits(ctx, records)
+}
+
+// GetContentPolicy returns the policy of a chat (chat admins) or the global policy (global moderators)
+func (s *chatService) GetContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) (*model.ContentPolicy, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetContentPolicy(ctx, chatID)
+}
+
+// SetContentPolicy validates and saves the policy of a chat, or the global policy when chatID is nil
+func (s *chatService) SetContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, rules model.ContentPolicyRules) (*model.ContentPolicy, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	if _, err := filter.Compile(rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContentPolicy, err)
+	}
+
+	policy := &model.ContentPolicy{
+		ChatID:    chatID,
+		Rules:     rules,
+		UpdatedBy: userID,
+	}
+	if err := s.repo.UpsertContentPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	s.filters.Invalidate(chatID)
+
+	return policy, nil
+}
+
+// DeleteContentPolicy removes a chat policy (the global one applies again) or the global policy
+func (s *chatService) DeleteContentPolicy(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) error {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteContentPolicy(ctx, chatID); err != nil {
+		return err
+	}
+	s.filters.Invalidate(chatID)
+
+	return nil
+}
+
+// ListContentFilterHits returns logged filter hits of a chat, or of all chats when chatID is nil
+func (s *chatService) ListContentFilterHits(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, page, count int) ([]model.ContentFilterHit, int, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListContentFilterHits(ctx, chatID, page, count)
+}
+
+// Outgoing webhooks
+
+// validateWebhook checks the target URL and event types and returns the event types without duplicates
+func validateWebhook(rawURL string, eventTypes []string) ([]string, error) {
+	if err := safehttp.ValidateURL(rawURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhook)
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	result := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !webhook.IsEventType(t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// getWebhookForUpdate loads a subscription and checks that the user manages its scope
+func (s *chatService) getWebhookForUpdate(ctx context.Context, userID, webhookID uuid.UUID) (*model.WebhookSubscription, error) {
+	sub, err := s.repo.GetWebhookSubscription(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkModerationAccess(ctx, userID, sub.ChatID); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// CreateWebhook subscribes a URL to events of a chat (chat admins) or of all chats (global moderators).
+// The returned subscription carries the signing secret; it is not returned again.
+func (s *chatService) CreateWebhook(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, targetURL string, eventTypes []string) (*model.WebhookSubscription, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := validateWebhook(targetURL, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &model.WebhookSubscription{
+		ChatID:     chatID,
+		URL:        targetURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.CreateWebhookSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// ListWebhooks returns the subscriptions of a chat, or the global subscriptions when chatID is nil
+func (s *chatService) ListWebhooks(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.WebhookSubscription, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWebhookSubscriptions(ctx, chatID)
+}
+
+// UpdateWebhook changes the URL and event types of a subscription, or pauses it. Deliveries already
+// queued are still sent to the new URL.
+func (s *chatService) UpdateWebhook(ctx context.Context, userID, webhookID uuid.UUID, targetURL string, eventTypes []string, isActive bool) (*model.WebhookSubscription, error) {
+	sub, err := s.getWebhookForUpdate(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err = validateWebhook(targetURL, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.URL = targetURL
+	sub.EventTypes = eventTypes
+	sub.IsActive = isActive
+	if err := s.repo.UpdateWebhookSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// DeleteWebhook removes a subscription together with its delivery log
+func (s *chatService) DeleteWebhook(ctx context.Context, userID, webhookID uuid.UUID) error {
+	if _, err := s.getWebhookForUpdate(ctx, userID, webhookID); err != nil {
+		return err
+	}
+	return s.repo.DeleteWebhookSubscription(ctx, webhookID)
+}
+
+// ListWebhookDeliveries returns the delivery log of a subscription
+func (s *chatService) ListWebhookDeliveries(ctx context.Context, userID, webhookID uuid.UUID, status model.WebhookDeliveryStatus, page, count int) ([]model.WebhookDelivery, int, error) {
+	if status != "" && !status.IsValid() {
+		return nil, 0, fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, status)
+	}
+	if _, err := s.getWebhookForUpdate(ctx, userID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListWebhookDeliveries(ctx, webhookID, status, page, count)
+}
+
+// RedeliverWebhook queues a delivery again with a fresh set of attempts, e.g. a dead one after
+// the receiver was fixed
+func (s *chatService) RedeliverWebhook(ctx context.Context, userID, deliveryID uuid.UUID) (*model.WebhookDelivery, error) {
+	delivery, err := s.repo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getWebhookForUpdate(ctx, userID, delivery.SubscriptionID); err != nil {
+		return nil, err
+	}
+	if delivery.Status == model.WebhookDeliveryPending {
+		return nil, fmt.Errorf("%w: delivery is still pending", ErrInvalidWebhook)
+	}
+
+	delivery.Status = model.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+	if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Incoming webhooks
+
+const (
+	defaultIncomingWebhookRate = 60
+	maxIncomingWebhookRate     = 600
+	maxIncomingWebhookFields   = 25
+)
+
+// getIncomingWebhookForUpdate loads an incoming webhook and checks that the user moderates its chat
+func (s *chatService) getIncomingWebhookForUpdate(ctx context.Context, userID, webhookID uuid.UUID) (*model.IncomingWebhook, error) {
+	hook, err := s.repo.GetIncomingWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkModerationAccess(ctx, userID, &hook.ChatID); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// checkBotAccess checks that botID is a bot account the user may post as: its owner or a global
+// moderator. Being a chat admin is not enough, or any admin could speak as someone else's bot.
+func (s *chatService) checkBotAccess(ctx context.Context, userID, botID uuid.UUID, errInvalid error) error {
+	isBot, ownerID, err := s.repo.GetBotAccount(ctx, botID)
+	if err != nil {
+		return err
+	}
+	if !isBot {
+		return fmt.Errorf("%w: bot_id must be a bot account", errInvalid)
+	}
+	if ownerID != nil && *ownerID == userID {
+		return nil
+	}
+	isGlobal, err := s.isGlobalModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isGlobal {
+		return fmt.Errorf("%w: only the bot's owner can use it", ErrAccessDenied)
+	}
+	return nil
+}
+
+// ensureBotParticipant adds the bot to the chat as a member unless it already takes part
+func (s *chatService) ensureBotParticipant(ctx context.Context, chatID, botID, addedBy uuid.UUID) error {
+	_, err := s.repo.GetParticipant(ctx, chatID, botID)
+	if err == nil || !errors.Is(err, repository.ErrParticipantNotFound) {
+		return err
+	}
+
+	if err := s.checkNotRestricted(ctx, chatID, botID, model.RestrictionTypeBan); err != nil {
+		return err
+	}
+
+	participant := &model.ChatParticipant{ChatID: chatID, UserID: botID, Role: model.ParticipantRoleMember}
+	if err := s.repo.AddParticipant(ctx, participant); err != nil {
+		return err
+	}
+	_ = s.SyncParticipantToFileGroups(ctx, chatID, botID, participant.Role)
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	_ = s.publisher.PublishChatMemberAdded(ctx, participant, addedBy, participants)
+	s.recordChatListChange(ctx, participants, chatID, model.ChatListChangeParticipantAdded, addedBy)
+	return nil
+}
+
+// CreateIncomingWebhook creates a webhook URL that posts into the chat as the bot. Chat admins
+// can create them for bots they own, global moderators for any bot. The returned webhook carries the token; only its hash
+// is stored.
+func (s *chatService) CreateIncomingWebhook(ctx context.Context, userID, chatID, botID uuid.UUID, name string, threadID *uuid.UUID, rateLimitPerMinute int) (*model.IncomingWebhook, error) {
+	if err := s.checkModerationAccess(ctx, userID, &chatID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1-100 characters", ErrInvalidWebhook)
+	}
+	if rateLimitPerMinute == 0 {
+		rateLimitPerMinute = defaultIncomingWebhookRate
+	}
+	if rateLimitPerMinute < 1 || rateLimitPerMinute > maxIncomingWebhookRate {
+		return nil, fmt.Errorf("%w: rate limit must be between 1 and %d messages per minute", ErrInvalidWebhook, maxIncomingWebhookRate)
+	}
+
+	if err := s.checkBotAccess(ctx, userID, botID, ErrInvalidWebhook); err != nil {
+		return nil, err
+	}
+
+	if threadID != nil {
+		thread, err := s.repo.GetThread(ctx, *threadID)
+		if err != nil {
+			return nil, err
+		}
+		if thread.ChatID != chatID {
+			return nil, fmt.Errorf("%w: thread does not belong to this chat", ErrInvalidWebhook)
+		}
+	}
+
+	if err := s.ensureBotParticipant(ctx, chatID, botID, userID); err != nil {
+		return nil, err
+	}
+
+	token, hash, err := webhook.GenerateIncomingToken()
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &model.IncomingWebhook{
+		ChatID:             chatID,
+		ThreadID:           threadID,
+		BotID:              botID,
+		Name:               name,
+		TokenHash:          hash,
+		Token:              token,
+		RateLimitPerMinute: rateLimitPerMinute,
+		CreatedBy:          userID,
+	}
+	if err := s.repo.CreateIncomingWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (s *chatService) ListIncomingWebhooks(ctx context.Context, userID, chatID uuid.UUID) ([]model.IncomingWebhook, error) {
+	if err := s.checkModerationAccess(ctx, userID, &chatID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListIncomingWebhooks(ctx, chatID)
+}
+
+// RotateIncomingWebhookToken issues a new token; the old URL stops working immediately
+func (s *chatService) RotateIncomingWebhookToken(ctx context.Context, userID, webhookID uuid.UUID) (*model.IncomingWebhook, error) {
+	hook, err := s.getIncomingWebhookForUpdate(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, err := webhook.GenerateIncomingToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateIncomingWebhookToken(ctx, webhookID, hash); err != nil {
+		return nil, err
+	}
+
+	hook.TokenHash = hash
+	hook.Token = token
+	hook.TokenRotatedAt = time.Now()
+	return hook, nil
+}
+
+func (s *chatService) DeleteIncomingWebhook(ctx context.Context, userID, webhookID uuid.UUID) error {
+	if _, err := s.getIncomingWebhookForUpdate(ctx, userID, webhookID); err != nil {
+		return err
+	}
+	return s.repo.DeleteIncomingWebhook(ctx, webhookID)
+}
+
+// ExecuteIncomingWebhook posts a payload as the webhook's bot. Unknown webhooks and wrong tokens
+// both return ErrInvalidWebhookToken so that callers cannot probe for webhook IDs.
+func (s *chatService) ExecuteIncomingWebhook(ctx context.Context, webhookID uuid.UUID, token string, payload model.IncomingWebhookPayload) (*model.Message, error) {
+	hook, err := s.repo.GetIncomingWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, repository.ErrIncomingWebhookNotFound) {
+			return nil, ErrInvalidWebhookToken
+		}
+		return nil, err
+	}
+	if !webhook.VerifyToken(token, hook.TokenHash) {
+		return nil, ErrInvalidWebhookToken
+	}
+
+	wait, err := s.limiter.AllowWebhook(ctx, hook.ID, hook.RateLimitPerMinute)
+	if err == nil && wait > 0 {
+		return nil, &RateLimitError{Reason: "too many webhook messages", RetryAfter: wait}
+	}
+
+	if len(payload.Fields) > maxIncomingWebhookFields {
+		return nil, fmt.Errorf("%w: at most %d fields are allowed", ErrInvalidWebhook, maxIncomingWebhookFields)
+	}
+	content := webhook.RenderMessage(payload)
+	if content == "" && len(payload.FileLinkIDs) == 0 {
+		return nil, fmt.Errorf("%w: message has no text, body or attachments", ErrInvalidWebhook)
+	}
+
+	threadID := hook.ThreadID
+	if payload.ThreadID != nil {
+		if hook.ThreadID != nil && *payload.ThreadID != *hook.ThreadID {
+			return nil, fmt.Errorf("%w: webhook is bound to another thread", ErrInvalidWebhook)
+		}
+		if err := s.checkWebhookThread(ctx, hook, *payload.ThreadID); err != nil {
+			return nil, err
+		}
+		threadID = payload.ThreadID
+	}
+
+	message, err := s.SendMessageToThread(ctx, hook.ChatID, hook.BotID, content, nil, threadID, payload.FileLinkIDs, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.repo.TouchIncomingWebhook(ctx, hook.ID)
+	return message, nil
+}
+
+// checkWebhookThread checks that a thread named in a payload belongs to the webhook's chat and,
+// if its participants are restricted, that the webhook's bot is one of them
+func (s *chatService) checkWebhookThread(ctx context.Context, hook *model.IncomingWebhook, threadID uuid.UUID) error {
+	thread, err := s.repo.GetThread(ctx, threadID)
+	if err != nil {
+		return err
+	}
+	if thread.ChatID != hook.ChatID {
+		return fmt.Errorf("%w: thread does not belong to the webhook's chat", ErrInvalidWebhook)
+	}
+	if !thread.RestrictedParticipants {
+		return nil
+	}
+	isThreadParticipant, err := s.repo.IsThreadParticipant(ctx, threadID, hook.BotID)
+	if err != nil {
+		return err
+	}
+	if !isThreadParticipant {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// Slash commands
+
+const (
+	maxPollOptions       = 10
+	maxMessageButtons    = 10
+	maxButtonLabelLength = 80
+	maxCommandTextLength = 200
+	// commandRequestTimeout bounds calls to bot endpoints; the sender waits for them
+	commandRequestTimeout = 5 * time.Second
+)
+
+// Built-in command names. They cannot be registered by bots.
+const (
+	commandHelp   = "help"
+	commandPoll   = "poll"
+	commandCall   = "call"
+	commandRemind = "remind"
+)
+
+// pollVoteAction is the action ID of /poll buttons; the button value is the option index
+const pollVoteAction = "poll.vote"
+
+var builtinCommands = []model.SlashCommand{
+	{Name: commandHelp, Description: "List the commands available in this chat", Usage: "/help"},
+	{Name: commandPoll, Description: "Start a poll", Usage: `/poll "Question" "Option 1" "Option 2"`},
+	{Name: commandCall, Description: "Start a call in this chat", Usage: "/call"},
+	{Name: commandRemind, Description: "Remind yourself later, only you see the reminder", Usage: "/remind 30m Check the build"},
+}
+
+func isBuiltinCommand(name string) bool {
+	for _, cmd := range builtinCommands {
+		if cmd.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// commandInvocation is a slash command sent by a chat participant
+type commandInvocation struct {
+	chatID   uuid.UUID
+	threadID *uuid.UUID
+	invoker  *model.ChatParticipant
+	name     string
+	args     string
+}
+
+// pollState is stored with /poll messages to re-render them when votes change
+type pollState struct {
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+}
+
+// runCommand runs the slash command in content instead of posting it as a message.
+// Returns ok=false when content is not a command.
+func (s *chatService) runCommand(ctx context.Context, invoker *model.ChatParticipant, threadID *uuid.UUID, content string) (*model.Message, bool, error) {
+	name, args, ok := commands.Parse(content)
+	if !ok {
+		return nil, false, nil
+	}
+
+	// Commands count against the same limits as messages
+	if err := s.checkSendRate(ctx, invoker); err != nil {
+		return nil, true, err
+	}
+
+	inv := &commandInvocation{chatID: invoker.ChatID, threadID: threadID, invoker: invoker, name: name, args: args}
+	var message *model.Message
+	var err error
+	switch name {
+	case commandHelp:
+		message, err = s.runHelpCommand(ctx, inv)
+	case commandPoll:
+		message, err = s.runPollCommand(ctx, inv)
+	case commandCall:
+		message, err = s.runCallCommand(ctx, inv)
+	case commandRemind:
+		message, err = s.runRemindCommand(ctx, inv)
+	default:
+		cmd, findErr := s.repo.FindBotCommand(ctx, inv.chatID, name)
+		if findErr != nil {
+			if errors.Is(findErr, repository.ErrBotCommandNotFound) {
+				return nil, true, fmt.Errorf("%w: unknown command /%s, see /help", ErrInvalidCommand, name)
+			}
+			return nil, true, findErr
+		}
+		message, err = s.runBotCommand(ctx, inv, cmd)
+	}
+	return message, true, err
+}
+
+func (s *chatService) runHelpCommand(ctx context.Context, inv *commandInvocation) (*model.Message, error) {
+	available, err := s.ListCommands(ctx, inv.chatID, inv.invoker.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("Available commands:")
+	for _, cmd := range available {
+		fmt.Fprintf(&b, "\n/%s - %s", cmd.Name, cmd.Description)
+		if cmd.Usage != "" {
+			fmt.Fprintf(&b, " (%s)", cmd.Usage)
+		}
+	}
+	b.WriteString("\nStart a message with // to send it as text.")
+
+	return s.sendEphemeral(ctx, inv.chatID, inv.threadID, inv.invoker.UserID, nil, b.String(), nil), nil
+}
+
+func (s *chatService) runPollCommand(ctx context.Context, inv *commandInvocation) (*model.Message, error) {
+	args := commands.SplitArgs(inv.args)
+	if len(args) < 3 || len(args)-1 > maxPollOptions {
+		return nil, fmt.Errorf("%w: a poll needs a question and 2-%d options, e.g. %s", ErrInvalidCommand, maxPollOptions, builtinCommands[1].Usage)
+	}
+
+	poll := pollState{Question: args[0], Options: args[1:]}
+	buttons := make([]model.MessageButton, len(poll.Options))
+	for i, option := range poll.Options {
+		if option == "" || len(option) > maxButtonLabelLength {
+			return nil, fmt.Errorf("%w: poll options must be 1-%d characters", ErrInvalidCommand, maxButtonLabelLength)
+		}
+		buttons[i] = model.MessageButton{ActionID: pollVoteAction, Label: option, Value: strconv.Itoa(i)}
+	}
+
+	state, err := json.Marshal(poll)
+	if err != nil {
+		return nil, err
+	}
+	return s.postCommandMessage(ctx, inv.chatID, inv.threadID, inv.invoker, renderPoll(poll, nil), &model.MessageInteraction{
+		Command: commandPoll,
+		Buttons: buttons,
+		State:   state,
+	})
+}
+
+// renderPoll formats a poll with its vote counts
+func renderPoll(poll pollState, votes map[int]int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Poll: %s", poll.Question)
+	for i, option := range poll.Options {
+		fmt.Fprintf(&b, "\n%d. %s - %d", i+1, option, votes[i])
+	}
+	return b.String()
+}
+
+func (s *chatService) runCallCommand(ctx context.Context, inv *commandInvocation) (*model.Message, error) {
+	content := fmt.Sprintf("%s started a call", shortUsername(inv.invoker.UserID, inv.invoker.Username))
+	if inv.args != "" {
+		content += ": " + inv.args
+	}
+	join := model.MessageButton{
+		Label: "Join call",
+		URL:   fmt.Sprintf("/api/voice/chats/%s/call", inv.chatID),
+		Style: "primary",
+	}
+	return s.postCommandMessage(ctx, inv.chatID, inv.threadID, inv.invoker, content, &model.MessageInteraction{
+		Command: commandCall,
+		Buttons: []model.MessageButton{join},
+	})
+}
+
+func (s *chatService) runRemindCommand(ctx context.Context, inv *commandInvocation) (*model.Message, error) {
+	delay, text, _ := strings.Cut(inv.args, " ")
+	text = strings.TrimSpace(text)
+	d, err := commands.ParseDuration(delay)
+	if err != nil || text == "" {
+		return nil, fmt.Errorf("%w: usage: %s", ErrInvalidCommand, builtinCommands[3].Usage)
+	}
+	reminder := &model.Reminder{
+		ChatID:   inv.chatID,
+		ThreadID: inv.threadID,
+		UserID:   inv.invoker.UserID,
+		Text:     text,
+		RemindAt: time.Now().Add(d),
+	}
+	if err := s.createReminder(ctx, reminder); err != nil {
+		return nil, err
+	}
+
+	ack := fmt.Sprintf("I will remind you at %s: %s", reminder.RemindAt.UTC().Format("2006-01-02 15:04 MST"), text)
+	return s.sendEphemeral(ctx, inv.chatID, inv.threadID, inv.invoker.UserID, nil, ack, nil), nil
+}
+
+// runBotCommand sends the invocation to the bot endpoint and posts its reply. Endpoint
+// failures are reported to the invoker only.
+func (s *chatService) runBotCommand(ctx context.Context, inv *commandInvocation, cmd *model.BotCommand) (*model.Message, error) {
+	req := commands.Request{
+		Type:      commands.RequestCommand,
+		CommandID: cmd.ID.String(),
+		Command:   cmd.Name,
+		Text:      inv.args,
+		ChatID:    inv.chatID.String(),
+		UserID:    inv.invoker.UserID.String(),
+	}
+	if inv.threadID != nil {
+		req.ThreadID = inv.threadID.String()
+	}
+	if inv.invoker.Username != nil {
+		req.Username = *inv.invoker.Username
+	}
+
+	resp, err := s.commands.Send(ctx, cmd.URL, cmd.Secret, req)
+	if err != nil {
+		return s.sendEphemeral(ctx, inv.chatID, inv.threadID, inv.invoker.UserID, nil, commandFailedText(cmd.Name, err), nil), nil
+	}
+	if resp != nil {
+		message, err := s.postBotResponse(ctx, cmd, inv.chatID, inv.threadID, inv.invoker.UserID, resp)
+		if err != nil || message != nil {
+			return message, err
+		}
+	}
+	// No reply: show the invoker that the command was accepted
+	return s.sendEphemeral(ctx, inv.chatID, inv.threadID, inv.invoker.UserID, nil, strings.TrimSpace("/"+cmd.Name+" "+inv.args), nil), nil
+}
+
+// postBotResponse posts a bot reply as the bot, or shows it to userID only when it is ephemeral
+func (s *chatService) postBotResponse(ctx context.Context, cmd *model.BotCommand, chatID uuid.UUID, threadID *uuid.UUID, userID uuid.UUID, resp *commands.Response) (*model.Message, error) {
+	if err := validateButtons(resp.Buttons); err != nil {
+		return s.sendEphemeral(ctx, chatID, threadID, userID, nil, fmt.Sprintf("/%s returned an invalid reply: %v", cmd.Name, err), nil), nil
+	}
+	if strings.TrimSpace(resp.Text) == "" {
+		return nil, nil
+	}
+
+	if err := s.ensureBotParticipant(ctx, chatID, cmd.BotID, userID); err != nil {
+		return nil, err
+	}
+	bot, err := s.repo.GetParticipant(ctx, chatID, cmd.BotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Ephemeral {
+		return s.sendEphemeral(ctx, chatID, threadID, userID, bot, resp.Text, linkButtons(resp.Buttons)), nil
+	}
+	return s.postCommandMessage(ctx, chatID, threadID, bot, resp.Text, &model.MessageInteraction{
+		Command:      cmd.Name,
+		BotCommandID: &cmd.ID,
+		Buttons:      resp.Buttons,
+	})
+}
+
+// validateButtons checks buttons returned by a bot
+func validateButtons(buttons []model.MessageButton) error {
+	if len(buttons) > maxMessageButtons {
+		return fmt.Errorf("at most %d buttons are allowed", maxMessageButtons)
+	}
+	for _, b := range buttons {
+		if b.Label == "" || len(b.Label) > maxButtonLabelLength {
+			return fmt.Errorf("button labels must be 1-%d characters", maxButtonLabelLength)
+		}
+		if (b.ActionID == "") == (b.URL == "") {
+			return fmt.Errorf("button %q needs either an action_id or a url", b.Label)
+		}
+		if b.URL != "" {
+			u, err := url.Parse(b.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("button %q has an invalid url", b.Label)
+			}
+		}
+	}
+	return nil
+}
+
+// linkButtons drops action buttons: ephemeral messages are not stored, so nothing could route their clicks
+func linkButtons(buttons []model.MessageButton) []model.MessageButton {
+	var links []model.MessageButton
+	for _, b := range buttons {
+		if b.URL != "" {
+			links = append(links, b)
+		}
+	}
+	return links
+}
+
+// postCommandMessage stores and publishes a message produced by a command. Buttons of the
+// interaction are attached to the message. The sender's rate limit was already applied to the
+// command itself.
+func (s *chatService) postCommandMessage(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, sender *model.ChatParticipant, content string, interaction *model.MessageInteraction) (*model.Message, error) {
+	content, filterHits, err := s.applyContentFilters(ctx, chatID, sender.UserID, content, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &model.Message{
+		ChatID:   chatID,
+		SenderID: sender.UserID,
+		Content:  content,
+		ThreadID: threadID,
+	}
+	if err := s.repo.CreateMessage(ctx, message); err != nil {
+		return nil, err
+	}
+	s.logContentFilterHits(ctx, chatID, sender.UserID, &message.ID, filterHits)
+
+	message.SenderUsername = sender.Username
+	message.SenderDisplayName = sender.DisplayName
+	message.SenderAvatarURL = sender.AvatarURL
+	message.SenderIsBot = sender.IsBot
+
+	if interaction != nil && len(interaction.Buttons) > 0 {
+		interaction.MessageID = message.ID
+		if err := s.repo.CreateMessageInteraction(ctx, interaction); err != nil {
+			return nil, err
+		}
+		message.Buttons = interaction.Buttons
+	}
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, chatID)
+	_ = s.publisher.PublishMessageCreated(ctx, message, participants)
+
+	return message, nil
+}
+
+// sendEphemeral shows a message to one user only. It is not stored. Without a sender it is
+// shown as a system message.
+func (s *chatService) sendEphemeral(ctx context.Context, chatID uuid.UUID, threadID *uuid.UUID, userID uuid.UUID, sender *model.ChatParticipant, content string, buttons []model.MessageButton) *model.Message {
+	message := newEphemeralMessage(chatID, threadID, content, buttons)
+	if sender != nil {
+		message.SenderID = sender.UserID
+		message.SenderUsername = sender.Username
+		message.SenderDisplayName = sender.DisplayName
+		message.SenderAvatarURL = sender.AvatarURL
+		message.SenderIsBot = sender.IsBot
+	} else {
+		message.IsSystem = true
+	}
+
+	_ = s.publisher.PublishEphemeralMessage(ctx, message, userID)
+	return message
+}
+
+func newEphemeralMessage(chatID uuid.UUID, threadID *uuid.UUID, content string, buttons []model.MessageButton) *model.Message {
+	return &model.Message{
+		ID:          uuid.New(),
+		ChatID:      chatID,
+		ThreadID:    threadID,
+		Content:     content,
+		SentAt:      time.Now(),
+		IsEphemeral: true,
+		Buttons:     buttons,
+	}
+}
+
+// attachMessageExtras loads the buttons of interactive messages and the reaction summaries as
+// seen by userID, one batch query each. Failures are ignored: the messages are still readable
+// without them.
+func (s *chatService) attachMessageExtras(ctx context.Context, userID uuid.UUID, messages ...*model.Message) {
+	if len(messages) == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+	if buttons, err := s.repo.GetMessageButtons(ctx, ids); err == nil {
+		for _, m := range messages {
+			m.Buttons = buttons[m.ID]
+		}
+	}
+	if reactions, err := s.repo.GetReactionSummariesBatch(ctx, ids, userID); err == nil {
+		for _, m := range messages {
+			m.Reactions = reactions[m.ID]
+		}
+	}
+}
+
+func messagePointers(messages []model.Message) []*model.Message {
+	result := make([]*model.Message, len(messages))
+	for i := range messages {
+		result[i] = &messages[i]
+	}
+	return result
+}
+
+// InteractMessage handles a click on an action button of an interactive message. The button
+// must exist on the message. Returns the updated or newly posted message, if any.
+func (s *chatService) InteractMessage(ctx context.Context, messageID, userID uuid.UUID, actionID, value string) (*model.Message, error) {
+	message, err := s.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.IsDeleted {
+		return nil, repository.ErrMessageNotFound
+	}
+
+	participant, err := s.repo.GetParticipant(ctx, message.ChatID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrParticipantNotFound) {
+			return nil, ErrNotParticipant
+		}
+		return nil, err
+	}
+
+	interaction, err := s.repo.GetMessageInteraction(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, b := range interaction.Buttons {
+		if b.ActionID != "" && b.ActionID == actionID && b.Value == value {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: the message has no such button", ErrInvalidCommand)
+	}
+
+	if err := s.checkRate(ctx, ratelimit.ActionSendMessage, userID, "too many actions"); err != nil {
+		return nil, err
+	}
+
+	if interaction.BotCommandID == nil {
+		if interaction.Command == commandPoll {
+			return s.votePoll(ctx, message, interaction, userID, value)
+		}
+		return nil, fmt.Errorf("%w: the message has no such button", ErrInvalidCommand)
+	}
+
+	cmd, err := s.repo.GetBotCommand(ctx, *interaction.BotCommandID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := commands.Request{
+		Type:      commands.RequestInteraction,
+		CommandID: cmd.ID.String(),
+		Command:   cmd.Name,
+		ChatID:    message.ChatID.String(),
+		UserID:    userID.String(),
+		MessageID: messageID.String(),
+		ActionID:  actionID,
+		Value:     value,
+	}
+	if message.ThreadID != nil {
+		req.ThreadID = message.ThreadID.String()
+	}
+	if participant.Username != nil {
+		req.Username = *participant.Username
+	}
+
+	resp, err := s.commands.Send(ctx, cmd.URL, cmd.Secret, req)
+	if err != nil {
+		return s.sendEphemeral(ctx, message.ChatID, message.ThreadID, userID, nil, commandFailedText(cmd.Name, err), nil), nil
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	if !resp.ReplaceOriginal {
+		return s.postBotResponse(ctx, cmd, message.ChatID, message.ThreadID, userID, resp)
+	}
+
+	if err := validateButtons(resp.Buttons); err != nil {
+		return s.sendEphemeral(ctx, message.ChatID, message.ThreadID, userID, nil, fmt.Sprintf("/%s returned an invalid reply: %v", cmd.Name, err), nil), nil
+	}
+	if strings.TrimSpace(resp.Text) != "" {
+		message.Content = resp.Text
+		if err := s.repo.UpdateMessage(ctx, message); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.UpdateMessageInteractionButtons(ctx, messageID, resp.Buttons); err != nil {
+		return nil, err
+	}
+	message.Buttons = resp.Buttons
+	message.SenderIsBot = true
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, message.ChatID)
+	_ = s.publisher.PublishMessageUpdated(ctx, message, participants)
+
+	return message, nil
+}
+
+// votePoll records a /poll vote and re-renders the poll with the new counts
+func (s *chatService) votePoll(ctx context.Context, message *model.Message, interaction *model.MessageInteraction, userID uuid.UUID, value string) (*model.Message, error) {
+	var poll pollState
+	if err := json.Unmarshal(interaction.State, &poll); err != nil {
+		return nil, fmt.Errorf("invalid poll state: %w", err)
+	}
+	option, err := strconv.Atoi(value)
+	if err != nil || option < 0 || option >= len(poll.Options) {
+		return nil, fmt.Errorf("%w: unknown poll option", ErrInvalidCommand)
+	}
+
+	if err := s.repo.UpsertPollVote(ctx, message.ID, userID, option); err != nil {
+		return nil, err
+	}
+	votes, err := s.repo.CountPollVotes(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Content = renderPoll(poll, votes)
+	if err := s.repo.UpdateMessage(ctx, message); err != nil {
+		return nil, err
+	}
+	message.Buttons = interaction.Buttons
+
+	participants, _ := s.repo.GetParticipantIDs(ctx, message.ChatID)
+	_ = s.publisher.PublishMessageUpdated(ctx, message, participants)
+
+	return message, nil
+}
+
+// ListCommands returns the built-in commands and the bot commands available in the chat
+func (s *chatService) ListCommands(ctx context.Context, chatID, userID uuid.UUID) ([]model.SlashCommand, error) {
+	isParticipant, err := s.repo.IsParticipant(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, ErrNotParticipant
+	}
+
+	botCommands, err := s.repo.ListAvailableBotCommands(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.SlashCommand, 0, len(builtinCommands)+len(botCommands))
+	result = append(result, builtinCommands...)
+	for _, cmd := range botCommands {
+		if isBuiltinCommand(cmd.Name) {
+			continue
+		}
+		result = append(result, model.SlashCommand{
+			Name:         cmd.Name,
+			Description:  cmd.Description,
+			Usage:        cmd.Usage,
+			BotCommandID: &cmd.ID,
+		})
+	}
+	return result, nil
+}
+
+// commandFailedText is the ephemeral reply to a bot command that failed. Only failures of the
+// bot endpoint are described; anything else could expose internal details to the invoker.
+func commandFailedText(name string, err error) string {
+	var cmdErr *commands.Error
+	if errors.As(err, &cmdErr) {
+		return fmt.Sprintf("/%s failed: %s", name, cmdErr.Reason)
+	}
+	return fmt.Sprintf("/%s failed", name)
+}
+
+// RegisterBotCommand registers an external command handled by the bot at targetURL. Chat admins
+// register commands of bots they own for their chat, global moderators those of any bot for one
+// or all chats (chatID nil). The returned
+// command carries the signing secret; it is not returned again.
+func (s *chatService) RegisterBotCommand(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, botID uuid.UUID, name, description, usage, targetURL string) (*model.BotCommand, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "/"))
+	if !commands.IsValidName(name) {
+		return nil, fmt.Errorf("%w: name must be 1-32 lowercase letters, digits, '-' or '_'", ErrInvalidCommand)
+	}
+	if isBuiltinCommand(name) {
+		return nil, fmt.Errorf("%w: /%s is a built-in command", ErrInvalidCommand, name)
+	}
+	description = strings.TrimSpace(description)
+	usage = strings.TrimSpace(usage)
+	if len(description) > maxCommandTextLength || len(usage) > maxCommandTextLength {
+		return nil, fmt.Errorf("%w: description and usage are limited to %d characters", ErrInvalidCommand, maxCommandTextLength)
+	}
+	if err := safehttp.ValidateURL(targetURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommand, err)
+	}
+
+	if err := s.checkBotAccess(ctx, userID, botID, ErrInvalidCommand); err != nil {
+		return nil, err
+	}
+
+	if chatID != nil {
+		if err := s.ensureBotParticipant(ctx, *chatID, botID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &model.BotCommand{
+		ChatID:      chatID,
+		Name:        name,
+		Description: description,
+		Usage:       usage,
+		BotID:       botID,
+		URL:         targetURL,
+		Secret:      secret,
+		CreatedBy:   userID,
+	}
+	if err := s.repo.CreateBotCommand(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (s *chatService) ListBotCommands(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.BotCommand, error) {
+	if err := s.checkModerationAccess(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListBotCommands(ctx, chatID)
+}
+
+func (s *chatService) DeleteBotCommand(ctx context.Context, userID, commandID uuid.UUID) error {
+	cmd, err := s.repo.GetBotCommand(ctx, commandID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkModerationAccess(ctx, userID, cmd.ChatID); err != nil {
+		return err
+	}
+	return s.repo.DeleteBotCommand(ctx, commandID)
+}
+
+// Reminders
+
+const (
+	minReminderDelay      = time.Minute
+	maxReminderDelay      = 30 * 24 * time.Hour
+	maxReminderNoteLength = 500
+	// maxPendingReminders caps the undelivered reminders of one user
+	maxPendingReminders = 100
+	// deliverRemindersBatchSize limits how many reminders one delivery run sends
+	deliverRemindersBatchSize = 100
+	// reminderDeliveryLease is how long a claimed reminder waits before its delivery is retried
+	reminderDeliveryLease = 5 * time.Minute
+	// deliveredReminderRetention is how long fired reminders are listed with includeDelivered
+	deliveredReminderRetention = 7 * 24 * time.Hour
+)
+
+// createReminder validates the schedule and the user's reminder quota and stores the reminder
+func (s *chatService) createReminder(ctx context.Context, reminder *model.Reminder) error {
+	delay := time.Until(reminder.RemindAt)
+	if delay < minReminderDelay-time.Second || delay > maxReminderDelay {
+		return fmt.Errorf("%w: reminders can be set from 1 minute to 30 days ahead", ErrInvalidReminder)
+	}
+	if len(reminder.Text) > maxReminderNoteLength {
+		return fmt.Errorf("%w: the note is limited to %d characters", ErrInvalidReminder, maxReminderNoteLength)
+	}
+
+	pending, err := s.repo.CountPendingReminders(ctx, reminder.UserID)
+	if err != nil {
+		return err
+	}
+	if pending >= maxPendingReminders {
+		return fmt.Errorf("%w: at most %d reminders can be pending", ErrInvalidReminder, maxPendingReminders)
+	}
+
+	return s.repo.CreateReminder(ctx, reminder)
+}
+
+// CreateMessageReminder schedules a private reminder about a message for the user
+func (s *chatService) CreateMessageReminder(ctx context.Context, messageID, userID uuid.UUID, remindAt time.Time, note string) (*model.Reminder, error) {
+	message, err := s.repo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.IsDeleted {
+		return nil, repository.ErrMessageNotFound
+	}
+
+	isParticipant, err := s.repo.IsParticipant(ctx, message.ChatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, ErrNotParticipant
+	}
+
+	reminder := &model.Reminder{
+		ChatID:    message.ChatID,
+		ThreadID:  message.ThreadID,
+		MessageID: &message.ID,
+		UserID:    userID,
+		Text:      strings.TrimSpace(note),
+		RemindAt:  remindAt,
+	}
+	if err := s.createReminder(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// ListReminders returns the user's pending reminders, optionally of one chat. With
+// includeDelivered, reminders that fired in the last week are included too, so a client that was
+// offline at the due time still shows them.
+func (s *chatService) ListReminders(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, includeDelivered bool) ([]model.Reminder, error) {
+	var deliveredSince *time.Time
+	if includeDelivered {
+		since := time.Now().Add(-deliveredReminderRetention)
+		deliveredSince = &since
+	}
+	return s.repo.ListReminders(ctx, userID, chatID, deliveredSince)
+}
+
+// CancelReminder deletes a pending reminder, or dismisses one that already fired. Reminders of
+// other users are reported as not found.
+func (s *chatService) CancelReminder(ctx context.Context, reminderID, userID uuid.UUID) error {
+	reminder, err := s.repo.GetReminder(ctx, reminderID)
+	if err != nil {
+		return err
+	}
+	if reminder.UserID != userID {
+		return repository.ErrReminderNotFound
+	}
+	return s.repo.DeleteReminder(ctx, reminderID)
+}
+
+// DeliverDueReminders sends due reminders as ephemeral system messages; reminders about a
+// message quote it. A reminder is marked delivered only once its message was published; until
+// then its claim expires and it is sent again. Reminders of users who left the chat are dropped.
+// Returns the number of reminders handled.
+func (s *chatService) DeliverDueReminders(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := s.repo.ClaimDueReminders(ctx, now, reminderDeliveryLease, deliverRemindersBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, reminder := range reminders {
+		isParticipant, err := s.repo.IsParticipant(ctx, reminder.ChatID, reminder.UserID)
+		if err != nil {
+			continue // Retried when the claim expires
+		}
+		if !isParticipant {
+			_ = s.repo.DeleteReminder(ctx, reminder.ID)
+			continue
+		}
+
+		content := "Reminder: " + reminder.Text
+		if reminder.Text == "" {
+			content = "Reminder about this message"
+		}
+		message := newEphemeralMessage(reminder.ChatID, reminder.ThreadID, content, nil)
+		message.IsSystem = true
+		if reminder.MessageID != nil {
+			message.ReplyToIDs = []uuid.UUID{*reminder.MessageID}
+		}
+		if err := s.publisher.PublishEphemeralMessage(ctx, message, reminder.UserID); err != nil {
+			continue // Retried when the claim expires
+		}
+		if err := s.repo.MarkReminderDelivered(ctx, reminder.ID, now); err != nil {
+			return 0, err
+		}
+	}
+	return len(reminders), nil
+}
+
+// Custom emoji
+
+const (
+	// maxCustomEmojiPerChat caps the emoji set of one chat
+	maxCustomEmojiPerChat = 200
+	// maxCustomEmojiBytes limits the image size; emoji are shown at small sizes only
+	maxCustomEmojiBytes = 256 << 10
+)
+
+var customEmojiContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+	"image/jpeg": true,
+}
+
+// CreateCustomEmoji adds an emoji to the chat's set. The image must already be uploaded to
+// files-service; its link is shared with the chat participants like a message attachment.
+func (s *chatService) CreateCustomEmoji(ctx context.Context, chatID, userID uuid.UUID, name string, fileLinkID uuid.UUID) (*model.CustomEmoji, error) {
+	if err := s.checkModerationAccess(ctx, userID, &chatID); err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), ":"))
+	if !emoji.IsValidName(name) {
+		return nil, fmt.Errorf("%w: name must be 2-32 lowercase letters, digits or '_'", ErrInvalidEmoji)
+	}
+
+	count, err := s.repo.CountCustomEmoji(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCustomEmojiPerChat {
+		return nil, fmt.Errorf("%w: a chat can have at most %d custom emoji", ErrInvalidEmoji, maxCustomEmojiPerChat)
+	}
+
+	if err := s.validateEmojiImage(ctx, fileLinkID); err != nil {
+		return nil, err
+	}
+
+	e := &model.CustomEmoji{
+		ChatID:     chatID,
+		Name:       name,
+		FileLinkID: fileLinkID,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.CreateCustomEmoji(ctx, e); err != nil {
+		return nil, err
+	}
+
+	if err := s.AttachFileLinkToChat(ctx, chatID, fileLinkID, userID); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// validateEmojiImage checks the uploaded file's type and size
+func (s *chatService) validateEmojiImage(ctx context.Context, fileLinkID uuid.UUID) error {
+	if s.filesClient == nil {
+		return fmt.Errorf("%w: file storage is not available", ErrInvalidEmoji)
+	}
+	resp, err := s.filesClient.GetFilesByLinkIDs(ctx, &filesPb.GetFilesByLinkIDsRequest{
+		LinkIds: []string{fileLinkID.String()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get emoji file: %w", err)
+	}
+	if len(resp.Files) == 0 {
+		return fmt.Errorf("%w: file not found", ErrInvalidEmoji)
+	}
+	file := resp.Files[0]
+	if !customEmojiContentTypes[file.ContentType] {
+		return fmt.Errorf("%w: image must be PNG, GIF, WebP or JPEG", ErrInvalidEmoji)
+	}
+	if file.Size > maxCustomEmojiBytes {
+		return fmt.Errorf("%w: image is limited to %d KB", ErrInvalidEmoji, maxCustomEmojiBytes>>10)
+	}
+	return nil
+}
+
+func (s *chatService) ListCustomEmoji(ctx context.Context, chatID, userID uuid.UUID) ([]model.CustomEmoji, error) {
+	isParticipant, err := s.repo.IsParticipant(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, ErrNotParticipant
+	}
+	return s.repo.ListCustomEmoji(ctx, chatID)
+}
+
+// DeleteCustomEmoji removes an emoji from the set. Existing reactions with it are kept and can
+// still be removed, but it cannot be used for new ones.
+func (s *chatService) DeleteCustomEmoji(ctx context.Context, emojiID, userID uuid.UUID) error {
+	e, err := s.repo.GetCustomEmoji(ctx, emojiID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkModerationAccess(ctx, userID, &e.ChatID); err != nil {
+		return err
+	}
+	return s.repo.DeleteCustomEmoji(ctx, emojiID)
+}
+
+// Drafts
+
+const (
+	// maxDraftLength limits a draft in characters
+	maxDraftLength = 10000
+	// maxDraftReplies limits the quoted messages kept with a draft
+	maxDraftReplies = 10
+)
+
+// SaveDraft stores the user's unsent message in the chat or thread and syncs it to the user's
+// other devices. An empty draft deletes the stored one.
+func (s *chatService) SaveDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID, content string, replyToIDs []uuid.UUID) (*model.Draft, error) {
+	if err := s.checkDraftAccess(ctx, userID, chatID, threadID); err != nil {
+		return nil, err
+	}
+
+	draft := &model.Draft{
+		UserID:     userID,
+		ChatID:     chatID,
+		ThreadID:   threadID,
+		Content:    content,
+		ReplyToIDs: replyToIDs,
+	}
+	if strings.TrimSpace(content) == "" && len(replyToIDs) == 0 {
+		if err := s.DeleteDraft(ctx, userID, chatID, threadID); err != nil {
+			return nil, err
+		}
+		draft.Content = ""
+		draft.UpdatedAt = time.Now()
+		return draft, nil
+	}
+	if utf8.RuneCountInString(content) > maxDraftLength {
+		return nil, fmt.Errorf("%w: drafts are limited to %d characters", ErrInvalidDraft, maxDraftLength)
+	}
+	if len(replyToIDs) > maxDraftReplies {
+		return nil, fmt.Errorf("%w: a draft can quote at most %d messages", ErrInvalidDraft, maxDraftReplies)
+	}
+
+	if err := s.repo.UpsertDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	_ = s.publisher.PublishDraftUpdated(ctx, draft, false)
+	return draft, nil
+}
+
+// checkDraftAccess requires chat participation and, for restricted threads, thread participation
+func (s *chatService) checkDraftAccess(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) error {
+	isParticipant, err := s.repo.IsParticipant(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	if !isParticipant {
+		return ErrNotParticipant
+	}
+	if threadID == nil {
+		return nil
+	}
+
+	thread, err := s.repo.GetThread(ctx, *threadID)
+	if err != nil {
+		return err
+	}
+	if thread.ChatID != chatID {
+		return repository.ErrThreadNotFound
+	}
+	if thread.RestrictedParticipants {
+		isThreadParticipant, err := s.repo.IsThreadParticipant(ctx, *threadID, userID)
+		if err != nil {
+			return err
+		}
+		if !isThreadParticipant {
+			return ErrAccessDenied
+		}
+	}
+	return nil
+}
+
+// GetDrafts returns the user's drafts, most recently edited first, optionally of one chat
+func (s *chatService) GetDrafts(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID) ([]model.Draft, error) {
+	return s.repo.ListDrafts(ctx, userID, chatID)
+}
+
+// DeleteDraft discards the user's draft in the chat or thread. Deleting a missing draft is not an error.
+func (s *chatService) DeleteDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) error {
+	deleted, err := s.repo.DeleteDraft(ctx, userID, chatID, threadID)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		_ = s.publisher.PublishDraftUpdated(ctx, &model.Draft{
+			UserID:    userID,
+			ChatID:    chatID,
+			ThreadID:  threadID,
+			UpdatedAt: time.Now(),
+		}, true)
+	}
+	return nil
+}
+
+// clearDraft deletes the user's draft after sending and tells the user's other devices to clear
+// their input. Failures are ignored: a stale draft is harmless.
+func (s *chatService) clearDraft(ctx context.Context, userID, chatID uuid.UUID, threadID *uuid.UUID) {
+	_ = s.DeleteDraft(ctx, userID, chatID, threadID)
+}
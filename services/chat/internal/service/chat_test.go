@@ -0,0 +1,969 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/events"
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+	"github.com/icegreg/chat-smpl/services/chat/internal/repository"
+)
+
+// MockChatRepository is a mock implementation of ChatRepository. Only the methods used by the
+// tests are mocked; calling any other method panics on the nil embedded interface.
+type MockChatRepository struct {
+	mock.Mock
+	repository.ChatRepository
+}
+
+func (m *MockChatRepository) IsParticipant(ctx context.Context, chatID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, chatID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) ClaimDueReminders(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.Reminder, error) {
+	args := m.Called(ctx, now, lease, limit)
+	return args.Get(0).([]model.Reminder), args.Error(1)
+}
+
+func (m *MockChatRepository) MarkReminderDelivered(ctx context.Context, id uuid.UUID, deliveredAt time.Time) error {
+	return m.Called(ctx, id, deliveredAt).Error(0)
+}
+
+func (m *MockChatRepository) GetReminder(ctx context.Context, id uuid.UUID) (*model.Reminder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reminder), args.Error(1)
+}
+
+func (m *MockChatRepository) DeleteReminder(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockChatRepository) ListReminders(ctx context.Context, userID uuid.UUID, chatID *uuid.UUID, deliveredSince *time.Time) ([]model.Reminder, error) {
+	args := m.Called(ctx, userID, chatID, deliveredSince)
+	return args.Get(0).([]model.Reminder), args.Error(1)
+}
+
+func (m *MockChatRepository) GetThread(ctx context.Context, id uuid.UUID) (*model.Thread, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Thread), args.Error(1)
+}
+
+func (m *MockChatRepository) IsThreadParticipant(ctx context.Context, threadID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, threadID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) UpsertDraft(ctx context.Context, draft *model.Draft) error {
+	return m.Called(ctx, draft).Error(0)
+}
+
+func (m *MockChatRepository) GetParticipant(ctx context.Context, chatID, userID uuid.UUID) (*model.ChatParticipant, error) {
+	args := m.Called(ctx, chatID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ChatParticipant), args.Error(1)
+}
+
+func (m *MockChatRepository) GetParticipantIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, chatID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) GetMessagesById(ctx context.Context, ids []uuid.UUID) ([]model.Message, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]model.Message), args.Error(1)
+}
+
+func (m *MockChatRepository) MoveMessagesToNewThread(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, thread *model.Thread) (map[uuid.UUID]*uuid.UUID, error) {
+	args := m.Called(ctx, chatID, messageIDs, thread)
+	moved, _ := args.Get(0).(map[uuid.UUID]*uuid.UUID)
+	return moved, args.Error(1)
+}
+
+func (m *MockChatRepository) FollowThread(ctx context.Context, threadID, userID uuid.UUID) error {
+	return m.Called(ctx, threadID, userID).Error(0)
+}
+
+func (m *MockChatRepository) GetSystemThread(ctx context.Context, chatID uuid.UUID) (*model.Thread, error) {
+	args := m.Called(ctx, chatID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Thread), args.Error(1)
+}
+
+func (m *MockChatRepository) GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Message), args.Error(1)
+}
+
+func (m *MockChatRepository) GetActiveChatRestriction(ctx context.Context, chatID, userID uuid.UUID, restrictionType model.RestrictionType) (*model.ChatRestriction, error) {
+	args := m.Called(ctx, chatID, userID, restrictionType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ChatRestriction), args.Error(1)
+}
+
+func (m *MockChatRepository) CustomEmojiExists(ctx context.Context, chatID uuid.UUID, name string) (bool, error) {
+	args := m.Called(ctx, chatID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) AddReactionWithinLimit(ctx context.Context, reaction *model.Reaction, limit int) (bool, error) {
+	args := m.Called(ctx, reaction, limit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) BulkDeleteMessages(ctx context.Context, chatID uuid.UUID, ids []uuid.UUID, deletedBy uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, chatID, ids, deletedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) GetMessageIDsBySender(ctx context.Context, chatID, senderID uuid.UUID, from, to *time.Time, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, chatID, senderID, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) GetUserRole(ctx context.Context, userID uuid.UUID) (model.UserRole, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(model.UserRole), args.Error(1)
+}
+
+func (m *MockChatRepository) GetGlobalModeratorIDs(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) GetChatModeratorIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, chatID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatRepository) CreateMessageReport(ctx context.Context, report *model.MessageReport) error {
+	return m.Called(ctx, report).Error(0)
+}
+
+func (m *MockChatRepository) GetMessageReport(ctx context.Context, id uuid.UUID) (*model.MessageReport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MessageReport), args.Error(1)
+}
+
+func (m *MockChatRepository) ListMessageReports(ctx context.Context, chatID *uuid.UUID, status model.ReportStatus, page, count int) ([]model.MessageReport, int, error) {
+	args := m.Called(ctx, chatID, status, page, count)
+	return args.Get(0).([]model.MessageReport), args.Int(1), args.Error(2)
+}
+
+func (m *MockChatRepository) ResolveMessageReports(ctx context.Context, messageID uuid.UUID, action model.ReportAction, resolvedBy uuid.UUID) (int64, error) {
+	args := m.Called(ctx, messageID, action, resolvedBy)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChatRepository) AddModerationAuditEntry(ctx context.Context, entry *model.ModerationAuditEntry) error {
+	return m.Called(ctx, entry).Error(0)
+}
+
+func (m *MockChatRepository) UpdateParticipantRole(ctx context.Context, chatID, userID uuid.UUID, role model.ParticipantRole) error {
+	return m.Called(ctx, chatID, userID, role).Error(0)
+}
+
+func (m *MockChatRepository) CreateChatRestriction(ctx context.Context, restriction *model.ChatRestriction) error {
+	return m.Called(ctx, restriction).Error(0)
+}
+
+func (m *MockChatRepository) LiftChatRestriction(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id, liftedBy)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) GetExpiredChatRestrictions(ctx context.Context, now time.Time, limit int) ([]model.ChatRestriction, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.ChatRestriction), args.Error(1)
+}
+
+// MockPublisher records the events the tests care about and ignores the others
+type MockPublisher struct {
+	mock.Mock
+	events.NoOpPublisher
+}
+
+func (m *MockPublisher) PublishEphemeralMessage(ctx context.Context, message *model.Message, userID uuid.UUID) error {
+	return m.Called(ctx, message, userID).Error(0)
+}
+
+func (m *MockPublisher) PublishDraftUpdated(ctx context.Context, draft *model.Draft, deleted bool) error {
+	return m.Called(ctx, draft, deleted).Error(0)
+}
+
+func (m *MockPublisher) PublishThreadCreated(ctx context.Context, thread *model.Thread, participants []uuid.UUID) error {
+	return m.Called(ctx, thread, participants).Error(0)
+}
+
+func (m *MockPublisher) PublishMessagesMoved(ctx context.Context, chatID, threadID, movedBy uuid.UUID, moved map[uuid.UUID]*uuid.UUID, participants []uuid.UUID) error {
+	return m.Called(ctx, chatID, threadID, movedBy, moved, participants).Error(0)
+}
+
+func (m *MockPublisher) PublishReactionAdded(ctx context.Context, messageID, chatID, userID uuid.UUID, emoji string, participants []uuid.UUID) error {
+	return m.Called(ctx, messageID, chatID, userID, emoji, participants).Error(0)
+}
+
+func (m *MockPublisher) PublishMessagesBulkDeleted(ctx context.Context, chatID, deletedBy uuid.UUID, senderID *uuid.UUID, messageIDs []uuid.UUID, participants []uuid.UUID) error {
+	return m.Called(ctx, chatID, deletedBy, senderID, messageIDs, participants).Error(0)
+}
+
+func (m *MockPublisher) PublishReportCreated(ctx context.Context, report *model.MessageReport, moderators []uuid.UUID) error {
+	return m.Called(ctx, report, moderators).Error(0)
+}
+
+func newTestService(repo *MockChatRepository, pub *MockPublisher) *chatService {
+	return NewChatService(repo, pub, nil, nil).(*chatService)
+}
+
+func TestDeliverDueReminders(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	messageID := uuid.New()
+	reminder := model.Reminder{ID: uuid.New(), ChatID: uuid.New(), UserID: uuid.New(), MessageID: &messageID, RemindAt: now}
+
+	tests := []struct {
+		name        string
+		participant bool
+		publishErr  error
+		delivered   bool
+		dropped     bool
+	}{
+		{name: "published reminder is marked delivered", participant: true, delivered: true},
+		{name: "failed publish is left for the next claim", participant: true, publishErr: errors.New("broker down")},
+		{name: "reminder of a former participant is dropped", dropped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			pub := &MockPublisher{}
+			repo.On("ClaimDueReminders", ctx, now, reminderDeliveryLease, deliverRemindersBatchSize).Return([]model.Reminder{reminder}, nil)
+			repo.On("IsParticipant", ctx, reminder.ChatID, reminder.UserID).Return(tt.participant, nil)
+			if tt.participant {
+				pub.On("PublishEphemeralMessage", ctx, mock.MatchedBy(func(m *model.Message) bool {
+					return m.IsSystem && m.IsEphemeral && len(m.ReplyToIDs) == 1 && m.ReplyToIDs[0] == messageID
+				}), reminder.UserID).Return(tt.publishErr)
+			}
+			if tt.delivered {
+				repo.On("MarkReminderDelivered", ctx, reminder.ID, now).Return(nil)
+			}
+			if tt.dropped {
+				repo.On("DeleteReminder", ctx, reminder.ID).Return(nil)
+			}
+
+			count, err := newTestService(repo, pub).DeliverDueReminders(ctx, now)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+			if !tt.delivered {
+				repo.AssertNotCalled(t, "MarkReminderDelivered", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestListReminders_IncludeDelivered(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo := &MockChatRepository{}
+	repo.On("ListReminders", ctx, userID, (*uuid.UUID)(nil), (*time.Time)(nil)).Return([]model.Reminder{}, nil).Once()
+	repo.On("ListReminders", ctx, userID, (*uuid.UUID)(nil), mock.MatchedBy(func(since *time.Time) bool {
+		return since != nil && time.Since(*since) >= deliveredReminderRetention-time.Minute
+	})).Return([]model.Reminder{}, nil).Once()
+	s := newTestService(repo, &MockPublisher{})
+
+	_, err := s.ListReminders(ctx, userID, nil, false)
+	require.NoError(t, err)
+	_, err = s.ListReminders(ctx, userID, nil, true)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCancelReminder(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	delivered := time.Now()
+
+	tests := []struct {
+		name     string
+		reminder *model.Reminder
+		wantErr  error
+	}{
+		{name: "pending reminder", reminder: &model.Reminder{ID: uuid.New(), UserID: userID}},
+		{name: "fired reminder is dismissed", reminder: &model.Reminder{ID: uuid.New(), UserID: userID, DeliveredAt: &delivered}},
+		{name: "reminder of another user", reminder: &model.Reminder{ID: uuid.New(), UserID: uuid.New()}, wantErr: repository.ErrReminderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			repo.On("GetReminder", ctx, tt.reminder.ID).Return(tt.reminder, nil)
+			if tt.wantErr == nil {
+				repo.On("DeleteReminder", ctx, tt.reminder.ID).Return(nil)
+			}
+
+			err := newTestService(repo, &MockPublisher{}).CancelReminder(ctx, tt.reminder.ID, userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSaveDraft(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	chatID := uuid.New()
+	threadID := uuid.New()
+
+	tests := []struct {
+		name              string
+		participant       bool
+		thread            *model.Thread
+		threadParticipant bool
+		content           string
+		replyToIDs        []uuid.UUID
+		wantErr           error
+	}{
+		{name: "saved", participant: true, content: "hello"},
+		{name: "too long", participant: true, content: strings.Repeat("я", maxDraftLength+1), wantErr: ErrInvalidDraft},
+		{name: "too many quotes", participant: true, content: "hello", replyToIDs: make([]uuid.UUID, maxDraftReplies+1), wantErr: ErrInvalidDraft},
+		{name: "not a participant", content: "hello", wantErr: ErrNotParticipant},
+		{
+			name:        "thread of another chat",
+			participant: true,
+			thread:      &model.Thread{ID: threadID, ChatID: uuid.New()},
+			content:     "hello",
+			wantErr:     repository.ErrThreadNotFound,
+		},
+		{
+			name:        "restricted thread",
+			participant: true,
+			thread:      &model.Thread{ID: threadID, ChatID: chatID, RestrictedParticipants: true},
+			content:     "hello",
+			wantErr:     ErrAccessDenied,
+		},
+		{
+			name:              "restricted thread participant",
+			participant:       true,
+			thread:            &model.Thread{ID: threadID, ChatID: chatID, RestrictedParticipants: true},
+			threadParticipant: true,
+			content:           "hello",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			pub := &MockPublisher{}
+			repo.On("IsParticipant", ctx, chatID, userID).Return(tt.participant, nil)
+			var draftThreadID *uuid.UUID
+			if tt.thread != nil {
+				draftThreadID = &threadID
+				repo.On("GetThread", ctx, threadID).Return(tt.thread, nil)
+				repo.On("IsThreadParticipant", ctx, threadID, userID).Return(tt.threadParticipant, nil).Maybe()
+			}
+			if tt.wantErr == nil {
+				repo.On("UpsertDraft", ctx, mock.AnythingOfType("*model.Draft")).Return(nil)
+				pub.On("PublishDraftUpdated", ctx, mock.AnythingOfType("*model.Draft"), false).Return(nil)
+			}
+
+			draft, err := newTestService(repo, pub).SaveDraft(ctx, userID, chatID, draftThreadID, tt.content, tt.replyToIDs)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, draft)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.content, draft.Content)
+			}
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveMessagesToThread_NewThread(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	adminID := uuid.New()
+	messageID := uuid.New()
+	messageIDs := []uuid.UUID{messageID}
+	participants := []uuid.UUID{adminID}
+	moveFailed := errors.New("move failed")
+
+	tests := []struct {
+		name    string
+		moved   map[uuid.UUID]*uuid.UUID
+		moveErr error
+		wantErr error
+	}{
+		{name: "moved", moved: map[uuid.UUID]*uuid.UUID{messageID: nil}},
+		{name: "failed move", moveErr: moveFailed, wantErr: moveFailed},
+		{name: "nothing moved", moved: map[uuid.UUID]*uuid.UUID{}, wantErr: ErrInvalidMove},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			pub := &MockPublisher{}
+			threadID := uuid.New()
+
+			repo.On("GetParticipant", ctx, chatID, adminID).Return(&model.ChatParticipant{Role: model.ParticipantRoleAdmin}, nil)
+			repo.On("GetMessagesById", ctx, messageIDs).Return([]model.Message{{ID: messageID, ChatID: chatID}}, nil)
+			repo.On("MoveMessagesToNewThread", ctx, chatID, messageIDs, mock.MatchedBy(func(thread *model.Thread) bool {
+				return thread.ChatID == chatID && *thread.Title == "Triage" && *thread.CreatedBy == adminID
+			})).Run(func(args mock.Arguments) {
+				args.Get(3).(*model.Thread).ID = threadID
+			}).Return(tt.moved, tt.moveErr)
+
+			// thread.created is only published after a successful move; unexpected calls fail the test
+			if tt.wantErr == nil {
+				created := &model.Thread{ID: threadID, ChatID: chatID, MessageCount: 1}
+				repo.On("FollowThread", ctx, threadID, adminID).Return(nil)
+				repo.On("GetThread", ctx, threadID).Return(created, nil)
+				repo.On("GetParticipantIDs", ctx, chatID).Return(participants, nil)
+				// The Activity thread message is best effort
+				repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable"))
+				pub.On("PublishThreadCreated", ctx, created, participants).Return(nil)
+				pub.On("PublishMessagesMoved", ctx, chatID, threadID, adminID, tt.moved, participants).Return(nil)
+			}
+
+			thread, moved, err := newTestService(repo, pub).MoveMessagesToThread(ctx, chatID, messageIDs, nil, "Triage", adminID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, thread)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, threadID, thread.ID)
+				assert.Equal(t, 1, moved)
+			}
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddReaction(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	message := &model.Message{ID: uuid.New(), ChatID: uuid.New()}
+	participants := []uuid.UUID{userID}
+
+	tests := []struct {
+		name        string
+		reaction    string
+		customEmoji *bool // Result of the custom emoji lookup, nil when not looked up
+		underCap    bool
+		wantErr     error
+	}{
+		{name: "unicode emoji", reaction: "👍🏽", underCap: true},
+		{name: "text", reaction: "ok", wantErr: ErrInvalidReaction},
+		{name: "custom emoji of the chat", reaction: ":parrot:", customEmoji: ptr(true), underCap: true},
+		{name: "unknown custom emoji", reaction: ":parrot:", customEmoji: ptr(false), wantErr: ErrInvalidReaction},
+		{name: "per-user cap reached", reaction: "🎉", underCap: false, wantErr: ErrInvalidReaction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			pub := &MockPublisher{}
+			repo.On("GetMessage", ctx, message.ID).Return(message, nil)
+			repo.On("IsParticipant", ctx, message.ChatID, userID).Return(true, nil)
+			repo.On("GetActiveChatRestriction", ctx, message.ChatID, userID, model.RestrictionTypeMute).
+				Return(nil, repository.ErrRestrictionNotFound)
+			if tt.customEmoji != nil {
+				repo.On("CustomEmojiExists", ctx, message.ChatID, "parrot").Return(*tt.customEmoji, nil)
+			}
+			validEmoji := tt.customEmoji == nil || *tt.customEmoji
+			if validEmoji && tt.reaction != "ok" {
+				repo.On("AddReactionWithinLimit", ctx, mock.MatchedBy(func(r *model.Reaction) bool {
+					return r.MessageID == message.ID && r.UserID == userID && r.Reaction == tt.reaction
+				}), maxReactionsPerUser).Return(tt.underCap, nil)
+			}
+			if tt.wantErr == nil {
+				repo.On("GetParticipantIDs", ctx, message.ChatID).Return(participants, nil)
+				pub.On("PublishReactionAdded", ctx, message.ID, message.ChatID, userID, tt.reaction, participants).Return(nil)
+			}
+
+			err := newTestService(repo, pub).AddReaction(ctx, message.ID, userID, tt.reaction)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+		})
+	}
+}
+
+func newUUIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestBulkDeleteMessages_Rejected(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, senderID := uuid.New(), uuid.New(), uuid.New()
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		role       *model.ParticipantRole // nil when the caller is not a participant
+		messageIDs []uuid.UUID
+		senderID   *uuid.UUID
+		wantErr    error
+	}{
+		{name: "not a participant", messageIDs: newUUIDs(1), wantErr: ErrNotParticipant},
+		{name: "member", role: ptr(model.ParticipantRoleMember), messageIDs: newUUIDs(1), wantErr: ErrAccessDenied},
+		{name: "nothing selected", role: ptr(model.ParticipantRoleAdmin), wantErr: ErrInvalidBulkDelete},
+		{name: "too many messages", role: ptr(model.ParticipantRoleAdmin), messageIDs: newUUIDs(maxBulkDeleteMessages + 1), wantErr: ErrInvalidBulkDelete},
+		{name: "time window end before start", role: ptr(model.ParticipantRoleAdmin), senderID: &senderID, wantErr: ErrInvalidBulkDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			if tt.role == nil {
+				repo.On("GetParticipant", ctx, chatID, moderatorID).Return(nil, repository.ErrParticipantNotFound)
+			} else {
+				repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: *tt.role}, nil)
+			}
+
+			_, err := newTestService(repo, &MockPublisher{}).BulkDeleteMessages(ctx, chatID, moderatorID, tt.messageIDs, tt.senderID, &from, &to)
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkDeleteMessages_ByIDs(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID := uuid.New(), uuid.New()
+	messageIDs := newUUIDs(bulkDeleteBatchSize + 1)
+	participants := []uuid.UUID{moderatorID}
+
+	repo := &MockChatRepository{}
+	pub := &MockPublisher{}
+	repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleAdmin}, nil)
+	// The list is deleted in batches; an already deleted message is skipped
+	repo.On("BulkDeleteMessages", ctx, chatID, messageIDs[:bulkDeleteBatchSize], moderatorID).Return(messageIDs[1:bulkDeleteBatchSize], nil).Once()
+	repo.On("BulkDeleteMessages", ctx, chatID, messageIDs[bulkDeleteBatchSize:], moderatorID).Return(messageIDs[bulkDeleteBatchSize:], nil).Once()
+	repo.On("GetParticipantIDs", ctx, chatID).Return(participants, nil)
+	repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable"))
+	deleted := append(append([]uuid.UUID{}, messageIDs[1:bulkDeleteBatchSize]...), messageIDs[bulkDeleteBatchSize:]...)
+	pub.On("PublishMessagesBulkDeleted", ctx, chatID, moderatorID, (*uuid.UUID)(nil), deleted, participants).Return(nil).Once()
+
+	result, err := newTestService(repo, pub).BulkDeleteMessages(ctx, chatID, moderatorID, messageIDs, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, deleted, result.DeletedIDs)
+	assert.True(t, result.RestorableUntil.After(time.Now()))
+	repo.AssertExpectations(t)
+	pub.AssertExpectations(t)
+}
+
+func TestPurgeUserMessages(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, targetID := uuid.New(), uuid.New(), uuid.New()
+	first, second := newUUIDs(bulkDeleteBatchSize), newUUIDs(3)
+	participants := []uuid.UUID{moderatorID}
+
+	repo := &MockChatRepository{}
+	pub := &MockPublisher{}
+	repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleAdmin}, nil)
+	// The purged user has left the chat
+	repo.On("GetParticipant", ctx, chatID, targetID).Return(nil, repository.ErrParticipantNotFound)
+	repo.On("GetMessageIDsBySender", ctx, chatID, targetID, (*time.Time)(nil), (*time.Time)(nil), bulkDeleteBatchSize).Return(first, nil).Once()
+	repo.On("GetMessageIDsBySender", ctx, chatID, targetID, (*time.Time)(nil), (*time.Time)(nil), bulkDeleteBatchSize).Return(second, nil).Once()
+	repo.On("BulkDeleteMessages", ctx, chatID, first, moderatorID).Return(first, nil).Once()
+	repo.On("BulkDeleteMessages", ctx, chatID, second, moderatorID).Return(second, nil).Once()
+	repo.On("GetParticipantIDs", ctx, chatID).Return(participants, nil)
+	repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable"))
+	deleted := append(append([]uuid.UUID{}, first...), second...)
+	pub.On("PublishMessagesBulkDeleted", ctx, chatID, moderatorID, &targetID, deleted, participants).Return(nil).Once()
+
+	result, err := newTestService(repo, pub).PurgeUserMessages(ctx, chatID, targetID, moderatorID)
+	require.NoError(t, err)
+	assert.Len(t, result.DeletedIDs, bulkDeleteBatchSize+3)
+	repo.AssertExpectations(t)
+	pub.AssertExpectations(t)
+
+	t.Run("member cannot purge", func(t *testing.T) {
+		repo := &MockChatRepository{}
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleMember}, nil)
+
+		_, err := newTestService(repo, &MockPublisher{}).PurgeUserMessages(ctx, chatID, targetID, moderatorID)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestReportMessage(t *testing.T) {
+	ctx := context.Background()
+	chatID, senderID, reporterID, adminID, globalModID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		reason    model.ReportReason
+		message   model.Message
+		member    bool
+		createErr error
+		wantErr   error
+	}{
+		{name: "reported", reason: model.ReportReasonSpam, member: true},
+		{name: "unknown reason", reason: "boring", wantErr: ErrInvalidReport},
+		{name: "not a participant", reason: model.ReportReasonSpam, wantErr: ErrNotParticipant},
+		{name: "own message", reason: model.ReportReasonSpam, message: model.Message{SenderID: reporterID}, member: true, wantErr: ErrInvalidReport},
+		{name: "system message", reason: model.ReportReasonSpam, message: model.Message{IsSystem: true}, member: true, wantErr: ErrInvalidReport},
+		{name: "deleted message", reason: model.ReportReasonSpam, message: model.Message{IsDeleted: true}, member: true, wantErr: ErrInvalidReport},
+		{name: "reported twice", reason: model.ReportReasonAbuse, member: true, createErr: repository.ErrAlreadyExists, wantErr: ErrAlreadyReported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.message
+			message.ID, message.ChatID = uuid.New(), chatID
+			if message.SenderID == uuid.Nil {
+				message.SenderID = senderID
+			}
+
+			repo := &MockChatRepository{}
+			pub := &MockPublisher{}
+			if tt.reason.IsValid() {
+				repo.On("GetMessage", ctx, message.ID).Return(&message, nil)
+				repo.On("IsParticipant", ctx, chatID, reporterID).Return(tt.member, nil)
+			}
+			validMessage := tt.member && !message.IsSystem && !message.IsDeleted && message.SenderID != reporterID
+			if validMessage {
+				repo.On("CreateMessageReport", ctx, mock.MatchedBy(func(r *model.MessageReport) bool {
+					return r.MessageID == message.ID && r.SenderID == senderID && r.ReporterID == reporterID && r.Reason == tt.reason
+				})).Return(tt.createErr)
+			}
+			if tt.wantErr == nil {
+				repo.On("AddModerationAuditEntry", ctx, mock.Anything).Return(nil)
+				repo.On("GetChatModeratorIDs", ctx, chatID).Return([]uuid.UUID{adminID, senderID}, nil)
+				repo.On("GetGlobalModeratorIDs", ctx).Return([]uuid.UUID{globalModID, adminID}, nil)
+				// Moderators are notified once each, the reported sender never is
+				pub.On("PublishReportCreated", ctx, mock.Anything, []uuid.UUID{adminID, globalModID}).Return(nil)
+			}
+
+			report, err := newTestService(repo, pub).ReportMessage(ctx, message.ID, reporterID, tt.reason, "")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, message.ID, report.MessageID)
+				assert.Nil(t, report.Comment)
+			}
+			repo.AssertExpectations(t)
+			pub.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListReports_Access(t *testing.T) {
+	ctx := context.Background()
+	chatID, userID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		chatID   *uuid.UUID
+		role     *model.ParticipantRole // nil when the user is not a participant
+		userRole model.UserRole
+		allowed  bool
+	}{
+		{name: "chat admin, chat queue", chatID: &chatID, role: ptr(model.ParticipantRoleAdmin), allowed: true},
+		{name: "global moderator, chat queue", chatID: &chatID, userRole: model.UserRoleModerator, allowed: true},
+		{name: "member, chat queue", chatID: &chatID, role: ptr(model.ParticipantRoleMember), userRole: model.UserRoleUser},
+		{name: "global moderator, global queue", userRole: model.UserRoleOwner, allowed: true},
+		{name: "user, global queue", userRole: model.UserRoleUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			if tt.chatID != nil {
+				if tt.role == nil {
+					repo.On("GetParticipant", ctx, chatID, userID).Return(nil, repository.ErrParticipantNotFound)
+				} else {
+					repo.On("GetParticipant", ctx, chatID, userID).Return(&model.ChatParticipant{ChatID: chatID, UserID: userID, Role: *tt.role}, nil)
+				}
+			}
+			if tt.userRole != "" {
+				repo.On("GetUserRole", ctx, userID).Return(tt.userRole, nil)
+			}
+			if tt.allowed {
+				repo.On("ListMessageReports", ctx, tt.chatID, model.ReportStatusOpen, 1, 20).Return([]model.MessageReport{}, 0, nil)
+			}
+
+			_, _, err := newTestService(repo, &MockPublisher{}).ListReports(ctx, userID, tt.chatID, model.ReportStatusOpen, 1, 20)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAccessDenied)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, senderID := uuid.New(), uuid.New(), uuid.New()
+	admin := &model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleAdmin}
+
+	newReport := func(status model.ReportStatus) *model.MessageReport {
+		return &model.MessageReport{ID: uuid.New(), ChatID: chatID, MessageID: uuid.New(), SenderID: senderID, Status: status}
+	}
+
+	t.Run("unknown action", func(t *testing.T) {
+		_, err := newTestService(&MockChatRepository{}, &MockPublisher{}).ResolveReport(ctx, uuid.New(), moderatorID, "ban", "")
+		assert.ErrorIs(t, err, ErrInvalidReport)
+	})
+
+	t.Run("member cannot resolve", func(t *testing.T) {
+		report := newReport(model.ReportStatusOpen)
+		repo := &MockChatRepository{}
+		repo.On("GetMessageReport", ctx, report.ID).Return(report, nil)
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleMember}, nil)
+		repo.On("GetUserRole", ctx, moderatorID).Return(model.UserRoleUser, nil)
+
+		_, err := newTestService(repo, &MockPublisher{}).ResolveReport(ctx, report.ID, moderatorID, model.ReportActionDismiss, "")
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("already resolved", func(t *testing.T) {
+		report := newReport(model.ReportStatusResolved)
+		repo := &MockChatRepository{}
+		repo.On("GetMessageReport", ctx, report.ID).Return(report, nil)
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(admin, nil)
+
+		_, err := newTestService(repo, &MockPublisher{}).ResolveReport(ctx, report.ID, moderatorID, model.ReportActionDismiss, "")
+		assert.ErrorIs(t, err, ErrReportResolved)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("chat admin cannot restrict another admin", func(t *testing.T) {
+		report := newReport(model.ReportStatusOpen)
+		repo := &MockChatRepository{}
+		repo.On("GetMessageReport", ctx, report.ID).Return(report, nil)
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(admin, nil)
+		repo.On("GetParticipant", ctx, chatID, senderID).Return(&model.ChatParticipant{ChatID: chatID, UserID: senderID, Role: model.ParticipantRoleAdmin}, nil)
+		repo.On("GetUserRole", ctx, moderatorID).Return(model.UserRoleUser, nil)
+
+		_, err := newTestService(repo, &MockPublisher{}).ResolveReport(ctx, report.ID, moderatorID, model.ReportActionRestrict, "")
+		assert.ErrorIs(t, err, ErrAccessDenied)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dismissed", func(t *testing.T) {
+		report := newReport(model.ReportStatusOpen)
+		resolved := *report
+		resolved.Status = model.ReportStatusResolved
+		repo := &MockChatRepository{}
+		repo.On("GetMessageReport", ctx, report.ID).Return(report, nil).Once()
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(admin, nil)
+		repo.On("AddModerationAuditEntry", ctx, mock.MatchedBy(func(e *model.ModerationAuditEntry) bool {
+			return e.Action == model.ModerationActionReportDismissed && e.ActorID == moderatorID && *e.ReportID == report.ID
+		})).Return(nil)
+		repo.On("ResolveMessageReports", ctx, report.MessageID, model.ReportActionDismiss, moderatorID).Return(int64(2), nil)
+		repo.On("GetMessageReport", ctx, report.ID).Return(&resolved, nil).Once()
+
+		got, err := newTestService(repo, &MockPublisher{}).ResolveReport(ctx, report.ID, moderatorID, model.ReportActionDismiss, "not spam")
+		require.NoError(t, err)
+		assert.Equal(t, model.ReportStatusResolved, got.Status)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestBanUser_Rejected(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, targetID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name          string
+		targetID      uuid.UUID
+		moderatorRole model.ParticipantRole
+		targetRole    *model.ParticipantRole // nil when the target is not a participant
+		userRole      model.UserRole         // Global role of the moderator, if looked up
+		duration      time.Duration
+		createErr     error
+		wantErr       error
+	}{
+		{name: "yourself", targetID: moderatorID, wantErr: ErrInvalidRestriction},
+		{name: "member", targetID: targetID, moderatorRole: model.ParticipantRoleMember, userRole: model.UserRoleUser, wantErr: ErrAccessDenied},
+		{name: "chat admin bans another admin", targetID: targetID, moderatorRole: model.ParticipantRoleAdmin, targetRole: ptr(model.ParticipantRoleAdmin), userRole: model.UserRoleUser, wantErr: ErrAccessDenied},
+		{name: "negative duration", targetID: targetID, moderatorRole: model.ParticipantRoleAdmin, duration: -time.Hour, wantErr: ErrInvalidRestriction},
+		{name: "longer than a year", targetID: targetID, moderatorRole: model.ParticipantRoleAdmin, duration: maxRestrictionDuration + time.Hour, wantErr: ErrInvalidRestriction},
+		{name: "already banned", targetID: targetID, moderatorRole: model.ParticipantRoleAdmin, createErr: repository.ErrAlreadyExists, wantErr: ErrAlreadyRestricted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockChatRepository{}
+			if tt.targetID != moderatorID {
+				repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: tt.moderatorRole}, nil)
+				if tt.moderatorRole.CanModerate() {
+					if tt.targetRole == nil {
+						repo.On("GetParticipant", ctx, chatID, targetID).Return(nil, repository.ErrParticipantNotFound)
+					} else {
+						repo.On("GetParticipant", ctx, chatID, targetID).Return(&model.ChatParticipant{ChatID: chatID, UserID: targetID, Role: *tt.targetRole}, nil)
+					}
+				}
+			}
+			if tt.userRole != "" {
+				repo.On("GetUserRole", ctx, moderatorID).Return(tt.userRole, nil)
+			}
+			if tt.createErr != nil {
+				repo.On("CreateChatRestriction", ctx, mock.Anything).Return(tt.createErr)
+			}
+
+			_, err := newTestService(repo, &MockPublisher{}).BanUser(ctx, chatID, tt.targetID, moderatorID, "", tt.duration)
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBanUser_InAdvance(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, targetID := uuid.New(), uuid.New(), uuid.New()
+
+	repo := &MockChatRepository{}
+	repo.On("GetParticipant", ctx, chatID, moderatorID).Return(&model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleAdmin}, nil)
+	repo.On("GetParticipant", ctx, chatID, targetID).Return(nil, repository.ErrParticipantNotFound)
+	repo.On("CreateChatRestriction", ctx, mock.MatchedBy(func(r *model.ChatRestriction) bool {
+		return r.UserID == targetID && r.Type == model.RestrictionTypeBan && r.CreatedBy == moderatorID
+	})).Return(nil)
+	repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable"))
+	repo.On("AddModerationAuditEntry", ctx, mock.MatchedBy(func(e *model.ModerationAuditEntry) bool {
+		return e.Action == model.ModerationActionUserBanned && *e.TargetUserID == targetID
+	})).Return(nil)
+
+	ban, err := newTestService(repo, &MockPublisher{}).BanUser(ctx, chatID, targetID, moderatorID, "spam", 0)
+	require.NoError(t, err)
+	assert.Nil(t, ban.ExpiresAt, "duration 0 is permanent")
+	require.NotNil(t, ban.Reason)
+	assert.Equal(t, "spam", *ban.Reason)
+	repo.AssertExpectations(t)
+}
+
+func TestAddParticipant_Banned(t *testing.T) {
+	ctx := context.Background()
+	chatID, adminID, userID := uuid.New(), uuid.New(), uuid.New()
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	repo := &MockChatRepository{}
+	repo.On("GetParticipant", ctx, chatID, adminID).Return(&model.ChatParticipant{ChatID: chatID, UserID: adminID, Role: model.ParticipantRoleAdmin}, nil)
+	repo.On("GetActiveChatRestriction", ctx, chatID, userID, model.RestrictionTypeBan).
+		Return(&model.ChatRestriction{ChatID: chatID, UserID: userID, Type: model.RestrictionTypeBan, ExpiresAt: &expiresAt}, nil)
+
+	_, err := newTestService(repo, &MockPublisher{}).AddParticipant(ctx, chatID, userID, adminID, model.ParticipantRoleMember)
+	assert.ErrorIs(t, err, ErrUserBanned)
+	assert.Contains(t, err.Error(), "until 2030-01-02T03:04:05Z")
+	repo.AssertExpectations(t)
+}
+
+func TestMuteUser(t *testing.T) {
+	ctx := context.Background()
+	chatID, moderatorID, targetID := uuid.New(), uuid.New(), uuid.New()
+	moderator := &model.ChatParticipant{ChatID: chatID, UserID: moderatorID, Role: model.ParticipantRoleAdmin}
+
+	t.Run("duration is required", func(t *testing.T) {
+		_, err := newTestService(&MockChatRepository{}, &MockPublisher{}).MuteUser(ctx, chatID, targetID, moderatorID, "", 0)
+		assert.ErrorIs(t, err, ErrInvalidRestriction)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		repo := &MockChatRepository{}
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(moderator, nil)
+		repo.On("GetParticipant", ctx, chatID, targetID).Return(nil, repository.ErrParticipantNotFound)
+
+		_, err := newTestService(repo, &MockPublisher{}).MuteUser(ctx, chatID, targetID, moderatorID, "", time.Hour)
+		assert.ErrorIs(t, err, ErrNotParticipant)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("muted", func(t *testing.T) {
+		repo := &MockChatRepository{}
+		repo.On("GetParticipant", ctx, chatID, moderatorID).Return(moderator, nil)
+		repo.On("GetParticipant", ctx, chatID, targetID).Return(&model.ChatParticipant{ChatID: chatID, UserID: targetID, Role: model.ParticipantRoleMember}, nil)
+		repo.On("CreateChatRestriction", ctx, mock.MatchedBy(func(r *model.ChatRestriction) bool {
+			return r.Type == model.RestrictionTypeMute && *r.PreviousRole == model.ParticipantRoleMember
+		})).Return(nil)
+		repo.On("UpdateParticipantRole", ctx, chatID, targetID, model.ParticipantRoleReadonly).Return(nil)
+		repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable"))
+		repo.On("AddModerationAuditEntry", ctx, mock.Anything).Return(nil)
+
+		mute, err := newTestService(repo, &MockPublisher{}).MuteUser(ctx, chatID, targetID, moderatorID, "", time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, mute.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *mute.ExpiresAt, time.Minute)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestExpireRestrictions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	chatID := uuid.New()
+	previousRole := model.ParticipantRoleMember
+	mute := model.ChatRestriction{ID: uuid.New(), ChatID: chatID, UserID: uuid.New(), Type: model.RestrictionTypeMute, PreviousRole: &previousRole}
+	ban := model.ChatRestriction{ID: uuid.New(), ChatID: chatID, UserID: uuid.New(), Type: model.RestrictionTypeBan}
+
+	repo := &MockChatRepository{}
+	repo.On("GetExpiredChatRestrictions", ctx, now, expireRestrictionsBatchSize).Return([]model.ChatRestriction{mute, ban}, nil)
+	repo.On("LiftChatRestriction", ctx, mute.ID, (*uuid.UUID)(nil)).Return(true, nil)
+	// Lifted by another replica in the meantime
+	repo.On("LiftChatRestriction", ctx, ban.ID, (*uuid.UUID)(nil)).Return(false, nil)
+	repo.On("GetParticipant", ctx, chatID, mute.UserID).Return(&model.ChatParticipant{ChatID: chatID, UserID: mute.UserID, Role: model.ParticipantRoleReadonly}, nil)
+	repo.On("UpdateParticipantRole", ctx, chatID, mute.UserID, model.ParticipantRoleMember).Return(nil)
+	repo.On("GetSystemThread", ctx, chatID).Return(nil, errors.New("unavailable")).Once()
+
+	count, err := newTestService(repo, &MockPublisher{}).ExpireRestrictions(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	repo.AssertExpectations(t)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
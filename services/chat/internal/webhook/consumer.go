@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
+	"github.com/icegreg/chat-smpl/services/chat/internal/events"
+	"go.uber.org/zap"
+)
+
+const (
+	QueueName    = "chat.webhooks"
+	ConsumerName = "chat-service-webhooks"
+)
+
+// Consumer queues a delivery for every subscription matching an event published on chat.events
+type Consumer struct {
+	rmqConn *rabbitmq.Connection
+	store   Store
+}
+
+func NewConsumer(rmqConn *rabbitmq.Connection, store Store) *Consumer {
+	return &Consumer{
+		rmqConn: rmqConn,
+		store:   store,
+	}
+}
+
+// Setup declares the webhook queue and binds it to every event type subscriptions can listen to
+func (c *Consumer) Setup() error {
+	_, err := c.rmqConn.DeclareQueue(rabbitmq.Queue{
+		Name:       QueueName,
+		Durable:    true,
+		AutoDelete: false,
+		Exclusive:  false,
+		NoWait:     false,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	for _, eventType := range EventTypes {
+		if err := c.rmqConn.BindQueue(QueueName, eventType, events.ExchangeName); err != nil {
+			return fmt.Errorf("failed to bind queue to %s: %w", eventType, err)
+		}
+	}
+
+	logger.Info("webhook consumer setup complete", zap.String("queue", QueueName))
+	return nil
+}
+
+func (c *Consumer) Start(ctx context.Context) error {
+	consumer := rabbitmq.NewConsumer(
+		c.rmqConn,
+		QueueName,
+		ConsumerName,
+		rabbitmq.WithPrefetch(20),
+		rabbitmq.WithWorkers(4),
+		rabbitmq.WithRetryPolicy(rabbitmq.DefaultRetryPolicy()),
+	)
+	return consumer.Consume(ctx, c.handleMessage)
+}
+
+func (c *Consumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
+	var event struct {
+		Type   string `json:"type"`
+		ChatID string `json:"chat_id"`
+	}
+	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		logger.Error("failed to unmarshal event for webhooks", zap.Error(err), zap.String("routing_key", msg.RoutingKey))
+		return nil // Don't retry malformed messages
+	}
+	if !IsEventType(event.Type) {
+		return nil
+	}
+
+	var chatID *uuid.UUID
+	if id, err := uuid.Parse(event.ChatID); err == nil {
+		chatID = &id
+	}
+
+	queued, err := c.store.EnqueueWebhookDeliveries(ctx, event.Type, chatID, msg.Body)
+	if err != nil {
+		return err // Retried later, the subscriptions were not matched yet
+	}
+	if queued > 0 {
+		logger.Debug("queued webhook deliveries", zap.String("event_type", event.Type), zap.Int64("deliveries", queued))
+	}
+	return nil
+}
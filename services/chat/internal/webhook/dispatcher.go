@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+	"github.com/icegreg/chat-smpl/services/chat/internal/safehttp"
+	"go.uber.org/zap"
+)
+
+// Dispatcher sends queued deliveries
+type Dispatcher struct {
+	store  Store
+	cfg    Config
+	client *http.Client
+}
+
+func NewDispatcher(store Store, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		cfg:    cfg,
+		client: safehttp.NewClient(cfg.Timeout),
+	}
+}
+
+// DeliverDue sends one batch of due deliveries and returns how many were attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	// The lease covers a full batch at the configured concurrency
+	rounds := (d.cfg.BatchSize + d.cfg.Concurrency - 1) / d.cfg.Concurrency
+	lease := time.Duration(rounds+1) * d.cfg.Timeout
+
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	sem := make(chan struct{}, d.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(delivery *model.WebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			d.deliver(ctx, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt and records its result
+func (d *Dispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	statusCode, err := d.post(ctx, delivery, now)
+	if ctx.Err() != nil {
+		// Shutting down: the attempt does not count, the delivery is picked up again after its lease
+		return
+	}
+
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	} else {
+		errMsg := err.Error()
+		delivery.LastError = &errMsg
+		if delivery.Attempts >= d.cfg.MaxAttempts {
+			delivery.Status = model.WebhookDeliveryDead
+			logger.Warn("webhook delivery dead-lettered",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.String("subscription_id", delivery.SubscriptionID.String()),
+				zap.Int("attempts", delivery.Attempts),
+				zap.Error(err),
+			)
+		} else {
+			delivery.NextAttemptAt = now.Add(d.cfg.Backoff(delivery.Attempts))
+		}
+	}
+
+	if err := d.store.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		logger.Error("failed to record webhook delivery attempt", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+	}
+}
+
+// post sends the payload and returns the response status code (0 if no response was received).
+// Any status outside 2xx is an error. Errors are shown to whoever manages the subscription, so
+// they carry neither the response body nor transport details; the latter are only logged.
+func (d *Dispatcher) post(ctx context.Context, delivery *model.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "chat-smpl-webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderAttempt, strconv.Itoa(delivery.Attempts))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Debug("webhook request failed", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		}
+		return 0, errors.New(safehttp.Describe(err))
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Lets the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+// Incoming webhooks: external systems post messages into a chat through a secret URL
+// /api/hooks/{id}/{token}. Only the SHA-256 hash of the token is stored.
+
+// GenerateIncomingToken returns a new URL token and its hash for storage
+func GenerateIncomingToken() (token, hash string, err error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate webhook token: %w", err)
+	}
+	token = "ihk_" + hex.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns the stored form of an incoming webhook token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyToken compares a token with a stored hash in constant time
+func VerifyToken(token, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(hash)) == 1
+}
+
+// RenderMessage turns a webhook payload into message content: the text, then the title with its
+// link, then one "name: value" line per field. Empty parts are skipped.
+func RenderMessage(p model.IncomingWebhookPayload) string {
+	var parts []string
+	if text := strings.TrimSpace(p.Text); text != "" {
+		parts = append(parts, text)
+	}
+
+	var body []string
+	if title := strings.TrimSpace(p.Title); title != "" {
+		body = append(body, title)
+	}
+	if link := strings.TrimSpace(p.URL); link != "" {
+		body = append(body, link)
+	}
+	for _, f := range p.Fields {
+		name, value := strings.TrimSpace(f.Name), strings.TrimSpace(f.Value)
+		if name == "" && value == "" {
+			continue
+		}
+		body = append(body, name+": "+value)
+	}
+	if len(body) > 0 {
+		parts = append(parts, strings.Join(body, "\n"))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
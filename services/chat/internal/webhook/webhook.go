@@ -0,0 +1,128 @@
+// Package webhook delivers chat events to external HTTP endpoints.
+//
+// Events are read from the chat.events exchange by Consumer and queued in the database, one
+// delivery per matching subscription. Dispatcher sends due deliveries as signed POSTs of the
+// original event envelope JSON (pkg/events), retries failures with exponential backoff and marks a delivery dead
+// once it runs out of attempts.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/events"
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery" // Stays the same across retries, receivers can use it to drop duplicates
+	HeaderAttempt   = "X-Webhook-Attempt"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// EventTypes lists the events a subscription can listen to. Typing indicators are not offered.
+var EventTypes = []string{
+	events.RoutingKeyChatCreated,
+	events.RoutingKeyChatUpdated,
+	events.RoutingKeyChatDeleted,
+	events.RoutingKeyMessageCreated,
+	events.RoutingKeyMessageUpdated,
+	events.RoutingKeyMessageDeleted,
+	events.RoutingKeyMessageRestored,
+	events.RoutingKeyMessageMoved,
+	events.RoutingKeyMessageBulkDeleted,
+	events.RoutingKeyReactionAdded,
+	events.RoutingKeyReactionRemoved,
+	events.RoutingKeyThreadCreated,
+	events.RoutingKeyThreadArchived,
+	events.RoutingKeyThreadReply,
+	events.RoutingKeyReportCreated,
+}
+
+// IsEventType reports whether subscriptions can listen to the event type
+func IsEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Store is the part of the chat repository used for the delivery queue
+type Store interface {
+	EnqueueWebhookDeliveries(ctx context.Context, eventType string, chatID *uuid.UUID, payload []byte) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+// GenerateSecret returns a new random signing secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign returns the X-Webhook-Signature value for a request body:
+//
+//	t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">
+//
+// The timestamp is part of the signed data so receivers can reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Config controls delivery timing
+type Config struct {
+	MaxAttempts int           // Deliveries are marked dead after this many failed attempts
+	Timeout     time.Duration // Per request
+	BaseBackoff time.Duration // Delay after the first failure, doubled after each further failure
+	MaxBackoff  time.Duration
+	BatchSize   int // Deliveries claimed per database round trip
+	Concurrency int // Requests in flight per replica
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: 8,
+		Timeout:     10 * time.Second,
+		BaseBackoff: 10 * time.Second,
+		MaxBackoff:  time.Hour,
+		BatchSize:   50,
+		Concurrency: 8,
+	}
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts.
+// Up to 20% jitter is added so that deliveries failing together do not retry together.
+func (c Config) Backoff(attempts int) time.Duration {
+	delay := c.BaseBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	if delay > 0 {
+		delay += time.Duration(mathrand.Int63n(int64(delay)/5 + 1))
+	}
+	return delay
+}
@@ -0,0 +1,273 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/icegreg/chat-smpl/services/chat/internal/model"
+)
+
+// verify checks a signature the way receivers are documented to: recompute the HMAC of
+// "<t>.<body>" and reject timestamps outside the tolerance
+func verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) bool {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
+
+func TestSign(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"type":"message.created"}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := Sign(secret, signedAt, body)
+
+	assert.True(t, strings.HasPrefix(header, "t=1700000000,v1="))
+	assert.Equal(t, header, Sign(secret, signedAt, body), "signatures are deterministic")
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		now    time.Time
+		valid  bool
+	}{
+		{"valid", secret, body, signedAt.Add(time.Minute), true},
+		{"wrong secret", "whsec_other", body, signedAt, false},
+		{"tampered body", secret, []byte(`{"type":"message.deleted"}`), signedAt, false},
+		{"replayed too late", secret, body, signedAt.Add(10 * time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, verify(tt.secret, header, tt.body, tt.now, 5*time.Minute))
+		})
+	}
+
+	assert.NotEqual(t, header, Sign(secret, signedAt.Add(time.Second), body), "timestamp is signed")
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(a, "whsec_"))
+	assert.Len(t, a, len("whsec_")+64)
+	assert.NotEqual(t, a, b)
+}
+
+func TestIncomingToken(t *testing.T) {
+	token, hash, err := GenerateIncomingToken()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(token, "ihk_"))
+	assert.Equal(t, HashToken(token), hash)
+	assert.NotContains(t, hash, token)
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"same token", token, true},
+		{"other token", token + "x", false},
+		{"empty token", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, VerifyToken(tt.token, hash))
+		})
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload model.IncomingWebhookPayload
+		want    string
+	}{
+		{"text only", model.IncomingWebhookPayload{Text: "  deployed  "}, "deployed"},
+		{"empty", model.IncomingWebhookPayload{Text: " "}, ""},
+		{
+			name: "text, title, link and fields",
+			payload: model.IncomingWebhookPayload{
+				Text:  "Build finished",
+				Title: "main #42",
+				URL:   "https://ci.example.com/42",
+				Fields: []model.IncomingWebhookField{
+					{Name: "status", Value: "passed"},
+					{Name: " ", Value: " "},
+					{Name: "duration", Value: "3m"},
+				},
+			},
+			want: "Build finished\n\nmain #42\nhttps://ci.example.com/42\nstatus: passed\nduration: 3m",
+		},
+		{
+			name:    "body without text",
+			payload: model.IncomingWebhookPayload{Title: "Alert"},
+			want:    "Alert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderMessage(tt.payload))
+		})
+	}
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	cfg := Config{BaseBackoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	tests := []struct {
+		attempts int
+		base     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{20, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			delay := cfg.Backoff(tt.attempts)
+			assert.GreaterOrEqual(t, delay, tt.base)
+			assert.LessOrEqual(t, delay, tt.base+tt.base/5)
+		})
+	}
+}
+
+func TestIsEventType(t *testing.T) {
+	assert.True(t, IsEventType("message.created"))
+	assert.False(t, IsEventType("typing"))
+	assert.False(t, IsEventType("unknown"))
+}
+
+// memoryStore records delivery updates
+type memoryStore struct {
+	updated []model.WebhookDelivery
+}
+
+func (s *memoryStore) EnqueueWebhookDeliveries(ctx context.Context, eventType string, chatID *uuid.UUID, payload []byte) (int64, error) {
+	return 0, nil
+}
+
+func (s *memoryStore) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (s *memoryStore) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	s.updated = append(s.updated, *delivery)
+	return nil
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		attempts  int
+		want      model.WebhookDeliveryStatus
+		wantError string
+	}{
+		{"delivered", http.StatusNoContent, 0, model.WebhookDeliveryDelivered, ""},
+		{"failure is retried", http.StatusInternalServerError, 0, "", "status 500"},
+		{"last attempt is dead-lettered", http.StatusBadRequest, 2, model.WebhookDeliveryDead, "status 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSignature, gotEvent string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotSignature = r.Header.Get(HeaderSignature)
+				gotEvent = r.Header.Get(HeaderEvent)
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `{"id":"1"}`, string(body))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("internal details the subscriber must not see"))
+			}))
+			defer srv.Close()
+
+			store := &memoryStore{}
+			cfg := Config{MaxAttempts: 3, Timeout: time.Second, BaseBackoff: time.Second, MaxBackoff: time.Minute, BatchSize: 1, Concurrency: 1}
+			// The address-checking client refuses the loopback test server
+			d := &Dispatcher{store: store, cfg: cfg, client: srv.Client()}
+
+			delivery := &model.WebhookDelivery{
+				ID:        uuid.New(),
+				EventType: "message.created",
+				Payload:   []byte(`{"id":"1"}`),
+				Attempts:  tt.attempts,
+				URL:       srv.URL,
+				Secret:    "whsec_test",
+			}
+			d.deliver(context.Background(), delivery)
+
+			require.Len(t, store.updated, 1)
+			got := store.updated[0]
+			assert.Equal(t, tt.want, got.Status)
+			assert.Equal(t, tt.attempts+1, got.Attempts)
+			require.NotNil(t, got.LastStatusCode)
+			assert.Equal(t, tt.status, *got.LastStatusCode)
+			if tt.wantError == "" {
+				assert.Nil(t, got.LastError)
+				assert.NotNil(t, got.DeliveredAt)
+			} else {
+				require.NotNil(t, got.LastError)
+				assert.Equal(t, tt.wantError, *got.LastError, "the response body is not stored")
+			}
+
+			assert.Equal(t, "message.created", gotEvent)
+			assert.True(t, verify("whsec_test", gotSignature, []byte(`{"id":"1"}`), time.Now(), time.Minute))
+		})
+	}
+}
+
+func TestDispatcher_RefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the internal server")
+	}))
+	defer srv.Close()
+
+	store := &memoryStore{}
+	d := NewDispatcher(store, Config{MaxAttempts: 3, Timeout: time.Second, BaseBackoff: time.Second, MaxBackoff: time.Minute})
+	d.deliver(context.Background(), &model.WebhookDelivery{ID: uuid.New(), Payload: []byte(`{}`), URL: srv.URL})
+
+	require.Len(t, store.updated, 1)
+	got := store.updated[0]
+	assert.Nil(t, got.LastStatusCode)
+	require.NotNil(t, got.LastError)
+	assert.Equal(t, "destination address is not allowed", *got.LastError)
+	assert.NotContains(t, *got.LastError, "127.0.0.1")
+}
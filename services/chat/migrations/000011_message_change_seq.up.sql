@@ -0,0 +1,38 @@
+-- Tombstones for message sync
+-- Every edit, delete, restore or move of a message takes the next seq_num of its chat
+-- and stores it in change_seq_num, so clients can reconcile changes since a known seq_num.
+
+ALTER TABLE con_test.messages ADD COLUMN IF NOT EXISTS change_seq_num BIGINT;
+ALTER TABLE con_test.messages ADD COLUMN IF NOT EXISTS change_type VARCHAR(16);
+ALTER TABLE con_test.messages ADD COLUMN IF NOT EXISTS previous_thread_id UUID;
+
+CREATE OR REPLACE FUNCTION con_test.track_message_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.is_deleted AND NOT OLD.is_deleted THEN
+        NEW.change_type := 'deleted';
+    ELSIF OLD.is_deleted AND NOT NEW.is_deleted THEN
+        NEW.change_type := 'restored';
+    ELSIF NEW.thread_id IS DISTINCT FROM OLD.thread_id THEN
+        NEW.change_type := 'moved';
+        NEW.previous_thread_id := OLD.thread_id;
+    ELSIF NEW.content IS DISTINCT FROM OLD.content THEN
+        NEW.change_type := 'edited';
+    ELSE
+        RETURN NEW;
+    END IF;
+
+    NEW.change_seq_num := con_test.get_next_seq_num(NEW.chat_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_track_message_change ON con_test.messages;
+CREATE TRIGGER trigger_track_message_change
+    BEFORE UPDATE ON con_test.messages
+    FOR EACH ROW
+    EXECUTE FUNCTION con_test.track_message_change();
+
+-- Index for tombstone sync queries
+CREATE INDEX IF NOT EXISTS idx_messages_chat_change_seq ON con_test.messages(chat_id, change_seq_num)
+    WHERE change_seq_num IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Chat list change log for incremental chat list sync on multi-device clients
+-- Every mutation of a user's chat list (chat created/renamed/deleted, participant added/removed,
+-- archived, favorited) is recorded with a monotonically increasing per-user version.
+
+CREATE TABLE IF NOT EXISTS con_test.user_chat_list_versions (
+    user_id UUID PRIMARY KEY,
+    version BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS con_test.chat_list_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    version BIGINT NOT NULL,
+    chat_id UUID NOT NULL, -- No FK: changes for deleted chats must be kept
+    change_type VARCHAR(32) NOT NULL,
+    actor_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_list_changes_created_at ON con_test.chat_list_changes(created_at);
@@ -0,0 +1,24 @@
+-- Chat bans and timed mutes
+-- A ban removes the user from the chat and blocks adding them back; a mute makes the user
+-- read-only and restores previous_role when it expires or is lifted.
+
+CREATE TABLE IF NOT EXISTS con_test.chat_restrictions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    type VARCHAR(16) NOT NULL, -- ban, mute
+    reason TEXT,
+    previous_role VARCHAR(20), -- Role to restore when a mute ends
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ, -- NULL = until lifted manually
+    lifted_at TIMESTAMPTZ,
+    lifted_by UUID -- NULL with lifted_at set = expired
+);
+
+-- At most one active restriction of each type per user and chat
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chat_restrictions_active
+    ON con_test.chat_restrictions(chat_id, user_id, type) WHERE lifted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_chat_restrictions_expires_at
+    ON con_test.chat_restrictions(expires_at) WHERE lifted_at IS NULL AND expires_at IS NOT NULL;
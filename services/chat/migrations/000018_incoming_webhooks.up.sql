@@ -0,0 +1,19 @@
+-- Incoming webhooks
+-- External systems post messages into a chat as a bot account (users.is_bot).
+-- Only the SHA-256 hash of the URL token is stored; the token itself is shown once.
+
+CREATE TABLE IF NOT EXISTS con_test.incoming_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    thread_id UUID REFERENCES con_test.threads(id) ON DELETE SET NULL,
+    bot_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    rate_limit_per_minute INTEGER NOT NULL DEFAULT 60,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_incoming_webhooks_chat ON con_test.incoming_webhooks(chat_id);
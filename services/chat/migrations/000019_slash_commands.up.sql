@@ -0,0 +1,53 @@
+-- Slash commands and interactive messages
+-- Built-in commands (/poll, /call, /remind, /help) live in code; bot_commands registers external
+-- ones that are POSTed to a bot endpoint. chat_id NULL makes a command available in every chat.
+
+CREATE TABLE IF NOT EXISTS con_test.bot_commands (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    name VARCHAR(32) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    usage VARCHAR(200) NOT NULL DEFAULT '',
+    bot_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bot_commands_scope_name
+    ON con_test.bot_commands(COALESCE(chat_id, '00000000-0000-0000-0000-000000000000'::uuid), name);
+
+-- Buttons of an interactive message and the handler that receives their callbacks.
+-- bot_command_id NULL means a built-in command; state holds handler data (e.g. poll options).
+CREATE TABLE IF NOT EXISTS con_test.message_interactions (
+    message_id UUID PRIMARY KEY REFERENCES con_test.messages(id) ON DELETE CASCADE,
+    command VARCHAR(32) NOT NULL,
+    bot_command_id UUID REFERENCES con_test.bot_commands(id) ON DELETE SET NULL,
+    buttons JSONB NOT NULL DEFAULT '[]',
+    state JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- /poll votes, one per user and poll message
+CREATE TABLE IF NOT EXISTS con_test.command_poll_votes (
+    message_id UUID NOT NULL REFERENCES con_test.messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    option_index INTEGER NOT NULL,
+    voted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- /remind reminders, delivered as ephemeral messages by the scheduler
+CREATE TABLE IF NOT EXISTS con_test.chat_reminders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    thread_id UUID,
+    user_id UUID NOT NULL,
+    text TEXT NOT NULL,
+    remind_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_due ON con_test.chat_reminders(remind_at) WHERE delivered_at IS NULL;
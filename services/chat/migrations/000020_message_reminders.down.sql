@@ -0,0 +1 @@
+-- Rollback
@@ -0,0 +1,8 @@
+-- Reminders on messages ("remind me about this")
+-- Reuses the /remind table: message_id links the reminder to the message it is about.
+
+ALTER TABLE con_test.chat_reminders
+    ADD COLUMN IF NOT EXISTS message_id UUID REFERENCES con_test.messages(id) ON DELETE CASCADE;
+
+-- Pending reminders of a user, for listing
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_user ON con_test.chat_reminders(user_id, remind_at) WHERE delivered_at IS NULL;
@@ -0,0 +1,15 @@
+-- Custom emoji of a chat, usable as ":name:" reactions.
+-- Images are uploaded to files-service; file_link_id is the link shared with chat participants.
+
+CREATE TABLE IF NOT EXISTS con_test.chat_custom_emoji (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    name VARCHAR(32) NOT NULL,
+    file_link_id UUID NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (chat_id, name)
+);
+
+-- Per-user reaction cap and "reacted by me" lookups
+CREATE INDEX IF NOT EXISTS idx_message_reactions_message_user ON con_test.message_reactions(message_id, user_id);
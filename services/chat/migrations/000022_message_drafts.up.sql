@@ -0,0 +1,15 @@
+-- Unsent message drafts, synchronized across the user's devices.
+-- One draft per user and chat, or per user and thread when thread_id is set.
+
+CREATE TABLE IF NOT EXISTS con_test.message_drafts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    chat_id UUID NOT NULL REFERENCES con_test.chats(id) ON DELETE CASCADE,
+    thread_id UUID REFERENCES con_test.threads(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    reply_to_ids UUID[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_drafts_scope
+    ON con_test.message_drafts(user_id, chat_id, COALESCE(thread_id, '00000000-0000-0000-0000-000000000000'::uuid));
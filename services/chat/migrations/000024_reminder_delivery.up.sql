@@ -0,0 +1,9 @@
+-- Reminder delivery leases
+-- A reminder is claimed for a short lease and only marked delivered once its notification was
+-- published, so a failed publish is retried when the lease runs out. Delivered reminders stay
+-- listed until the user dismisses them, for clients that were offline at the due time.
+
+ALTER TABLE con_test.chat_reminders ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
+
+-- Fired reminders of a user, for listing
+CREATE INDEX IF NOT EXISTS idx_chat_reminders_user_delivered ON con_test.chat_reminders(user_id, delivered_at) WHERE delivered_at IS NOT NULL;
@@ -8,6 +8,7 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/icegreg/chat-smpl/pkg/events"
 	"github.com/icegreg/chat-smpl/services/presence/internal/repository"
 )
 
@@ -15,22 +16,6 @@ const (
 	exchangeName = "presence.events"
 )
 
-// PresenceEvent represents a presence change event
-type PresenceEvent struct {
-	Type      string    `json:"type"` // presence.changed
-	Timestamp time.Time `json:"timestamp"`
-	UserID    string    `json:"user_id"`
-	Data      any       `json:"data"`
-}
-
-// PresenceData is the data in presence events
-type PresenceData struct {
-	Status          string `json:"status"`
-	IsOnline        bool   `json:"is_online"`
-	ConnectionCount int    `json:"connection_count"`
-	LastSeenAt      string `json:"last_seen_at,omitempty"`
-}
-
 // Service handles presence business logic
 type Service struct {
 	repo      *repository.Repository
@@ -151,17 +136,17 @@ func (s *Service) publishPresenceChange(presence *repository.PresenceInfo) error
 		lastSeenStr = presence.LastSeenAt.Format(time.RFC3339)
 	}
 
-	event := PresenceEvent{
-		Type:      "presence.changed",
-		Timestamp: time.Now(),
-		UserID:    presence.UserID,
-		Data: PresenceData{
-			Status:          string(presence.Status),
-			IsOnline:        presence.IsOnline,
-			ConnectionCount: presence.ConnectionCount,
-			LastSeenAt:      lastSeenStr,
-		},
+	event, err := events.New(events.ProducerPresence, "presence.changed", events.PresenceData{
+		UserID:          presence.UserID,
+		Status:          string(presence.Status),
+		IsOnline:        presence.IsOnline,
+		ConnectionCount: presence.ConnectionCount,
+		LastSeenAt:      lastSeenStr,
+	})
+	if err != nil {
+		return err
 	}
+	event.ActorID = presence.UserID
 
 	body, err := json.Marshal(event)
 	if err != nil {
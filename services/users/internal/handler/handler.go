@@ -65,6 +65,15 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 		r.Get("/", h.ListGroups)
 		r.Get("/{groupID}", h.GetGroup)
 	})
+
+	// Bot routes (protected, moderators only)
+	r.Route("/api/bots", func(r chi.Router) {
+		r.Use(h.AuthMiddleware)
+		r.Use(h.ModeratorMiddleware)
+		r.Get("/", h.ListBots)
+		r.Post("/", h.CreateBot)
+		r.Delete("/{botID}", h.DeleteBot)
+	})
 }
 
 // Auth handlers
@@ -260,8 +269,83 @@ func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
 	h.respondError(w, http.StatusNotFound, "NOT_FOUND", "Group not found")
 }
 
+// Bot handlers
+
+func (h *Handler) CreateBot(w http.ResponseWriter, r *http.Request) {
+	userID, _ := GetUserID(r.Context())
+
+	var req model.CreateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	if err := validator.Validate(req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	bot, err := h.userService.CreateBot(r.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserAlreadyExists) {
+			h.respondError(w, http.StatusConflict, "USER_EXISTS", "User with this username already exists")
+			return
+		}
+		logger.Error("failed to create bot", zap.Error(err))
+		h.respondError(w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, bot)
+}
+
+func (h *Handler) ListBots(w http.ResponseWriter, r *http.Request) {
+	bots, err := h.userService.ListBots(r.Context())
+	if err != nil {
+		logger.Error("failed to list bots", zap.Error(err))
+		h.respondError(w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"bots": bots,
+	})
+}
+
+func (h *Handler) DeleteBot(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "botID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Invalid bot ID format")
+		return
+	}
+
+	if err := h.userService.DeleteBot(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			h.respondError(w, http.StatusNotFound, "NOT_FOUND", "Bot not found")
+			return
+		}
+		logger.Error("failed to delete bot", zap.Error(err))
+		h.respondError(w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Middleware
 
+// ModeratorMiddleware allows only moderators and admins; must run after AuthMiddleware
+func (h *Handler) ModeratorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := GetUserRole(r.Context())
+		if !ok || !role.CanModerate() {
+			h.respondError(w, http.StatusForbidden, "FORBIDDEN", "Moderator role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -55,6 +55,7 @@ type UserDTO struct {
 	DisplayName *string   `json:"display_name,omitempty"`
 	AvatarURL   *string   `json:"avatar_url,omitempty"`
 	Role        Role      `json:"role"`
+	IsBot       bool      `json:"is_bot"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -67,6 +68,7 @@ func (u *User) ToDTO() UserDTO {
 		DisplayName: u.DisplayName,
 		AvatarURL:   u.AvatarURL,
 		Role:        u.Role,
+		IsBot:       u.IsBot,
 		CreatedAt:   u.CreatedAt,
 		UpdatedAt:   u.UpdatedAt,
 	}
@@ -91,6 +93,12 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=6"`
 }
 
+// Bot DTOs
+type CreateBotRequest struct {
+	Username    string  `json:"username" validate:"required,min=3,max=50"`
+	DisplayName *string `json:"display_name,omitempty" validate:"omitempty,max=100"`
+}
+
 // Group DTOs
 type GroupDTO struct {
 	ID          uuid.UUID `json:"id"`
@@ -36,17 +36,19 @@ func (r Role) IsAdmin() bool {
 }
 
 type User struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Username     string    `json:"username" db:"username"`
-	Email        string    `json:"email" db:"email"`
-	DisplayName  *string   `json:"display_name,omitempty" db:"display_name"`
-	AvatarURL    *string   `json:"avatar_url,omitempty" db:"avatar_url"`
-	Extension    *string   `json:"extension,omitempty" db:"extension"`       // SIP/Verto extension (10000-99999)
-	SIPPassword  *string   `json:"-" db:"sip_password"`                      // Password for SIP/Verto auth
-	PasswordHash string    `json:"-" db:"password_hash"`
-	Role         Role      `json:"role" db:"role"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           uuid.UUID  `json:"id" db:"id"`
+	Username     string     `json:"username" db:"username"`
+	Email        string     `json:"email" db:"email"`
+	DisplayName  *string    `json:"display_name,omitempty" db:"display_name"`
+	AvatarURL    *string    `json:"avatar_url,omitempty" db:"avatar_url"`
+	Extension    *string    `json:"extension,omitempty" db:"extension"` // SIP/Verto extension (10000-99999)
+	SIPPassword  *string    `json:"-" db:"sip_password"`                // Password for SIP/Verto auth
+	PasswordHash string     `json:"-" db:"password_hash"`
+	Role         Role       `json:"role" db:"role"`
+	IsBot        bool       `json:"is_bot" db:"is_bot"`
+	BotOwnerID   *uuid.UUID `json:"bot_owner_id,omitempty" db:"bot_owner_id"` // User who created the bot
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type RefreshToken struct {
@@ -32,6 +32,9 @@ type UserRepository interface {
 	Update(ctx context.Context, user *model.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Bots
+	ListBots(ctx context.Context) ([]model.User, error)
+
 	// Extension management
 	GetNextExtension(ctx context.Context) (string, error)
 	AssignExtension(ctx context.Context, userID uuid.UUID) (string, error)
@@ -53,8 +56,8 @@ func NewUserRepository(pool *pgxpool.Pool) UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	query := `
-		INSERT INTO con_test.users (id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO con_test.users (id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
 	user.ID = uuid.New()
@@ -62,8 +65,8 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	// Auto-assign extension and SIP password for non-guest users
-	if user.Extension == nil && user.Role != model.RoleGuest {
+	// Auto-assign extension and SIP password for non-guest users (bots don't make calls)
+	if user.Extension == nil && user.Role != model.RoleGuest && !user.IsBot {
 		ext, err := r.GetNextExtension(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get next extension: %w", err)
@@ -88,6 +91,8 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 		user.SIPPassword,
 		user.PasswordHash,
 		user.Role,
+		user.IsBot,
+		user.BotOwnerID,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -114,7 +119,7 @@ func (r *userRepository) generateSIPPassword(ctx context.Context) (string, error
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	query := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		WHERE id = $1
 	`
@@ -130,6 +135,8 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User
 		&user.SIPPassword,
 		&user.PasswordHash,
 		&user.Role,
+		&user.IsBot,
+		&user.BotOwnerID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -146,7 +153,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	query := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		WHERE email = $1
 	`
@@ -162,6 +169,8 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 		&user.SIPPassword,
 		&user.PasswordHash,
 		&user.Role,
+		&user.IsBot,
+		&user.BotOwnerID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -178,7 +187,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
 	query := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		WHERE username = $1
 	`
@@ -194,6 +203,8 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 		&user.SIPPassword,
 		&user.PasswordHash,
 		&user.Role,
+		&user.IsBot,
+		&user.BotOwnerID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -210,7 +221,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 
 func (r *userRepository) GetByExtension(ctx context.Context, extension string) (*model.User, error) {
 	query := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		WHERE extension = $1
 	`
@@ -226,6 +237,8 @@ func (r *userRepository) GetByExtension(ctx context.Context, extension string) (
 		&user.SIPPassword,
 		&user.PasswordHash,
 		&user.Role,
+		&user.IsBot,
+		&user.BotOwnerID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -259,7 +272,7 @@ func (r *userRepository) List(ctx context.Context, page, count int) ([]model.Use
 
 	// Get users
 	query := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -284,6 +297,8 @@ func (r *userRepository) List(ctx context.Context, page, count int) ([]model.Use
 			&user.SIPPassword,
 			&user.PasswordHash,
 			&user.Role,
+			&user.IsBot,
+			&user.BotOwnerID,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -319,7 +334,7 @@ func (r *userRepository) Search(ctx context.Context, query string, page, count i
 
 	// Get users with search filter
 	searchQuery := `
-		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, created_at, updated_at
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
 		FROM con_test.users
 		WHERE username ILIKE $1
 		   OR display_name ILIKE $1
@@ -354,6 +369,8 @@ func (r *userRepository) Search(ctx context.Context, query string, page, count i
 			&user.SIPPassword,
 			&user.PasswordHash,
 			&user.Role,
+			&user.IsBot,
+			&user.BotOwnerID,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		); err != nil {
@@ -401,6 +418,47 @@ func (r *userRepository) Update(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+// ListBots returns all bot accounts, newest first
+func (r *userRepository) ListBots(ctx context.Context) ([]model.User, error) {
+	query := `
+		SELECT id, username, email, display_name, avatar_url, extension, sip_password, password_hash, role, is_bot, bot_owner_id, created_at, updated_at
+		FROM con_test.users
+		WHERE is_bot
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bots: %w", err)
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.DisplayName,
+			&user.AvatarURL,
+			&user.Extension,
+			&user.SIPPassword,
+			&user.PasswordHash,
+			&user.Role,
+			&user.IsBot,
+			&user.BotOwnerID,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan bot: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) GetNextExtension(ctx context.Context) (string, error) {
 	query := `SELECT con_test.get_next_extension()`
 
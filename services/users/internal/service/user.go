@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -42,6 +45,11 @@ type UserService interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	ChangePassword(ctx context.Context, id uuid.UUID, req model.ChangePasswordRequest) error
 
+	// Bots
+	CreateBot(ctx context.Context, ownerID uuid.UUID, req model.CreateBotRequest) (*model.UserDTO, error)
+	ListBots(ctx context.Context) ([]model.UserDTO, error)
+	DeleteBot(ctx context.Context, id uuid.UUID) error
+
 	// Avatars
 	GetAvatarPath(userID uuid.UUID) string
 	RegenerateMissingAvatars(ctx context.Context) (int, error)
@@ -107,6 +115,11 @@ func (s *userService) Login(ctx context.Context, req model.LoginRequest) (*model
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Bots post through integrations only
+	if user.IsBot {
+		return nil, ErrInvalidCredentials
+	}
+
 	if err := password.Verify(req.Password, user.PasswordHash); err != nil {
 		return nil, ErrInvalidCredentials
 	}
@@ -354,6 +367,75 @@ func (s *userService) ChangePassword(ctx context.Context, id uuid.UUID, req mode
 	return s.repo.Update(ctx, user)
 }
 
+// botEmailDomain is the synthetic mail domain given to bot accounts
+const botEmailDomain = "bots.local"
+
+// CreateBot creates a bot account owned by ownerID. Bots cannot log in; they
+// post into chats through integrations such as incoming webhooks.
+func (s *userService) CreateBot(ctx context.Context, ownerID uuid.UUID, req model.CreateBotRequest) (*model.UserDTO, error) {
+	// Bots never authenticate with a password, so store a random unusable one
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate bot password: %w", err)
+	}
+	hashedPassword, err := password.Hash(hex.EncodeToString(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &model.User{
+		Username:     req.Username,
+		Email:        strings.ToLower(req.Username) + "@" + botEmailDomain,
+		DisplayName:  req.DisplayName,
+		PasswordHash: hashedPassword,
+		Role:         model.RoleUser,
+		IsBot:        true,
+		BotOwnerID:   &ownerID,
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.avatarGenerator.Generate(user.ID); err != nil {
+		fmt.Printf("warning: failed to generate avatar: %v\n", err)
+	}
+
+	avatarURL := generateLocalAvatarURL(user.ID)
+	user.AvatarURL = &avatarURL
+	if err := s.repo.Update(ctx, user); err != nil {
+		fmt.Printf("warning: failed to update avatar URL: %v\n", err)
+	}
+
+	dto := user.ToDTO()
+	return &dto, nil
+}
+
+func (s *userService) ListBots(ctx context.Context) ([]model.UserDTO, error) {
+	bots, err := s.repo.ListBots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]model.UserDTO, len(bots))
+	for i, bot := range bots {
+		dtos[i] = bot.ToDTO()
+	}
+	return dtos, nil
+}
+
+// DeleteBot removes a bot account; regular users are reported as not found
+func (s *userService) DeleteBot(ctx context.Context, id uuid.UUID) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.IsBot {
+		return repository.ErrUserNotFound
+	}
+	return s.repo.Delete(ctx, id)
+}
+
 // GetAvatarPath returns the filesystem path to a user's avatar
 func (s *userService) GetAvatarPath(userID uuid.UUID) string {
 	return s.avatarGenerator.GetPath(userID)
@@ -92,6 +92,11 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ListBots(ctx context.Context) ([]model.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.User), args.Error(1)
+}
+
 func (m *MockUserRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
 	args := m.Called(ctx, token)
 	return args.Error(0)
@@ -298,3 +303,70 @@ func TestUserService_Delete(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUserService_Login_BotRejected(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	jwtManager := jwt.NewManager(jwt.DefaultConfig("test-secret"))
+	svc := NewUserService(mockRepo, jwtManager, "/tmp/avatars")
+
+	ctx := context.Background()
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+	bot := &model.User{
+		ID:           uuid.New(),
+		Username:     "deploybot",
+		Email:        "deploybot@bots.local",
+		PasswordHash: string(hashedPassword),
+		Role:         model.RoleUser,
+		IsBot:        true,
+	}
+
+	mockRepo.On("GetByEmail", ctx, bot.Email).Return(bot, nil)
+
+	resp, err := svc.Login(ctx, model.LoginRequest{Email: bot.Email, Password: password})
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Nil(t, resp)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_CreateBot(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	jwtManager := jwt.NewManager(jwt.DefaultConfig("test-secret"))
+	svc := NewUserService(mockRepo, jwtManager, "/tmp/avatars")
+
+	ctx := context.Background()
+	ownerID := uuid.New()
+
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(u *model.User) bool {
+		return u.IsBot && u.BotOwnerID != nil && *u.BotOwnerID == ownerID
+	})).Return(nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*model.User")).Return(nil)
+
+	bot, err := svc.CreateBot(ctx, ownerID, model.CreateBotRequest{Username: "DeployBot"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, bot)
+	assert.True(t, bot.IsBot)
+	assert.Equal(t, "deploybot@bots.local", bot.Email)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_DeleteBot_NotABot(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	jwtManager := jwt.NewManager(jwt.DefaultConfig("test-secret"))
+	svc := NewUserService(mockRepo, jwtManager, "/tmp/avatars")
+
+	ctx := context.Background()
+	user := &model.User{ID: uuid.New(), Username: "human", Role: model.RoleUser}
+
+	mockRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	err := svc.DeleteBot(ctx, user.ID)
+
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	mockRepo.AssertNotCalled(t, "Delete", ctx, user.ID)
+}
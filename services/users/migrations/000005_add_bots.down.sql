@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS con_test.idx_users_bots;
+ALTER TABLE con_test.users DROP COLUMN IF EXISTS bot_owner_id;
+ALTER TABLE con_test.users DROP COLUMN IF EXISTS is_bot;
@@ -0,0 +1,5 @@
+-- Bot accounts: users that post through integrations (incoming webhooks) and cannot log in
+ALTER TABLE con_test.users ADD COLUMN IF NOT EXISTS is_bot BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE con_test.users ADD COLUMN IF NOT EXISTS bot_owner_id UUID REFERENCES con_test.users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_bots ON con_test.users(created_at DESC) WHERE is_bot;
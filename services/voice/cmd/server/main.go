@@ -101,6 +101,8 @@ func main() {
 				"CHANNEL_HANGUP",
 				"CONFERENCE_DATA",
 				"CONFERENCE_MEMBER_FLAGS",
+				// Subclasses follow CUSTOM, so it stays last
+				"CUSTOM", "conference::maintenance",
 			); err != nil {
 				logger.Warn("failed to subscribe to events", zap.Error(err))
 			}
@@ -438,6 +440,52 @@ func handleFreeSwitchEvent(
 		logger.Debug("conference member flags changed",
 			zap.String("confName", confName),
 			zap.String("memberID", memberID))
+
+	case "CUSTOM":
+		if event.GetHeader("Event-Subclass") != "conference::maintenance" {
+			return
+		}
+		switch action := event.GetHeader("Action"); action {
+		case "start-talking", "stop-talking":
+			handleTalkingEvent(ctx, event, action == "start-talking", confRepo, eventPublisher, logger)
+		}
+	}
+}
+
+// handleTalkingEvent publishes a speaking signal for a FreeSWITCH start-talking/stop-talking
+// event. The speaking state is not stored; it only matters while it is current.
+func handleTalkingEvent(
+	ctx context.Context,
+	event *esl.Event,
+	speaking bool,
+	confRepo repository.ConferenceRepository,
+	eventPublisher events.Publisher,
+	logger *zap.Logger,
+) {
+	if eventPublisher == nil {
+		return
+	}
+
+	confName := event.GetHeader("Conference-Name")
+	memberID := event.GetHeader("Member-ID")
+
+	conf, err := confRepo.GetConferenceByFSName(ctx, confName)
+	if err != nil {
+		logger.Debug("conference not found for talking event", zap.String("confName", confName), zap.Error(err))
+		return
+	}
+	participant, err := confRepo.GetParticipantByFSMemberID(ctx, conf.ID, memberID)
+	if err != nil {
+		logger.Debug("participant not found for talking event",
+			zap.String("confName", confName),
+			zap.String("memberID", memberID),
+			zap.Error(err))
+		return
+	}
+
+	participant.IsSpeaking = speaking
+	if err := eventPublisher.PublishParticipantSpeaking(ctx, participant); err != nil {
+		logger.Debug("failed to publish speaking signal", zap.String("participantID", participant.ID.String()), zap.Error(err))
 	}
 }
 
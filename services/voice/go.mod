@@ -6,6 +6,7 @@ toolchain go1.24.11
 
 require (
 	github.com/google/uuid v1.6.0
+	github.com/icegreg/chat-smpl/pkg/events v0.0.0
 	github.com/icegreg/chat-smpl/pkg/metrics v0.0.0
 	github.com/icegreg/chat-smpl/pkg/migrate v0.0.0
 	github.com/icegreg/chat-smpl/proto/chat v0.0.0
@@ -37,6 +38,7 @@ require (
 	golang.org/x/sys v0.38.0 // indirect
 	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250818200422-3122310a409c // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/icegreg/chat-smpl/proto/voice => ../../proto/voice
@@ -45,4 +47,6 @@ replace github.com/icegreg/chat-smpl/proto/chat => ../../proto/chat
 
 replace github.com/icegreg/chat-smpl/pkg/metrics => ../../pkg/metrics
 
+replace github.com/icegreg/chat-smpl/pkg/events => ../../pkg/events
+
 replace github.com/icegreg/chat-smpl/pkg/migrate => ../../pkg/migrate
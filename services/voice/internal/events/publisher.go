@@ -4,17 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.uber.org/zap"
 
+	sharedevents "github.com/icegreg/chat-smpl/pkg/events"
 	"github.com/icegreg/chat-smpl/services/voice/internal/model"
 )
 
 const (
 	exchangeName = "voice.events"
 	exchangeType = "topic"
+
+	// signalsExchangeName carries ephemeral signals (speaking indicators). It is not durable and
+	// its messages are transient and expire after signalTTL, so they never reach the disk.
+	signalsExchangeName = "voice.signals"
+	signalTTL           = 5 * time.Second
 )
 
 // Event routing keys
@@ -46,7 +53,7 @@ type Publisher interface {
 	PublishParticipantJoined(ctx context.Context, p *model.Participant, chatID string) error
 	PublishParticipantLeft(ctx context.Context, p *model.Participant, chatID string) error
 	PublishParticipantMuted(ctx context.Context, p *model.Participant, chatID string) error
-	PublishParticipantSpeaking(ctx context.Context, participantID string, isSpeaking bool) error
+	PublishParticipantSpeaking(ctx context.Context, p *model.Participant) error
 	PublishCallInitiated(ctx context.Context, call *model.Call) error
 	PublishCallAnswered(ctx context.Context, call *model.Call) error
 	PublishCallEnded(ctx context.Context, call *model.Call) error
@@ -97,6 +104,20 @@ func NewPublisher(amqpURL string, logger *zap.Logger) (Publisher, error) {
 		return nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
+	if err := ch.ExchangeDeclare(
+		signalsExchangeName,
+		exchangeType,
+		false, // durable
+		false, // auto-deleted
+		false, // internal
+		false, // no-wait
+		nil,   // arguments
+	); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare signals exchange: %w", err)
+	}
+
 	logger.Info("voice events publisher initialized", zap.String("exchange", exchangeName))
 
 	return &publisher{
@@ -117,24 +138,42 @@ func (p *publisher) Close() error {
 	return nil
 }
 
+// publish wraps payload in an event envelope; routingKey is the event type
 func (p *publisher) publish(ctx context.Context, routingKey string, payload interface{}) error {
-	body, err := json.Marshal(payload)
+	return p.publishTo(ctx, exchangeName, routingKey, payload, amqp.Publishing{DeliveryMode: amqp.Persistent})
+}
+
+// publishSignal publishes an ephemeral signal that the broker keeps in memory only and drops
+// once it is older than signalTTL
+func (p *publisher) publishSignal(ctx context.Context, routingKey string, payload interface{}) error {
+	return p.publishTo(ctx, signalsExchangeName, routingKey, payload, amqp.Publishing{
+		DeliveryMode: amqp.Transient,
+		Expiration:   strconv.FormatInt(signalTTL.Milliseconds(), 10),
+	})
+}
+
+// publishTo wraps payload in an envelope and sends it with the delivery settings of msg
+func (p *publisher) publishTo(ctx context.Context, exchange, routingKey string, payload interface{}, msg amqp.Publishing) error {
+	env, err := sharedevents.New(sharedevents.ProducerVoice, routingKey, payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		return err
 	}
 
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg.ContentType = "application/json"
+	msg.Body = body
+	msg.Timestamp = time.Now()
 	err = p.channel.PublishWithContext(
 		ctx,
-		exchangeName,
+		exchange,
 		routingKey,
 		false, // mandatory
 		false, // immediate
-		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
-			DeliveryMode: amqp.Persistent,
-			Timestamp:    time.Now(),
-		},
+		msg,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to publish message: %w", err)
@@ -147,22 +186,8 @@ func (p *publisher) publish(ctx context.Context, routingKey string, payload inte
 	return nil
 }
 
-// ConferenceEvent represents a conference event payload
-type ConferenceEvent struct {
-	ID               string    `json:"id"`
-	Name             string    `json:"name"`
-	ChatID           *string   `json:"chat_id,omitempty"`
-	CreatedBy        string    `json:"created_by"`
-	Status           string    `json:"status"`
-	MaxMembers       int       `json:"max_members"`
-	ParticipantCount int       `json:"participant_count"`
-	StartedAt        *string   `json:"started_at,omitempty"`
-	EndedAt          *string   `json:"ended_at,omitempty"`
-	CreatedAt        string    `json:"created_at"`
-}
-
-func conferenceToEvent(conf *model.Conference) ConferenceEvent {
-	event := ConferenceEvent{
+func conferenceToEvent(conf *model.Conference) sharedevents.ConferenceData {
+	event := sharedevents.ConferenceData{
 		ID:               conf.ID.String(),
 		Name:             conf.Name,
 		CreatedBy:        conf.CreatedBy.String(),
@@ -196,25 +221,8 @@ func (p *publisher) PublishConferenceEnded(ctx context.Context, conf *model.Conf
 	return p.publish(ctx, ConferenceEndedKey, conferenceToEvent(conf))
 }
 
-// ParticipantEvent represents a participant event payload
-type ParticipantEvent struct {
-	ID           string  `json:"id"`
-	ConferenceID string  `json:"conference_id"`
-	ChatID       string  `json:"chat_id,omitempty"`
-	UserID       string  `json:"user_id"`
-	Status       string  `json:"status"`
-	IsMuted      bool    `json:"is_muted"`
-	IsDeaf       bool    `json:"is_deaf"`
-	IsSpeaking   bool    `json:"is_speaking"`
-	Username     *string `json:"username,omitempty"`
-	DisplayName  *string `json:"display_name,omitempty"`
-	AvatarURL    *string `json:"avatar_url,omitempty"`
-	JoinedAt     *string `json:"joined_at,omitempty"`
-	LeftAt       *string `json:"left_at,omitempty"`
-}
-
-func participantToEvent(p *model.Participant, chatID string) ParticipantEvent {
-	event := ParticipantEvent{
+func participantToEvent(p *model.Participant, chatID string) sharedevents.ParticipantData {
+	event := sharedevents.ParticipantData{
 		ID:           p.ID.String(),
 		ConferenceID: p.ConferenceID.String(),
 		ChatID:       chatID,
@@ -253,41 +261,22 @@ func (p *publisher) PublishParticipantMuted(ctx context.Context, participant *mo
 	return p.publish(ctx, ParticipantMutedKey, participantToEvent(participant, chatID))
 }
 
-// SpeakingEvent represents a speaking state change
-type SpeakingEvent struct {
-	ParticipantID string `json:"participant_id"`
-	IsSpeaking    bool   `json:"is_speaking"`
-}
-
-// PublishParticipantSpeaking publishes participant.speaking event
-func (p *publisher) PublishParticipantSpeaking(ctx context.Context, participantID string, isSpeaking bool) error {
-	return p.publish(ctx, ParticipantSpeakingKey, SpeakingEvent{
-		ParticipantID: participantID,
-		IsSpeaking:    isSpeaking,
-	})
-}
-
-// CallEvent represents a call event payload
-type CallEvent struct {
-	ID                string  `json:"id"`
-	CallerID          string  `json:"caller_id"`
-	CalleeID          string  `json:"callee_id"`
-	ChatID            *string `json:"chat_id,omitempty"`
-	ConferenceID      *string `json:"conference_id,omitempty"`
-	Status            string  `json:"status"`
-	Duration          int     `json:"duration"`
-	EndReason         *string `json:"end_reason,omitempty"`
-	CallerUsername    *string `json:"caller_username,omitempty"`
-	CallerDisplayName *string `json:"caller_display_name,omitempty"`
-	CalleeUsername    *string `json:"callee_username,omitempty"`
-	CalleeDisplayName *string `json:"callee_display_name,omitempty"`
-	StartedAt         *string `json:"started_at,omitempty"`
-	AnsweredAt        *string `json:"answered_at,omitempty"`
-	EndedAt           *string `json:"ended_at,omitempty"`
+// PublishParticipantSpeaking publishes participant.speaking as a signal
+func (p *publisher) PublishParticipantSpeaking(ctx context.Context, participant *model.Participant) error {
+	data := sharedevents.SpeakingData{
+		ParticipantID: participant.ID.String(),
+		ConferenceID:  participant.ConferenceID.String(),
+		UserID:        participant.UserID.String(),
+		IsSpeaking:    participant.IsSpeaking,
+	}
+	if participant.FSMemberID != nil {
+		data.FSMemberID = *participant.FSMemberID
+	}
+	return p.publishSignal(ctx, ParticipantSpeakingKey, data)
 }
 
-func callToEvent(call *model.Call) CallEvent {
-	event := CallEvent{
+func callToEvent(call *model.Call) sharedevents.CallData {
+	event := sharedevents.CallData{
 		ID:                call.ID.String(),
 		CallerID:          call.CallerID.String(),
 		CalleeID:          call.CalleeID.String(),
@@ -337,24 +326,8 @@ func (p *publisher) PublishCallEnded(ctx context.Context, call *model.Call) erro
 	return p.publish(ctx, CallEndedKey, callToEvent(call))
 }
 
-// ScheduledConferenceEvent represents a scheduled conference event payload
-type ScheduledConferenceEvent struct {
-	ID               string  `json:"id"`
-	Name             string  `json:"name"`
-	ChatID           *string `json:"chat_id,omitempty"`
-	CreatedBy        string  `json:"created_by"`
-	Status           string  `json:"status"`
-	EventType        string  `json:"event_type"`
-	ScheduledAt      *string `json:"scheduled_at,omitempty"`
-	SeriesID         *string `json:"series_id,omitempty"`
-	AcceptedCount    int     `json:"accepted_count"`
-	DeclinedCount    int     `json:"declined_count"`
-	ParticipantCount int     `json:"participant_count"`
-	CreatedAt        string  `json:"created_at"`
-}
-
-func scheduledConferenceToEvent(conf *model.Conference) ScheduledConferenceEvent {
-	event := ScheduledConferenceEvent{
+func scheduledConferenceToEvent(conf *model.Conference) sharedevents.ScheduledConferenceData {
+	event := sharedevents.ScheduledConferenceData{
 		ID:               conf.ID.String(),
 		Name:             conf.Name,
 		CreatedBy:        conf.CreatedBy.String(),
@@ -390,33 +363,18 @@ func (p *publisher) PublishConferenceCancelled(ctx context.Context, conf *model.
 	return p.publish(ctx, ConferenceCancelledKey, scheduledConferenceToEvent(conf))
 }
 
-// RSVPUpdatedEvent represents RSVP update event
-type RSVPUpdatedEvent struct {
-	ConferenceID string `json:"conference_id"`
-	UserID       string `json:"user_id"`
-	RSVPStatus   string `json:"rsvp_status"`
-}
-
 // PublishRSVPUpdated publishes conference.rsvp_updated event
 func (p *publisher) PublishRSVPUpdated(ctx context.Context, confID, userID string, status model.RSVPStatus) error {
-	return p.publish(ctx, ConferenceRSVPUpdatedKey, RSVPUpdatedEvent{
+	return p.publish(ctx, ConferenceRSVPUpdatedKey, sharedevents.RSVPUpdatedData{
 		ConferenceID: confID,
 		UserID:       userID,
 		RSVPStatus:   string(status),
 	})
 }
 
-// ParticipantRoleChangedEvent represents role change event
-type ParticipantRoleChangedEvent struct {
-	ConferenceID string `json:"conference_id"`
-	UserID       string `json:"user_id"`
-	OldRole      string `json:"old_role"`
-	NewRole      string `json:"new_role"`
-}
-
 // PublishParticipantRoleChanged publishes participant.role_changed event
 func (p *publisher) PublishParticipantRoleChanged(ctx context.Context, confID, userID string, oldRole, newRole model.ConferenceRole) error {
-	return p.publish(ctx, ParticipantRoleChangedKey, ParticipantRoleChangedEvent{
+	return p.publish(ctx, ParticipantRoleChangedKey, sharedevents.ParticipantRoleChangedData{
 		ConferenceID: confID,
 		UserID:       userID,
 		OldRole:      string(oldRole),
@@ -429,29 +387,14 @@ func (p *publisher) PublishParticipantAdded(ctx context.Context, participant *mo
 	return p.publish(ctx, ParticipantAddedKey, participantToEvent(participant, chatID))
 }
 
-// ParticipantRemovedEvent represents participant removal event
-type ParticipantRemovedEvent struct {
-	ConferenceID string `json:"conference_id"`
-	UserID       string `json:"user_id"`
-}
-
 // PublishParticipantRemoved publishes participant.removed event
 func (p *publisher) PublishParticipantRemoved(ctx context.Context, confID, userID string) error {
-	return p.publish(ctx, ParticipantRemovedKey, ParticipantRemovedEvent{
+	return p.publish(ctx, ParticipantRemovedKey, sharedevents.ParticipantRemovedData{
 		ConferenceID: confID,
 		UserID:       userID,
 	})
 }
 
-// ConferenceReminderEventPayload represents reminder event payload
-type ConferenceReminderEventPayload struct {
-	ConferenceID   string `json:"conference_id"`
-	UserID         string `json:"user_id"`
-	ConferenceName string `json:"conference_name"`
-	ScheduledAt    string `json:"scheduled_at"`
-	MinutesBefore  int    `json:"minutes_before"`
-}
-
 // PublishConferenceReminder publishes conference.reminder event
 func (p *publisher) PublishConferenceReminder(ctx context.Context, reminder *model.ConferenceReminder) error {
 	scheduledAt := ""
@@ -463,7 +406,7 @@ func (p *publisher) PublishConferenceReminder(ctx context.Context, reminder *mod
 		conferenceName = *reminder.ConferenceName
 	}
 
-	return p.publish(ctx, ConferenceReminderKey, ConferenceReminderEventPayload{
+	return p.publish(ctx, ConferenceReminderKey, sharedevents.ConferenceReminderData{
 		ConferenceID:   reminder.ConferenceID.String(),
 		UserID:         reminder.UserID.String(),
 		ConferenceName: conferenceName,
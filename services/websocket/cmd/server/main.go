@@ -2,15 +2,23 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 
 	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/pkg/metrics"
 	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
 	pb "github.com/icegreg/chat-smpl/proto/chat"
 	"github.com/icegreg/chat-smpl/services/websocket/internal/centrifugo"
 	"github.com/icegreg/chat-smpl/services/websocket/internal/consumer"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/dedup"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/membership"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -29,6 +37,12 @@ func main() {
 	centrifugoAPIKey := getEnv("CENTRIFUGO_API_KEY", "centrifugo-api-key")
 	centrifugoSecret := getEnv("CENTRIFUGO_SECRET", "your-centrifugo-secret-key-change-in-production")
 	chatServiceAddr := getEnv("CHAT_SERVICE_ADDR", "localhost:50051")
+	redisAddr := getEnv("REDIS_ADDR", "") // Optional: shares the dedup window between replicas
+	redisPassword := getEnv("REDIS_PASSWORD", "")
+	dedupCfg := loadDedupConfig()
+	delivery := loadDelivery()
+	membershipCfg := loadMembershipConfig()
+	metricsAddr := getEnv("METRICS_ADDR", ":9093")
 
 	// Connect to chat service via gRPC
 	chatConn, err := grpc.NewClient(chatServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -54,21 +68,60 @@ func main() {
 
 	// Create Centrifugo client
 	centrifugoClient := centrifugo.NewClient(centrifugo.Config{
-		APIURL:     centrifugoAPIURL,
-		APIKey:     centrifugoAPIKey,
-		HMACSecret: centrifugoSecret,
+		APIURL:               centrifugoAPIURL,
+		APIKey:               centrifugoAPIKey,
+		HMACSecret:           centrifugoSecret,
+		BroadcastBatchSize:   getEnvInt("BROADCAST_BATCH_SIZE", centrifugo.DefaultBroadcastBatchSize),
+		BroadcastConcurrency: getEnvInt("BROADCAST_CONCURRENCY", centrifugo.DefaultBroadcastConcurrency),
 	})
 
+	// Fan-out metrics
+	fanoutMetrics := metrics.NewFanoutMetrics("websocket")
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		logger.Info("metrics server starting", zap.String("addr", metricsAddr))
+		if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+			logger.Error("metrics server failed", zap.Error(err))
+		}
+	}()
+
+	// Dedup window of forwarded event IDs: shared through Redis when configured, otherwise per replica
+	var dedupStore dedup.Store = dedup.NewMemoryStore(dedupCfg)
+	if redisAddr != "" {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     redisAddr,
+			Password: redisPassword,
+		})
+		if err := redisClient.Ping(context.Background()).Err(); err != nil {
+			logger.Warn("failed to connect to Redis, using in-memory event dedup", zap.Error(err))
+			redisClient.Close()
+		} else {
+			defer redisClient.Close()
+			dedupStore = dedup.NewRedisStore(redisClient, dedupCfg)
+			logger.Info("connected to Redis for event dedup", zap.String("addr", redisAddr))
+		}
+	}
+
 	// Create chat consumer
-	chatConsumer := consumer.New(rmqConn, centrifugoClient)
+	// Recipients of compact events (published without a participant list) are looked up in chat-service
+	members := membership.NewResolver(chatClient, membershipCfg, fanoutMetrics)
+	chatConsumer := consumer.New(rmqConn, centrifugoClient, dedupStore, delivery, members, fanoutMetrics)
+	logger.Info("chat events delivery", zap.String("mode", string(delivery)))
 
 	// Setup chat queue bindings
 	if err := chatConsumer.Setup(); err != nil {
 		logger.Fatal("failed to setup chat consumer", zap.Error(err))
 	}
 
+	// Typing and speaking signals reach the same channels as chat events, without history
+	signalConsumer := consumer.NewSignalConsumer(rmqConn, centrifugoClient, delivery, members, fanoutMetrics)
+	if err := signalConsumer.Setup(); err != nil {
+		logger.Fatal("failed to setup signals consumer", zap.Error(err))
+	}
+
 	// Create voice consumer
-	voiceConsumer := consumer.NewVoiceConsumer(rmqConn, centrifugoClient, chatClient)
+	voiceConsumer := consumer.NewVoiceConsumer(rmqConn, centrifugoClient, chatClient, dedupStore)
 
 	// Setup voice queue bindings
 	if err := voiceConsumer.Setup(); err != nil {
@@ -97,6 +150,12 @@ func main() {
 		}
 	}()
 
+	go func() {
+		if err := signalConsumer.Start(ctx); err != nil && err != context.Canceled {
+			logger.Error("signals consumer error", zap.Error(err))
+		}
+	}()
+
 	// Start voice consumer
 	logger.Info("websocket-service started, waiting for events...")
 	if err := voiceConsumer.Start(ctx); err != nil && err != context.Canceled {
@@ -106,9 +165,55 @@ func main() {
 	logger.Info("websocket-service stopped")
 }
 
+// loadDedupConfig reads DEDUP_WINDOW (a duration like 10m) and DEDUP_CACHE_SIZE
+func loadDedupConfig() dedup.Config {
+	cfg := dedup.DefaultConfig()
+	if v, err := time.ParseDuration(os.Getenv("DEDUP_WINDOW")); err == nil && v > 0 {
+		cfg.Window = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("DEDUP_CACHE_SIZE")); err == nil && v > 0 {
+		cfg.Size = v
+	}
+	return cfg
+}
+
+// loadMembershipConfig reads MEMBERSHIP_CACHE_TTL (a duration like 30s) and MEMBERSHIP_CACHE_SIZE
+func loadMembershipConfig() membership.Config {
+	cfg := membership.DefaultConfig()
+	if v, err := time.ParseDuration(os.Getenv("MEMBERSHIP_CACHE_TTL")); err == nil && v > 0 {
+		cfg.TTL = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MEMBERSHIP_CACHE_SIZE")); err == nil && v > 0 {
+		cfg.Size = v
+	}
+	return cfg
+}
+
+// loadDelivery reads CHAT_EVENTS_DELIVERY: "user" (personal channels, default) or "chat"
+// (chat:{id} channels with history). With "chat" a client only gets the events of the chats it
+// could subscribe to within client_channel_limit of Centrifugo.
+func loadDelivery() consumer.Delivery {
+	switch v := consumer.Delivery(os.Getenv("CHAT_EVENTS_DELIVERY")); v {
+	case consumer.DeliveryUser, consumer.DeliveryChat:
+		return v
+	case "":
+		return consumer.DeliveryUser
+	default:
+		logger.Warn("unknown CHAT_EVENTS_DELIVERY, using user channels", zap.String("value", string(v)))
+		return consumer.DeliveryUser
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return defaultValue
+}
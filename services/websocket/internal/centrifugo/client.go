@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/icegreg/chat-smpl/pkg/logger"
@@ -26,38 +27,66 @@ const (
 	maxRetryDelay  = 2 * time.Second
 )
 
+// Broadcast batching defaults
+const (
+	DefaultBroadcastBatchSize   = 1000
+	DefaultBroadcastConcurrency = 4
+)
+
 type Config struct {
-	APIURL    string
-	APIKey    string
+	APIURL     string
+	APIKey     string
 	HMACSecret string
+	// BroadcastBatchSize is the most channels sent in one broadcast request; larger
+	// broadcasts are split into batches
+	BroadcastBatchSize int
+	// BroadcastConcurrency is how many batches of one broadcast are sent at a time
+	BroadcastConcurrency int
 }
 
 type Client struct {
-	httpClient *http.Client
-	apiURL     string
-	apiKey     string
-	hmacSecret string
+	httpClient  *http.Client
+	apiURL      string
+	apiKey      string
+	hmacSecret  string
+	batchSize   int
+	concurrency int
 }
 
 func NewClient(cfg Config) *Client {
+	if cfg.BroadcastBatchSize <= 0 {
+		cfg.BroadcastBatchSize = DefaultBroadcastBatchSize
+	}
+	if cfg.BroadcastConcurrency <= 0 {
+		cfg.BroadcastConcurrency = DefaultBroadcastConcurrency
+	}
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		apiURL:     cfg.APIURL,
-		apiKey:     cfg.APIKey,
-		hmacSecret: cfg.HMACSecret,
+		apiURL:      cfg.APIURL,
+		apiKey:      cfg.APIKey,
+		hmacSecret:  cfg.HMACSecret,
+		batchSize:   cfg.BroadcastBatchSize,
+		concurrency: cfg.BroadcastConcurrency,
 	}
 }
 
 type publishRequest struct {
-	Channel string      `json:"channel"`
-	Data    interface{} `json:"data"`
+	Channel     string      `json:"channel"`
+	Data        interface{} `json:"data"`
+	SkipHistory bool        `json:"skip_history,omitempty"`
 }
 
 type broadcastRequest struct {
-	Channels []string    `json:"channels"`
-	Data     interface{} `json:"data"`
+	Channels    []string    `json:"channels"`
+	Data        interface{} `json:"data"`
+	SkipHistory bool        `json:"skip_history,omitempty"`
+}
+
+type unsubscribeRequest struct {
+	Channel string `json:"channel"`
+	User    string `json:"user"`
 }
 
 type apiRequest struct {
@@ -77,15 +106,22 @@ type apiError struct {
 // PublishToUser publishes an event to a user's personal channel
 func (c *Client) PublishToUser(ctx context.Context, userID string, event interface{}) error {
 	channel := fmt.Sprintf("user:%s", userID)
-	return c.publish(ctx, channel, event)
+	return c.publish(ctx, channel, event, false)
 }
 
 // PublishToChannel publishes an event to a specific channel
 func (c *Client) PublishToChannel(ctx context.Context, channel string, event interface{}) error {
-	return c.publish(ctx, channel, event)
+	return c.publish(ctx, channel, event, false)
 }
 
-// BroadcastToUsers broadcasts an event to multiple users' personal channels in a single request
+// PublishTransient publishes an event to a channel without saving it to the channel history,
+// so short-lived events are not recovered and do not push others out of the history
+func (c *Client) PublishTransient(ctx context.Context, channel string, event interface{}) error {
+	return c.publish(ctx, channel, event, true)
+}
+
+// BroadcastToUsers broadcasts an event to multiple users' personal channels, in batches of at
+// most BroadcastBatchSize channels
 func (c *Client) BroadcastToUsers(ctx context.Context, userIDs []string, event interface{}) error {
 	if len(userIDs) == 0 {
 		return nil
@@ -97,20 +133,96 @@ func (c *Client) BroadcastToUsers(ctx context.Context, userIDs []string, event i
 		channels[i] = fmt.Sprintf("user:%s", userID)
 	}
 
-	return c.broadcast(ctx, channels, event)
+	return c.broadcastBatched(ctx, channels, event, false)
+}
+
+// BroadcastTransient broadcasts an event to users' personal channels without saving it to their
+// history, like PublishTransient
+func (c *Client) BroadcastTransient(ctx context.Context, userIDs []string, event interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	channels := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		channels[i] = fmt.Sprintf("user:%s", userID)
+	}
+
+	return c.broadcastBatched(ctx, channels, event, true)
 }
 
-// Broadcast sends an event to multiple channels in a single request
+// Unsubscribe ends the user's subscriptions to the channel on all of their connections
+func (c *Client) Unsubscribe(ctx context.Context, channel, userID string) error {
+	body, err := json.Marshal(apiRequest{
+		Method: "unsubscribe",
+		Params: unsubscribeRequest{Channel: channel, User: userID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	if err := c.doRequest(ctx, body); err != nil {
+		return fmt.Errorf("unsubscribe from %s failed: %w", channel, err)
+	}
+	return nil
+}
+
+// Broadcast sends an event to multiple channels, in batches of at most BroadcastBatchSize channels
 func (c *Client) Broadcast(ctx context.Context, channels []string, event interface{}) error {
-	return c.broadcast(ctx, channels, event)
+	return c.broadcastBatched(ctx, channels, event, false)
+}
+
+// broadcastBatched splits channels into batches sent up to BroadcastConcurrency at a time. Every
+// batch is attempted even if another fails; the returned error joins the failed ones.
+func (c *Client) broadcastBatched(ctx context.Context, channels []string, event interface{}, skipHistory bool) error {
+	if len(channels) <= c.batchSize {
+		return c.broadcast(ctx, channels, event, skipHistory)
+	}
+
+	// The event is marshaled once rather than for every batch
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, c.concurrency)
+	)
+	for start := 0; start < len(channels); start += c.batchSize {
+		batch := channels[start:min(start+c.batchSize, len(channels))]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := c.broadcast(ctx, batch, json.RawMessage(data), skipHistory); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d broadcast batches failed: %w",
+			len(errs), (len(channels)+c.batchSize-1)/c.batchSize, errors.Join(errs...))
+	}
+	return nil
 }
 
-func (c *Client) broadcast(ctx context.Context, channels []string, data interface{}) error {
+func (c *Client) broadcast(ctx context.Context, channels []string, data interface{}, skipHistory bool) error {
 	req := apiRequest{
 		Method: "broadcast",
 		Params: broadcastRequest{
-			Channels: channels,
-			Data:     data,
+			Channels:    channels,
+			Data:        data,
+			SkipHistory: skipHistory,
 		},
 	}
 
@@ -165,12 +277,13 @@ func (c *Client) broadcast(ctx context.Context, channels []string, data interfac
 	return fmt.Errorf("broadcast failed after %d retries: %w", maxRetries, lastErr)
 }
 
-func (c *Client) publish(ctx context.Context, channel string, data interface{}) error {
+func (c *Client) publish(ctx context.Context, channel string, data interface{}, skipHistory bool) error {
 	req := apiRequest{
 		Method: "publish",
 		Params: publishRequest{
-			Channel: channel,
-			Data:    data,
+			Channel:     channel,
+			Data:        data,
+			SkipHistory: skipHistory,
 		},
 	}
 
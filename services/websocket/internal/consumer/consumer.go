@@ -2,14 +2,19 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/icegreg/chat-smpl/pkg/events"
 	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/pkg/metrics"
 	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
 	"github.com/icegreg/chat-smpl/services/websocket/internal/centrifugo"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/dedup"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/membership"
 	"go.uber.org/zap"
 )
 
@@ -19,25 +24,56 @@ const (
 	ConsumerName = "websocket-service"
 )
 
-// ChatEvent matches the structure from chat-service publisher
-type ChatEvent struct {
-	Type         string          `json:"type"`
-	Timestamp    string          `json:"timestamp"`
-	ActorID      string          `json:"actor_id"`
-	ChatID       string          `json:"chat_id"`
-	Participants []string        `json:"participants"`
-	Data         json.RawMessage `json:"data"`
+// Delivery selects the Centrifugo channels chat events are published to
+type Delivery string
+
+const (
+	// DeliveryUser broadcasts every event to the personal channel of each recipient
+	DeliveryUser Delivery = "user"
+	// DeliveryChat publishes events addressed to the whole chat once, to its chat:{id} channel.
+	// Centrifugo keeps history per channel, so clients recover missed events on resubscribe.
+	DeliveryChat Delivery = "chat"
+)
+
+// userTargetedEvents are not addressed to every chat member, or reach users that are not
+// subscribed to the chat channel yet; they always go to personal channels
+var userTargetedEvents = map[string]bool{
+	"chat.created":      true,
+	"chat.deleted":      true,
+	"chat.member_added": true,
+	"message.ephemeral": true,
+	"draft.updated":     true,
+	"report.created":    true,
+	"thread.reply":      true,
+}
+
+// membershipEvents change who takes part in the chat; the cached participant list is stale after them
+var membershipEvents = map[string]bool{
+	"chat.member_added":   true,
+	"chat.member_removed": true,
+	"chat.deleted":        true,
 }
 
 type Consumer struct {
 	rmqConn    *rabbitmq.Connection
 	centrifugo *centrifugo.Client
+	dedup      dedup.Store
+	delivery   Delivery
+	members    *membership.Resolver
+	metrics    *metrics.FanoutMetrics // Optional
 }
 
-func New(rmqConn *rabbitmq.Connection, centrifugoClient *centrifugo.Client) *Consumer {
+// New creates the chat events consumer. members resolves recipients of events published without
+// a recipient list; fanoutMetrics may be nil.
+func New(rmqConn *rabbitmq.Connection, centrifugoClient *centrifugo.Client, dedupStore dedup.Store, delivery Delivery,
+	members *membership.Resolver, fanoutMetrics *metrics.FanoutMetrics) *Consumer {
 	return &Consumer{
 		rmqConn:    rmqConn,
 		centrifugo: centrifugoClient,
+		dedup:      dedupStore,
+		delivery:   delivery,
+		members:    members,
+		metrics:    fanoutMetrics,
 	}
 }
 
@@ -58,8 +94,10 @@ func (c *Consumer) Setup() error {
 	patterns := []string{
 		"chat.#",
 		"message.#",
-		"typing",
 		"reaction.#",
+		"thread.#",
+		"report.#",
+		"draft.#",
 	}
 
 	for _, pattern := range patterns {
@@ -68,6 +106,12 @@ func (c *Consumer) Setup() error {
 		}
 	}
 
+	// Typing moved to the signals exchange (see SignalConsumer); drop the binding left on the
+	// durable queue by earlier versions
+	if err := c.rmqConn.Channel().QueueUnbind(QueueName, "typing", ExchangeName, nil); err != nil {
+		return fmt.Errorf("failed to unbind typing from queue: %w", err)
+	}
+
 	logger.Info("consumer setup complete",
 		zap.String("queue", QueueName),
 		zap.String("exchange", ExchangeName),
@@ -79,13 +123,18 @@ func (c *Consumer) Setup() error {
 func (c *Consumer) Start(ctx context.Context) error {
 	// Configure consumer with prefetch and worker pool for high throughput
 	// prefetch=100 allows RabbitMQ to deliver up to 100 unacked messages
-	// workers=10 processes messages in parallel
+	// workers=10 processes messages in parallel; events of one chat always go to the same
+	// worker, so clients see them in publish order
+	// Failed events are retried with backoff and then dead-lettered, so a payload Centrifugo
+	// keeps rejecting does not block the queue
 	consumer := rabbitmq.NewConsumer(
 		c.rmqConn,
 		QueueName,
 		ConsumerName,
 		rabbitmq.WithPrefetch(100),
 		rabbitmq.WithWorkers(10),
+		rabbitmq.WithPartitionKey(rabbitmq.JSONPartitionKey("partition_key")),
+		rabbitmq.WithRetryPolicy(rabbitmq.DefaultRetryPolicy()),
 	)
 
 	logger.Info("starting to consume messages",
@@ -98,9 +147,14 @@ func (c *Consumer) Start(ctx context.Context) error {
 }
 
 func (c *Consumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
-	var event ChatEvent
-	if err := json.Unmarshal(msg.Body, &event); err != nil {
-		logger.Error("failed to unmarshal event",
+	event, err := events.Decode(msg.Body)
+	if errors.Is(err, events.ErrUnsupportedVersion) {
+		// Published by a newer chat-service; dead-lettered for replay once this service is upgraded
+		logger.Warn("unsupported event version", zap.Error(err), zap.String("routing_key", msg.RoutingKey))
+		return err
+	}
+	if err != nil {
+		logger.Error("failed to decode event",
 			zap.Error(err),
 			zap.String("routing_key", msg.RoutingKey),
 		)
@@ -109,37 +163,160 @@ func (c *Consumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
 
 	logger.Debug("received event",
 		zap.String("type", event.Type),
+		zap.String("event_id", event.ID),
 		zap.String("chat_id", event.ChatID),
 		zap.String("actor_id", event.ActorID),
-		zap.Int("participants", len(event.Participants)),
+		zap.Int("participants", len(event.Recipients)),
+		zap.String("audience", event.Audience),
 	)
 
-	// Prepare the event to send to users
-	userEvent := map[string]interface{}{
+	// A redelivered event that was already broadcast is dropped
+	if alreadyForwarded(ctx, c.dedup, event) {
+		return nil
+	}
+
+	// Before forwarding, so this and later events of the chat reach the new participant list
+	if membershipEvents[event.Type] {
+		c.members.Invalidate(event.ChatID)
+	}
+
+	if err := c.forward(ctx, event, chatClientEvent(event)); err != nil {
+		return err // Retried with backoff, then dead-lettered
+	}
+
+	// After forwarding, so a removed user still gets the event on the chat channel
+	if err := c.dropSubscriptions(ctx, event); err != nil {
+		return err
+	}
+
+	markForwarded(ctx, c.dedup, event)
+
+	logger.Debug("event forwarded", zap.String("type", event.Type))
+
+	return nil
+}
+
+// chatClientEvent is what clients receive: the payload as data, in the same shape as before the
+// envelope. The event ID lets them drop a duplicate left by a partially failed broadcast.
+func chatClientEvent(event *events.Envelope) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        event.ID,
 		"type":      event.Type,
-		"timestamp": event.Timestamp,
+		"timestamp": event.OccurredAt,
 		"actor_id":  event.ActorID,
 		"chat_id":   event.ChatID,
-		"data":      json.RawMessage(event.Data),
+		"data":      event.Payload,
 	}
+}
 
-	// Use broadcast API to send to all participants in a single HTTP request
-	// This reduces HTTP calls from N (participants) to 1
-	if len(event.Participants) > 0 {
-		if err := c.centrifugo.BroadcastToUsers(ctx, event.Participants, userEvent); err != nil {
-			logger.Error("failed to broadcast to users",
+// forward publishes the event to the chat channel or broadcasts it to the recipients' personal
+// channels, resolving the recipients of compact events
+func (c *Consumer) forward(ctx context.Context, event *events.Envelope, userEvent map[string]interface{}) error {
+	start := time.Now()
+
+	if c.toChatChannel(event) {
+		channel := fmt.Sprintf("chat:%s", event.ChatID)
+		err := c.centrifugo.PublishToChannel(ctx, channel, userEvent)
+		c.observe(DeliveryChat, start, -1, err)
+		if err != nil {
+			logger.Error("failed to publish to chat channel",
 				zap.Error(err),
 				zap.String("event_type", event.Type),
-				zap.Int("participants", len(event.Participants)),
+				zap.String("chat_id", event.ChatID),
 			)
-			return err // Return error to trigger requeue
 		}
+		return err
 	}
 
-	logger.Debug("event broadcasted to participants",
-		zap.String("type", event.Type),
-		zap.Int("count", len(event.Participants)),
-	)
+	recipients := event.Recipients
+	if event.Audience == events.AudienceChat {
+		var err error
+		if recipients, err = c.members.Participants(ctx, event.ChatID); err != nil {
+			c.observe(DeliveryUser, start, -1, err)
+			logger.Error("failed to resolve chat participants",
+				zap.Error(err),
+				zap.String("event_type", event.Type),
+				zap.String("chat_id", event.ChatID),
+			)
+			return err
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	// Broadcast sends to all participants in one HTTP request per batch instead of one per user
+	err := c.centrifugo.BroadcastToUsers(ctx, recipients, userEvent)
+	c.observe(DeliveryUser, start, len(recipients), err)
+	if err != nil {
+		logger.Error("failed to broadcast to users",
+			zap.Error(err),
+			zap.String("event_type", event.Type),
+			zap.Int("participants", len(recipients)),
+		)
+	}
+	return err
+}
 
+// dropSubscriptions ends the chat channel subscriptions of users who lost access to the chat.
+// The gateway refuses them new subscription tokens, but existing subscriptions would go on
+// receiving the chat's events.
+func (c *Consumer) dropSubscriptions(ctx context.Context, event *events.Envelope) error {
+	var userIDs []string
+	switch event.Type {
+	case "chat.member_removed":
+		var data events.ChatMemberRemovedData
+		if err := event.DecodePayload(&data); err != nil {
+			logger.Error("failed to decode chat.member_removed", zap.Error(err), zap.String("event_id", event.ID))
+			return nil
+		}
+		userIDs = []string{data.UserID}
+	case "chat.deleted":
+		userIDs = event.Recipients
+	default:
+		return nil
+	}
+
+	channel := fmt.Sprintf("chat:%s", event.ChatID)
+	var errs []error
+	for _, userID := range userIDs {
+		if err := c.centrifugo.Unsubscribe(ctx, channel, userID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		logger.Error("failed to unsubscribe users from chat channel",
+			zap.Error(err),
+			zap.String("event_type", event.Type),
+			zap.String("chat_id", event.ChatID),
+			zap.Int("failed", len(errs)),
+		)
+		return err
+	}
 	return nil
 }
+
+// observe records a fan-out; recipients is -1 when unknown (published to a chat channel)
+func (c *Consumer) observe(delivery Delivery, start time.Time, recipients int, err error) {
+	observeFanout(c.metrics, delivery, start, recipients, err)
+}
+
+func observeFanout(m *metrics.FanoutMetrics, delivery Delivery, start time.Time, recipients int, err error) {
+	if m == nil {
+		return
+	}
+	label := string(delivery)
+	m.Duration.WithLabelValues(label).Observe(time.Since(start).Seconds())
+	if recipients >= 0 {
+		m.Recipients.WithLabelValues(label).Observe(float64(recipients))
+	}
+	if err != nil {
+		m.Errors.WithLabelValues(label).Inc()
+	}
+}
+
+// toChatChannel reports whether the event is published to the chat channel instead of
+// personal channels
+func (c *Consumer) toChatChannel(event *events.Envelope) bool {
+	return c.delivery == DeliveryChat && event.ChatID != "" && !userTargetedEvents[event.Type]
+}
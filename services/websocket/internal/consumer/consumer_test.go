@@ -0,0 +1,31 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
+)
+
+func TestConsumer_ToChatChannel(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery Delivery
+		event    events.Envelope
+		want     bool
+	}{
+		{"user delivery", DeliveryUser, events.Envelope{Type: "message.created", ChatID: "c1"}, false},
+		{"chat event", DeliveryChat, events.Envelope{Type: "message.created", ChatID: "c1"}, true},
+		{"event without a chat", DeliveryChat, events.Envelope{Type: "message.created"}, false},
+		{"new participant is not subscribed yet", DeliveryChat, events.Envelope{Type: "chat.member_added", ChatID: "c1"}, false},
+		{"event for one user", DeliveryChat, events.Envelope{Type: "message.ephemeral", ChatID: "c1"}, false},
+		{"removed participant still gets the event", DeliveryChat, events.Envelope{Type: "chat.member_removed", ChatID: "c1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Consumer{delivery: tt.delivery}
+			assert.Equal(t, tt.want, c.toChatChannel(&tt.event))
+		})
+	}
+}
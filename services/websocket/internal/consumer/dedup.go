@@ -0,0 +1,38 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/dedup"
+	"go.uber.org/zap"
+)
+
+// alreadyForwarded reports whether the event was forwarded within the dedup window. A failed
+// lookup counts as not forwarded: a duplicate reaches clients, which drop it by event ID.
+// Events without an ID are never deduplicated, as they would all share one key.
+func alreadyForwarded(ctx context.Context, store dedup.Store, event *events.Envelope) bool {
+	if event.ID == "" {
+		return false
+	}
+	seen, err := store.Seen(ctx, event.ID)
+	if err != nil {
+		logger.Warn("dedup lookup failed", zap.Error(err), zap.String("event_id", event.ID))
+		return false
+	}
+	if seen {
+		logger.Debug("dropping duplicate event", zap.String("event_id", event.ID), zap.String("type", event.Type))
+	}
+	return seen
+}
+
+// markForwarded records the event once it reached every recipient
+func markForwarded(ctx context.Context, store dedup.Store, event *events.Envelope) {
+	if event.ID == "" {
+		return
+	}
+	if err := store.Mark(ctx, event.ID); err != nil {
+		logger.Warn("failed to mark event as forwarded", zap.Error(err), zap.String("event_id", event.ID))
+	}
+}
@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/dedup"
+)
+
+func TestAlreadyForwarded(t *testing.T) {
+	ctx := context.Background()
+	store := dedup.NewMemoryStore(dedup.Config{Window: time.Minute, Size: 10})
+
+	event := &events.Envelope{ID: "e1", Type: "message.created"}
+	assert.False(t, alreadyForwarded(ctx, store, event))
+	markForwarded(ctx, store, event)
+	assert.True(t, alreadyForwarded(ctx, store, event))
+
+	// Events without an ID must not all collapse into one
+	noID := &events.Envelope{Type: "message.created"}
+	markForwarded(ctx, store, noID)
+	assert.False(t, alreadyForwarded(ctx, store, noID))
+	seen, err := store.Seen(ctx, "")
+	assert.NoError(t, err)
+	assert.False(t, seen, "an empty ID is not stored")
+}
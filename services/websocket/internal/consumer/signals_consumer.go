@@ -0,0 +1,199 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
+	"github.com/icegreg/chat-smpl/pkg/logger"
+	"github.com/icegreg/chat-smpl/pkg/metrics"
+	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/centrifugo"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/membership"
+	"go.uber.org/zap"
+)
+
+const (
+	ChatSignalsExchangeName  = "chat.signals"
+	VoiceSignalsExchangeName = "voice.signals"
+	SignalsQueueName         = "websocket.signals"
+	SignalsConsumerName      = "websocket-signals-service"
+
+	// signalTTL drops signals older than clients would show them, in the queue and after it
+	signalTTL = 5 * time.Second
+	// signalsQueueLength bounds the queue while no replica consumes it; the oldest signals go first
+	signalsQueueLength = 10000
+)
+
+// SignalConsumer forwards ephemeral signals (typing, speaking) from the non-durable signals
+// exchanges. They skip the dedup window, retries and dead-lettering of events, and are
+// published without channel history: a signal that fails or comes late is dropped.
+type SignalConsumer struct {
+	rmqConn    *rabbitmq.Connection
+	centrifugo *centrifugo.Client
+	delivery   Delivery
+	members    *membership.Resolver
+	metrics    *metrics.FanoutMetrics // Optional
+}
+
+// NewSignalConsumer creates the signals consumer; delivery and members are the ones of the chat
+// events consumer, so signals reach the same channels as chat events. fanoutMetrics may be nil.
+func NewSignalConsumer(rmqConn *rabbitmq.Connection, centrifugoClient *centrifugo.Client, delivery Delivery,
+	members *membership.Resolver, fanoutMetrics *metrics.FanoutMetrics) *SignalConsumer {
+	return &SignalConsumer{
+		rmqConn:    rmqConn,
+		centrifugo: centrifugoClient,
+		delivery:   delivery,
+		members:    members,
+		metrics:    fanoutMetrics,
+	}
+}
+
+func (c *SignalConsumer) Setup() error {
+	for _, exchange := range []string{ChatSignalsExchangeName, VoiceSignalsExchangeName} {
+		if err := c.rmqConn.DeclareExchange(rabbitmq.Exchange{
+			Name:       exchange,
+			Kind:       "topic",
+			Durable:    false,
+			AutoDelete: false,
+		}); err != nil {
+			return fmt.Errorf("failed to declare exchange %s: %w", exchange, err)
+		}
+	}
+
+	// Shared by all replicas like websocket.events, but kept in memory only and bounded in both
+	// age and length
+	_, err := c.rmqConn.DeclareQueue(rabbitmq.Queue{
+		Name:       SignalsQueueName,
+		Durable:    false,
+		AutoDelete: false,
+		Exclusive:  false,
+		NoWait:     false,
+		Args: amqp.Table{
+			"x-message-ttl": signalTTL.Milliseconds(),
+			"x-max-length":  int64(signalsQueueLength),
+			"x-overflow":    "drop-head",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	bindings := []struct {
+		exchange string
+		pattern  string
+	}{
+		{ChatSignalsExchangeName, "typing"},
+		{VoiceSignalsExchangeName, "participant.speaking"},
+	}
+	for _, b := range bindings {
+		if err := c.rmqConn.BindQueue(SignalsQueueName, b.pattern, b.exchange); err != nil {
+			return fmt.Errorf("failed to bind queue with pattern %s: %w", b.pattern, err)
+		}
+	}
+
+	logger.Info("signals consumer setup complete", zap.String("queue", SignalsQueueName))
+
+	return nil
+}
+
+func (c *SignalConsumer) Start(ctx context.Context) error {
+	// Signals of one chat (or conference) share a partition key, so a stop is never overtaken by
+	// the start before it. No retry policy: failed signals are dropped by the handler.
+	consumer := rabbitmq.NewConsumer(
+		c.rmqConn,
+		SignalsQueueName,
+		SignalsConsumerName,
+		rabbitmq.WithPrefetch(200),
+		rabbitmq.WithWorkers(4),
+		rabbitmq.WithPartitionKey(rabbitmq.JSONPartitionKey("partition_key")),
+	)
+
+	logger.Info("starting signals consumer",
+		zap.String("queue", SignalsQueueName),
+		zap.Int("prefetch", 200),
+		zap.Int("workers", 4),
+	)
+
+	return consumer.Consume(ctx, c.handleMessage)
+}
+
+// handleMessage never returns an error, so signals are acked whatever happens to them
+func (c *SignalConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
+	event, err := events.Decode(msg.Body)
+	if err != nil {
+		logger.Warn("dropping undecodable signal", zap.Error(err), zap.String("routing_key", msg.RoutingKey))
+		return nil
+	}
+
+	// Held in the prefetch buffer past its TTL; clients would already have hidden it
+	if age := time.Since(event.OccurredAt); age > signalTTL {
+		logger.Debug("dropping stale signal", zap.String("type", event.Type), zap.Duration("age", age))
+		return nil
+	}
+
+	switch event.Type {
+	case "typing":
+		err = c.forwardTyping(ctx, event)
+	case "participant.speaking":
+		err = c.forwardSpeaking(ctx, event)
+	default:
+		logger.Debug("signal not forwarded to clients", zap.String("type", event.Type))
+	}
+	if err != nil {
+		logger.Warn("dropping signal that failed to forward",
+			zap.Error(err),
+			zap.String("type", event.Type),
+			zap.String("chat_id", event.ChatID),
+		)
+	}
+	return nil
+}
+
+// forwardTyping sends a typing signal to the channels chat events of the chat go to
+func (c *SignalConsumer) forwardTyping(ctx context.Context, event *events.Envelope) error {
+	userEvent := chatClientEvent(event)
+	start := time.Now()
+
+	if c.delivery == DeliveryChat && event.ChatID != "" {
+		err := c.centrifugo.PublishTransient(ctx, fmt.Sprintf("chat:%s", event.ChatID), userEvent)
+		c.observe(DeliveryChat, start, -1, err)
+		return err
+	}
+
+	recipients := event.Recipients
+	if event.Audience == events.AudienceChat {
+		var err error
+		if recipients, err = c.members.Participants(ctx, event.ChatID); err != nil {
+			c.observe(DeliveryUser, start, -1, err)
+			return err
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err := c.centrifugo.BroadcastTransient(ctx, recipients, userEvent)
+	c.observe(DeliveryUser, start, len(recipients), err)
+	return err
+}
+
+// forwardSpeaking sends a speaking signal to the conference channel
+func (c *SignalConsumer) forwardSpeaking(ctx context.Context, event *events.Envelope) error {
+	var data events.SpeakingData
+	if err := event.DecodePayload(&data); err != nil {
+		return err
+	}
+	if data.ConferenceID == "" {
+		return nil // Published by an older voice-service, which did not say where to send it
+	}
+	return c.centrifugo.PublishTransient(ctx, fmt.Sprintf("conference:%s", data.ConferenceID), clientEvent(event))
+}
+
+// observe records a fan-out like Consumer.observe
+func (c *SignalConsumer) observe(delivery Delivery, start time.Time, recipients int, err error) {
+	observeFanout(c.metrics, delivery, start, recipients, err)
+}
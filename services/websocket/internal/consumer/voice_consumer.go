@@ -2,15 +2,17 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/icegreg/chat-smpl/pkg/events"
 	"github.com/icegreg/chat-smpl/pkg/logger"
 	"github.com/icegreg/chat-smpl/pkg/rabbitmq"
 	pb "github.com/icegreg/chat-smpl/proto/chat"
 	"github.com/icegreg/chat-smpl/services/websocket/internal/centrifugo"
+	"github.com/icegreg/chat-smpl/services/websocket/internal/dedup"
 	"go.uber.org/zap"
 )
 
@@ -20,52 +22,19 @@ const (
 	VoiceConsumerName = "websocket-voice-service"
 )
 
-// VoiceEvent represents events from voice-service
-type VoiceEvent struct {
-	// Common fields
-	ID        string `json:"id"`
-	Timestamp string `json:"timestamp,omitempty"`
-
-	// Conference fields
-	ConferenceID     string `json:"conference_id,omitempty"`
-	Name             string `json:"name,omitempty"`
-	ChatID           string `json:"chat_id,omitempty"`
-	CreatedBy        string `json:"created_by,omitempty"`
-	Status           string `json:"status,omitempty"`
-	MaxMembers       int    `json:"max_members,omitempty"`
-	ParticipantCount int    `json:"participant_count,omitempty"`
-
-	// Participant fields
-	UserID      string `json:"user_id,omitempty"`
-	IsMuted     bool   `json:"is_muted,omitempty"`
-	IsDeaf      bool   `json:"is_deaf,omitempty"`
-	IsSpeaking  bool   `json:"is_speaking,omitempty"`
-	Username    string `json:"username,omitempty"`
-	DisplayName string `json:"display_name,omitempty"`
-	AvatarURL   string `json:"avatar_url,omitempty"`
-
-	// Call fields
-	CallerID          string `json:"caller_id,omitempty"`
-	CalleeID          string `json:"callee_id,omitempty"`
-	Duration          int    `json:"duration,omitempty"`
-	EndReason         string `json:"end_reason,omitempty"`
-	CallerUsername    string `json:"caller_username,omitempty"`
-	CallerDisplayName string `json:"caller_display_name,omitempty"`
-	CalleeUsername    string `json:"callee_username,omitempty"`
-	CalleeDisplayName string `json:"callee_display_name,omitempty"`
-}
-
 type VoiceConsumer struct {
 	rmqConn    *rabbitmq.Connection
 	centrifugo *centrifugo.Client
 	chatClient pb.ChatServiceClient
+	dedup      dedup.Store
 }
 
-func NewVoiceConsumer(rmqConn *rabbitmq.Connection, centrifugoClient *centrifugo.Client, chatClient pb.ChatServiceClient) *VoiceConsumer {
+func NewVoiceConsumer(rmqConn *rabbitmq.Connection, centrifugoClient *centrifugo.Client, chatClient pb.ChatServiceClient, dedupStore dedup.Store) *VoiceConsumer {
 	return &VoiceConsumer{
 		rmqConn:    rmqConn,
 		centrifugo: centrifugoClient,
 		chatClient: chatClient,
+		dedup:      dedupStore,
 	}
 }
 
@@ -120,6 +89,9 @@ func (c *VoiceConsumer) Start(ctx context.Context) error {
 		VoiceConsumerName,
 		rabbitmq.WithPrefetch(50),
 		rabbitmq.WithWorkers(5),
+		// Events of one conference (or call) share a partition key and stay in order
+		rabbitmq.WithPartitionKey(rabbitmq.JSONPartitionKey("partition_key")),
+		rabbitmq.WithRetryPolicy(rabbitmq.DefaultRetryPolicy()),
 	)
 
 	logger.Info("starting voice consumer",
@@ -138,55 +110,93 @@ func (c *VoiceConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) er
 		zap.String("routing_key", routingKey),
 	)
 
-	var event VoiceEvent
-	if err := json.Unmarshal(msg.Body, &event); err != nil {
-		logger.Error("failed to unmarshal voice event",
+	event, err := events.Decode(msg.Body)
+	if errors.Is(err, events.ErrUnsupportedVersion) {
+		// Published by a newer voice-service; dead-lettered for replay once this service is upgraded
+		logger.Warn("unsupported voice event version", zap.Error(err), zap.String("routing_key", routingKey))
+		return err
+	}
+	if err != nil {
+		logger.Error("failed to decode voice event",
 			zap.Error(err),
 			zap.String("routing_key", routingKey),
 		)
 		return nil // Don't retry malformed messages
 	}
 
-	// Determine event type and recipients based on routing key
-	switch {
-	case routingKey == "conference.created" || routingKey == "conference.ended":
-		return c.handleConferenceEvent(ctx, routingKey, event, msg.Body)
+	// A redelivered event that was already forwarded is dropped
+	if alreadyForwarded(ctx, c.dedup, event) {
+		return nil
+	}
+	if err := c.forward(ctx, event); err != nil {
+		return err
+	}
+	markForwarded(ctx, c.dedup, event)
+	return nil
+}
 
-	case routingKey == "participant.joined" || routingKey == "participant.left" ||
-		routingKey == "participant.muted" || routingKey == "participant.speaking":
-		return c.handleParticipantEvent(ctx, routingKey, event, msg.Body)
+// forward sends the event to its channels, determined by the event type
+func (c *VoiceConsumer) forward(ctx context.Context, event *events.Envelope) error {
+	switch event.Type {
+	case "conference.created", "conference.ended":
+		var data events.ConferenceData
+		if err := event.DecodePayload(&data); err != nil {
+			logger.Error("failed to decode conference event", zap.Error(err))
+			return nil
+		}
+		return c.handleConferenceEvent(ctx, event, data)
 
-	case routingKey == "call.initiated" || routingKey == "call.answered" || routingKey == "call.ended":
-		return c.handleCallEvent(ctx, routingKey, event, msg.Body)
+	case "participant.joined", "participant.left", "participant.muted":
+		var data events.ParticipantData
+		if err := event.DecodePayload(&data); err != nil {
+			logger.Error("failed to decode participant event", zap.Error(err))
+			return nil
+		}
+		return c.handleParticipantEvent(ctx, event, data)
+
+	case "call.initiated", "call.answered", "call.ended":
+		var data events.CallData
+		if err := event.DecodePayload(&data); err != nil {
+			logger.Error("failed to decode call event", zap.Error(err))
+			return nil
+		}
+		return c.handleCallEvent(ctx, event, data)
 
 	default:
-		logger.Warn("unknown voice event type", zap.String("routing_key", routingKey))
+		logger.Debug("voice event not forwarded to clients", zap.String("type", event.Type))
 		return nil
 	}
 }
 
-func (c *VoiceConsumer) handleConferenceEvent(ctx context.Context, eventType string, event VoiceEvent, rawData []byte) error {
+// clientEvent is what clients receive; the ID lets them drop duplicates
+func clientEvent(env *events.Envelope) map[string]interface{} {
+	return map[string]interface{}{
+		"id":   env.ID,
+		"type": env.Type,
+		"data": env.Payload,
+	}
+}
+
+func (c *VoiceConsumer) handleConferenceEvent(ctx context.Context, env *events.Envelope, event events.ConferenceData) error {
+	eventType := env.Type
 	// Conference events are sent to the conference channel if chat_id exists,
 	// otherwise to the creator's personal channel
-	voiceEvent := map[string]interface{}{
-		"type": eventType,
-		"data": json.RawMessage(rawData),
-	}
+	voiceEvent := clientEvent(env)
 
-	if event.ChatID != "" {
+	if event.ChatID != nil && *event.ChatID != "" {
 		// Broadcast to chat channel - all chat participants will receive it
-		channel := fmt.Sprintf("chat:%s", event.ChatID)
+		channel := fmt.Sprintf("chat:%s", *event.ChatID)
 		if err := c.centrifugo.PublishToChannel(ctx, channel, voiceEvent); err != nil {
 			logger.Error("failed to publish conference event to chat channel",
 				zap.Error(err),
 				zap.String("event_type", eventType),
-				zap.String("chat_id", event.ChatID),
+				zap.Stringp("chat_id", event.ChatID),
 			)
 			return err
 		}
 		logger.Debug("conference event published to chat",
 			zap.String("event_type", eventType),
-			zap.String("chat_id", event.ChatID),
+			zap.Stringp("chat_id", event.ChatID),
 		)
 	}
 
@@ -206,11 +216,9 @@ func (c *VoiceConsumer) handleConferenceEvent(ctx context.Context, eventType str
 	return nil
 }
 
-func (c *VoiceConsumer) handleParticipantEvent(ctx context.Context, eventType string, event VoiceEvent, rawData []byte) error {
-	voiceEvent := map[string]interface{}{
-		"type": eventType,
-		"data": json.RawMessage(rawData),
-	}
+func (c *VoiceConsumer) handleParticipantEvent(ctx context.Context, env *events.Envelope, event events.ParticipantData) error {
+	eventType := env.Type
+	voiceEvent := clientEvent(env)
 
 	// Send to conference channel
 	if event.ConferenceID != "" {
@@ -239,9 +247,12 @@ func (c *VoiceConsumer) handleParticipantEvent(ctx context.Context, eventType st
 
 	// Send system message to chat when user joins/leaves conference
 	if c.chatClient != nil && event.ChatID != "" {
-		userName := event.DisplayName
-		if userName == "" {
-			userName = event.Username
+		var userName string
+		if event.DisplayName != nil {
+			userName = *event.DisplayName
+		}
+		if userName == "" && event.Username != nil {
+			userName = *event.Username
 		}
 		if userName == "" {
 			userName = "User"
@@ -284,11 +295,9 @@ func (c *VoiceConsumer) handleParticipantEvent(ctx context.Context, eventType st
 	return nil
 }
 
-func (c *VoiceConsumer) handleCallEvent(ctx context.Context, eventType string, event VoiceEvent, rawData []byte) error {
-	voiceEvent := map[string]interface{}{
-		"type": eventType,
-		"data": json.RawMessage(rawData),
-	}
+func (c *VoiceConsumer) handleCallEvent(ctx context.Context, env *events.Envelope, event events.CallData) error {
+	eventType := env.Type
+	voiceEvent := clientEvent(env)
 
 	// For call events, send to both caller and callee
 	var recipients []string
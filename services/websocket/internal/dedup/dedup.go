@@ -0,0 +1,32 @@
+// Package dedup remembers the IDs of events websocket-service already forwarded, so an event
+// redelivered by RabbitMQ (after a failed ack or a retried publish) is not sent to clients again.
+// IDs are kept for a window: in memory for a single replica, or in Redis (with a local cache in
+// front) so that all replicas share the window.
+package dedup
+
+import (
+	"context"
+	"time"
+)
+
+// Config sets how long and how many event IDs are remembered
+type Config struct {
+	Window time.Duration
+	Size   int // Maximum IDs kept in memory; the least recently marked are dropped first
+}
+
+// DefaultConfig covers the retry policy's delays with room for a slow redelivery
+func DefaultConfig() Config {
+	return Config{
+		Window: 10 * time.Minute,
+		Size:   100000,
+	}
+}
+
+// Store keeps the IDs of handled events
+type Store interface {
+	// Seen reports whether id was marked within the window
+	Seen(ctx context.Context, id string) (bool, error)
+	// Mark records id as handled
+	Mark(ctx context.Context, id string) error
+}
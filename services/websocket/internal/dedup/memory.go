@@ -0,0 +1,69 @@
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	id       string
+	markedAt time.Time
+}
+
+// MemoryStore is an LRU of event IDs in process memory (single replica only)
+type MemoryStore struct {
+	mu      sync.Mutex
+	window  time.Duration
+	size    int
+	order   *list.List // Front is the most recently marked
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore(cfg Config) *MemoryStore {
+	return &MemoryStore{
+		window:  cfg.Window,
+		size:    cfg.Size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Seen(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[id]
+	if !ok {
+		return false, nil
+	}
+	if s.now().Sub(el.Value.(*entry).markedAt) >= s.window {
+		s.order.Remove(el)
+		delete(s.entries, id)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *MemoryStore) Mark(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[id]; ok {
+		el.Value.(*entry).markedAt = s.now()
+		s.order.MoveToFront(el)
+		return nil
+	}
+
+	s.entries[id] = s.order.PushFront(&entry{id: id, markedAt: s.now()})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*entry).id)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemoryStore returns a store with a clock the test advances
+func newTestMemoryStore(cfg Config) (*MemoryStore, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryStore(cfg)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func seen(t *testing.T, s Store, id string) bool {
+	t.Helper()
+	ok, err := s.Seen(context.Background(), id)
+	require.NoError(t, err)
+	return ok
+}
+
+func TestMemoryStore_Window(t *testing.T) {
+	ctx := context.Background()
+	s, now := newTestMemoryStore(Config{Window: time.Minute, Size: 10})
+
+	assert.False(t, seen(t, s, "a"))
+	require.NoError(t, s.Mark(ctx, "a"))
+	assert.True(t, seen(t, s, "a"))
+
+	*now = now.Add(59 * time.Second)
+	assert.True(t, seen(t, s, "a"))
+
+	*now = now.Add(time.Second)
+	assert.False(t, seen(t, s, "a"), "expired at the end of the window")
+	assert.Equal(t, 0, s.order.Len(), "expired ids are dropped")
+}
+
+func TestMemoryStore_MarkAgainExtendsWindow(t *testing.T) {
+	ctx := context.Background()
+	s, now := newTestMemoryStore(Config{Window: time.Minute, Size: 10})
+
+	require.NoError(t, s.Mark(ctx, "a"))
+	*now = now.Add(50 * time.Second)
+	require.NoError(t, s.Mark(ctx, "a"))
+	*now = now.Add(50 * time.Second)
+
+	assert.True(t, seen(t, s, "a"))
+	assert.Equal(t, 1, s.order.Len())
+}
+
+func TestMemoryStore_EvictsLeastRecentlyMarked(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestMemoryStore(Config{Window: time.Minute, Size: 2})
+
+	require.NoError(t, s.Mark(ctx, "a"))
+	require.NoError(t, s.Mark(ctx, "b"))
+	require.NoError(t, s.Mark(ctx, "a")) // a is now the most recent
+	require.NoError(t, s.Mark(ctx, "c"))
+
+	assert.True(t, seen(t, s, "a"))
+	assert.False(t, seen(t, s, "b"), "evicted")
+	assert.True(t, seen(t, s, "c"))
+	assert.Len(t, s.entries, 2)
+}
@@ -0,0 +1,46 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "websocket:event:"
+
+// RedisStore shares event IDs between websocket-service replicas. IDs this replica marked are
+// answered from a local MemoryStore without a round trip.
+type RedisStore struct {
+	client *redis.Client
+	cfg    Config
+	local  *MemoryStore
+}
+
+// NewRedisStore creates a Redis-backed store
+func NewRedisStore(client *redis.Client, cfg Config) *RedisStore {
+	return &RedisStore{
+		client: client,
+		cfg:    cfg,
+		local:  NewMemoryStore(cfg),
+	}
+}
+
+func (s *RedisStore) Seen(ctx context.Context, id string) (bool, error) {
+	if seen, _ := s.local.Seen(ctx, id); seen {
+		return true, nil
+	}
+	n, err := s.client.Exists(ctx, keyPrefix+id).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to look up event id: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (s *RedisStore) Mark(ctx context.Context, id string) error {
+	_ = s.local.Mark(ctx, id)
+	if err := s.client.Set(ctx, keyPrefix+id, 1, s.cfg.Window).Err(); err != nil {
+		return fmt.Errorf("failed to mark event id: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package dedup
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStore_LocalCache(t *testing.T) {
+	ctx := context.Background()
+	// Nothing listens on the port: every Redis call fails
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer client.Close()
+	s := NewRedisStore(client, Config{Window: time.Minute, Size: 10})
+
+	assert.Error(t, s.Mark(ctx, "a"), "a failed write is reported")
+	assert.True(t, seen(t, s, "a"), "ids marked by this replica are answered locally")
+
+	_, err := s.Seen(ctx, "b")
+	assert.Error(t, err, "unknown ids are looked up in Redis")
+}
+
+func TestRedisStore_SharedBetweenReplicas(t *testing.T) {
+	if os.Getenv("INTEGRATION_TESTS") != "true" {
+		t.Skip("Skipping integration test. Set INTEGRATION_TESTS=true to run.")
+	}
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	defer client.Close()
+	cfg := Config{Window: time.Second, Size: 10}
+	replica1 := NewRedisStore(client, cfg)
+	replica2 := NewRedisStore(client, cfg)
+
+	id := uuid.NewString()
+	assert.False(t, seen(t, replica2, id))
+	require.NoError(t, replica1.Mark(ctx, id))
+	assert.True(t, seen(t, replica2, id), "marks are shared through Redis")
+
+	// Redis drops the key after the window; the other replica has no local entry
+	time.Sleep(cfg.Window + 100*time.Millisecond)
+	assert.False(t, seen(t, replica2, id))
+}
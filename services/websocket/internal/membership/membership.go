@@ -0,0 +1,164 @@
+// Package membership resolves the participants of a chat for events published without a
+// recipient list (audience "chat"). Lists are fetched from chat-service and cached for a short
+// TTL. The consumer invalidates a chat's list when its membership changes; the TTL bounds how
+// long a list stays stale if such an event is lost.
+package membership
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/icegreg/chat-smpl/pkg/metrics"
+	pb "github.com/icegreg/chat-smpl/proto/chat"
+)
+
+// pageSize is the number of participants fetched per ListParticipants call
+const pageSize = 1000
+
+type Config struct {
+	// TTL is how long a chat's participant list is reused
+	TTL time.Duration
+	// Size is the most chats kept in the cache
+	Size int
+}
+
+// DefaultConfig returns the default cache configuration
+func DefaultConfig() Config {
+	return Config{
+		TTL:  30 * time.Second,
+		Size: 10000,
+	}
+}
+
+type entry struct {
+	chatID    string
+	userIDs   []string
+	fetchedAt time.Time
+}
+
+// Resolver returns chat participants from an LRU cache, loading misses from chat-service.
+// Events of one chat are handled by a single consumer worker, so concurrent misses for the same
+// chat are rare and not coalesced.
+type Resolver struct {
+	client  pb.ChatServiceClient
+	metrics *metrics.FanoutMetrics // Optional
+
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	order   *list.List // Front is the most recently fetched
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+// NewResolver creates a resolver backed by chat-service. m may be nil.
+func NewResolver(client pb.ChatServiceClient, cfg Config, m *metrics.FanoutMetrics) *Resolver {
+	return &Resolver{
+		client:  client,
+		metrics: m,
+		ttl:     cfg.TTL,
+		size:    cfg.Size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Participants returns the user IDs of the chat's participants
+func (r *Resolver) Participants(ctx context.Context, chatID string) ([]string, error) {
+	if userIDs, ok := r.cached(chatID); ok {
+		r.observe("hit")
+		return userIDs, nil
+	}
+
+	userIDs, err := r.fetch(ctx, chatID)
+	if err != nil {
+		r.observe("error")
+		return nil, err
+	}
+	r.observe("miss")
+	r.store(chatID, userIDs)
+	return userIDs, nil
+}
+
+// Invalidate drops the cached participants of the chat, so they are loaded again for its next
+// event
+func (r *Resolver) Invalidate(chatID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.entries[chatID]; ok {
+		r.order.Remove(el)
+		delete(r.entries, chatID)
+	}
+}
+
+func (r *Resolver) cached(chatID string) ([]string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.entries[chatID]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if r.now().Sub(e.fetchedAt) >= r.ttl {
+		r.order.Remove(el)
+		delete(r.entries, chatID)
+		return nil, false
+	}
+	return e.userIDs, true
+}
+
+func (r *Resolver) store(chatID string, userIDs []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.entries[chatID]; ok {
+		e := el.Value.(*entry)
+		e.userIDs = userIDs
+		e.fetchedAt = r.now()
+		r.order.MoveToFront(el)
+		return
+	}
+
+	r.entries[chatID] = r.order.PushFront(&entry{chatID: chatID, userIDs: userIDs, fetchedAt: r.now()})
+	for r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*entry).chatID)
+	}
+}
+
+func (r *Resolver) fetch(ctx context.Context, chatID string) ([]string, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("chat service is not connected")
+	}
+
+	var userIDs []string
+	for page := int32(1); ; page++ {
+		resp, err := r.client.ListParticipants(ctx, &pb.ListParticipantsRequest{
+			ChatId: chatID,
+			Page:   page,
+			Count:  pageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list participants of chat %s: %w", chatID, err)
+		}
+		for _, p := range resp.Participants {
+			userIDs = append(userIDs, p.UserId)
+		}
+		if len(resp.Participants) < pageSize || page >= resp.GetPagination().GetTotalPages() {
+			return userIDs, nil
+		}
+	}
+}
+
+func (r *Resolver) observe(result string) {
+	if r.metrics != nil {
+		r.metrics.MembershipLookups.WithLabelValues(result).Inc()
+	}
+}
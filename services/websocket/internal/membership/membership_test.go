@@ -0,0 +1,173 @@
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pb "github.com/icegreg/chat-smpl/proto/chat"
+)
+
+// fakeChatService serves ListParticipants from a map and counts the calls
+type fakeChatService struct {
+	pb.ChatServiceClient
+
+	participants map[string][]string
+	err          error
+	calls        int
+}
+
+func (f *fakeChatService) ListParticipants(ctx context.Context, in *pb.ListParticipantsRequest, opts ...grpc.CallOption) (*pb.ListParticipantsResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	users := f.participants[in.ChatId]
+	start := min(int(in.Page-1)*int(in.Count), len(users))
+	end := min(start+int(in.Count), len(users))
+	resp := &pb.ListParticipantsResponse{
+		Pagination: &pb.Pagination{TotalPages: int32((len(users) + int(in.Count) - 1) / int(in.Count))},
+	}
+	for _, id := range users[start:end] {
+		resp.Participants = append(resp.Participants, &pb.ChatParticipant{UserId: id})
+	}
+	return resp, nil
+}
+
+// newTestResolver returns a resolver on a settable clock
+func newTestResolver(client *fakeChatService, cfg Config) (*Resolver, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := NewResolver(client, cfg, nil)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestResolver_Participants(t *testing.T) {
+	cfg := Config{TTL: 30 * time.Second, Size: 2}
+
+	tests := []struct {
+		name string
+		// run resolves chats and returns the expected number of ListParticipants calls
+		run func(t *testing.T, r *Resolver, now *time.Time) int
+	}{
+		{
+			name: "repeated lookups are served from the cache",
+			run: func(t *testing.T, r *Resolver, now *time.Time) int {
+				resolve(t, r, "a")
+				*now = now.Add(29 * time.Second)
+				resolve(t, r, "a")
+				return 1
+			},
+		},
+		{
+			name: "expired entries are loaded again",
+			run: func(t *testing.T, r *Resolver, now *time.Time) int {
+				resolve(t, r, "a")
+				*now = now.Add(30 * time.Second)
+				resolve(t, r, "a")
+				return 2
+			},
+		},
+		{
+			name: "least recently fetched chat is evicted",
+			run: func(t *testing.T, r *Resolver, now *time.Time) int {
+				resolve(t, r, "a")
+				resolve(t, r, "b")
+				resolve(t, r, "c")
+				resolve(t, r, "c")
+				resolve(t, r, "b")
+				resolve(t, r, "a")
+				return 4
+			},
+		},
+		{
+			name: "invalidated chat is loaded again",
+			run: func(t *testing.T, r *Resolver, now *time.Time) int {
+				resolve(t, r, "a")
+				resolve(t, r, "b")
+				r.Invalidate("a")
+				r.Invalidate("unknown")
+				resolve(t, r, "a")
+				resolve(t, r, "b")
+				return 3
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeChatService{participants: map[string][]string{
+				"a": {"u1", "u2"},
+				"b": {"u3"},
+				"c": {},
+			}}
+			r, now := newTestResolver(client, cfg)
+			wantCalls := tt.run(t, r, now)
+			assert.Equal(t, wantCalls, client.calls)
+		})
+	}
+}
+
+func resolve(t *testing.T, r *Resolver, chatID string) {
+	t.Helper()
+	_, err := r.Participants(context.Background(), chatID)
+	require.NoError(t, err)
+}
+
+func TestResolver_InvalidateReturnsNewMembers(t *testing.T) {
+	client := &fakeChatService{participants: map[string][]string{"a": {"u1"}}}
+	r, _ := newTestResolver(client, DefaultConfig())
+
+	users, err := r.Participants(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, users)
+
+	client.participants["a"] = []string{"u1", "u2"}
+	r.Invalidate("a")
+
+	users, err = r.Participants(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, users)
+}
+
+func TestResolver_Pagination(t *testing.T) {
+	users := make([]string, 2*pageSize+1)
+	for i := range users {
+		users[i] = fmt.Sprintf("u%d", i)
+	}
+	client := &fakeChatService{participants: map[string][]string{"a": users}}
+	r, _ := newTestResolver(client, DefaultConfig())
+
+	got, err := r.Participants(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, users, got)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestResolver_ErrorsAreNotCached(t *testing.T) {
+	client := &fakeChatService{err: errors.New("unavailable")}
+	r, _ := newTestResolver(client, DefaultConfig())
+
+	_, err := r.Participants(context.Background(), "a")
+	assert.ErrorIs(t, err, client.err)
+
+	client.err = nil
+	client.participants = map[string][]string{"a": {"u1"}}
+	users, err := r.Participants(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, users)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestResolver_NoClient(t *testing.T) {
+	r := NewResolver(nil, DefaultConfig(), nil)
+	_, err := r.Participants(context.Background(), "a")
+	assert.Error(t, err)
+}
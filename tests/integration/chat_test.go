@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -357,3 +359,137 @@ func TestChat_List_CursorPagination(t *testing.T) {
 		assert.True(t, hasMore.(bool), "Should have more chats")
 	}
 }
+
+func TestChat_Drafts(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	user := createTestUser(t, "draftowner")
+	outsider := createTestUser(t, "draftoutsider")
+	chat := createTestChat(t, user, "group", "Drafts Chat", nil)
+	draftURL := apiGatewayURL + "/api/chats/" + chat.ID + "/draft"
+
+	resp, body := doRequest(t, "PUT", draftURL, map[string]interface{}{"content": "unsent text"}, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	resp, body = doRequest(t, "GET", apiGatewayURL+"/api/chats/drafts?chat_id="+chat.ID, nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+	var result struct {
+		Drafts []struct {
+			Content string `json:"content"`
+		} `json:"drafts"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.Drafts, 1)
+	assert.Equal(t, "unsent text", result.Drafts[0].Content)
+
+	// An oversized draft is rejected as a bad request, not an internal error
+	resp, body = doRequest(t, "PUT", draftURL, map[string]interface{}{"content": strings.Repeat("a", 10001)}, user.AccessToken)
+	assertErrorResponse(t, resp, body, http.StatusBadRequest)
+
+	resp, _ = doRequest(t, "PUT", draftURL, map[string]interface{}{"content": "not mine"}, outsider.AccessToken)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, _ = doRequest(t, "DELETE", draftURL, nil, user.AccessToken)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, body = doRequest(t, "GET", apiGatewayURL+"/api/chats/drafts?chat_id="+chat.ID, nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	result.Drafts = nil
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Empty(t, result.Drafts)
+}
+
+// chatListChanges is the response of GET /api/chats/changes
+type chatListChanges struct {
+	Changes []struct {
+		Version    int64  `json:"version"`
+		ChatID     string `json:"chat_id"`
+		ChangeType string `json:"change_type"`
+	} `json:"changes"`
+	CurrentVersion int64 `json:"current_version"`
+	HasMore        bool  `json:"has_more"`
+	ResetRequired  bool  `json:"reset_required"`
+}
+
+func getChatListChanges(t *testing.T, user *TestUser, sinceVersion int64) chatListChanges {
+	t.Helper()
+
+	url := fmt.Sprintf("%s/api/chats/changes?since_version=%d&limit=1000", apiGatewayURL, sinceVersion)
+	resp, body := doRequest(t, "GET", url, nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result chatListChanges
+	require.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
+
+func TestChat_ListChanges(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	user := createTestUser(t, "listchanges")
+	chat := createTestChat(t, user, "group", "List Changes Chat", nil)
+
+	initial := getChatListChanges(t, user, 0)
+	require.NotEmpty(t, initial.Changes)
+	assert.Equal(t, chat.ID, initial.Changes[len(initial.Changes)-1].ChatID)
+	assert.Equal(t, "chat_created", initial.Changes[len(initial.Changes)-1].ChangeType)
+
+	resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chat.ID+"/favorite", nil, user.AccessToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Response: %s", string(body))
+
+	changes := getChatListChanges(t, user, initial.CurrentVersion)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, "favorited", changes.Changes[0].ChangeType)
+	assert.Equal(t, initial.CurrentVersion+1, changes.CurrentVersion)
+
+	// Up to date: nothing after the current version
+	upToDate := getChatListChanges(t, user, changes.CurrentVersion)
+	assert.Empty(t, upToDate.Changes)
+	assert.False(t, upToDate.ResetRequired)
+
+	// Changes of other users are not visible
+	other := createTestUser(t, "listchangesother")
+	assert.Empty(t, getChatListChanges(t, other, 0).Changes)
+
+	resp, body = doRequest(t, "GET", apiGatewayURL+"/api/chats/changes", nil, "")
+	assertErrorResponse(t, resp, body, http.StatusUnauthorized)
+}
+
+func TestChat_ListChanges_Concurrent(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	alice := createTestUser(t, "listchangesa")
+	bob := createTestUser(t, "listchangesb")
+
+	// Both users create chats with each other at the same time, so every change bumps
+	// the versions of the same two users from both sides
+	const perUser = 10
+	var wg sync.WaitGroup
+	for i := 0; i < perUser; i++ {
+		for _, pair := range [][2]*TestUser{{alice, bob}, {bob, alice}} {
+			wg.Add(1)
+			go func(creator, member *TestUser, i int) {
+				defer wg.Done()
+				chatReq := map[string]interface{}{
+					"type":            "group",
+					"name":            fmt.Sprintf("Concurrent Changes %d", i),
+					"participant_ids": []string{member.ID},
+				}
+				resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats", chatReq, creator.AccessToken)
+				assert.Equal(t, http.StatusCreated, resp.StatusCode, "Response: %s", string(body))
+			}(pair[0], pair[1], i)
+		}
+	}
+	wg.Wait()
+
+	for _, user := range []*TestUser{alice, bob} {
+		changes := getChatListChanges(t, user, 0)
+		require.Len(t, changes.Changes, 2*perUser)
+		assert.Equal(t, int64(2*perUser), changes.CurrentVersion)
+
+		// Versions are distinct and gapless
+		for i, change := range changes.Changes {
+			assert.Equal(t, int64(i+1), change.Version)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// moveMessages moves messages of a chat into a new thread and returns the thread ID
+func moveMessages(t *testing.T, user *TestUser, chatID string, messageIDs []string) string {
+	t.Helper()
+
+	moveReq := map[string]interface{}{
+		"message_ids":      messageIDs,
+		"new_thread_title": "Moved messages",
+	}
+	resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chatID+"/messages/move", moveReq, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result struct {
+		Thread struct {
+			ID string `json:"id"`
+		} `json:"thread"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.NotEmpty(t, result.Thread.ID)
+	return result.Thread.ID
+}
+
+// syncResult is the response of the message sync endpoint
+type syncResult struct {
+	Messages   []TestMessage `json:"messages"`
+	Tombstones []struct {
+		MessageID  string `json:"message_id"`
+		ThreadID   string `json:"thread_id"`
+		ChangeType string `json:"change_type"`
+	} `json:"tombstones"`
+	LastSeqNum int64 `json:"last_seq_num"`
+}
+
+// changeTypes maps the IDs of the tombstoned messages to their change type
+func (r syncResult) changeTypes() map[string]string {
+	types := make(map[string]string, len(r.Tombstones))
+	for _, ts := range r.Tombstones {
+		types[ts.MessageID] = ts.ChangeType
+	}
+	return types
+}
+
+// syncMessages syncs the main chat, or a thread when threadID is set, after a sequence number
+func syncMessages(t *testing.T, user *TestUser, chatID, threadID string, afterSeq int64) syncResult {
+	t.Helper()
+
+	url := fmt.Sprintf("%s/api/chats/%s/messages/sync?after_seq=%d&limit=100", apiGatewayURL, chatID, afterSeq)
+	if threadID != "" {
+		url += "&thread_id=" + threadID
+	}
+	resp, body := doRequest(t, "GET", url, nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result syncResult
+	require.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
+
+// listMessageIDs returns the IDs of the messages listed at url
+func listMessageIDs(t *testing.T, user *TestUser, url string) []string {
+	t.Helper()
+
+	resp, body := doRequest(t, "GET", url, nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result struct {
+		Messages []TestMessage `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	ids := make([]string, 0, len(result.Messages))
+	for _, m := range result.Messages {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+// followedThreadIDs lists the IDs of the threads the user follows
+func followedThreadIDs(t *testing.T, user *TestUser) []string {
+	t.Helper()
+
+	resp, body := doRequest(t, "GET", apiGatewayURL+"/api/chats/threads/followed?count=100", nil, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result struct {
+		Threads []struct {
+			Thread struct {
+				ID string `json:"id"`
+			} `json:"thread"`
+		} `json:"threads"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+	ids := make([]string, len(result.Threads))
+	for i, ft := range result.Threads {
+		ids[i] = ft.Thread.ID
+	}
+	return ids
+}
+
+func TestThread_MoveMessages(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	user := createTestUser(t, "move")
+	chat := createTestChat(t, user, "group", "Move Messages Chat", nil)
+	msg1 := sendTestMessage(t, user, chat.ID, "Off-topic 1")
+	msg2 := sendTestMessage(t, user, chat.ID, "Off-topic 2")
+	msg3 := sendTestMessage(t, user, chat.ID, "On topic")
+
+	// Repeated IDs are moved and counted once
+	moveReq := map[string]interface{}{
+		"message_ids":      []string{msg1.ID, msg2.ID, msg1.ID},
+		"new_thread_title": "Off-topic",
+	}
+	resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chat.ID+"/messages/move", moveReq, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+
+	var result struct {
+		Thread struct {
+			ID           string `json:"id"`
+			ChatID       string `json:"chat_id"`
+			Title        string `json:"title"`
+			MessageCount int    `json:"message_count"`
+		} `json:"thread"`
+		MovedCount int `json:"moved_count"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, 2, result.MovedCount)
+	assert.Equal(t, chat.ID, result.Thread.ChatID)
+	assert.Equal(t, "Off-topic", result.Thread.Title)
+	assert.Equal(t, 2, result.Thread.MessageCount)
+
+	threadIDs := listMessageIDs(t, user, apiGatewayURL+"/api/chats/threads/"+result.Thread.ID+"/messages")
+	assert.ElementsMatch(t, []string{msg1.ID, msg2.ID}, threadIDs)
+
+	chatIDs := listMessageIDs(t, user, apiGatewayURL+"/api/chats/"+chat.ID+"/messages?limit=50")
+	assert.Contains(t, chatIDs, msg3.ID)
+	assert.NotContains(t, chatIDs, msg1.ID)
+	assert.NotContains(t, chatIDs, msg2.ID)
+}
+
+func TestThread_MoveMessages_NotAdmin(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	owner := createTestUser(t, "moveowner")
+	member := createTestUser(t, "movemember")
+	chat := createTestChat(t, owner, "group", "Move Not Admin Chat", []string{member.ID})
+	msg := sendTestMessage(t, member, chat.ID, "Cannot move this")
+
+	moveReq := map[string]interface{}{
+		"message_ids": []string{msg.ID},
+	}
+	resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chat.ID+"/messages/move", moveReq, member.AccessToken)
+
+	assertErrorResponse(t, resp, body, http.StatusForbidden)
+}
+
+func TestThread_SyncTombstones(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	user := createTestUser(t, "tombstone")
+	chat := createTestChat(t, user, "group", "Tombstone Sync Chat", nil)
+	moved := sendTestMessage(t, user, chat.ID, "Moved, then edited")
+	deleted := sendTestMessage(t, user, chat.ID, "Deleted")
+	edited := sendTestMessage(t, user, chat.ID, "Edited")
+
+	cursor := syncMessages(t, user, chat.ID, "", 0).LastSeqNum
+	require.Positive(t, cursor)
+
+	threadID := moveMessages(t, user, chat.ID, []string{moved.ID})
+
+	// A later edit in the target thread must not hide the move from the main chat
+	resp, body := doRequest(t, "PUT", apiGatewayURL+"/api/chats/messages/"+moved.ID, map[string]string{"content": "Edited in thread"}, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+	resp, body = doRequest(t, "PUT", apiGatewayURL+"/api/chats/messages/"+edited.ID, map[string]string{"content": "Edited in chat"}, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+	resp, body = doRequest(t, "DELETE", apiGatewayURL+"/api/chats/messages/"+deleted.ID, nil, user.AccessToken)
+	require.True(t, resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK,
+		"Expected 204 or 200, got %d. Response: %s", resp.StatusCode, string(body))
+
+	// Changes to a message that was always in the thread are not reported to the main chat
+	resp, body = doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chat.ID+"/messages",
+		map[string]string{"content": "Sent in thread", "thread_id": threadID}, user.AccessToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Response: %s", string(body))
+	var inThread TestMessage
+	require.NoError(t, json.Unmarshal(body, &inThread))
+	resp, body = doRequest(t, "PUT", apiGatewayURL+"/api/chats/messages/"+inThread.ID, map[string]string{"content": "Edited in thread"}, user.AccessToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Response: %s", string(body))
+	inThreadEdited := syncMessages(t, user, chat.ID, "", cursor)
+	assert.NotContains(t, inThreadEdited.changeTypes(), inThread.ID)
+	resp, body = doRequest(t, "DELETE", apiGatewayURL+"/api/chats/messages/"+inThread.ID, nil, user.AccessToken)
+	require.True(t, resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK,
+		"Expected 204 or 200, got %d. Response: %s", resp.StatusCode, string(body))
+
+	main := syncMessages(t, user, chat.ID, "", cursor)
+	assert.Empty(t, main.Messages)
+	assert.Equal(t, map[string]string{
+		moved.ID:   "moved",
+		edited.ID:  "edited",
+		deleted.ID: "deleted",
+	}, main.changeTypes())
+	for _, ts := range main.Tombstones {
+		if ts.MessageID == moved.ID {
+			assert.Equal(t, threadID, ts.ThreadID)
+		}
+	}
+	assert.Greater(t, main.LastSeqNum, cursor)
+
+	thread := syncMessages(t, user, chat.ID, threadID, cursor)
+	assert.Equal(t, map[string]string{moved.ID: "edited", inThread.ID: "deleted"}, thread.changeTypes())
+
+	// Nothing changed since the last sync
+	again := syncMessages(t, user, chat.ID, "", main.LastSeqNum)
+	assert.Empty(t, again.Messages)
+	assert.Empty(t, again.Tombstones)
+}
+
+func TestThread_FollowedThreads_Restricted(t *testing.T) {
+	SkipIfNotIntegration(t)
+
+	owner := createTestUser(t, "followowner")
+	member := createTestUser(t, "followmember")
+	chat := createTestChat(t, owner, "group", "Followed Threads Chat", []string{member.ID})
+
+	threadReq := map[string]interface{}{
+		"thread_type":             "user",
+		"title":                   "Restricted",
+		"restricted_participants": true,
+	}
+	resp, body := doRequest(t, "POST", apiGatewayURL+"/api/chats/"+chat.ID+"/threads", threadReq, owner.AccessToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Response: %s", string(body))
+	var thread struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(body, &thread))
+	threadURL := apiGatewayURL + "/api/chats/threads/" + thread.ID
+
+	resp, body = doRequest(t, "POST", threadURL+"/participants", map[string]string{"user_id": member.ID}, owner.AccessToken)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Response: %s", string(body))
+	resp, body = doRequest(t, "POST", threadURL+"/follow", nil, member.AccessToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, "Response: %s", string(body))
+	assert.Contains(t, followedThreadIDs(t, member), thread.ID)
+
+	// Removed from the restricted thread, the member keeps the subscription but no longer sees the thread
+	resp, body = doRequest(t, "DELETE", threadURL+"/participants/"+member.ID, nil, owner.AccessToken)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, "Response: %s", string(body))
+	assert.NotContains(t, followedThreadIDs(t, member), thread.ID)
+}
@@ -0,0 +1,28 @@
+// eventdocs renders docs/asyncapi.yaml from the event registry in pkg/events.
+//
+//	go run ./tools/eventdocs
+//	go run ./tools/eventdocs -out /tmp/asyncapi.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/icegreg/chat-smpl/pkg/events"
+)
+
+func main() {
+	out := flag.String("out", "docs/asyncapi.yaml", "Output file")
+	flag.Parse()
+
+	doc, err := events.AsyncAPI()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render asyncapi: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, doc, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}